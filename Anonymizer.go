@@ -0,0 +1,147 @@
+package dataobject
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// RedactedValue replaces the redacted values (see Redact)
+const RedactedValue = "[REDACTED]"
+
+// HashWithSaltMinLength is the minimum length
+// of the salt in bytes (see HashWithSalt)
+const HashWithSaltMinLength = 16
+
+// Anonymizer anonymizes or pseudonymizes a value,
+// i.e. for exports shared with analysts (see Export)
+type Anonymizer func(value string) string
+
+// HashWithSalt returns an anonymizer replacing the values with their
+// hex encoded HMAC-SHA256 with the salt, truncated to 32 characters.
+// Equal values get equal pseudonyms, so exports can still be joined on
+// them, while the values cannot be recovered without the salt.
+// Empty values stay empty.
+//
+// Returns an error wrapping ErrSaltTooShort, if the salt is shorter than
+// HashWithSaltMinLength bytes, as short salts can be brute forced
+func HashWithSalt(salt []byte) (Anonymizer, error) {
+	if len(salt) < HashWithSaltMinLength {
+		return nil, fmt.Errorf("%w: %d bytes, at least %d required", ErrSaltTooShort, len(salt), HashWithSaltMinLength)
+	}
+
+	salt = append([]byte(nil), salt...)
+
+	return func(value string) string {
+		if value == "" {
+			return ""
+		}
+
+		mac := hmac.New(sha256.New, salt)
+		mac.Write([]byte(value))
+		return hex.EncodeToString(mac.Sum(nil))[:32]
+	}, nil
+}
+
+// MustHashWithSalt is like HashWithSalt, but panics on error
+func MustHashWithSalt(salt []byte) Anonymizer {
+	return must(HashWithSalt(salt))
+}
+
+// GeneralizeToMonth returns an anonymizer replacing the dates (in
+// DateTimeFormat, RFC 3339 or YYYY-MM-DD) with their month as YYYY-MM.
+// Values, which are not dates, are replaced with an empty string
+func GeneralizeToMonth() Anonymizer {
+	return func(value string) string {
+		t, err := parseDateTime(value)
+		if err != nil {
+			t, err = time.Parse(time.DateOnly, value)
+		}
+		if err != nil {
+			return ""
+		}
+		return t.Format("2006-01")
+	}
+}
+
+// Redact returns an anonymizer replacing the values with RedactedValue,
+// i.e. for free text. Empty values stay empty
+func Redact() Anonymizer {
+	return func(value string) string {
+		if value == "" {
+			return ""
+		}
+		return RedactedValue
+	}
+}
+
+// Keep returns an anonymizer keeping the values, i.e. for the keys,
+// which are not personal data, to list them in an allowlist
+// (see Anonymization.ApplyAllowlist)
+func Keep() Anonymizer {
+	return func(value string) string {
+		return value
+	}
+}
+
+// Anonymization holds the anonymizers per key
+//
+// Example:
+//
+//	anonymization := Anonymization{
+//		"id":         Keep(),
+//		"email":      MustHashWithSalt(salt),
+//		"birth_date": GeneralizeToMonth(),
+//		"notes":      Redact(),
+//	}
+type Anonymization map[string]Anonymizer
+
+// ApplyAllowlist returns a copy of the data with only the keys, which
+// have an anonymizer, with the values anonymized. The other keys are
+// dropped, so keys added to the data later are not shared by accident
+func (a Anonymization) ApplyAllowlist(data map[string]string) map[string]string {
+	result := make(map[string]string, len(a))
+
+	for key, value := range data {
+		if anonymizer, exists := a[key]; exists {
+			result[key] = anonymizer(value)
+		}
+	}
+
+	return result
+}
+
+// Apply returns a copy of the data with the values anonymized. The values
+// of the sensitive keys (see LogRedactedKeys) without an anonymizer are
+// redacted, so secrets like password hashes never leave the database
+func (a Anonymization) Apply(data map[string]string) map[string]string {
+	result := make(map[string]string, len(data))
+
+	for key, value := range data {
+		if anonymizer, exists := a[key]; exists {
+			value = anonymizer(value)
+		} else if isSensitiveKey(key) && value != "" {
+			value = RedactedValue
+		}
+		result[key] = value
+	}
+
+	return result
+}
+
+// isSensitiveKey returns if the key contains
+// a sensitive key fragment (see LogRedactedKeys)
+func isSensitiveKey(key string) bool {
+	lowerKey := strings.ToLower(key)
+
+	for _, redacted := range LogRedactedKeys {
+		if strings.Contains(lowerKey, redacted) {
+			return true
+		}
+	}
+
+	return false
+}
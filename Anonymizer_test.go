@@ -0,0 +1,102 @@
+package dataobject
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestHashWithSalt(t *testing.T) {
+	hash, err := HashWithSalt([]byte("0123456789abcdef"))
+
+	if err != nil {
+		t.Fatal("Error must be nil, but found:", err.Error())
+	}
+
+	if hash("jon@test.com") != hash("jon@test.com") {
+		t.Error("Expected: equal values to get equal pseudonyms")
+	}
+
+	if hash("jon@test.com") == MustHashWithSalt([]byte("fedcba9876543210"))("jon@test.com") {
+		t.Error("Expected: the pseudonym to depend on the salt")
+	}
+
+	if len(hash("jon@test.com")) != 32 || hash("") != "" {
+		t.Error("Expected: 32 characters, and empty for empty values, but found:", hash("jon@test.com"), hash(""))
+	}
+}
+
+func TestHashWithSaltTooShort(t *testing.T) {
+	for _, salt := range [][]byte{nil, {}, []byte("0123456789abcde")} {
+		if _, err := HashWithSalt(salt); !errors.Is(err, ErrSaltTooShort) {
+			t.Error("Expected: ErrSaltTooShort for", len(salt), "bytes, but found:", err)
+		}
+	}
+}
+
+func TestGeneralizeToMonth(t *testing.T) {
+	generalize := GeneralizeToMonth()
+
+	values := map[string]string{
+		"2024-03-15 10:20:30":  "2024-03",
+		"2024-03-15T10:20:30Z": "2024-03",
+		"1990-12-01":           "1990-12",
+		"yesterday":            "",
+	}
+
+	for value, expected := range values {
+		if found := generalize(value); found != expected {
+			t.Error("Expected:", expected, "but found:", found)
+		}
+	}
+}
+
+func TestAnonymizationApply(t *testing.T) {
+	anonymization := Anonymization{
+		"notes":         Redact(),
+		"api_key_label": func(value string) string { return value },
+	}
+
+	data := map[string]string{
+		"id":            "1",
+		"notes":         "Called about the invoice",
+		"password_hash": "$2a$10$abc",
+		"api_key_label": "production",
+		"empty_token":   "",
+	}
+
+	found := anonymization.Apply(data)
+
+	if found["id"] != "1" || found["notes"] != RedactedValue {
+		t.Error("Expected: the id kept and the notes redacted, but found:", found)
+	}
+
+	if found["password_hash"] != RedactedValue {
+		t.Error("Expected: the sensitive key redacted, but found:", found["password_hash"])
+	}
+
+	if found["api_key_label"] != "production" || found["empty_token"] != "" {
+		t.Error("Expected: the explicit anonymizer to win, and empty values kept, but found:", found)
+	}
+
+	if data["notes"] != "Called about the invoice" {
+		t.Error("Expected: the data not to be modified, but found:", data["notes"])
+	}
+}
+
+func TestAnonymizationApplyAllowlist(t *testing.T) {
+	anonymization := Anonymization{
+		"id":    Keep(),
+		"notes": Redact(),
+	}
+
+	found := anonymization.ApplyAllowlist(map[string]string{
+		"id":         "1",
+		"notes":      "Called about the invoice",
+		"phone":      "+44 20 7946 0958",
+		"session_id": "abc",
+	})
+
+	if len(found) != 2 || found["id"] != "1" || found["notes"] != RedactedValue {
+		t.Error("Expected: only the id and the redacted notes, but found:", found)
+	}
+}
@@ -0,0 +1,139 @@
+package dataobject
+
+import (
+	"bufio"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+)
+
+// RestorePolicy decides what happens to the objects, which already exist
+type RestorePolicy string
+
+const (
+	// RestoreSkip keeps the existing objects as they are
+	RestoreSkip RestorePolicy = "skip"
+
+	// RestoreOverwrite replaces the existing objects with the backed up ones
+	RestoreOverwrite RestorePolicy = "overwrite"
+
+	// RestoreMerge sets the backed up keys on the existing objects,
+	// keeping the keys, which are not in the backup
+	RestoreMerge RestorePolicy = "merge"
+)
+
+// RestoreOptions configures Restore
+type RestoreOptions struct {
+	// Policy decides what happens to the existing objects, defaults to RestoreSkip
+	Policy RestorePolicy
+}
+
+// RestoreReport is the result of Restore
+type RestoreReport struct {
+	Created int `json:"created"`
+	Updated int `json:"updated"`
+	Skipped int `json:"skipped"`
+}
+
+// Backup writes all the objects of the repository to the writer as
+// gzip compressed NDJSON (one JSON object per line), reading them in
+// batches. Returns the number of written objects
+func Backup(ctx context.Context, repo DataObjectRepositoryInterface, w io.Writer) (int, error) {
+	writer := gzip.NewWriter(w)
+	encoder := json.NewEncoder(writer)
+	written := 0
+
+	for offset := 0; ; offset += VerifyDefaultBatchSize {
+		if err := ctx.Err(); err != nil {
+			return written, err
+		}
+
+		objects, err := repo.List(ctx, offset, VerifyDefaultBatchSize)
+		if err != nil {
+			return written, err
+		}
+
+		for _, do := range objects {
+			if err := encoder.Encode(do.Data()); err != nil {
+				return written, err
+			}
+			written++
+		}
+
+		if len(objects) < VerifyDefaultBatchSize {
+			break
+		}
+	}
+
+	return written, writer.Close()
+}
+
+// Restore reads the objects written by Backup from the reader and stores
+// them in the repository, handling the existing objects per the policy
+func Restore(ctx context.Context, repo DataObjectRepositoryInterface, r io.Reader, opts RestoreOptions) (RestoreReport, error) {
+	report := RestoreReport{}
+
+	reader, err := gzip.NewReader(r)
+	if err != nil {
+		return report, fmt.Errorf("dataobject: invalid backup: %w", err)
+	}
+
+	decoder := json.NewDecoder(bufio.NewReader(reader))
+
+	for {
+		if err := ctx.Err(); err != nil {
+			return report, err
+		}
+
+		data := map[string]string{}
+		if err := decoder.Decode(&data); errors.Is(err, io.EOF) {
+			return report, nil
+		} else if err != nil {
+			return report, fmt.Errorf("dataobject: invalid backup: %w", err)
+		}
+
+		if err := restoreObject(ctx, repo, data, opts.Policy, &report); err != nil {
+			return report, err
+		}
+	}
+}
+
+// restoreObject stores the backed up data, handling an existing object per the policy
+func restoreObject(ctx context.Context, repo DataObjectRepositoryInterface, data map[string]string, policy RestorePolicy, report *RestoreReport) error {
+	existing, err := repo.Find(ctx, data["id"])
+
+	if IsNotFound(err) {
+		if err := repo.Create(ctx, NewDataObjectFromExistingData(data)); err != nil {
+			return err
+		}
+		report.Created++
+		return nil
+	}
+
+	if err != nil {
+		return err
+	}
+
+	switch policy {
+	case RestoreOverwrite:
+		err = repo.Update(ctx, NewDataObjectFromExistingData(data))
+	case RestoreMerge:
+		merged := NewDataObjectFromExistingData(copyData(existing.Data()))
+		merged.SetData(data)
+		err = repo.Update(ctx, merged)
+	default:
+		report.Skipped++
+		return nil
+	}
+
+	if err != nil {
+		return err
+	}
+
+	report.Updated++
+
+	return nil
+}
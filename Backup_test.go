@@ -0,0 +1,65 @@
+package dataobject
+
+import (
+	"bytes"
+	"context"
+	"strconv"
+	"testing"
+)
+
+func TestBackupAndRestore(t *testing.T) {
+	ctx := context.Background()
+	repo := NewMemoryRepository()
+
+	for i := 0; i < 150; i++ {
+		_ = repo.Create(ctx, NewDataObjectFromExistingData(map[string]string{"id": strconv.Itoa(i), "name": "user" + strconv.Itoa(i)}))
+	}
+
+	buffer := &bytes.Buffer{}
+	written, err := Backup(ctx, repo, buffer)
+
+	if err != nil {
+		t.Fatal("Error must be nil, but found:", err.Error())
+	}
+
+	if written != 150 {
+		t.Error("Expected: 150, but found:", written)
+	}
+
+	tests := []struct {
+		policy   RestorePolicy
+		expected RestoreReport
+		name     string
+	}{
+		{RestoreSkip, RestoreReport{Created: 149, Skipped: 1}, "existing"},
+		{RestoreOverwrite, RestoreReport{Created: 149, Updated: 1}, "user1"},
+		{RestoreMerge, RestoreReport{Created: 149, Updated: 1}, "user1"},
+	}
+
+	for _, test := range tests {
+		target := NewMemoryRepository()
+		_ = target.Create(ctx, NewDataObjectFromExistingData(map[string]string{"id": "1", "name": "existing", "extra": "kept"}))
+
+		report, err := Restore(ctx, target, bytes.NewReader(buffer.Bytes()), RestoreOptions{Policy: test.policy})
+
+		if err != nil {
+			t.Fatal("Error must be nil, but found:", err.Error())
+		}
+
+		if report != test.expected {
+			t.Error("Expected:", test.expected, "but found:", report, "for", test.policy)
+		}
+
+		found, _ := target.Find(ctx, "1")
+
+		if found.Data()["name"] != test.name {
+			t.Error("Expected:", test.name, "but found:", found.Data()["name"], "for", test.policy)
+		}
+
+		_, hasExtra := found.Data()["extra"]
+
+		if hasExtra == (test.policy == RestoreOverwrite) {
+			t.Error("Expected extra to be kept only if not overwritten, but found:", found.Data(), "for", test.policy)
+		}
+	}
+}
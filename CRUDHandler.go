@@ -0,0 +1,254 @@
+package dataobject
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+)
+
+// CRUDHandlerDefaultPerPage is the default number of objects per list page
+const CRUDHandlerDefaultPerPage = 20
+
+// CRUDHandlerMaxPerPage is the maximum number of objects per list page
+const CRUDHandlerMaxPerPage = 100
+
+// NewCRUDHandler creates an HTTP handler exposing a JSON REST API
+// over the repository:
+//
+//	GET    /      lists the objects (?page=1&per_page=20)
+//	POST   /      creates a new object with a generated ID
+//	GET    /{id}  returns the object
+//	PUT    /{id}  updates the object (PATCH is accepted as well)
+//	DELETE /{id}  deletes the object
+//
+// Objects are returned with an ETag header. Updates with an If-Match
+// header are rejected with 412 Precondition Failed, if the object has
+// been modified since (atomically if the repository is a ConditionalUpdater)
+//
+// Only the allowed keys are accepted from requests, or the schema keys
+// if none are passed, to prevent mass assignment (i.e. of a role).
+// Without a schema and allowed keys the objects cannot be created or
+// updated (405 Method Not Allowed). The values are only read from the
+// request body, and the ID can never be set from a request. If a schema
+// is passed, the objects are validated before being stored
+//
+// Errors are returned as {"error": "..."} objects, without the details
+// of the repository errors
+//
+// Mount the handler with http.StripPrefix to serve it under a path:
+//
+//	mux.Handle("/api/users/", http.StripPrefix("/api/users", NewCRUDHandler(repo, schema)))
+func NewCRUDHandler(repo DataObjectRepositoryInterface, schema *Schema, allowedKeys ...string) http.Handler {
+	if len(allowedKeys) < 1 && schema != nil {
+		allowedKeys = schema.Keys()
+	}
+
+	h := &crudHandler{repo: repo, schema: schema, allowedKeys: allowedKeys}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /{$}", h.list)
+	mux.HandleFunc("POST /{$}", h.create)
+	mux.HandleFunc("GET /{id}", h.show)
+	mux.HandleFunc("PUT /{id}", h.update)
+	mux.HandleFunc("PATCH /{id}", h.update)
+	mux.HandleFunc("DELETE /{id}", h.delete)
+
+	return mux
+}
+
+type crudHandler struct {
+	repo        DataObjectRepositoryInterface
+	schema      *Schema
+	allowedKeys []string
+}
+
+// crudError is the JSON body of an error response
+type crudError struct {
+	Error  string       `json:"error"`
+	Fields []FieldError `json:"fields,omitempty"`
+}
+
+// crudList is the JSON body of a list response
+type crudList struct {
+	Data    []map[string]string `json:"data"`
+	Page    int                 `json:"page"`
+	PerPage int                 `json:"per_page"`
+	Total   int                 `json:"total"`
+}
+
+func (h *crudHandler) list(w http.ResponseWriter, r *http.Request) {
+	page := queryInt(r, "page", 1, 1, 0)
+	perPage := queryInt(r, "per_page", CRUDHandlerDefaultPerPage, 1, CRUDHandlerMaxPerPage)
+
+	total, err := h.repo.Count(r.Context())
+	if err != nil {
+		h.error(w, err)
+		return
+	}
+
+	objects, err := h.repo.List(r.Context(), (page-1)*perPage, perPage)
+	if err != nil {
+		h.error(w, err)
+		return
+	}
+
+	list := crudList{Data: []map[string]string{}, Page: page, PerPage: perPage, Total: total}
+	for _, do := range objects {
+		list.Data = append(list.Data, do.Data())
+	}
+
+	_ = writeJSON(w, writeJSONOptions{status: http.StatusOK}, list)
+}
+
+func (h *crudHandler) create(w http.ResponseWriter, r *http.Request) {
+	do := NewDataObject()
+
+	if !h.apply(w, r, do) {
+		return
+	}
+
+	if err := h.repo.Create(r.Context(), do); err != nil {
+		h.error(w, err)
+		return
+	}
+
+	_ = do.WriteJSON(w, JSONStatus(http.StatusCreated))
+}
+
+func (h *crudHandler) show(w http.ResponseWriter, r *http.Request) {
+	found, err := h.repo.Find(r.Context(), r.PathValue("id"))
+	if err != nil {
+		h.error(w, err)
+		return
+	}
+
+	w.Header().Set("ETag", ETagOf(found.Data()))
+	_ = writeJSON(w, writeJSONOptions{status: http.StatusOK}, found.Data())
+}
+
+func (h *crudHandler) update(w http.ResponseWriter, r *http.Request) {
+	found, err := h.repo.Find(r.Context(), r.PathValue("id"))
+	if err != nil {
+		h.error(w, err)
+		return
+	}
+
+	ifMatch := r.Header.Get("If-Match")
+	if ifMatch != "" && !etagMatches(ifMatch, ETagOf(found.Data())) {
+		h.writeError(w, http.StatusPreconditionFailed, crudError{Error: "precondition failed"})
+		return
+	}
+
+	do := NewDataObjectFromExistingData(copyData(found.Data()))
+
+	if !h.apply(w, r, do) {
+		return
+	}
+
+	if err := h.save(r, do, ifMatch); err != nil {
+		if ifMatch != "" && errors.Is(err, ErrVersionConflict) {
+			h.writeError(w, http.StatusPreconditionFailed, crudError{Error: "precondition failed"})
+			return
+		}
+		h.error(w, err)
+		return
+	}
+
+	w.Header().Set("ETag", do.ETag())
+	_ = do.WriteJSON(w)
+}
+
+func (h *crudHandler) delete(w http.ResponseWriter, r *http.Request) {
+	if err := h.repo.Delete(r.Context(), r.PathValue("id")); err != nil {
+		h.error(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// save updates the object, conditionally on the If-Match
+// header if the repository supports it
+func (h *crudHandler) save(r *http.Request, do *DataObject, ifMatch string) error {
+	if ifMatch != "" {
+		if err := forwardUpdateIf(r.Context(), h.repo, do, ifMatch); !errors.Is(err, ErrNotSupported) {
+			return err
+		}
+	}
+	return h.repo.Update(r.Context(), do)
+}
+
+// apply sets the submitted values on the object and validates it,
+// writes an error response and returns false on failure
+func (h *crudHandler) apply(w http.ResponseWriter, r *http.Request, do *DataObject) bool {
+	if len(h.allowedKeys) < 1 {
+		h.writeError(w, http.StatusMethodNotAllowed, crudError{Error: "method not allowed"})
+		return false
+	}
+
+	values, err := requestBodyValues(r)
+	if errors.Is(err, ErrTooLarge) {
+		h.writeError(w, http.StatusRequestEntityTooLarge, crudError{Error: "request body too large"})
+		return false
+	}
+	if err != nil {
+		h.writeError(w, http.StatusBadRequest, crudError{Error: "invalid request body"})
+		return false
+	}
+
+	values = onlyKeys(values, h.allowedKeys)
+	delete(values, "id")
+
+	do.SetData(values)
+
+	if h.schema == nil {
+		return true
+	}
+
+	if errs := h.schema.Validate(do.Data()); len(errs) > 0 {
+		h.writeError(w, http.StatusUnprocessableEntity, crudError{Error: "validation failed", Fields: errs})
+		return false
+	}
+
+	return true
+}
+
+// error writes the error response for a repository error
+func (h *crudHandler) error(w http.ResponseWriter, err error) {
+	if IsNotFound(err) {
+		h.writeError(w, http.StatusNotFound, crudError{Error: "not found"})
+		return
+	}
+
+	if errors.Is(err, ErrAlreadyExists) || errors.Is(err, ErrVersionConflict) || errors.Is(err, ErrDeleteRestricted) || errors.Is(err, ErrDuplicateRequest) {
+		h.writeError(w, http.StatusConflict, crudError{Error: "conflict"})
+		return
+	}
+
+	if errors.Is(err, ErrForbidden) {
+		h.writeError(w, http.StatusForbidden, crudError{Error: "forbidden"})
+		return
+	}
+
+	h.writeError(w, http.StatusInternalServerError, crudError{Error: "internal server error"})
+}
+
+func (h *crudHandler) writeError(w http.ResponseWriter, status int, body crudError) {
+	_ = writeJSON(w, writeJSONOptions{status: status}, body)
+}
+
+// queryInt returns the integer query parameter clamped to [min, max]
+// (no upper bound if max is 0), or the default value if missing or invalid
+func queryInt(r *http.Request, key string, defaultValue int, minValue int, maxValue int) int {
+	value, err := strconv.Atoi(r.URL.Query().Get(key))
+	if err != nil {
+		return defaultValue
+	}
+
+	value = max(value, minValue)
+	if maxValue > 0 {
+		value = min(value, maxValue)
+	}
+
+	return value
+}
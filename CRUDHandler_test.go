@@ -0,0 +1,132 @@
+package dataobject
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestCRUDHandler(t *testing.T) {
+	repo := NewMemoryRepository()
+	schema := NewSchema(
+		SchemaField{Key: "email", Required: true},
+		SchemaField{Key: "age", Type: FieldTypeInt},
+	)
+	handler := NewCRUDHandler(repo, schema)
+
+	// create
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest("POST", "/", strings.NewReader(`{"email":"jon@test.com","age":"30","role":"admin"}`))
+	r.Header.Set("Content-Type", "application/json")
+	handler.ServeHTTP(w, r)
+
+	if w.Code != http.StatusCreated {
+		t.Fatal("Expected:", http.StatusCreated, "but found:", w.Code, w.Body.String())
+	}
+
+	created := map[string]string{}
+	_ = json.Unmarshal(w.Body.Bytes(), &created)
+
+	if created["id"] == "" {
+		t.Fatal("ID must NOT be empty, but found:", created)
+	}
+
+	if _, exists := created["role"]; exists {
+		t.Error("Expected role NOT to be set, but found:", created["role"])
+	}
+
+	// validation
+	w = httptest.NewRecorder()
+	r = httptest.NewRequest("PUT", "/"+created["id"], strings.NewReader(`{"age":"thirty"}`))
+	r.Header.Set("Content-Type", "application/json")
+	handler.ServeHTTP(w, r)
+
+	if w.Code != http.StatusUnprocessableEntity {
+		t.Error("Expected:", http.StatusUnprocessableEntity, "but found:", w.Code)
+	}
+
+	// update
+	w = httptest.NewRecorder()
+	r = httptest.NewRequest("PATCH", "/"+created["id"], strings.NewReader(`{"age":"31"}`))
+	r.Header.Set("Content-Type", "application/json")
+	handler.ServeHTTP(w, r)
+
+	if w.Code != http.StatusOK {
+		t.Fatal("Expected:", http.StatusOK, "but found:", w.Code, w.Body.String())
+	}
+
+	found, _ := repo.Find(context.Background(), created["id"])
+	if found.Data()["age"] != "31" || found.Data()["email"] != "jon@test.com" {
+		t.Error("Expected: 31 jon@test.com, but found:", found.Data())
+	}
+
+	// list
+	w = httptest.NewRecorder()
+	handler.ServeHTTP(w, httptest.NewRequest("GET", "/?per_page=10", nil))
+
+	if !strings.Contains(w.Body.String(), `"total":1`) {
+		t.Error(`Expected to contain: "total":1, but found:`, w.Body.String())
+	}
+
+	// delete
+	w = httptest.NewRecorder()
+	handler.ServeHTTP(w, httptest.NewRequest("DELETE", "/"+created["id"], nil))
+
+	if w.Code != http.StatusNoContent {
+		t.Error("Expected:", http.StatusNoContent, "but found:", w.Code)
+	}
+
+	// not found
+	w = httptest.NewRecorder()
+	handler.ServeHTTP(w, httptest.NewRequest("GET", "/"+created["id"], nil))
+
+	if w.Code != http.StatusNotFound {
+		t.Error("Expected:", http.StatusNotFound, "but found:", w.Code)
+	}
+}
+
+func TestCRUDHandlerAllowedKeys(t *testing.T) {
+	repo := NewMemoryRepository()
+	_ = repo.Create(context.Background(), NewDataObjectFromExistingData(map[string]string{"id": "1", "name": "Jon"}))
+
+	// without a schema and allowed keys, writes are not allowed
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest("PUT", "/1", strings.NewReader(`{"name":"Jane"}`))
+	r.Header.Set("Content-Type", "application/json")
+	NewCRUDHandler(repo, nil).ServeHTTP(w, r)
+
+	if w.Code != http.StatusMethodNotAllowed {
+		t.Error("Expected:", http.StatusMethodNotAllowed, "but found:", w.Code)
+	}
+
+	// query parameters are not accepted
+	w = httptest.NewRecorder()
+	r = httptest.NewRequest("PUT", "/1?role=admin", strings.NewReader(`{"name":"Jane","soft_deleted_at":"2024-01-01"}`))
+	r.Header.Set("Content-Type", "application/json")
+	NewCRUDHandler(repo, nil, "name", "role").ServeHTTP(w, r)
+
+	if w.Code != http.StatusOK {
+		t.Fatal("Expected:", http.StatusOK, "but found:", w.Code, w.Body.String())
+	}
+
+	found, _ := repo.Find(context.Background(), "1")
+	if found.Data()["name"] != "Jane" || found.Data()["role"] != "" || found.Data()["soft_deleted_at"] != "" {
+		t.Error("Expected: only the name to be updated, but found:", found.Data())
+	}
+}
+
+func TestCRUDHandlerErrorDetails(t *testing.T) {
+	handler := NewCRUDHandler(&unavailableOutbox{MemoryRepository: NewMemoryRepository()}, nil, "name")
+
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest("POST", "/", strings.NewReader(`{"name":"Jon"}`))
+	r.Header.Set("Content-Type", "application/json")
+	handler.ServeHTTP(w, r)
+
+	if w.Code != http.StatusInternalServerError || strings.Contains(w.Body.String(), ErrUnavailable.Error()) {
+		t.Error("Expected: 500 without the error details, but found:", w.Code, w.Body.String())
+	}
+}
@@ -0,0 +1,470 @@
+package dataobject
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// CacheDefaultTTL is the default time the objects are cached for
+const CacheDefaultTTL = 5 * time.Minute
+
+// CacheKeyPrefix is the prefix of the cache keys of the objects
+const CacheKeyPrefix = "dataobject:"
+
+// Cache is a key value cache with expiring entries, i.e. an adapter
+// over an in-memory cache, Redis or Memcached
+type Cache interface {
+	// Get returns the value of the key, and false if it is missing or expired
+	Get(ctx context.Context, key string) ([]byte, bool, error)
+
+	// Set sets the value of the key, expiring after the TTL (never if 0)
+	Set(ctx context.Context, key string, value []byte, ttl time.Duration) error
+
+	// Delete deletes the key
+	Delete(ctx context.Context, key string) error
+}
+
+// CacheEncoding is the encoding of the data of the cached objects
+type CacheEncoding string
+
+const (
+	CacheEncodingJSON CacheEncoding = "json"
+	CacheEncodingGob  CacheEncoding = "gob"
+)
+
+// CachedFinder finds objects through a read-through cache. Concurrent
+// lookups of the same missing ID share a single repository read,
+// preventing thundering herds on popular objects
+//
+// Example:
+//
+//	finder := NewCachedFinder(repo, NewMemoryCache()).SetEncoding(CacheEncodingGob)
+//	user, err := finder.Find(ctx, id)
+type CachedFinder struct {
+	repo  DataObjectRepositoryInterface
+	cache Cache
+	ttl   time.Duration
+	codec Codec
+	group singleflight
+
+	// mu guards the loads
+	mu sync.Mutex
+
+	// loads are the in-flight repository reads per ID
+	loads map[string]*cachedLoad
+}
+
+// cachedLoad is an in-flight repository read of a CachedFinder,
+// which is not cached if invalidated while reading
+type cachedLoad struct {
+	invalidated bool
+}
+
+// NewCachedFinder creates a new cached finder of the repository objects,
+// caching them as JSON for CacheDefaultTTL
+func NewCachedFinder(repo DataObjectRepositoryInterface, cache Cache) *CachedFinder {
+	return &CachedFinder{repo: repo, cache: cache, ttl: CacheDefaultTTL, codec: JSONCodec{}}
+}
+
+// SetTTL sets the time the objects are cached for
+func (f *CachedFinder) SetTTL(ttl time.Duration) *CachedFinder {
+	f.ttl = ttl
+	return f
+}
+
+// SetEncoding sets the encoding of the cached data
+func (f *CachedFinder) SetEncoding(encoding CacheEncoding) *CachedFinder {
+	if encoding == CacheEncodingGob {
+		return f.WithCodec(GobCodec{})
+	}
+	return f.WithCodec(JSONCodec{})
+}
+
+// WithCodec sets the codec of the cached data (i.e. MsgpackCodec)
+func (f *CachedFinder) WithCodec(codec Codec) *CachedFinder {
+	f.codec = codec
+	return f
+}
+
+// Find returns the object with the ID from the cache, or reads it
+// from the repository and caches it. Cache errors are ignored,
+// falling back to the repository
+func (f *CachedFinder) Find(ctx context.Context, id string) (DataObjectInterface, error) {
+	if cached, found, err := f.cache.Get(ctx, CacheKeyPrefix+id); err == nil && found {
+		if data, err := f.decode(cached); err == nil {
+			currentMetrics().ObserveCacheLookup(true)
+			return NewDataObjectFromExistingData(data), nil
+		}
+	}
+
+	currentMetrics().ObserveCacheLookup(false)
+
+	data, err := f.group.do(ctx, id, func(ctx context.Context) (map[string]string, error) {
+		load := f.startLoad(id)
+		defer f.endLoad(id, load)
+
+		found, err := f.repo.Find(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+
+		data := copyData(found.Data())
+
+		if encoded, err := f.encode(data); err == nil {
+			f.mu.Lock()
+			if !load.invalidated {
+				_ = f.cache.Set(ctx, CacheKeyPrefix+id, encoded, f.ttl)
+			}
+			f.mu.Unlock()
+		}
+
+		return data, nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return NewDataObjectFromExistingData(copyData(data)), nil
+}
+
+// Invalidate deletes the cached object with the ID, i.e. after an update.
+// A read of the object in flight is not cached, as it may have read the
+// object before the update, and later lookups read the object again
+func (f *CachedFinder) Invalidate(ctx context.Context, id string) error {
+	f.mu.Lock()
+	if load, loading := f.loads[id]; loading {
+		load.invalidated = true
+	}
+	f.mu.Unlock()
+
+	f.group.forget(id)
+
+	return f.cache.Delete(ctx, CacheKeyPrefix+id)
+}
+
+// startLoad registers a repository read of the object with the ID
+func (f *CachedFinder) startLoad(id string) *cachedLoad {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if f.loads == nil {
+		f.loads = map[string]*cachedLoad{}
+	}
+
+	load := &cachedLoad{}
+	f.loads[id] = load
+	return load
+}
+
+// endLoad unregisters the repository read of the object with the ID
+func (f *CachedFinder) endLoad(id string, load *cachedLoad) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if f.loads[id] == load {
+		delete(f.loads, id)
+	}
+}
+
+// encode encodes the data with the configured codec
+func (f *CachedFinder) encode(data map[string]string) ([]byte, error) {
+	return f.codec.Encode(data)
+}
+
+// decode decodes the data with the configured codec
+func (f *CachedFinder) decode(encoded []byte) (map[string]string, error) {
+	return f.codec.Decode(encoded)
+}
+
+// CachedFind returns the object with the ID from the cache, or reads it
+// from the repository and caches it as JSON for CacheDefaultTTL.
+// See CachedFinder to configure the TTL and the encoding
+//
+// Only lookups through the same finder share repository reads, so to
+// prevent thundering herds keep a CachedRepository (or a CachedFinder)
+// and find through it instead
+func CachedFind(ctx context.Context, repo DataObjectRepositoryInterface, cache Cache, id string) (DataObjectInterface, error) {
+	return NewCachedFinder(repo, cache).Find(ctx, id)
+}
+
+var _ DataObjectRepositoryInterface = (*CachedRepository)(nil) // verify it extends the repository interface
+var _ ConditionalUpdater = (*CachedRepository)(nil)            // verify it forwards conditional updates
+var _ Querier = (*CachedRepository)(nil)                       // verify it forwards queries
+
+// CachedRepository decorates a repository finding the objects through
+// a read-through cache (see CachedFinder), and invalidating the cached
+// objects on every write. Queries are not cached
+//
+// Example:
+//
+//	repo := NewCachedRepository(sqlRepo, NewMemoryCache()).SetTTL(time.Minute)
+//	user, err := repo.Find(ctx, id)
+type CachedRepository struct {
+	DataObjectRepositoryInterface
+	finder *CachedFinder
+}
+
+// NewCachedRepository creates a new cached decorator of the repository,
+// caching the objects as JSON for CacheDefaultTTL
+func NewCachedRepository(inner DataObjectRepositoryInterface, cache Cache) *CachedRepository {
+	return &CachedRepository{DataObjectRepositoryInterface: inner, finder: NewCachedFinder(inner, cache)}
+}
+
+// SetTTL sets the time the objects are cached for
+func (repo *CachedRepository) SetTTL(ttl time.Duration) *CachedRepository {
+	repo.finder.SetTTL(ttl)
+	return repo
+}
+
+// SetEncoding sets the encoding of the cached data
+func (repo *CachedRepository) SetEncoding(encoding CacheEncoding) *CachedRepository {
+	repo.finder.SetEncoding(encoding)
+	return repo
+}
+
+// WithCodec sets the codec of the cached data (i.e. MsgpackCodec)
+func (repo *CachedRepository) WithCodec(codec Codec) *CachedRepository {
+	repo.finder.WithCodec(codec)
+	return repo
+}
+
+// Find returns the object with the ID from the cache,
+// or reads it from the repository and caches it
+func (repo *CachedRepository) Find(ctx context.Context, id string) (DataObjectInterface, error) {
+	return repo.finder.Find(ctx, id)
+}
+
+// Update updates the object, and invalidates its cached copy
+func (repo *CachedRepository) Update(ctx context.Context, do DataObjectInterface) error {
+	if err := repo.DataObjectRepositoryInterface.Update(ctx, do); err != nil {
+		return err
+	}
+	return repo.finder.Invalidate(ctx, do.ID())
+}
+
+// Delete deletes the object with the ID, and invalidates its cached copy
+func (repo *CachedRepository) Delete(ctx context.Context, id string) error {
+	if err := repo.DataObjectRepositoryInterface.Delete(ctx, id); err != nil {
+		return err
+	}
+	return repo.finder.Invalidate(ctx, id)
+}
+
+// UpdateIf updates the object, if it matches the entity tag,
+// and invalidates its cached copy
+func (repo *CachedRepository) UpdateIf(ctx context.Context, do DataObjectInterface, expectedETag string) error {
+	if err := forwardUpdateIf(ctx, repo.DataObjectRepositoryInterface, do, expectedETag); err != nil {
+		return err
+	}
+	return repo.finder.Invalidate(ctx, do.ID())
+}
+
+// ForceDelete deletes the object with the ID permanently,
+// and invalidates its cached copy
+func (repo *CachedRepository) ForceDelete(ctx context.Context, id string) error {
+	if err := forwardForceDelete(ctx, repo.DataObjectRepositoryInterface, id); err != nil {
+		return err
+	}
+	return repo.finder.Invalidate(ctx, id)
+}
+
+// Restore restores the soft deleted object with the ID,
+// and invalidates its cached copy
+func (repo *CachedRepository) Restore(ctx context.Context, id string) error {
+	if err := forwardRestore(ctx, repo.DataObjectRepositoryInterface, id); err != nil {
+		return err
+	}
+	return repo.finder.Invalidate(ctx, id)
+}
+
+// Query returns the objects matching the query from the repository
+func (repo *CachedRepository) Query(ctx context.Context, query *Query) ([]DataObjectInterface, error) {
+	return forwardQuery(ctx, repo.DataObjectRepositoryInterface, query)
+}
+
+// singleflight shares the result of concurrent calls with the same key
+type singleflight struct {
+	mu    sync.Mutex
+	calls map[string]*singleflightCall
+}
+
+// singleflightCall is an in-flight or completed call
+type singleflightCall struct {
+	done chan struct{}
+	data map[string]string
+	err  error
+}
+
+// do calls fn once for concurrent calls with the same key, returning
+// the shared result to all of them. The call runs detached from the
+// cancellation of the context of the caller starting it, and every
+// caller waits for it only until its own context is done
+func (g *singleflight) do(ctx context.Context, key string, fn func(ctx context.Context) (map[string]string, error)) (map[string]string, error) {
+	g.mu.Lock()
+	if g.calls == nil {
+		g.calls = map[string]*singleflightCall{}
+	}
+	call, inFlight := g.calls[key]
+	if !inFlight {
+		call = &singleflightCall{done: make(chan struct{})}
+		g.calls[key] = call
+		go g.run(context.WithoutCancel(ctx), key, call, fn)
+	}
+	g.mu.Unlock()
+
+	select {
+	case <-call.done:
+		return call.data, call.err
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// run runs the call, and removes it once done
+func (g *singleflight) run(ctx context.Context, key string, call *singleflightCall, fn func(ctx context.Context) (map[string]string, error)) {
+	call.data, call.err = fn(ctx)
+
+	g.mu.Lock()
+	if g.calls[key] == call {
+		delete(g.calls, key)
+	}
+	g.mu.Unlock()
+	close(call.done)
+}
+
+// forget removes the in-flight call with the key, so later calls with
+// the key do not share its result, but start a new call
+func (g *singleflight) forget(key string) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	delete(g.calls, key)
+}
+
+var _ Cache = (*MemoryCache)(nil) // verify it extends the cache interface
+
+// MemoryCacheDefaultMaxEntries is the default maximum number of entries of a MemoryCache
+const MemoryCacheDefaultMaxEntries = 10000
+
+// memoryCacheEvictionSamples is the number of entries sampled to find
+// the entry to evict, when a MemoryCache is full
+const memoryCacheEvictionSamples = 16
+
+// MemoryCache is an in-memory cache holding up to a maximum number of
+// entries. Expired entries are removed when read, or when the cache
+// is full, else the entry expiring first of a random sample is evicted
+type MemoryCache struct {
+	mu         sync.Mutex
+	entries    map[string]memoryCacheEntry
+	maxEntries int
+}
+
+// memoryCacheEntry is a cached value with its expiry (zero for never)
+type memoryCacheEntry struct {
+	value     []byte
+	expiresAt time.Time
+}
+
+// NewMemoryCache creates a new in-memory cache
+// of up to MemoryCacheDefaultMaxEntries entries
+func NewMemoryCache() *MemoryCache {
+	return &MemoryCache{entries: map[string]memoryCacheEntry{}, maxEntries: MemoryCacheDefaultMaxEntries}
+}
+
+// SetMaxEntries sets the maximum number of entries (0 for no limit)
+func (c *MemoryCache) SetMaxEntries(maxEntries int) *MemoryCache {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.maxEntries = maxEntries
+	for c.maxEntries > 0 && len(c.entries) > c.maxEntries {
+		c.evict(time.Now())
+	}
+	return c
+}
+
+// Len returns the number of entries, including the expired
+// entries, which have not been removed yet
+func (c *MemoryCache) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return len(c.entries)
+}
+
+// evict removes an entry, preferring an expired entry of a random
+// sample, or else the entry of the sample expiring first
+func (c *MemoryCache) evict(now time.Time) {
+	victim := ""
+	var victimExpiresAt time.Time
+	sampled := 0
+
+	for key, entry := range c.entries {
+		if !entry.expiresAt.IsZero() && !now.Before(entry.expiresAt) {
+			victim = key
+			break
+		}
+
+		if sampled == 0 || (!entry.expiresAt.IsZero() && (victimExpiresAt.IsZero() || entry.expiresAt.Before(victimExpiresAt))) {
+			victim = key
+			victimExpiresAt = entry.expiresAt
+		}
+
+		sampled++
+		if sampled >= memoryCacheEvictionSamples {
+			break
+		}
+	}
+
+	delete(c.entries, victim)
+}
+
+// Get returns the value of the key, and false if it is missing or expired
+func (c *MemoryCache) Get(ctx context.Context, key string) ([]byte, bool, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	entry, exists := c.entries[key]
+	if !exists {
+		return nil, false, nil
+	}
+
+	if !entry.expiresAt.IsZero() && !time.Now().Before(entry.expiresAt) {
+		delete(c.entries, key)
+		return nil, false, nil
+	}
+
+	return append([]byte{}, entry.value...), true, nil
+}
+
+// Set sets the value of the key, expiring after the TTL (never if 0)
+func (c *MemoryCache) Set(ctx context.Context, key string, value []byte, ttl time.Duration) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	now := time.Now()
+
+	if _, exists := c.entries[key]; !exists && c.maxEntries > 0 && len(c.entries) >= c.maxEntries {
+		c.evict(now)
+	}
+
+	entry := memoryCacheEntry{value: append([]byte{}, value...)}
+	if ttl > 0 {
+		entry.expiresAt = now.Add(ttl)
+	}
+	c.entries[key] = entry
+
+	return nil
+}
+
+// Delete deletes the key
+func (c *MemoryCache) Delete(ctx context.Context, key string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	delete(c.entries, key)
+
+	return nil
+}
@@ -0,0 +1,233 @@
+package dataobject
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// slowRepository counts the finds of the decorated repository, delaying them
+type slowRepository struct {
+	*MemoryRepository
+	finds atomic.Int32
+}
+
+func (repo *slowRepository) Find(ctx context.Context, id string) (DataObjectInterface, error) {
+	repo.finds.Add(1)
+	time.Sleep(20 * time.Millisecond)
+	return repo.MemoryRepository.Find(ctx, id)
+}
+
+func TestCachedFinder(t *testing.T) {
+	for _, encoding := range []CacheEncoding{CacheEncodingJSON, CacheEncodingGob} {
+		ctx := context.Background()
+		repo := &slowRepository{MemoryRepository: NewMemoryRepository()}
+		_ = repo.Create(ctx, NewDataObjectFromExistingData(map[string]string{"id": "1", "name": "Jon"}))
+
+		finder := NewCachedFinder(repo, NewMemoryCache()).SetEncoding(encoding)
+
+		wg := sync.WaitGroup{}
+		for i := 0; i < 10; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				found, err := finder.Find(ctx, "1")
+				if err != nil || found.Data()["name"] != "Jon" {
+					t.Error("Expected: Jon, but found:", found, err)
+				}
+			}()
+		}
+		wg.Wait()
+
+		if _, err := finder.Find(ctx, "1"); err != nil {
+			t.Fatal("Error must be nil, but found:", err.Error())
+		}
+
+		if repo.finds.Load() != 1 {
+			t.Error("Expected: 1, but found:", repo.finds.Load())
+		}
+
+		_ = finder.Invalidate(ctx, "1")
+		_, _ = finder.Find(ctx, "1")
+
+		if repo.finds.Load() != 2 {
+			t.Error("Expected: 2, but found:", repo.finds.Load())
+		}
+
+		if _, err := finder.Find(ctx, "2"); !errors.Is(err, ErrNotFound) {
+			t.Error("Expected: ErrNotFound, but found:", err)
+		}
+	}
+}
+
+func TestCachedFind(t *testing.T) {
+	ctx := context.Background()
+	repo := &slowRepository{MemoryRepository: NewMemoryRepository()}
+	cache := NewMemoryCache()
+	_ = repo.Create(ctx, NewDataObjectFromExistingData(map[string]string{"id": "1"}))
+
+	_, _ = CachedFind(ctx, repo, cache, "1")
+	found, err := CachedFind(ctx, repo, cache, "1")
+
+	if err != nil || found.ID() != "1" {
+		t.Fatal("Expected: 1, but found:", found, err)
+	}
+
+	if repo.finds.Load() != 1 {
+		t.Error("Expected: 1, but found:", repo.finds.Load())
+	}
+}
+
+func TestMemoryCacheTTL(t *testing.T) {
+	ctx := context.Background()
+	cache := NewMemoryCache()
+
+	_ = cache.Set(ctx, "key", []byte("value"), time.Millisecond)
+	time.Sleep(2 * time.Millisecond)
+
+	if _, found, _ := cache.Get(ctx, "key"); found {
+		t.Error("Expected: false, but found:", found)
+	}
+}
+
+func TestCachedRepository(t *testing.T) {
+	ctx := context.Background()
+	inner := &slowRepository{MemoryRepository: NewMemoryRepository()}
+	repo := NewCachedRepository(inner, NewMemoryCache())
+	_ = repo.Create(ctx, NewDataObjectFromExistingData(map[string]string{"id": "1", "name": "Jon"}))
+
+	_, _ = repo.Find(ctx, "1")
+	_ = repo.Update(ctx, NewDataObjectFromExistingData(map[string]string{"id": "1", "name": "Jane"}))
+
+	found, err := repo.Find(ctx, "1")
+	if err != nil || found.Data()["name"] != "Jane" {
+		t.Error("Expected: Jane after the update, but found:", found, err)
+	}
+
+	_ = repo.Delete(ctx, "1")
+
+	if _, err := repo.Find(ctx, "1"); !errors.Is(err, ErrNotFound) {
+		t.Error("Expected: ErrNotFound after the delete, but found:", err)
+	}
+
+	if inner.finds.Load() != 3 {
+		t.Error("Expected: 3, but found:", inner.finds.Load())
+	}
+}
+
+func TestCachedFinderCanceledCaller(t *testing.T) {
+	ctx := context.Background()
+	repo := &slowRepository{MemoryRepository: NewMemoryRepository()}
+	_ = repo.Create(ctx, NewDataObjectFromExistingData(map[string]string{"id": "1"}))
+	finder := NewCachedFinder(repo, NewMemoryCache())
+
+	canceled, cancel := context.WithCancel(ctx)
+	leaderErr := make(chan error)
+	go func() {
+		_, err := finder.Find(canceled, "1")
+		leaderErr <- err
+	}()
+
+	time.Sleep(5 * time.Millisecond)
+	cancel()
+
+	if err := <-leaderErr; !errors.Is(err, context.Canceled) {
+		t.Error("Expected: context.Canceled, but found:", err)
+	}
+
+	found, err := finder.Find(ctx, "1")
+	if err != nil || found.ID() != "1" {
+		t.Error("Expected: the shared read not to be canceled, but found:", found, err)
+	}
+
+	if repo.finds.Load() != 1 {
+		t.Error("Expected: 1, but found:", repo.finds.Load())
+	}
+}
+
+func TestMemoryCacheMaxEntries(t *testing.T) {
+	ctx := context.Background()
+	cache := NewMemoryCache().SetMaxEntries(10)
+
+	_ = cache.Set(ctx, "expired", []byte("value"), time.Millisecond)
+	time.Sleep(2 * time.Millisecond)
+
+	for i := 0; i < 100; i++ {
+		_ = cache.Set(ctx, strconv.Itoa(i), []byte("value"), time.Minute)
+	}
+
+	if cache.Len() != 10 {
+		t.Error("Expected: 10, but found:", cache.Len())
+	}
+
+	if _, found, _ := cache.Get(ctx, "99"); !found {
+		t.Error("Expected: the last entry to be cached, but found:", found)
+	}
+}
+
+// blockingRepository reads the object of the decorated repository, then
+// blocks the find until released
+type blockingRepository struct {
+	*MemoryRepository
+	read    chan struct{}
+	release chan struct{}
+}
+
+func (repo *blockingRepository) Find(ctx context.Context, id string) (DataObjectInterface, error) {
+	found, err := repo.MemoryRepository.Find(ctx, id)
+	repo.read <- struct{}{}
+	<-repo.release
+	return found, err
+}
+
+func TestCachedFinderInvalidatedLoad(t *testing.T) {
+	ctx := context.Background()
+	inner := NewMemoryRepository()
+	_ = inner.Create(ctx, NewDataObjectFromExistingData(map[string]string{"id": "1", "name": "Jon"}))
+	repo := &blockingRepository{MemoryRepository: inner, read: make(chan struct{}, 2), release: make(chan struct{})}
+	finder := NewCachedFinder(repo, NewMemoryCache())
+
+	go func() {
+		_, _ = finder.Find(ctx, "1")
+	}()
+	<-repo.read
+
+	_ = inner.Update(ctx, NewDataObjectFromExistingData(map[string]string{"id": "1", "name": "Jane"}))
+	if err := finder.Invalidate(ctx, "1"); err != nil {
+		t.Fatal("Error must be nil, but found:", err)
+	}
+
+	close(repo.release)
+
+	found, err := finder.Find(ctx, "1")
+	if err != nil {
+		t.Fatal("Error must be nil, but found:", err)
+	}
+
+	if found.Data()["name"] != "Jane" {
+		t.Error("Expected: Jane, but found:", found.Data()["name"])
+	}
+
+	found, _ = finder.Find(ctx, "1")
+	if found.Data()["name"] != "Jane" {
+		t.Error("Expected: Jane, but found:", found.Data()["name"])
+	}
+}
+
+func TestMemoryCacheGetCopy(t *testing.T) {
+	ctx := context.Background()
+	cache := NewMemoryCache()
+	_ = cache.Set(ctx, "key", []byte("value"), time.Minute)
+
+	value, _, _ := cache.Get(ctx, "key")
+	value[0] = 'V'
+
+	value, _, _ = cache.Get(ctx, "key")
+	if string(value) != "value" {
+		t.Error("Expected: value, but found:", string(value))
+	}
+}
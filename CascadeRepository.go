@@ -0,0 +1,192 @@
+package dataobject
+
+import (
+	"context"
+	"fmt"
+)
+
+// CascadeAction is what happens to the related objects when an object is deleted
+type CascadeAction string
+
+const (
+	// CascadeDelete deletes the related objects
+	CascadeDelete CascadeAction = "delete"
+
+	// CascadeNullify empties the key of the related objects
+	CascadeNullify CascadeAction = "nullify"
+
+	// CascadeRestrict refuses to delete the object, while it has related objects
+	CascadeRestrict CascadeAction = "restrict"
+)
+
+// CascadeRule declares what happens to the objects of the repository,
+// which reference a deleted object in the key
+type CascadeRule struct {
+	// Repository is the repository of the related objects,
+	// it must be a Querier (i.e. MemoryRepository)
+	Repository DataObjectRepositoryInterface
+
+	// Key is the key of the related objects holding the ID of the object
+	Key string
+
+	// Action is what happens to the related objects
+	Action CascadeAction
+}
+
+var _ DataObjectRepositoryInterface = (*CascadeRepository)(nil) // verify it extends the repository interface
+var _ Querier = (*CascadeRepository)(nil)                       // verify it supports queries
+
+// CascadeRepository decorates a repository enforcing the cascade
+// rules on Delete, so aggregates do not leave orphaned objects.
+// Child repositories can be cascade repositories themselves
+//
+// Example:
+//
+//	comments := NewCascadeRepository(commentsRepo)
+//	posts := NewCascadeRepository(postsRepo,
+//		CascadeRule{Repository: comments, Key: "post_id", Action: CascadeDelete},
+//	)
+//	users := NewCascadeRepository(usersRepo,
+//		CascadeRule{Repository: posts, Key: "author_id", Action: CascadeRestrict},
+//	)
+type CascadeRepository struct {
+	DataObjectRepositoryInterface
+	rules []CascadeRule
+}
+
+// NewCascadeRepository creates a new cascade decorator of the repository
+func NewCascadeRepository(inner DataObjectRepositoryInterface, rules ...CascadeRule) *CascadeRepository {
+	return &CascadeRepository{DataObjectRepositoryInterface: inner, rules: rules}
+}
+
+// AddRule adds a cascade rule, i.e. a self-referential rule
+// with the repository itself as the related repository
+func (repo *CascadeRepository) AddRule(rule CascadeRule) *CascadeRepository {
+	repo.rules = append(repo.rules, rule)
+	return repo
+}
+
+// Delete deletes the object with the ID applying the cascade rules.
+// Returns an error wrapping ErrDeleteRestricted, if a restrict rule
+// has related objects, also of an object the delete cascades to
+// (a child cascade repository), in which case nothing is deleted
+//
+// The objects to delete are collected before anything is deleted, each
+// only once, so deletes along reference cycles terminate
+func (repo *CascadeRepository) Delete(ctx context.Context, id string) error {
+	if _, err := repo.DataObjectRepositoryInterface.Find(ctx, id); err != nil {
+		return err
+	}
+
+	plan := &cascadePlan{visited: map[cascadeVisit]struct{}{}}
+	if err := repo.plan(ctx, id, plan); err != nil {
+		return err
+	}
+
+	for _, nullify := range plan.nullify {
+		if err := nullify.rule.nullify(ctx, nullify.object); err != nil {
+			return err
+		}
+	}
+
+	for _, visit := range plan.deletes {
+		if err := visit.delete(ctx); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// cascadeVisit is an object of a repository visited by plan
+type cascadeVisit struct {
+	repo DataObjectRepositoryInterface
+	id   string
+}
+
+// delete deletes the object, without applying the cascade rules
+// of a cascade repository again, as they are already planned
+func (visit cascadeVisit) delete(ctx context.Context) error {
+	if cascade, isCascade := visit.repo.(*CascadeRepository); isCascade {
+		return cascade.DataObjectRepositoryInterface.Delete(ctx, visit.id)
+	}
+	return visit.repo.Delete(ctx, visit.id)
+}
+
+// cascadePlan is what a delete does: the related objects to nullify,
+// and the objects to delete, the related objects before the objects
+// referenced by them
+type cascadePlan struct {
+	visited map[cascadeVisit]struct{}
+	nullify []cascadeNullify
+	deletes []cascadeVisit
+}
+
+// cascadeNullify is a related object to nullify by the rule
+type cascadeNullify struct {
+	rule   CascadeRule
+	object DataObjectInterface
+}
+
+// plan adds the deletion of the ID, and the actions on its related
+// objects to the plan, before anything is deleted, checking the
+// restrict rules of the objects the delete cascades to recursively.
+// Objects already visited are skipped, so cyclic rules terminate
+func (repo *CascadeRepository) plan(ctx context.Context, id string, plan *cascadePlan) error {
+	plan.visited[cascadeVisit{repo: repo, id: id}] = struct{}{}
+
+	for _, rule := range repo.rules {
+		objects, err := rule.related(ctx, id)
+		if err != nil {
+			return err
+		}
+
+		if rule.Action == CascadeRestrict && len(objects) > 0 {
+			return fmt.Errorf("%w: %s has %d related objects by %s", ErrDeleteRestricted, id, len(objects), rule.Key)
+		}
+
+		for _, do := range objects {
+			switch rule.Action {
+			case CascadeNullify:
+				plan.nullify = append(plan.nullify, cascadeNullify{rule: rule, object: do})
+			case CascadeDelete:
+				visit := cascadeVisit{repo: rule.Repository, id: do.ID()}
+				if _, seen := plan.visited[visit]; seen {
+					continue
+				}
+
+				if child, isCascade := rule.Repository.(*CascadeRepository); isCascade {
+					if err := child.plan(ctx, do.ID(), plan); err != nil {
+						return err
+					}
+					continue
+				}
+
+				plan.visited[visit] = struct{}{}
+				plan.deletes = append(plan.deletes, visit)
+			}
+		}
+	}
+
+	plan.deletes = append(plan.deletes, cascadeVisit{repo: repo, id: id})
+
+	return nil
+}
+
+// Query returns the objects matching the query,
+// if the decorated repository supports queries
+func (repo *CascadeRepository) Query(ctx context.Context, query *Query) ([]DataObjectInterface, error) {
+	return forwardQuery(ctx, repo.DataObjectRepositoryInterface, query)
+}
+
+// related returns the objects, which reference the ID in the key
+func (rule CascadeRule) related(ctx context.Context, id string) ([]DataObjectInterface, error) {
+	return forwardQuery(ctx, rule.Repository, Where(rule.Key).Eq(id))
+}
+
+// nullify empties the key of the related object
+func (rule CascadeRule) nullify(ctx context.Context, do DataObjectInterface) error {
+	nullified := NewDataObjectFromExistingData(copyData(do.Data()))
+	nullified.Set(rule.Key, "")
+	return rule.Repository.Update(ctx, nullified)
+}
@@ -0,0 +1,111 @@
+package dataobject
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+func TestCascadeRepository(t *testing.T) {
+	ctx := context.Background()
+
+	comments := NewCascadeRepository(NewMemoryRepository())
+	drafts := NewMemoryRepository()
+	posts := NewCascadeRepository(NewMemoryRepository(),
+		CascadeRule{Repository: comments, Key: "post_id", Action: CascadeDelete},
+		CascadeRule{Repository: drafts, Key: "post_id", Action: CascadeNullify},
+	)
+	users := NewCascadeRepository(NewMemoryRepository(),
+		CascadeRule{Repository: posts, Key: "author_id", Action: CascadeRestrict},
+	)
+
+	_ = users.Create(ctx, NewDataObjectFromExistingData(map[string]string{"id": "u1"}))
+	_ = posts.Create(ctx, NewDataObjectFromExistingData(map[string]string{"id": "p1", "author_id": "u1"}))
+	_ = comments.Create(ctx, NewDataObjectFromExistingData(map[string]string{"id": "c1", "post_id": "p1"}))
+	_ = comments.Create(ctx, NewDataObjectFromExistingData(map[string]string{"id": "c2", "post_id": "p2"}))
+	_ = drafts.Create(ctx, NewDataObjectFromExistingData(map[string]string{"id": "d1", "post_id": "p1"}))
+
+	if err := users.Delete(ctx, "u1"); !errors.Is(err, ErrDeleteRestricted) {
+		t.Error("Expected: ErrDeleteRestricted, but found:", err)
+	}
+
+	if err := posts.Delete(ctx, "p1"); err != nil {
+		t.Fatal("Error must be nil, but found:", err.Error())
+	}
+
+	if count, _ := comments.Count(ctx); count != 1 {
+		t.Error("Expected: 1, but found:", count)
+	}
+
+	draft, _ := drafts.Find(ctx, "d1")
+
+	if draft.Data()["post_id"] != "" {
+		t.Error("Expected: empty, but found:", draft.Data()["post_id"])
+	}
+
+	if err := users.Delete(ctx, "u1"); err != nil {
+		t.Error("Error must be nil, but found:", err.Error())
+	}
+
+	if err := users.Delete(ctx, "u1"); !errors.Is(err, ErrNotFound) {
+		t.Error("Expected: ErrNotFound, but found:", err)
+	}
+}
+
+func TestCascadeRepositoryRestrictedDeep(t *testing.T) {
+	ctx := context.Background()
+
+	payments := NewMemoryRepository()
+	orders := NewCascadeRepository(NewMemoryRepository(),
+		CascadeRule{Repository: payments, Key: "order_id", Action: CascadeRestrict},
+	)
+	sessions := NewMemoryRepository()
+	users := NewCascadeRepository(NewMemoryRepository(),
+		CascadeRule{Repository: sessions, Key: "user_id", Action: CascadeDelete},
+		CascadeRule{Repository: orders, Key: "user_id", Action: CascadeDelete},
+	)
+
+	_ = users.Create(ctx, NewDataObjectFromExistingData(map[string]string{"id": "u1"}))
+	_ = sessions.Create(ctx, NewDataObjectFromExistingData(map[string]string{"id": "s1", "user_id": "u1"}))
+	_ = orders.Create(ctx, NewDataObjectFromExistingData(map[string]string{"id": "o1", "user_id": "u1"}))
+	_ = orders.Create(ctx, NewDataObjectFromExistingData(map[string]string{"id": "o2", "user_id": "u1"}))
+	_ = payments.Create(ctx, NewDataObjectFromExistingData(map[string]string{"id": "pay1", "order_id": "o2"}))
+
+	if err := users.Delete(ctx, "u1"); !errors.Is(err, ErrDeleteRestricted) {
+		t.Error("Expected: ErrDeleteRestricted, but found:", err)
+	}
+
+	for name, repo := range map[string]DataObjectRepositoryInterface{"users": users, "sessions": sessions, "orders": orders} {
+		if count, _ := repo.Count(ctx); count == 0 {
+			t.Error("Expected: nothing deleted from", name, "but found:", count)
+		}
+	}
+
+	if count, _ := orders.Count(ctx); count != 2 {
+		t.Error("Expected: 2 orders, but found:", count)
+	}
+}
+
+func TestCascadeRepositoryCycle(t *testing.T) {
+	ctx := context.Background()
+
+	nodes := NewCascadeRepository(NewMemoryRepository())
+	nodes.AddRule(CascadeRule{Repository: nodes, Key: "parent_id", Action: CascadeDelete})
+
+	_ = nodes.Create(ctx, NewDataObjectFromExistingData(map[string]string{"id": "1", "parent_id": "2"}))
+	_ = nodes.Create(ctx, NewDataObjectFromExistingData(map[string]string{"id": "2", "parent_id": "1"}))
+	_ = nodes.Create(ctx, NewDataObjectFromExistingData(map[string]string{"id": "3", "parent_id": "2"}))
+	_ = nodes.Create(ctx, NewDataObjectFromExistingData(map[string]string{"id": "4"}))
+
+	if err := nodes.Delete(ctx, "1"); err != nil {
+		t.Fatal("Error must be nil, but found:", err.Error())
+	}
+
+	if count, _ := nodes.Count(ctx); count != 1 {
+		t.Error("Expected: 1 node left, but found:", count)
+	}
+
+	if _, err := nodes.Find(ctx, "4"); err != nil {
+		t.Error("Error must be nil, but found:", err.Error())
+	}
+}
@@ -0,0 +1,51 @@
+package dataobject
+
+import (
+	"context"
+	"sort"
+)
+
+// ChangeEvent describes the changes made to a data object
+type ChangeEvent struct {
+	// ObjectID is the ID of the changed object
+	ObjectID string `json:"object_id"`
+
+	// ChangedKeys are the keys, which have been changed or removed, sorted
+	ChangedKeys []string `json:"changed_keys"`
+
+	// Values are the new values of the changed keys (removed keys are omitted)
+	Values map[string]string `json:"values"`
+
+	// Action optionally names the operation, which caused the changes (i.e. "trash")
+	Action string `json:"action,omitempty"`
+
+	// EventID optionally identifies the event, so the receivers can
+	// deduplicate redelivered events (i.e. of OutboxRelay)
+	EventID string `json:"event_id,omitempty"`
+}
+
+// EventEmitter delivers change events, i.e. the WebhookEmitter
+type EventEmitter interface {
+	Emit(ctx context.Context, event ChangeEvent) error
+}
+
+// NewChangeEvent creates a change event from the changed data of the object
+func NewChangeEvent(do DataObjectInterface) ChangeEvent {
+	event := ChangeEvent{
+		ObjectID:    do.ID(),
+		ChangedKeys: []string{},
+		Values:      copyData(do.DataChanged()),
+	}
+
+	for key := range event.Values {
+		event.ChangedKeys = append(event.ChangedKeys, key)
+	}
+
+	if removable, ok := do.(interface{ DataRemoved() []string }); ok {
+		event.ChangedKeys = append(event.ChangedKeys, removable.DataRemoved()...)
+	}
+
+	sort.Strings(event.ChangedKeys)
+
+	return event
+}
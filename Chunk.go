@@ -0,0 +1,36 @@
+package dataobject
+
+// Chunk splits the objects into chunks of the specified size.
+// The last chunk may be smaller. A size less than 1 results
+// in a single chunk with all the objects
+func Chunk(objects []DataObjectInterface, size int) [][]DataObjectInterface {
+	chunks := [][]DataObjectInterface{}
+
+	_ = ForEachChunk(objects, size, func(chunk []DataObjectInterface) error {
+		chunks = append(chunks, chunk)
+		return nil
+	})
+
+	return chunks
+}
+
+// ForEachChunk calls fn for each consecutive chunk of the objects
+// of the specified size, without allocating all the chunks upfront
+//
+// The iteration stops at the first error returned by fn,
+// and the error is returned, so that the failed chunk can be retried
+func ForEachChunk(objects []DataObjectInterface, size int, fn func(chunk []DataObjectInterface) error) error {
+	if size < 1 {
+		size = len(objects)
+	}
+
+	for start := 0; start < len(objects); start += size {
+		end := min(start+size, len(objects))
+
+		if err := fn(objects[start:end:end]); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
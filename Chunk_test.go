@@ -0,0 +1,48 @@
+package dataobject
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestChunk(t *testing.T) {
+	objects := []DataObjectInterface{}
+	for i := 0; i < 5; i++ {
+		objects = append(objects, NewDataObject())
+	}
+
+	chunks := Chunk(objects, 2)
+
+	if len(chunks) != 3 {
+		t.Fatal("Expected: 3, but found:", len(chunks))
+	}
+
+	if len(chunks[2]) != 1 {
+		t.Error("Expected: 1, but found:", len(chunks[2]))
+	}
+
+	if len(Chunk(objects, 0)) != 1 {
+		t.Error("Expected: 1, but found:", len(Chunk(objects, 0)))
+	}
+}
+
+func TestForEachChunkStopsOnError(t *testing.T) {
+	objects := []DataObjectInterface{}
+	for i := 0; i < 5; i++ {
+		objects = append(objects, NewDataObject())
+	}
+
+	calls := 0
+	err := ForEachChunk(objects, 2, func(chunk []DataObjectInterface) error {
+		calls++
+		return errors.New("failed")
+	})
+
+	if err == nil {
+		t.Error("Error must NOT be nil, but found:", err)
+	}
+
+	if calls != 1 {
+		t.Error("Expected: 1, but found:", calls)
+	}
+}
@@ -0,0 +1,408 @@
+package dataobject
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/gob"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+)
+
+// Codec encodes the data of objects to bytes and back, so repositories
+// and caches can switch formats without changing the calling code
+// (see CachedFinder.WithCodec)
+type Codec interface {
+	// Name returns the name of the format (i.e. "json")
+	Name() string
+
+	// Encode encodes the data
+	Encode(data map[string]string) ([]byte, error)
+
+	// Decode decodes the data
+	Decode(encoded []byte) (map[string]string, error)
+}
+
+var _ Codec = JSONCodec{}    // verify it is a codec
+var _ Codec = GobCodec{}     // verify it is a codec
+var _ Codec = MsgpackCodec{} // verify it is a codec
+var _ Codec = CBORCodec{}    // verify it is a codec
+
+// JSONCodec encodes the data as a JSON object
+type JSONCodec struct{}
+
+// Name returns "json"
+func (JSONCodec) Name() string {
+	return "json"
+}
+
+// Encode encodes the data as a JSON object with sorted keys
+func (JSONCodec) Encode(data map[string]string) ([]byte, error) {
+	return json.Marshal(data)
+}
+
+// Decode decodes a JSON object with string values
+func (JSONCodec) Decode(encoded []byte) (map[string]string, error) {
+	data := map[string]string{}
+	if err := json.Unmarshal(encoded, &data); err != nil {
+		return nil, err
+	}
+	return data, nil
+}
+
+// GobCodec encodes the data with encoding/gob
+type GobCodec struct{}
+
+// Name returns "gob"
+func (GobCodec) Name() string {
+	return "gob"
+}
+
+// Encode encodes the data with gob
+func (GobCodec) Encode(data map[string]string) ([]byte, error) {
+	buffer := bytes.Buffer{}
+	if err := gob.NewEncoder(&buffer).Encode(data); err != nil {
+		return nil, err
+	}
+	return buffer.Bytes(), nil
+}
+
+// Decode decodes the data with gob
+func (GobCodec) Decode(encoded []byte) (map[string]string, error) {
+	data := map[string]string{}
+	if err := gob.NewDecoder(bytes.NewReader(encoded)).Decode(&data); err != nil {
+		return nil, err
+	}
+	return data, nil
+}
+
+// MsgpackCodec encodes the data as a MessagePack map of strings, with sorted keys
+type MsgpackCodec struct{}
+
+// Name returns "msgpack"
+func (MsgpackCodec) Name() string {
+	return "msgpack"
+}
+
+// Encode encodes the data as a MessagePack map
+func (MsgpackCodec) Encode(data map[string]string) ([]byte, error) {
+	keys := make([]string, 0, len(data))
+	for key := range data {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	buffer := bytes.Buffer{}
+
+	switch n := len(keys); {
+	case n < 16:
+		buffer.WriteByte(0x80 | byte(n))
+	case n <= 0xffff:
+		buffer.WriteByte(0xde)
+		buffer.Write(binary.BigEndian.AppendUint16(nil, uint16(n)))
+	default:
+		buffer.WriteByte(0xdf)
+		buffer.Write(binary.BigEndian.AppendUint32(nil, uint32(n)))
+	}
+
+	for _, key := range keys {
+		writeMsgpackString(&buffer, key)
+		writeMsgpackString(&buffer, data[key])
+	}
+
+	return buffer.Bytes(), nil
+}
+
+// Decode decodes a MessagePack map of strings
+func (MsgpackCodec) Decode(encoded []byte) (map[string]string, error) {
+	reader := msgpackReader{b: encoded}
+
+	n, err := reader.mapLength()
+	if err != nil {
+		return nil, err
+	}
+
+	data := make(map[string]string, min(n, len(encoded)/2))
+	for i := 0; i < n; i++ {
+		key, err := reader.string()
+		if err != nil {
+			return nil, err
+		}
+		value, err := reader.string()
+		if err != nil {
+			return nil, err
+		}
+		data[key] = value
+	}
+
+	if len(reader.b) > 0 {
+		return nil, errMsgpackTrailingData
+	}
+
+	return data, nil
+}
+
+var (
+	errMsgpackTruncated    = errors.New("msgpack: truncated data")
+	errMsgpackTrailingData = errors.New("msgpack: unexpected data after the map")
+)
+
+// writeMsgpackString writes the string with the shortest header
+func writeMsgpackString(buffer *bytes.Buffer, s string) {
+	switch n := len(s); {
+	case n < 32:
+		buffer.WriteByte(0xa0 | byte(n))
+	case n <= 0xff:
+		buffer.WriteByte(0xd9)
+		buffer.WriteByte(byte(n))
+	case n <= 0xffff:
+		buffer.WriteByte(0xda)
+		buffer.Write(binary.BigEndian.AppendUint16(nil, uint16(n)))
+	default:
+		buffer.WriteByte(0xdb)
+		buffer.Write(binary.BigEndian.AppendUint32(nil, uint32(n)))
+	}
+	buffer.WriteString(s)
+}
+
+// msgpackReader reads MessagePack values from the bytes
+type msgpackReader struct {
+	b []byte
+}
+
+// next consumes the next n bytes
+func (r *msgpackReader) next(n int) ([]byte, error) {
+	if n < 0 || len(r.b) < n {
+		return nil, errMsgpackTruncated
+	}
+	next := r.b[:n]
+	r.b = r.b[n:]
+	return next, nil
+}
+
+// length reads a big endian length of the size in bytes
+func (r *msgpackReader) length(size int) (int, error) {
+	b, err := r.next(size)
+	if err != nil {
+		return 0, err
+	}
+	switch size {
+	case 1:
+		return int(b[0]), nil
+	case 2:
+		return int(binary.BigEndian.Uint16(b)), nil
+	default:
+		return int(binary.BigEndian.Uint32(b)), nil
+	}
+}
+
+func (r *msgpackReader) mapLength() (int, error) {
+	header, err := r.next(1)
+	if err != nil {
+		return 0, err
+	}
+	switch h := header[0]; {
+	case h&0xf0 == 0x80:
+		return int(h & 0x0f), nil
+	case h == 0xde:
+		return r.length(2)
+	case h == 0xdf:
+		return r.length(4)
+	default:
+		return 0, fmt.Errorf("msgpack: expected a map, but found 0x%02x", h)
+	}
+}
+
+func (r *msgpackReader) string() (string, error) {
+	header, err := r.next(1)
+	if err != nil {
+		return "", err
+	}
+
+	var n int
+	switch h := header[0]; {
+	case h&0xe0 == 0xa0:
+		n = int(h & 0x1f)
+	case h == 0xd9:
+		n, err = r.length(1)
+	case h == 0xda:
+		n, err = r.length(2)
+	case h == 0xdb:
+		n, err = r.length(4)
+	default:
+		return "", fmt.Errorf("msgpack: expected a string, but found 0x%02x", h)
+	}
+	if err != nil {
+		return "", err
+	}
+
+	s, err := r.next(n)
+	return string(s), err
+}
+
+// CBORCodec encodes the data as a CBOR (RFC 8949) map of text strings, with sorted keys
+type CBORCodec struct{}
+
+// Name returns "cbor"
+func (CBORCodec) Name() string {
+	return "cbor"
+}
+
+// Encode encodes the data as a CBOR map
+func (CBORCodec) Encode(data map[string]string) ([]byte, error) {
+	keys := make([]string, 0, len(data))
+	for key := range data {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	encoded := appendCBORHead(nil, cborMajorMap, uint64(len(keys)))
+	for _, key := range keys {
+		encoded = appendCBORHead(encoded, cborMajorText, uint64(len(key)))
+		encoded = append(encoded, key...)
+		encoded = appendCBORHead(encoded, cborMajorText, uint64(len(data[key])))
+		encoded = append(encoded, data[key]...)
+	}
+
+	return encoded, nil
+}
+
+// Decode decodes a CBOR map of definite length text strings
+func (CBORCodec) Decode(encoded []byte) (map[string]string, error) {
+	reader := cborReader{b: encoded}
+
+	n, err := reader.head(cborMajorMap)
+	if err != nil {
+		return nil, err
+	}
+
+	data := make(map[string]string, min(n, len(encoded)/2))
+	for i := 0; i < n; i++ {
+		key, err := reader.text()
+		if err != nil {
+			return nil, err
+		}
+		value, err := reader.text()
+		if err != nil {
+			return nil, err
+		}
+		data[key] = value
+	}
+
+	if len(reader.b) > 0 {
+		return nil, errCBORTrailingData
+	}
+
+	return data, nil
+}
+
+const (
+	cborMajorText = 3
+	cborMajorMap  = 5
+)
+
+var (
+	errCBORTruncated    = errors.New("cbor: truncated data")
+	errCBORTrailingData = errors.New("cbor: unexpected data after the map")
+)
+
+// appendCBORHead appends the head of a data item of the major type with the argument
+func appendCBORHead(b []byte, major byte, argument uint64) []byte {
+	major <<= 5
+	switch {
+	case argument < 24:
+		return append(b, major|byte(argument))
+	case argument <= 0xff:
+		return append(b, major|24, byte(argument))
+	case argument <= 0xffff:
+		return binary.BigEndian.AppendUint16(append(b, major|25), uint16(argument))
+	case argument <= 0xffffffff:
+		return binary.BigEndian.AppendUint32(append(b, major|26), uint32(argument))
+	default:
+		return binary.BigEndian.AppendUint64(append(b, major|27), argument)
+	}
+}
+
+// cborReader reads CBOR data items from the bytes
+type cborReader struct {
+	b []byte
+}
+
+// head reads the head of a data item of the major type, returns the argument
+func (r *cborReader) head(major byte) (int, error) {
+	if len(r.b) < 1 {
+		return 0, errCBORTruncated
+	}
+
+	initial := r.b[0]
+	r.b = r.b[1:]
+
+	if initial>>5 != major {
+		return 0, fmt.Errorf("cbor: expected major type %d, but found 0x%02x", major, initial)
+	}
+
+	info := initial & 0x1f
+	if info < 24 {
+		return int(info), nil
+	}
+
+	size := 0
+	switch info {
+	case 24:
+		size = 1
+	case 25:
+		size = 2
+	case 26:
+		size = 4
+	case 27:
+		size = 8
+	default:
+		return 0, fmt.Errorf("cbor: unsupported additional information %d", info)
+	}
+
+	if len(r.b) < size {
+		return 0, errCBORTruncated
+	}
+
+	var argument uint64
+	for _, b := range r.b[:size] {
+		argument = argument<<8 | uint64(b)
+	}
+	r.b = r.b[size:]
+
+	if argument > uint64(len(r.b)) {
+		return 0, errCBORTruncated
+	}
+
+	return int(argument), nil
+}
+
+func (r *cborReader) text() (string, error) {
+	n, err := r.head(cborMajorText)
+	if err != nil {
+		return "", err
+	}
+	if len(r.b) < n {
+		return "", errCBORTruncated
+	}
+	s := string(r.b[:n])
+	r.b = r.b[n:]
+	return s, nil
+}
+
+// EncodeWith encodes the data of the object with the codec
+func (do *DataObject) EncodeWith(codec Codec) ([]byte, error) {
+	defer observeSerialization(codec.Name())()
+	return codec.Encode(do.Data())
+}
+
+// NewDataObjectFromCodec creates a new data object from the data
+// encoded with the codec. The ID is validated (see SetIDValidator)
+func NewDataObjectFromCodec(codec Codec, encoded []byte) (*DataObject, error) {
+	data, err := codec.Decode(encoded)
+	if err != nil {
+		return nil, fmt.Errorf("dataobject: %s: %w", codec.Name(), err)
+	}
+	return NewDataObjectFromData(data)
+}
@@ -0,0 +1,94 @@
+package dataobject
+
+import (
+	"context"
+	"strings"
+	"testing"
+)
+
+func TestCodecs(t *testing.T) {
+	data := map[string]string{
+		"id":    "1",
+		"name":  "Jon",
+		"empty": "",
+		"bio":   strings.Repeat("x", 300),
+		"huge":  strings.Repeat("y", 70000),
+	}
+	for i := 0; i < 20; i++ {
+		data["key"+toString(i)] = toString(i)
+	}
+
+	for _, codec := range []Codec{JSONCodec{}, GobCodec{}, MsgpackCodec{}, CBORCodec{}} {
+		encoded, err := codec.Encode(data)
+		if err != nil {
+			t.Fatal("Error must be nil, but found:", err.Error())
+		}
+
+		decoded, err := codec.Decode(encoded)
+		if err != nil {
+			t.Fatal("Error must be nil, but found:", err.Error())
+		}
+
+		if len(decoded) != len(data) || decoded["bio"] != data["bio"] || decoded["huge"] != data["huge"] || decoded["empty"] != "" {
+			t.Error("Expected: the same data with", codec.Name(), "but found:", len(decoded))
+		}
+
+		if _, err := codec.Decode(encoded[:len(encoded)/2]); err == nil {
+			t.Error("Error must NOT be nil for truncated", codec.Name())
+		}
+	}
+}
+
+func TestMsgpackCodecFormat(t *testing.T) {
+	encoded, _ := MsgpackCodec{}.Encode(map[string]string{"b": "2", "a": "1"})
+
+	expected := []byte{0x82, 0xa1, 'a', 0xa1, '1', 0xa1, 'b', 0xa1, '2'}
+	if string(encoded) != string(expected) {
+		t.Error("Expected:", expected, "but found:", encoded)
+	}
+}
+
+func TestCBORCodecFormat(t *testing.T) {
+	encoded, _ := CBORCodec{}.Encode(map[string]string{"b": "2", "a": "1"})
+
+	expected := []byte{0xa2, 0x61, 'a', 0x61, '1', 0x61, 'b', 0x61, '2'}
+	if string(encoded) != string(expected) {
+		t.Error("Expected:", expected, "but found:", encoded)
+	}
+}
+
+func TestNewDataObjectFromCodec(t *testing.T) {
+	do := NewDataObjectFromExistingData(map[string]string{"id": "1", "name": "Jon"})
+
+	encoded, err := do.EncodeWith(MsgpackCodec{})
+	if err != nil {
+		t.Fatal("Error must be nil, but found:", err.Error())
+	}
+
+	decoded, err := NewDataObjectFromCodec(MsgpackCodec{}, encoded)
+	if err != nil {
+		t.Fatal("Error must be nil, but found:", err.Error())
+	}
+
+	if decoded.ID() != "1" || decoded.Get("name") != "Jon" {
+		t.Error("Expected: the same data, but found:", decoded.Data())
+	}
+}
+
+func TestCachedFinderWithCodec(t *testing.T) {
+	ctx := context.Background()
+	repo := NewMemoryRepository()
+	_ = repo.Create(ctx, NewDataObjectFromExistingData(map[string]string{"id": "1", "name": "Jon"}))
+
+	cache := NewMemoryCache()
+	finder := NewCachedFinder(repo, cache).WithCodec(MsgpackCodec{})
+
+	if _, err := finder.Find(ctx, "1"); err != nil {
+		t.Fatal("Error must be nil, but found:", err.Error())
+	}
+
+	cached, found, _ := cache.Get(ctx, CacheKeyPrefix+"1")
+	if data, err := (MsgpackCodec{}).Decode(cached); !found || err != nil || data["name"] != "Jon" {
+		t.Error("Expected: the msgpack encoded object, but found:", cached)
+	}
+}
@@ -0,0 +1,30 @@
+package dataobject
+
+// Column describes a column of a table or CSV export
+type Column struct {
+	// Key is the key of the value displayed in the column
+	Key string
+
+	// Label is the column header, defaults to the key
+	Label string
+
+	// Format optionally formats the value for display
+	Format func(value string) string
+}
+
+// header returns the column header
+func (c Column) header() string {
+	if c.Label == "" {
+		return c.Key
+	}
+	return c.Label
+}
+
+// value returns the formatted value of the column for the object
+func (c Column) value(do DataObjectInterface) string {
+	value := do.Data()[c.Key]
+	if c.Format != nil {
+		return c.Format(value)
+	}
+	return value
+}
@@ -0,0 +1,85 @@
+package dataobject
+
+import (
+	"cmp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ValueKind is the kind of the values compared by CompareValues,
+// the same as the type of the schema fields (i.e. FieldTypeInt)
+type ValueKind = FieldType
+
+// CompareValues compares two values encoded the way the package
+// encodes them (i.e. "10.0000" for floats, DateTimeFormat or RFC 3339
+// for times), returning -1, 0 or +1
+//
+// Values, which cannot be parsed as the kind (including empty values),
+// sort before all parsable values and compare as strings among themselves,
+// so "9" sorts before "10" for FieldTypeInt and FieldTypeFloat
+func CompareValues(a string, b string, kind ValueKind) int {
+	switch kind {
+	case FieldTypeInt, FieldTypeFloat:
+		return compareParsed(a, b, func(value string) (float64, error) {
+			return strconv.ParseFloat(strings.TrimSpace(value), 64)
+		}, cmp.Compare[float64])
+	case FieldTypeBool:
+		return compareParsed(a, b, strconv.ParseBool, func(x bool, y bool) int {
+			return cmp.Compare(boolToInt(x), boolToInt(y))
+		})
+	case FieldTypeDateTime:
+		return compareParsed(a, b, parseDateTime, func(x, y time.Time) int {
+			return x.Compare(y)
+		})
+	default:
+		return strings.Compare(a, b)
+	}
+}
+
+// SortBy returns the objects sorted ascending by the values of the key
+// compared as the kind (see CompareValues). The sort is stable
+func SortBy(objects []DataObjectInterface, key string, kind ValueKind) []DataObjectInterface {
+	sorted := append([]DataObjectInterface{}, objects...)
+	slices.SortStableFunc(sorted, func(x DataObjectInterface, y DataObjectInterface) int {
+		return CompareValues(x.Data()[key], y.Data()[key], kind)
+	})
+	return sorted
+}
+
+// SortByDesc returns the objects sorted descending by the values of the key
+// compared as the kind (see CompareValues). The sort is stable
+func SortByDesc(objects []DataObjectInterface, key string, kind ValueKind) []DataObjectInterface {
+	sorted := append([]DataObjectInterface{}, objects...)
+	slices.SortStableFunc(sorted, func(x DataObjectInterface, y DataObjectInterface) int {
+		return CompareValues(y.Data()[key], x.Data()[key], kind)
+	})
+	return sorted
+}
+
+// compareParsed compares the values parsed with parse,
+// unparsable values sorting first and compared as strings
+func compareParsed[T any](a string, b string, parse func(string) (T, error), compare func(T, T) int) int {
+	x, errA := parse(a)
+	y, errB := parse(b)
+
+	switch {
+	case errA != nil && errB != nil:
+		return strings.Compare(a, b)
+	case errA != nil:
+		return -1
+	case errB != nil:
+		return +1
+	default:
+		return compare(x, y)
+	}
+}
+
+// boolToInt returns 1 for true and 0 for false
+func boolToInt(value bool) int {
+	if value {
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,49 @@
+package dataobject
+
+import "testing"
+
+func TestCompareValues(t *testing.T) {
+	tests := []struct {
+		a, b     string
+		kind     ValueKind
+		expected int
+	}{
+		{"9", "10", FieldTypeInt, -1},
+		{"9.5000", "10.0000", FieldTypeFloat, -1},
+		{"10", "10.0000", FieldTypeFloat, 0},
+		{"", "1", FieldTypeInt, -1},
+		{"true", "false", FieldTypeBool, +1},
+		{"2024-01-02 00:00:00", "2024-01-01T23:00:00Z", FieldTypeDateTime, +1},
+		{"9", "10", FieldTypeString, +1},
+	}
+
+	for _, test := range tests {
+		if result := CompareValues(test.a, test.b, test.kind); result != test.expected {
+			t.Error("Expected:", test.expected, "but found:", result, "for", test.a, test.b, test.kind)
+		}
+	}
+}
+
+func TestSortBy(t *testing.T) {
+	objects := []DataObjectInterface{
+		NewDataObjectFromExistingData(map[string]string{"id": "a", "price": "10.0000"}),
+		NewDataObjectFromExistingData(map[string]string{"id": "b", "price": "9.0000"}),
+		NewDataObjectFromExistingData(map[string]string{"id": "c", "price": "100.0000"}),
+	}
+
+	sorted := SortBy(objects, "price", FieldTypeFloat)
+
+	if sorted[0].ID() != "b" || sorted[1].ID() != "a" || sorted[2].ID() != "c" {
+		t.Error("Expected: b a c, but found:", sorted[0].ID(), sorted[1].ID(), sorted[2].ID())
+	}
+
+	if objects[0].ID() != "a" {
+		t.Error("Expected: a, but found:", objects[0].ID())
+	}
+
+	sorted = SortByDesc(objects, "price", FieldTypeFloat)
+
+	if sorted[0].ID() != "c" || sorted[2].ID() != "b" {
+		t.Error("Expected: c a b, but found:", sorted[0].ID(), sorted[1].ID(), sorted[2].ID())
+	}
+}
@@ -0,0 +1,217 @@
+package dataobject
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/mail"
+	"net/url"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+)
+
+// DataDictionaryMaxExamples is the maximum number of example values per key
+const DataDictionaryMaxExamples = 3
+
+// DataDictionaryEntry describes the observed usage of a single key
+type DataDictionaryEntry struct {
+	// Key is the key
+	Key string
+
+	// Count is the number of objects, which have the key
+	Count int
+
+	// Filled is the number of objects, which have a non empty value for the key
+	Filled int
+
+	// FillRate is the ratio of the objects with a non empty value to all objects
+	FillRate float64
+
+	// Types holds the number of non empty values per inferred type
+	Types map[FieldType]int
+
+	// Type is the narrowest type matching all non empty values
+	Type FieldType
+
+	// Formats holds the number of values per recognized format
+	// ("email", "url", "uuid", "json")
+	Formats map[string]int
+
+	// Examples holds up to DataDictionaryMaxExamples distinct non empty values
+	Examples []string
+}
+
+// DataDictionary is a report of all the keys in use across objects
+type DataDictionary []DataDictionaryEntry
+
+// NewDataDictionary scans the objects and reports all the keys in use,
+// their observed types and formats, fill rates and example values,
+// i.e. to understand a schema-less table before writing a Schema
+func NewDataDictionary(objects []DataObjectInterface) DataDictionary {
+	entries := map[string]*DataDictionaryEntry{}
+
+	for _, do := range objects {
+		for key, value := range do.Data() {
+			entry, exists := entries[key]
+			if !exists {
+				entry = &DataDictionaryEntry{Key: key, Types: map[FieldType]int{}, Formats: map[string]int{}}
+				entries[key] = entry
+			}
+
+			entry.Count++
+
+			if value == "" {
+				continue
+			}
+
+			entry.Filled++
+			entry.Types[inferFieldType(value)]++
+
+			if format := inferFormat(value); format != "" {
+				entry.Formats[format]++
+			}
+
+			if len(entry.Examples) < DataDictionaryMaxExamples && !slices.Contains(entry.Examples, value) {
+				entry.Examples = append(entry.Examples, value)
+			}
+		}
+	}
+
+	dictionary := make(DataDictionary, 0, len(entries))
+	for _, entry := range entries {
+		if len(objects) > 0 {
+			entry.FillRate = float64(entry.Filled) / float64(len(objects))
+		}
+		entry.Type = dominantFieldType(entry.Types)
+		dictionary = append(dictionary, *entry)
+	}
+
+	sort.Slice(dictionary, func(i, j int) bool {
+		return dictionary[i].Key < dictionary[j].Key
+	})
+
+	return dictionary
+}
+
+// NewDataDictionaryFromRepository scans all the objects of the repository
+// (see NewDataDictionary)
+func NewDataDictionaryFromRepository(ctx context.Context, repo DataObjectRepositoryInterface) (DataDictionary, error) {
+	objects, err := repo.List(ctx, 0, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewDataDictionary(objects), nil
+}
+
+// Schema returns a schema with a field per key of the inferred type.
+// Keys, which are filled in all the objects, are marked as required
+func (dd DataDictionary) Schema() *Schema {
+	fields := make([]SchemaField, 0, len(dd))
+	for _, entry := range dd {
+		fields = append(fields, SchemaField{
+			Key:      entry.Key,
+			Type:     entry.Type,
+			Required: entry.FillRate == 1,
+		})
+	}
+	return NewSchema(fields...)
+}
+
+// Write writes the report as a plain text table
+func (dd DataDictionary) Write(w io.Writer) error {
+	writer := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+
+	fmt.Fprintln(writer, "KEY\tTYPE\tFILL RATE\tFORMATS\tEXAMPLES")
+
+	for _, entry := range dd {
+		formats := []string{}
+		for format, count := range entry.Formats {
+			formats = append(formats, format+" ("+strconv.Itoa(count)+")")
+		}
+		sort.Strings(formats)
+
+		fmt.Fprintf(writer, "%s\t%s\t%.1f%%\t%s\t%s\n",
+			entry.Key,
+			entry.Type,
+			entry.FillRate*100,
+			strings.Join(formats, ", "),
+			strings.Join(entry.Examples, ", "),
+		)
+	}
+
+	return writer.Flush()
+}
+
+// inferFieldType returns the narrowest type matching the non empty value
+func inferFieldType(value string) FieldType {
+	if _, err := strconv.ParseInt(value, 10, 64); err == nil {
+		return FieldTypeInt
+	}
+	if _, err := strconv.ParseFloat(value, 64); err == nil {
+		return FieldTypeFloat
+	}
+	if value == "true" || value == "false" {
+		return FieldTypeBool
+	}
+	if _, err := parseDateTime(value); err == nil {
+		return FieldTypeDateTime
+	}
+	return FieldTypeString
+}
+
+// dominantFieldType returns the narrowest type matching all the counted types
+func dominantFieldType(types map[FieldType]int) FieldType {
+	switch {
+	case len(types) == 1:
+		for fieldType := range types {
+			return fieldType
+		}
+	case len(types) == 2 && types[FieldTypeInt] > 0 && types[FieldTypeFloat] > 0:
+		return FieldTypeFloat
+	}
+	return FieldTypeString
+}
+
+// inferFormat returns the recognized format of the value, if any
+func inferFormat(value string) string {
+	switch {
+	case isUUID(value):
+		return "uuid"
+	case strings.Contains(value, "@") && !strings.ContainsAny(value, " <>"):
+		if _, err := mail.ParseAddress(value); err == nil {
+			return "email"
+		}
+	case strings.HasPrefix(value, "http://") || strings.HasPrefix(value, "https://"):
+		if parsed, err := url.Parse(value); err == nil && parsed.Host != "" {
+			return "url"
+		}
+	case (strings.HasPrefix(value, "{") || strings.HasPrefix(value, "[")) && json.Valid([]byte(value)):
+		return "json"
+	}
+	return ""
+}
+
+// isUUID returns if the value is a UUID in the canonical 8-4-4-4-12 form
+func isUUID(value string) bool {
+	if len(value) != 36 {
+		return false
+	}
+	for i, r := range value {
+		switch i {
+		case 8, 13, 18, 23:
+			if r != '-' {
+				return false
+			}
+		default:
+			if !strings.ContainsRune("0123456789abcdefABCDEF", r) {
+				return false
+			}
+		}
+	}
+	return true
+}
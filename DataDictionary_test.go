@@ -0,0 +1,63 @@
+package dataobject
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+)
+
+func TestNewDataDictionary(t *testing.T) {
+	repo := NewMemoryRepository()
+	ctx := context.Background()
+
+	_ = repo.Create(ctx, NewDataObjectFromExistingData(map[string]string{"id": "1", "age": "30", "email": "jon@test.com", "score": "1"}))
+	_ = repo.Create(ctx, NewDataObjectFromExistingData(map[string]string{"id": "2", "age": "", "email": "jane@test.com", "score": "2.5000"}))
+	_ = repo.Create(ctx, NewDataObjectFromExistingData(map[string]string{"id": "3", "email": "joe@test.com", "score": "3"}))
+
+	dictionary, err := NewDataDictionaryFromRepository(ctx, repo)
+
+	if err != nil {
+		t.Fatal("Error must be nil, but found:", err.Error())
+	}
+
+	if len(dictionary) != 4 {
+		t.Fatal("Expected: 4, but found:", len(dictionary))
+	}
+
+	age := dictionary[0]
+
+	if age.Key != "age" || age.Count != 2 || age.Filled != 1 || age.Type != FieldTypeInt {
+		t.Error("Expected: age, 2, 1, int, but found:", age.Key, age.Count, age.Filled, age.Type)
+	}
+
+	email := dictionary[1]
+
+	if email.Formats["email"] != 3 || len(email.Examples) != DataDictionaryMaxExamples || email.FillRate != 1 {
+		t.Error("Expected: 3 emails, but found:", email.Formats, email.Examples, email.FillRate)
+	}
+
+	if dictionary[3].Type != FieldTypeFloat {
+		t.Error("Expected: float, but found:", dictionary[3].Type)
+	}
+
+	schema := dictionary.Schema()
+
+	if field, _ := schema.Field("age"); field.Required {
+		t.Error("Expected: false, but found:", field.Required)
+	}
+
+	if field, _ := schema.Field("email"); !field.Required {
+		t.Error("Expected: true, but found:", field.Required)
+	}
+
+	buffer := &bytes.Buffer{}
+
+	if err := dictionary.Write(buffer); err != nil {
+		t.Fatal("Error must be nil, but found:", err.Error())
+	}
+
+	if !strings.Contains(buffer.String(), "email (3)") {
+		t.Error("Expected the report to contain: email (3), but found:", buffer.String())
+	}
+}
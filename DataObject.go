@@ -1,12 +1,53 @@
 package dataobject
 
-import "encoding/json"
+import (
+	"encoding/json"
+	"sort"
+)
 
 var _ DataObjectInterface = (*DataObject)(nil) // verify it extends the data object interface
+var _ Serializable = (*DataObject)(nil)        // verify it is serializable
+var _ DirtyTrackable = (*DataObject)(nil)      // verify it tracks changes
+var _ TypedAccessor = (*DataObject)(nil)       // verify it provides typed access
 
 type DataObject struct {
+	// data is the base data, as last hydrated or marked as not dirty.
+	// The changes since are kept in dataChanged and dataRemoved, and
+	// merged into view on demand (see Data)
 	data        map[string]string
 	dataChanged map[string]string
+	dataRemoved map[string]struct{}
+	view        map[string]string
+	touched     bool
+
+	changeSeq uint64
+	changedAt map[string]uint64
+	removedAt map[string]uint64
+
+	beforeChangeHandlers []BeforeChangeHandler
+	changeHandlers       []ChangeHandler
+	subscriptions        []*subscription
+	batch                *changeBatch
+
+	history *undoHistory
+	clock   *lwwClock
+
+	frozen       bool
+	hashKey      string
+	suppressNoOp bool
+
+	meta map[string]any
+
+	children   map[string]*embeddedObject
+	childLists map[string]*embeddedList
+
+	aliases         map[string]string
+	keyNormalizer   KeyMapper
+	valueNormalizer ValueNormalizer
+	rawKeys         map[string]struct{}
+
+	collectErrors bool
+	fieldErrors   []FieldError
 }
 
 // ID returns the ID of the object
@@ -19,27 +60,105 @@ func (do *DataObject) SetID(id string) {
 	do.Set("id", id)
 }
 
+// RegenerateID assigns a new ID generated with the package-level
+// ID generator, keeping the old ID under the previous_id key.
+// Both keys are marked as dirty
+func (do *DataObject) RegenerateID() (oldID string, newID string) {
+	oldID = do.ID()
+	newID = generateID()
+	do.Set("previous_id", oldID)
+	do.SetID(newID)
+	return oldID, newID
+}
+
 // Data returns all the data of the object
 func (do *DataObject) Data() map[string]string {
 	do.Init()
-	return do.data
+
+	data := do.data
+	if len(do.dataChanged) > 0 || len(do.dataRemoved) > 0 {
+		if do.view == nil {
+			do.view = do.merge()
+		}
+		data = do.view
+	}
+
+	if do.frozen {
+		return copyData(data)
+	}
+	return data
+}
+
+// Original returns the value of the key before the changes made since
+// the object was hydrated or last marked as not dirty, and if it existed
+func (do *DataObject) Original(key string) (string, bool) {
+	value, exists := do.data[do.resolveAlias(key)]
+	return value, exists
+}
+
+// merge returns a copy of the base data with the changes applied
+func (do *DataObject) merge() map[string]string {
+	merged := make(map[string]string, len(do.data)+len(do.dataChanged))
+	for key, value := range do.data {
+		if _, removed := do.dataRemoved[key]; !removed {
+			merged[key] = value
+		}
+	}
+	for key, value := range do.dataChanged {
+		merged[key] = value
+	}
+	return merged
+}
+
+// lookup returns the current value of the key, and if it exists
+func (do *DataObject) lookup(key string) (string, bool) {
+	if value, changed := do.dataChanged[key]; changed {
+		return value, true
+	}
+	if _, removed := do.dataRemoved[key]; removed {
+		return "", false
+	}
+	value, exists := do.data[key]
+	return value, exists
 }
 
 // DataChanged returns only the modified data
 func (do *DataObject) DataChanged() map[string]string {
 	do.Init()
+	if do.frozen {
+		return copyData(do.dataChanged)
+	}
 	return do.dataChanged
 }
 
-// MarkAsNotDirty marks the object as not dirty
+// DataRemoved returns the keys, which have been removed, sorted
+func (do *DataObject) DataRemoved() []string {
+	do.Init()
+	keys := make([]string, 0, len(do.dataRemoved))
+	for key := range do.dataRemoved {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// MarkAsNotDirty marks the object as not dirty,
+// making the current data the base data (see Original)
 func (do *DataObject) MarkAsNotDirty() {
+	do.mustNotBeFrozen()
+	if len(do.dataChanged) > 0 || len(do.dataRemoved) > 0 {
+		do.data = do.merge()
+		do.view = nil
+	}
 	do.dataChanged = map[string]string{}
+	do.dataRemoved = map[string]struct{}{}
+	do.touched = false
 }
 
 // IsDirty returns if data has been modified
 func (do *DataObject) IsDirty() bool {
 	do.Init()
-	return len(do.dataChanged) > 0
+	return do.touched || len(do.dataChanged) > 0 || len(do.dataRemoved) > 0
 }
 
 // SetData sets the data for the object and marks it as dirty
@@ -52,31 +171,113 @@ func (do *DataObject) SetData(data map[string]string) {
 
 // Init initializes the data object if it is not already initialized
 func (do *DataObject) Init() {
-	if len(do.data) < 1 {
+	if do.data == nil {
 		do.data = map[string]string{}
 	}
-	if len(do.dataChanged) < 1 {
+	if do.dataChanged == nil {
 		do.dataChanged = map[string]string{}
 	}
+	if do.dataRemoved == nil {
+		do.dataRemoved = map[string]struct{}{}
+	}
 }
 
 // Set helper setter method
 func (do *DataObject) Set(key string, value string) {
+	do.set(key, value, do.suppressNoOp)
+}
+
+// set sets the normalized value, skipping values equal to the current
+// ones if skipEqual is set. Returns true if the value has been set
+func (do *DataObject) set(key string, value string, skipEqual bool) bool {
+	do.mustNotBeFrozen()
 	do.Init()
-	do.data[key] = value
+	key = do.resolveAlias(key)
+	value = do.normalizeValue(key, value)
+	oldValue, exists := do.lookup(key)
+	if skipEqual && exists && oldValue == value {
+		return false
+	}
+	if !do.beforeChange(key, oldValue, value) {
+		return false
+	}
 	do.dataChanged[key] = value
+	delete(do.dataRemoved, key)
+	if do.view != nil {
+		do.view[key] = value
+	}
+	do.recordChange(key, false)
+	do.change(key, oldValue, value)
+	return true
+}
+
+// Remove removes the key from the data and marks it as removed
+// see DataRemoved for the list of removed keys
+func (do *DataObject) Remove(key string) {
+	do.mustNotBeFrozen()
+	do.Init()
+	key = do.resolveAlias(key)
+	oldValue, exists := do.lookup(key)
+	if !exists {
+		return
+	}
+	if !do.beforeChange(key, oldValue, "") {
+		return
+	}
+	delete(do.dataChanged, key)
+	do.dataRemoved[key] = struct{}{}
+	if do.view != nil {
+		delete(do.view, key)
+	}
+	do.recordChange(key, true)
+	do.change(key, oldValue, "")
+}
+
+// RenameKey moves the value of the old key to the new key,
+// marking the old key as removed and the new key as changed
+func (do *DataObject) RenameKey(oldKey string, newKey string) {
+	do.Init()
+	oldKey = do.resolveAlias(oldKey)
+	newKey = do.resolveAlias(newKey)
+	value, exists := do.lookup(oldKey)
+	if !exists || oldKey == newKey {
+		return
+	}
+	do.Remove(oldKey)
+	do.Set(newKey, value)
 }
 
 // Get helper getter method
 func (do *DataObject) Get(key string) string {
-	do.Init()
-	return do.data[key]
+	value, _ := do.lookup(do.resolveAlias(key))
+	return value
 }
 
-// Hydrate sets the data for the object without marking it as dirty
+// Hydrate sets the data for the object without marking it as dirty.
+// Keys changed before, which are still present, stay marked as changed
+// with the new values. The keys and the values are normalized
+// (see WithKeyNormalizer and WithValueNormalizer)
 func (do *DataObject) Hydrate(data map[string]string) {
+	do.mustNotBeFrozen()
 	do.Init()
-	do.data = data
+	if data == nil {
+		data = map[string]string{}
+	}
+	normalized := do.normalizeValues(do.normalizeKeys(data))
+	do.data = normalized
+	do.view = nil
+	for key := range do.dataChanged {
+		if value, exists := normalized[key]; exists {
+			do.dataChanged[key] = value
+		} else {
+			delete(do.dataChanged, key)
+		}
+	}
+	for key := range do.dataRemoved {
+		if _, exists := normalized[key]; exists {
+			delete(do.dataRemoved, key)
+		}
+	}
 }
 
 // ToJSON converts the DataObject to a JSON string
@@ -85,7 +286,9 @@ func (do *DataObject) Hydrate(data map[string]string) {
 // - the JSON string representation of the DataObject
 // - an error if any
 func (do *DataObject) ToJSON() (string, error) {
-	jsonValue, jsonError := json.Marshal(do.data)
+	defer observeSerialization("json")()
+
+	jsonValue, jsonError := json.Marshal(do.Data())
 	if jsonError != nil {
 		return "", jsonError
 	}
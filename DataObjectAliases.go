@@ -0,0 +1,39 @@
+package dataobject
+
+// WithAliases registers aliases (alias to underlying key), so that
+// Get, Set and Remove with an alias transparently read and write the
+// underlying key, i.e. "email" for the legacy "user_email" column
+//
+// Data, DataChanged and ToJSON keep using the underlying keys.
+// Calling it again adds to the registered aliases
+//
+// Example:
+//
+//	user := NewDataObjectFromExistingData(row).WithAliases(map[string]string{
+//		"email": "user_email",
+//	})
+//	user.Set("email", "jon@test.com") // sets user_email
+func (do *DataObject) WithAliases(aliases map[string]string) *DataObject {
+	if do.aliases == nil {
+		do.aliases = map[string]string{}
+	}
+	for alias, key := range aliases {
+		do.aliases[do.normalizeKey(alias)] = key
+	}
+	return do
+}
+
+// Aliases returns a copy of the registered aliases
+func (do *DataObject) Aliases() map[string]string {
+	return copyData(do.aliases)
+}
+
+// resolveAlias returns the underlying key of the alias, or the key
+// itself if it is not an alias, normalized (see WithKeyNormalizer)
+func (do *DataObject) resolveAlias(key string) string {
+	key = do.normalizeKey(key)
+	if underlying, isAlias := do.aliases[key]; isAlias {
+		return do.normalizeKey(underlying)
+	}
+	return key
+}
@@ -0,0 +1,32 @@
+package dataobject
+
+import "testing"
+
+func TestWithAliases(t *testing.T) {
+	do := NewDataObjectFromExistingData(map[string]string{"id": "1", "user_email": "jon@test.com"}).
+		WithAliases(map[string]string{"email": "user_email"})
+
+	if do.Get("email") != "jon@test.com" {
+		t.Error("Expected: jon@test.com, but found:", do.Get("email"))
+	}
+
+	do.Set("email", "jane@test.com")
+
+	if do.Get("user_email") != "jane@test.com" {
+		t.Error("Expected: jane@test.com, but found:", do.Get("user_email"))
+	}
+
+	if _, exists := do.Data()["email"]; exists {
+		t.Error("Expected email NOT to be set, but found:", do.Data())
+	}
+
+	if do.DataChanged()["user_email"] != "jane@test.com" {
+		t.Error("Expected: jane@test.com, but found:", do.DataChanged())
+	}
+
+	do.Remove("email")
+
+	if _, exists := do.Data()["user_email"]; exists {
+		t.Error("Expected user_email to be removed, but found:", do.Data())
+	}
+}
@@ -0,0 +1,71 @@
+package dataobject
+
+// Setter is the write side of an object, as passed to Batch
+type Setter interface {
+	Set(key string, value string)
+	Remove(key string)
+}
+
+var _ Setter = (*DataObject)(nil) // verify it is a setter
+
+// Batch applies all the changes made by fn, and notifies the change
+// handlers only once at the end, with a single call per key from its
+// original to its final value (keys changed back to their original
+// value are not notified). Handlers never observe a partial state
+//
+// Before change handlers are still called for every change, so they
+// can veto them. Nested batches are part of the outer one
+func (do *DataObject) Batch(fn func(b Setter)) {
+	if do.batch != nil {
+		fn(do)
+		return
+	}
+
+	batch := &changeBatch{}
+	do.batch = batch
+
+	defer func() {
+		do.batch = nil
+		for _, key := range batch.keys {
+			change := batch.changes[key]
+			if change.oldValue != change.newValue {
+				do.change(key, change.oldValue, change.newValue)
+			}
+		}
+	}()
+
+	fn(do)
+}
+
+// changeBatch collects the changes made during a batch
+type changeBatch struct {
+	// keys are the changed keys, in the order they were first changed
+	keys    []string
+	changes map[string]*batchChange
+}
+
+type batchChange struct {
+	oldValue string
+	newValue string
+}
+
+// record coalesces the change with the earlier changes of the key
+func (b *changeBatch) record(key string, oldValue string, newValue string) {
+	if b.changes == nil {
+		b.changes = map[string]*batchChange{}
+	}
+
+	if change, exists := b.changes[key]; exists {
+		change.newValue = newValue
+		return
+	}
+
+	b.keys = append(b.keys, key)
+	b.changes[key] = &batchChange{oldValue: oldValue, newValue: newValue}
+}
+
+// hasChange returns if the key has been changed in the batch
+func (b *changeBatch) hasChange(key string) bool {
+	_, exists := b.changes[key]
+	return exists
+}
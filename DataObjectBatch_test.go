@@ -0,0 +1,36 @@
+package dataobject
+
+import "testing"
+
+func TestDataObjectBatch(t *testing.T) {
+	do := NewDataObjectFromExistingData(map[string]string{"name": "Jon", "city": "Paris"})
+
+	notifications := []string{}
+	do.OnChange(func(key string, oldValue string, newValue string) {
+		// no partial state
+		if do.Get("name") != "Tom" || do.Get("email") != "tom@example.com" {
+			t.Error("Expected: the final state, but found:", do.Data())
+		}
+		notifications = append(notifications, key+":"+oldValue+">"+newValue)
+	})
+
+	do.Batch(func(b Setter) {
+		b.Set("name", "Tim")
+		b.Set("name", "Tom")
+		b.Set("email", "tom@example.com")
+		b.Set("city", "Rome")
+		b.Set("city", "Paris")
+
+		if len(notifications) != 0 {
+			t.Error("Expected: no notifications during the batch, but found:", notifications)
+		}
+	})
+
+	if len(notifications) != 2 || notifications[0] != "name:Jon>Tom" || notifications[1] != "email:>tom@example.com" {
+		t.Error("Expected: one notification for name and email, but found:", notifications)
+	}
+
+	if !do.IsDirty() || do.DataChanged()["name"] != "Tom" {
+		t.Error("Expected: the object to be dirty, but found:", do.DataChanged())
+	}
+}
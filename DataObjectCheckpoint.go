@@ -0,0 +1,61 @@
+package dataobject
+
+import "sort"
+
+// ChangeToken marks a point in the change history of an object (see Checkpoint)
+type ChangeToken uint64
+
+// Checkpoint returns a token for the current state of the object, so
+// consumers (i.e. a cache, the database, a search index) can each ask
+// for the changes since their own last sync, independently of the
+// global dirty state (see DataChangedSince)
+func (do *DataObject) Checkpoint() ChangeToken {
+	return ChangeToken(do.changeSeq)
+}
+
+// DataChangedSince returns the keys set since the checkpoint
+// with their current values
+func (do *DataObject) DataChangedSince(token ChangeToken) map[string]string {
+	data := do.Data()
+	changed := map[string]string{}
+	for key, seq := range do.changedAt {
+		value, exists := data[key]
+		if seq > uint64(token) && exists {
+			changed[key] = value
+		}
+	}
+	return changed
+}
+
+// DataRemovedSince returns the keys removed since the checkpoint
+// (and not set again), sorted
+func (do *DataObject) DataRemovedSince(token ChangeToken) []string {
+	data := do.Data()
+	removed := []string{}
+	for key, seq := range do.removedAt {
+		if _, exists := data[key]; seq > uint64(token) && !exists {
+			removed = append(removed, key)
+		}
+	}
+	sort.Strings(removed)
+	return removed
+}
+
+// recordChange records the key as set (or removed) at the next sequence
+func (do *DataObject) recordChange(key string, removed bool) {
+	if do.changedAt == nil {
+		do.changedAt = map[string]uint64{}
+		do.removedAt = map[string]uint64{}
+	}
+
+	do.changeSeq++
+
+	if removed {
+		delete(do.changedAt, key)
+		do.removedAt[key] = do.changeSeq
+		return
+	}
+
+	delete(do.removedAt, key)
+	do.changedAt[key] = do.changeSeq
+}
@@ -0,0 +1,41 @@
+package dataobject
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestDataObjectDataChangedSince(t *testing.T) {
+	do := NewDataObjectFromExistingData(map[string]string{"name": "Jon", "city": "Paris"})
+
+	cache := do.Checkpoint()
+	do.Set("name", "Tom")
+
+	index := do.Checkpoint()
+	do.Set("email", "tom@example.com")
+	do.Remove("city")
+
+	changed := do.DataChangedSince(cache)
+	if len(changed) != 2 || changed["name"] != "Tom" || changed["email"] != "tom@example.com" {
+		t.Error("Expected: name and email, but found:", changed)
+	}
+
+	changed = do.DataChangedSince(index)
+	if len(changed) != 1 || changed["email"] != "tom@example.com" {
+		t.Error("Expected: email, but found:", changed)
+	}
+
+	if removed := do.DataRemovedSince(index); !slices.Equal(removed, []string{"city"}) {
+		t.Error("Expected: [city], but found:", removed)
+	}
+
+	do.MarkAsNotDirty()
+
+	if len(do.DataChangedSince(index)) != 1 {
+		t.Error("Expected: checkpoints to be independent of the dirty state, but found:", do.DataChangedSince(index))
+	}
+
+	if len(do.DataChangedSince(do.Checkpoint())) != 0 {
+		t.Error("Expected: no changes, but found:", do.DataChangedSince(do.Checkpoint()))
+	}
+}
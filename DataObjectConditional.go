@@ -0,0 +1,29 @@
+package dataobject
+
+// SetIfEmpty sets the value only if the key is not set or is empty
+//
+// Returns true if the value has been set
+func (do *DataObject) SetIfEmpty(key string, value string) bool {
+	if do.Get(key) != "" {
+		return false
+	}
+	return do.SetIfDifferent(key, value)
+}
+
+// SetIfDifferent sets the value only if its normalized form differs from
+// the current one, so the object is not marked as dirty by no-op changes
+//
+// Returns true if the value has been set, i.e. false also if a before
+// change handler rejected it
+func (do *DataObject) SetIfDifferent(key string, value string) bool {
+	return do.set(key, value, true)
+}
+
+// SuppressNoOpChanges makes Set ignore values equal to the current ones,
+// so touching a field with the same value does not make the object dirty
+// (nor notify the change handlers). See Touch and MarkFieldDirty to
+// force persistence anyway
+func (do *DataObject) SuppressNoOpChanges() *DataObject {
+	do.suppressNoOp = true
+	return do
+}
@@ -0,0 +1,83 @@
+package dataobject
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestDataObjectSetIfEmpty(t *testing.T) {
+	do := NewDataObjectFromExistingData(map[string]string{"name": "Jon", "email": ""})
+
+	if do.SetIfEmpty("name", "Tom") {
+		t.Error("Expected: false, but found: true")
+	}
+
+	if !do.SetIfEmpty("email", "jon@example.com") || !do.SetIfEmpty("city", "Paris") {
+		t.Error("Expected: true, but found: false")
+	}
+
+	if do.Get("name") != "Jon" || do.Get("email") != "jon@example.com" || do.Get("city") != "Paris" {
+		t.Error("Expected: the empty keys to be set, but found:", do.Data())
+	}
+}
+
+func TestDataObjectSetIfDifferent(t *testing.T) {
+	do := NewDataObjectFromExistingData(map[string]string{"name": "Jon"})
+
+	if do.SetIfDifferent("name", "Jon") {
+		t.Error("Expected: false, but found: true")
+	}
+
+	if do.IsDirty() {
+		t.Error("Expected: not dirty, but found:", do.DataChanged())
+	}
+
+	if !do.SetIfDifferent("name", "Tom") || !do.IsDirty() {
+		t.Error("Expected: the object to be dirty, but found:", do.DataChanged())
+	}
+}
+
+func TestDataObjectSetIfDifferentNormalized(t *testing.T) {
+	do := NewDataObjectFromExistingData(map[string]string{"name": "jon"}).WithValueNormalizer(strings.ToLower)
+
+	if do.SetIfDifferent("name", "JON") {
+		t.Error("Expected: false, but found: true")
+	}
+
+	if do.IsDirty() {
+		t.Error("Expected: not dirty, but found:", do.DataChanged())
+	}
+}
+
+func TestDataObjectSetIfDifferentRejected(t *testing.T) {
+	do := NewDataObjectFromExistingData(map[string]string{"name": "Jon"})
+	do.OnBeforeChange(func(string, string, string) bool { return false })
+
+	if do.SetIfDifferent("name", "Tom") {
+		t.Error("Expected: false, but found: true")
+	}
+
+	if do.Get("name") != "Jon" {
+		t.Error("Expected: Jon, but found:", do.Get("name"))
+	}
+}
+
+func TestDataObjectSuppressNoOpChanges(t *testing.T) {
+	do := NewDataObjectFromExistingData(map[string]string{"name": "Jon"}).SuppressNoOpChanges()
+
+	notified := 0
+	do.OnChange(func(string, string, string) { notified++ })
+
+	do.Set("name", "Jon")
+
+	if do.IsDirty() || notified != 0 {
+		t.Error("Expected: not dirty and not notified, but found:", do.DataChanged(), notified)
+	}
+
+	do.Set("name", "Tom")
+	do.Set("email", "")
+
+	if len(do.DataChanged()) != 2 || notified != 2 {
+		t.Error("Expected: 2 changes, but found:", do.DataChanged(), notified)
+	}
+}
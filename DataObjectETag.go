@@ -0,0 +1,26 @@
+package dataobject
+
+import "strings"
+
+// ETag returns the strong HTTP entity tag of the object,
+// which is the quoted content hash of the data (see HashOf)
+func (do *DataObject) ETag() string {
+	return ETagOf(do.Data())
+}
+
+// ETagOf returns the strong HTTP entity tag of the data
+func ETagOf(data map[string]string) string {
+	return `"` + HashOf(data) + `"`
+}
+
+// etagMatches returns if the If-Match header value (a comma separated
+// list of entity tags, or "*") matches the entity tag
+func etagMatches(ifMatch string, etag string) bool {
+	for _, candidate := range strings.Split(ifMatch, ",") {
+		candidate = strings.TrimSpace(candidate)
+		if candidate == "*" || candidate == etag {
+			return true
+		}
+	}
+	return false
+}
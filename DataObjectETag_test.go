@@ -0,0 +1,94 @@
+package dataobject
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestETag(t *testing.T) {
+	do := NewDataObjectFromExistingData(map[string]string{"id": "1", "name": "Jon"})
+
+	etag := do.ETag()
+
+	if !strings.HasPrefix(etag, `"`) || !strings.HasSuffix(etag, `"`) {
+		t.Error("Expected a quoted entity tag, but found:", etag)
+	}
+
+	do.Set("name", "Jane")
+
+	if do.ETag() == etag {
+		t.Error("Expected the entity tag to change, but found:", do.ETag())
+	}
+
+	etag = do.ETag()
+	do.Set("hash", "user data")
+
+	if do.ETag() == etag {
+		t.Error("Expected the entity tag to change with a field named hash, but found:", do.ETag())
+	}
+}
+
+func TestMemoryRepositoryUpdateIf(t *testing.T) {
+	ctx := context.Background()
+	repo := NewMemoryRepository()
+
+	do := NewDataObjectFromExistingData(map[string]string{"id": "1", "name": "Jon"})
+	_ = repo.Create(ctx, do)
+
+	etag := do.ETag()
+	do.Set("name", "Jane")
+
+	if err := repo.UpdateIf(ctx, do, etag); err != nil {
+		t.Fatal("Error must be nil, but found:", err.Error())
+	}
+
+	do.Set("name", "Joe")
+
+	err := repo.UpdateIf(ctx, do, etag)
+
+	if !errors.Is(err, ErrVersionConflict) {
+		t.Error("Expected: ErrVersionConflict, but found:", err)
+	}
+
+	if err := repo.UpdateIf(ctx, do, "*"); err != nil {
+		t.Error("Error must be nil, but found:", err.Error())
+	}
+}
+
+func TestCRUDHandlerIfMatch(t *testing.T) {
+	repo := NewMemoryRepository()
+	_ = repo.Create(context.Background(), NewDataObjectFromExistingData(map[string]string{"id": "1", "name": "Jon"}))
+	handler := NewCRUDHandler(repo, nil, "name")
+
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, httptest.NewRequest("GET", "/1", nil))
+	etag := w.Header().Get("ETag")
+
+	if etag == "" {
+		t.Fatal("ETag must NOT be empty, but found:", etag)
+	}
+
+	w = httptest.NewRecorder()
+	r := httptest.NewRequest("PUT", "/1", strings.NewReader(`{"name":"Jane"}`))
+	r.Header.Set("Content-Type", "application/json")
+	r.Header.Set("If-Match", etag)
+	handler.ServeHTTP(w, r)
+
+	if w.Code != http.StatusOK {
+		t.Fatal("Expected:", http.StatusOK, "but found:", w.Code, w.Body.String())
+	}
+
+	w = httptest.NewRecorder()
+	r = httptest.NewRequest("PUT", "/1", strings.NewReader(`{"name":"Joe"}`))
+	r.Header.Set("Content-Type", "application/json")
+	r.Header.Set("If-Match", etag)
+	handler.ServeHTTP(w, r)
+
+	if w.Code != http.StatusPreconditionFailed {
+		t.Error("Expected:", http.StatusPreconditionFailed, "but found:", w.Code)
+	}
+}
@@ -0,0 +1,124 @@
+package dataobject
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// embeddedObject is a child object embedded in a key of its parent
+type embeddedObject struct {
+	// object is the child object
+	object *DataObject
+
+	// json is the serialized child as last written to the parent
+	json string
+
+	// subscription writes the changes of the child back to the parent
+	subscription *subscription
+}
+
+// SetObject embeds the child object in the key as canonical JSON
+// (sorted keys), marking the key as dirty
+//
+// If the child is a *DataObject, any later change to it is written
+// back to the parent key, so the parent becomes dirty as well
+func (do *DataObject) SetObject(key string, child DataObjectInterface) error {
+	key = do.resolveAlias(key)
+
+	childJSON, err := canonicalJSON(child.Data())
+	if err != nil {
+		return err
+	}
+
+	do.Set(key, childJSON)
+
+	if object, isDataObject := child.(*DataObject); isDataObject {
+		do.embed(key, object, childJSON)
+	} else {
+		do.unembed(key)
+	}
+
+	return nil
+}
+
+// GetObject returns the child object embedded in the key. Changes to the
+// returned object are written back to the parent key (see SetObject).
+// The object is decoded with NewDataObjectFromJSON, as GetObjectList
+// decodes the objects of a list
+//
+// Returns an error wrapping ErrNotFound if the key is not set,
+// or ErrInvalidJSON if the value is not a JSON object
+func (do *DataObject) GetObject(key string) (*DataObject, error) {
+	key = do.resolveAlias(key)
+
+	value, exists := do.Data()[key]
+	if !exists {
+		return nil, notFound(key)
+	}
+
+	if child, cached := do.children[key]; cached && child.json == value {
+		return child.object, nil
+	}
+
+	object, err := NewDataObjectFromJSON(value)
+	if err != nil {
+		return nil, err
+	}
+
+	do.embed(key, object, value)
+
+	return object, nil
+}
+
+// embed caches the child and subscribes to its changes, replacing the
+// subscription to the child previously embedded in the key, so there is
+// only one per key, however often the key is set or read
+func (do *DataObject) embed(key string, object *DataObject, childJSON string) {
+	do.unembed(key)
+
+	if do.children == nil {
+		do.children = map[string]*embeddedObject{}
+	}
+
+	child := &embeddedObject{object: object, json: childJSON}
+	do.children[key] = child
+
+	child.subscription = object.subscribe(func(string, string, string) {
+		// the key has been replaced since
+		if do.children[key] != child {
+			object.unsubscribe(child.subscription)
+			return
+		}
+		if do.Get(key) != child.json {
+			return
+		}
+
+		childJSON, err := canonicalJSON(object.Data())
+		if err != nil {
+			return
+		}
+
+		child.json = childJSON
+		do.Set(key, childJSON)
+	})
+}
+
+// unembed unsubscribes from the child embedded in the key, if any
+func (do *DataObject) unembed(key string) {
+	child, exists := do.children[key]
+	if !exists {
+		return
+	}
+
+	child.object.unsubscribe(child.subscription)
+	delete(do.children, key)
+}
+
+// canonicalJSON serializes the data with sorted keys
+func canonicalJSON(data map[string]string) (string, error) {
+	jsonValue, err := json.Marshal(data)
+	if err != nil {
+		return "", fmt.Errorf("%w: %w", ErrInvalidJSON, err)
+	}
+	return string(jsonValue), nil
+}
@@ -0,0 +1,163 @@
+package dataobject
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// embeddedList is a list of child objects embedded in a key of its parent
+type embeddedList struct {
+	// objects are the child objects
+	objects []*DataObject
+
+	// json is the serialized list as last written to the parent
+	json string
+
+	// subscriptions write the changes of the objects back to the parent
+	subscriptions []*subscription
+}
+
+// SetObjectList embeds the list of child objects in the key as a JSON
+// array of canonical JSON objects, marking the key as dirty
+//
+// Any later change to an element, which is a *DataObject, is written back
+// to the parent key. To add or remove elements set the list again
+func (do *DataObject) SetObjectList(key string, children []DataObjectInterface) error {
+	key = do.resolveAlias(key)
+
+	list := make([]map[string]string, 0, len(children))
+	objects := make([]*DataObject, 0, len(children))
+
+	for _, child := range children {
+		list = append(list, child.Data())
+
+		if object, isDataObject := child.(*DataObject); isDataObject {
+			objects = append(objects, object)
+		} else {
+			objects = append(objects, NewDataObjectFromExistingData(copyData(child.Data())))
+		}
+	}
+
+	listJSON, err := canonicalListJSON(list)
+	if err != nil {
+		return err
+	}
+
+	do.Set(key, listJSON)
+	do.embedList(key, objects, listJSON)
+
+	return nil
+}
+
+// GetObjectList returns the child objects embedded in the key. The list
+// is decoded on first access and cached until the key is modified.
+// Changes to the returned objects are written back to the parent key
+//
+// The objects are decoded like GetObject, so the ID validator, the
+// migrator and the size limits apply to them (see NewDataObjectFromJSON)
+//
+// Returns an empty list if the key is not set,
+// or an error wrapping ErrInvalidJSON if the value is not a JSON array
+// of objects
+func (do *DataObject) GetObjectList(key string) ([]*DataObject, error) {
+	key = do.resolveAlias(key)
+
+	value, exists := do.Data()[key]
+	if !exists || value == "" {
+		return []*DataObject{}, nil
+	}
+
+	if list, cached := do.childLists[key]; cached && list.json == value {
+		return append([]*DataObject{}, list.objects...), nil
+	}
+
+	if err := checkEncodedSize(len(value)); err != nil {
+		return nil, err
+	}
+
+	var decoded []json.RawMessage
+	if err := json.Unmarshal([]byte(value), &decoded); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidJSON, err)
+	}
+
+	objects := make([]*DataObject, 0, len(decoded))
+	for _, element := range decoded {
+		object, err := NewDataObjectFromJSON(string(element))
+		if err != nil {
+			return nil, err
+		}
+		objects = append(objects, object)
+	}
+
+	do.embedList(key, objects, value)
+
+	return append([]*DataObject{}, objects...), nil
+}
+
+// embedList caches the child objects and subscribes to their changes,
+// replacing the subscriptions to the objects previously embedded in
+// the key, so there is only one per object, however often the key is
+// set or read
+func (do *DataObject) embedList(key string, objects []*DataObject, listJSON string) {
+	do.unembedList(key)
+
+	if do.childLists == nil {
+		do.childLists = map[string]*embeddedList{}
+	}
+
+	list := &embeddedList{objects: objects, json: listJSON}
+	do.childLists[key] = list
+
+	for _, object := range objects {
+		list.subscriptions = append(list.subscriptions, object.subscribe(func(string, string, string) {
+			// the key has been replaced since
+			if do.childLists[key] != list {
+				list.unsubscribe()
+				return
+			}
+			if do.Get(key) != list.json {
+				return
+			}
+
+			data := make([]map[string]string, 0, len(list.objects))
+			for _, object := range list.objects {
+				data = append(data, object.Data())
+			}
+
+			listJSON, err := canonicalListJSON(data)
+			if err != nil {
+				return
+			}
+
+			list.json = listJSON
+			do.Set(key, listJSON)
+		}))
+	}
+}
+
+// unembedList unsubscribes from the objects embedded in the key, if any
+func (do *DataObject) unembedList(key string) {
+	list, exists := do.childLists[key]
+	if !exists {
+		return
+	}
+
+	list.unsubscribe()
+	delete(do.childLists, key)
+}
+
+// unsubscribe unsubscribes from the changes of the objects
+func (list *embeddedList) unsubscribe() {
+	for i, object := range list.objects {
+		object.unsubscribe(list.subscriptions[i])
+	}
+}
+
+// canonicalListJSON serializes the list with sorted keys
+func canonicalListJSON(list []map[string]string) (string, error) {
+	jsonValue, err := json.Marshal(list)
+	if err != nil {
+		return "", fmt.Errorf("%w: %w", ErrInvalidJSON, err)
+	}
+	return string(jsonValue), nil
+}
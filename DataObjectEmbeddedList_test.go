@@ -0,0 +1,130 @@
+package dataobject
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestDataObjectSetObjectList(t *testing.T) {
+	order := NewDataObjectFromExistingData(map[string]string{"id": "1"})
+
+	item1 := NewDataObjectFromExistingData(map[string]string{"sku": "A", "quantity": "1"})
+	item2 := NewDataObjectFromExistingData(map[string]string{"sku": "B", "quantity": "2"})
+
+	if err := order.SetObjectList("items", []DataObjectInterface{item1, item2}); err != nil {
+		t.Fatal("Error must be nil, but found:", err.Error())
+	}
+
+	expected := `[{"quantity":"1","sku":"A"},{"quantity":"2","sku":"B"}]`
+
+	if order.Get("items") != expected {
+		t.Error("Expected:", expected, "but found:", order.Get("items"))
+	}
+
+	order.MarkAsNotDirty()
+
+	item2.Set("quantity", "5")
+
+	expected = `[{"quantity":"1","sku":"A"},{"quantity":"5","sku":"B"}]`
+
+	if order.DataChanged()["items"] != expected {
+		t.Error("Expected:", expected, "but found:", order.DataChanged()["items"])
+	}
+}
+
+func TestDataObjectGetObjectList(t *testing.T) {
+	order := NewDataObjectFromExistingData(map[string]string{
+		"id":    "1",
+		"items": `[{"sku":"A","quantity":"1"},{"sku":"B","quantity":"2"}]`,
+	})
+
+	items, err := order.GetObjectList("items")
+
+	if err != nil {
+		t.Fatal("Error must be nil, but found:", err.Error())
+	}
+
+	if len(items) != 2 || items[1].Get("sku") != "B" {
+		t.Fatal("Expected: 2 items, but found:", items)
+	}
+
+	items[0].Set("quantity", "3")
+
+	if !order.IsDirty() {
+		t.Error("Expected order to be dirty")
+	}
+
+	again, _ := order.GetObjectList("items")
+
+	if again[0] != items[0] {
+		t.Error("Expected the cached items to be returned")
+	}
+
+	empty, err := order.GetObjectList("missing")
+
+	if err != nil || len(empty) != 0 {
+		t.Error("Expected: empty list, but found:", empty, err)
+	}
+
+	order.Set("items", "not json")
+
+	if _, err := order.GetObjectList("items"); err == nil {
+		t.Error("Error must NOT be nil, but found:", err)
+	}
+}
+
+func TestDataObjectSetObjectListSubscribesOnce(t *testing.T) {
+	order := NewDataObjectFromExistingData(map[string]string{"id": "1"})
+	item := NewDataObjectFromExistingData(map[string]string{"id": "2"})
+
+	for i := 0; i < 10; i++ {
+		_ = order.SetObjectList("items", []DataObjectInterface{item, item})
+	}
+
+	// once for each position of the object in the list
+	if len(item.subscriptions) != 2 {
+		t.Error("Expected: 2 subscriptions, but found:", len(item.subscriptions))
+	}
+
+	_ = order.SetObjectList("items", []DataObjectInterface{})
+
+	if len(item.subscriptions) != 0 {
+		t.Error("Expected: 0 subscriptions, but found:", len(item.subscriptions))
+	}
+
+	order.Set("stored", `[{"id":"3"}]`)
+	for i := 0; i < 10; i++ {
+		_, _ = order.GetObjectList("stored")
+		order.Set("stored", `[{"id":"3","n":"`+string(rune('0'+i))+`"}]`)
+	}
+
+	stored, _ := order.GetObjectList("stored")
+	if len(stored[0].subscriptions) != 1 {
+		t.Error("Expected: 1 subscription, but found:", len(stored[0].subscriptions))
+	}
+}
+
+func TestDataObjectGetObjectListValidatesIDs(t *testing.T) {
+	SetIDValidator(SafeID)
+	defer SetIDValidator(nil)
+
+	order := NewDataObjectFromExistingData(map[string]string{
+		"id":       "1",
+		"customer": `{"id":"../1"}`,
+		"items":    `[{"id":"A"},{"id":"../B"}]`,
+	})
+
+	if _, err := order.GetObject("customer"); !errors.Is(err, ErrInvalidID) {
+		t.Error("Expected: ErrInvalidID, but found:", err)
+	}
+
+	if _, err := order.GetObjectList("items"); !errors.Is(err, ErrInvalidID) {
+		t.Error("Expected: ErrInvalidID, but found:", err)
+	}
+
+	order.Set("items", `[{"id":"A"},"B"]`)
+
+	if _, err := order.GetObjectList("items"); !errors.Is(err, ErrInvalidJSON) {
+		t.Error("Expected: ErrInvalidJSON, but found:", err)
+	}
+}
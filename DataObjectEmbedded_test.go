@@ -0,0 +1,101 @@
+package dataobject
+
+import (
+	"testing"
+)
+
+func TestDataObjectSetObject(t *testing.T) {
+	order := NewDataObjectFromExistingData(map[string]string{"id": "1"})
+
+	customer := NewDataObjectFromExistingData(map[string]string{"id": "2", "name": "Jon"})
+
+	if err := order.SetObject("customer", customer); err != nil {
+		t.Fatal("Error must be nil, but found:", err.Error())
+	}
+
+	if order.Get("customer") != `{"id":"2","name":"Jon"}` {
+		t.Error(`Expected: {"id":"2","name":"Jon"}, but found:`, order.Get("customer"))
+	}
+
+	order.MarkAsNotDirty()
+
+	// changes to the child propagate to the parent
+	customer.Set("name", "Jane")
+
+	if !order.IsDirty() {
+		t.Error("Expected order to be dirty")
+	}
+
+	if order.DataChanged()["customer"] != `{"id":"2","name":"Jane"}` {
+		t.Error(`Expected: {"id":"2","name":"Jane"}, but found:`, order.DataChanged()["customer"])
+	}
+}
+
+func TestDataObjectGetObject(t *testing.T) {
+	order := NewDataObjectFromExistingData(map[string]string{"id": "1", "customer": `{"id":"2","name":"Jon"}`})
+
+	customer, err := order.GetObject("customer")
+
+	if err != nil {
+		t.Fatal("Error must be nil, but found:", err.Error())
+	}
+
+	if customer.Get("name") != "Jon" {
+		t.Error("Expected: Jon, but found:", customer.Get("name"))
+	}
+
+	customer.Set("name", "Jane")
+
+	if order.Get("customer") != `{"id":"2","name":"Jane"}` {
+		t.Error(`Expected: {"id":"2","name":"Jane"}, but found:`, order.Get("customer"))
+	}
+
+	again, _ := order.GetObject("customer")
+
+	if again != customer {
+		t.Error("Expected the same child object to be returned")
+	}
+
+	// replacing the value directly detaches the previous child
+	order.Set("customer", `{"id":"3"}`)
+	customer.Set("name", "Jim")
+
+	if order.Get("customer") != `{"id":"3"}` {
+		t.Error(`Expected: {"id":"3"}, but found:`, order.Get("customer"))
+	}
+
+	if _, err := order.GetObject("missing"); !IsNotFound(err) {
+		t.Error("Expected: ErrNotFound, but found:", err)
+	}
+}
+
+func TestDataObjectSetObjectSubscribesOnce(t *testing.T) {
+	order := NewDataObjectFromExistingData(map[string]string{"id": "1"})
+	customer := NewDataObjectFromExistingData(map[string]string{"id": "2"})
+
+	for i := 0; i < 10; i++ {
+		_ = order.SetObject("customer", customer)
+	}
+
+	if len(customer.subscriptions) != 1 {
+		t.Error("Expected: 1 subscription, but found:", len(customer.subscriptions))
+	}
+
+	// replacing the child unsubscribes from the previous one
+	_ = order.SetObject("customer", NewDataObjectFromExistingData(map[string]string{"id": "3"}))
+
+	if len(customer.subscriptions) != 0 {
+		t.Error("Expected: 0 subscriptions, but found:", len(customer.subscriptions))
+	}
+
+	order.Set("stored", `{"id":"4"}`)
+	for i := 0; i < 10; i++ {
+		stored, _ := order.GetObject("stored")
+		order.Set("stored", `{"id":"`+stored.ID()+`","n":"`+string(rune('0'+i))+`"}`)
+	}
+
+	stored, _ := order.GetObject("stored")
+	if len(stored.subscriptions) != 1 {
+		t.Error("Expected: 1 subscription, but found:", len(stored.subscriptions))
+	}
+}
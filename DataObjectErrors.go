@@ -0,0 +1,81 @@
+package dataobject
+
+import "strconv"
+
+// CollectErrors switches the parsing setters (SetIntFromString, ...) to
+// record invalid values as field errors instead of returning them, so a
+// whole form can be applied and all the problems reported at once
+// (see Errors). Invalid values are never set
+func (do *DataObject) CollectErrors() *DataObject {
+	do.collectErrors = true
+	return do
+}
+
+// Errors returns the field errors recorded since CollectErrors
+// was enabled (or since ClearErrors), in the order they occurred
+func (do *DataObject) Errors() []FieldError {
+	errs := make([]FieldError, len(do.fieldErrors))
+	copy(errs, do.fieldErrors)
+	return errs
+}
+
+// HasErrors returns if any field errors have been recorded
+func (do *DataObject) HasErrors() bool {
+	return len(do.fieldErrors) > 0
+}
+
+// ClearErrors discards the recorded field errors
+func (do *DataObject) ClearErrors() {
+	do.fieldErrors = nil
+}
+
+// SetIntFromString parses the value as an integer and sets it
+func (do *DataObject) SetIntFromString(key string, value string) error {
+	parsed, err := strconv.ParseInt(value, 10, 64)
+	if err != nil {
+		return do.fieldError(key, "must be an integer")
+	}
+	do.SetInt(key, parsed)
+	return nil
+}
+
+// SetFloatFromString parses the value as a float and sets it
+func (do *DataObject) SetFloatFromString(key string, value string) error {
+	parsed, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		return do.fieldError(key, "must be a number")
+	}
+	do.SetFloat(key, parsed)
+	return nil
+}
+
+// SetBoolFromString parses the value as a boolean and sets it
+func (do *DataObject) SetBoolFromString(key string, value string) error {
+	parsed, err := strconv.ParseBool(value)
+	if err != nil {
+		return do.fieldError(key, "must be a boolean")
+	}
+	do.SetBool(key, parsed)
+	return nil
+}
+
+// SetTimeFromString parses the value in DateTimeFormat (as UTC)
+// or RFC 3339 and sets it
+func (do *DataObject) SetTimeFromString(key string, value string) error {
+	parsed, err := parseDateTime(value)
+	if err != nil {
+		return do.fieldError(key, "must be a date time")
+	}
+	do.SetTime(key, parsed)
+	return nil
+}
+
+// fieldError records the error if collecting errors, otherwise returns it
+func (do *DataObject) fieldError(key string, message string) error {
+	err := FieldError{Key: key, Message: message}
+	if !do.collectErrors {
+		return err
+	}
+	do.fieldErrors = append(do.fieldErrors, err)
+	return nil
+}
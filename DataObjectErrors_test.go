@@ -0,0 +1,46 @@
+package dataobject
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestDataObjectCollectErrors(t *testing.T) {
+	do := NewDataObject().CollectErrors()
+
+	if err := do.SetIntFromString("age", "abc"); err != nil {
+		t.Fatal("Error must be nil, but found:", err.Error())
+	}
+	_ = do.SetFloatFromString("price", "12.5")
+	_ = do.SetBoolFromString("active", "maybe")
+	_ = do.SetTimeFromString("born", "2020-01-02 03:04:05")
+
+	errs := do.Errors()
+	if len(errs) != 2 || errs[0].Key != "age" || errs[1].Key != "active" {
+		t.Error("Expected: errors for age and active, but found:", errs)
+	}
+
+	if do.Get("age") != "" || do.Get("price") != "12.5000" || do.Get("born") != "2020-01-02 03:04:05" {
+		t.Error("Expected only the valid values to be set, but found:", do.Data())
+	}
+
+	do.ClearErrors()
+	if do.HasErrors() {
+		t.Error("Expected: no errors, but found:", do.Errors())
+	}
+}
+
+func TestDataObjectSetFromStringReturnsError(t *testing.T) {
+	do := NewDataObject()
+
+	err := do.SetIntFromString("age", "abc")
+
+	var fieldErr FieldError
+	if !errors.As(err, &fieldErr) || fieldErr.Key != "age" {
+		t.Error("Error must be a FieldError for age, but found:", err)
+	}
+
+	if do.HasErrors() {
+		t.Error("Expected: no collected errors, but found:", do.Errors())
+	}
+}
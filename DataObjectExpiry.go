@@ -0,0 +1,124 @@
+package dataobject
+
+import (
+	"context"
+	"strings"
+	"time"
+)
+
+// ExpiresAtKey is the key holding the expiry time of the object
+const ExpiresAtKey = "expires_at"
+
+// keyExpiresAtSuffix is appended to a key to hold its expiry time
+const keyExpiresAtSuffix = "_expires_at"
+
+// SetExpiry sets the time after which the object is expired
+func (do *DataObject) SetExpiry(expiresAt time.Time) {
+	do.SetTime(ExpiresAtKey, expiresAt)
+}
+
+// Expiry returns the expiry time of the object,
+// and false if the object does not expire
+func (do *DataObject) Expiry() (time.Time, bool) {
+	return expiryOf(do.Data(), ExpiresAtKey)
+}
+
+// IsExpired returns if the object has an expiry time, which has passed
+func (do *DataObject) IsExpired() bool {
+	return isExpired(do.Data(), ExpiresAtKey)
+}
+
+// SetWithTTL sets the value of the key, which expires after the TTL.
+// The expiry time is kept under the "<key>_expires_at" key
+func (do *DataObject) SetWithTTL(key string, value string, ttl time.Duration) {
+	do.Set(key, value)
+	do.SetTime(key+keyExpiresAtSuffix, time.Now().Add(ttl))
+}
+
+// IsKeyExpired returns if the value of the key has been set with a TTL,
+// which has passed
+func (do *DataObject) IsKeyExpired(key string) bool {
+	return isExpired(do.Data(), key+keyExpiresAtSuffix)
+}
+
+// GetUnexpired returns the value of the key, or an empty string
+// if the value has expired
+func (do *DataObject) GetUnexpired(key string) string {
+	if do.IsKeyExpired(key) {
+		return ""
+	}
+	return do.Get(key)
+}
+
+// PurgeExpiredKeys removes the expired keys along with their expiry
+// times, returns the removed keys
+func (do *DataObject) PurgeExpiredKeys() []string {
+	purged := []string{}
+
+	for key := range do.Data() {
+		if !strings.HasSuffix(key, keyExpiresAtSuffix) {
+			continue
+		}
+
+		valueKey := strings.TrimSuffix(key, keyExpiresAtSuffix)
+		if !isExpired(do.Data(), key) {
+			continue
+		}
+
+		do.Remove(valueKey)
+		do.Remove(key)
+		purged = append(purged, valueKey)
+	}
+
+	return purged
+}
+
+// PurgeExpired deletes the expired objects from the repository,
+// returns the number of deleted objects. Objects deleted concurrently
+// (i.e. by another purge) are skipped and not counted
+func PurgeExpired(ctx context.Context, repo DataObjectRepositoryInterface) (int, error) {
+	objects, err := repo.List(ctx, 0, 0)
+	if err != nil {
+		return 0, err
+	}
+
+	purged := 0
+	for _, do := range objects {
+		if !isExpired(do.Data(), ExpiresAtKey) {
+			continue
+		}
+
+		err := repo.Delete(ctx, do.ID())
+		if IsNotFound(err) {
+			continue
+		}
+		if err != nil {
+			return purged, err
+		}
+
+		purged++
+	}
+
+	return purged, nil
+}
+
+// expiryOf returns the expiry time held under the key
+func expiryOf(data map[string]string, key string) (time.Time, bool) {
+	value, exists := data[key]
+	if !exists || value == "" {
+		return time.Time{}, false
+	}
+
+	expiresAt, err := parseDateTime(value)
+	if err != nil {
+		return time.Time{}, false
+	}
+
+	return expiresAt, true
+}
+
+// isExpired returns if the expiry time held under the key has passed
+func isExpired(data map[string]string, key string) bool {
+	expiresAt, expires := expiryOf(data, key)
+	return expires && !time.Now().Before(expiresAt)
+}
@@ -0,0 +1,114 @@
+package dataobject
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestDataObjectExpiry(t *testing.T) {
+	session := NewDataObject()
+
+	if session.IsExpired() {
+		t.Error("Expected object without expiry NOT to be expired")
+	}
+
+	session.SetExpiry(time.Now().Add(time.Hour))
+
+	if session.IsExpired() {
+		t.Error("Expected object NOT to be expired")
+	}
+
+	session.SetExpiry(time.Now().Add(-time.Hour))
+
+	if !session.IsExpired() {
+		t.Error("Expected object to be expired")
+	}
+}
+
+func TestDataObjectSetWithTTL(t *testing.T) {
+	session := NewDataObject()
+
+	session.SetWithTTL("csrf_token", "abc", time.Hour)
+	session.SetWithTTL("flash", "Saved", -time.Second)
+
+	if session.GetUnexpired("csrf_token") != "abc" {
+		t.Error("Expected: abc, but found:", session.GetUnexpired("csrf_token"))
+	}
+
+	if session.GetUnexpired("flash") != "" {
+		t.Error("Expected: empty, but found:", session.GetUnexpired("flash"))
+	}
+
+	purged := session.PurgeExpiredKeys()
+
+	if len(purged) != 1 || purged[0] != "flash" {
+		t.Error("Expected: [flash], but found:", purged)
+	}
+
+	if _, exists := session.Data()["flash_expires_at"]; exists {
+		t.Error("Expected flash_expires_at to be removed")
+	}
+}
+
+func TestPurgeExpired(t *testing.T) {
+	ctx := context.Background()
+	repo := NewMemoryRepository()
+
+	expired := NewDataObject()
+	expired.SetExpiry(time.Now().Add(-time.Minute))
+	_ = repo.Create(ctx, expired)
+
+	valid := NewDataObject()
+	valid.SetExpiry(time.Now().Add(time.Minute))
+	_ = repo.Create(ctx, valid)
+
+	_ = repo.Create(ctx, NewDataObject())
+
+	purged, err := PurgeExpired(ctx, repo)
+
+	if err != nil {
+		t.Fatal("Error must be nil, but found:", err.Error())
+	}
+
+	if purged != 1 {
+		t.Error("Expected: 1, but found:", purged)
+	}
+
+	if count, _ := repo.Count(ctx); count != 2 {
+		t.Error("Expected: 2, but found:", count)
+	}
+}
+
+// concurrentPurgeRepository deletes the listed objects right after
+// listing them, like a purge running concurrently
+type concurrentPurgeRepository struct {
+	*MemoryRepository
+}
+
+func (repo concurrentPurgeRepository) List(ctx context.Context, offset int, limit int) ([]DataObjectInterface, error) {
+	objects, err := repo.MemoryRepository.List(ctx, offset, limit)
+	for _, do := range objects {
+		_ = repo.MemoryRepository.Delete(ctx, do.ID())
+	}
+	return objects, err
+}
+
+func TestPurgeExpiredConcurrently(t *testing.T) {
+	ctx := context.Background()
+	repo := concurrentPurgeRepository{NewMemoryRepository()}
+
+	expired := NewDataObject()
+	expired.SetExpiry(time.Now().Add(-time.Minute))
+	_ = repo.Create(ctx, expired)
+
+	purged, err := PurgeExpired(ctx, repo)
+
+	if err != nil {
+		t.Fatal("Error must be nil, but found:", err.Error())
+	}
+
+	if purged != 0 {
+		t.Error("Expected: 0, but found:", purged)
+	}
+}
@@ -0,0 +1,77 @@
+package dataobject
+
+import "fmt"
+
+// Freeze makes the object read-only. Any following attempt to modify
+// the data via Set, SetData, Remove, Hydrate or MarkAsNotDirty panics
+// with an error wrapping ErrFrozen, and Data and DataChanged return
+// copies. TrySet, TrySetData, TryRemove and TryHydrate return the
+// error instead
+//
+// Use it for objects handed to plugins or templates, which must not
+// modify them. Check IsFrozen before modifying an object of unknown
+// origin. A frozen object cannot be unfrozen
+func (do *DataObject) Freeze() {
+	do.Init()
+	do.frozen = true
+}
+
+// IsFrozen returns if the object has been frozen
+func (do *DataObject) IsFrozen() bool {
+	return do.frozen
+}
+
+// TrySet is like Set, but returns an error wrapping ErrFrozen
+// instead of panicking, if the object is frozen
+func (do *DataObject) TrySet(key string, value string) error {
+	if err := do.checkNotFrozen(); err != nil {
+		return err
+	}
+	do.Set(key, value)
+	return nil
+}
+
+// TrySetData is like SetData, but returns an error wrapping ErrFrozen
+// instead of panicking, if the object is frozen
+func (do *DataObject) TrySetData(data map[string]string) error {
+	if err := do.checkNotFrozen(); err != nil {
+		return err
+	}
+	do.SetData(data)
+	return nil
+}
+
+// TryRemove is like Remove, but returns an error wrapping ErrFrozen
+// instead of panicking, if the object is frozen
+func (do *DataObject) TryRemove(key string) error {
+	if err := do.checkNotFrozen(); err != nil {
+		return err
+	}
+	do.Remove(key)
+	return nil
+}
+
+// TryHydrate is like Hydrate, but returns an error wrapping ErrFrozen
+// instead of panicking, if the object is frozen
+func (do *DataObject) TryHydrate(data map[string]string) error {
+	if err := do.checkNotFrozen(); err != nil {
+		return err
+	}
+	do.Hydrate(data)
+	return nil
+}
+
+// checkNotFrozen returns an error wrapping ErrFrozen, if the object is frozen
+func (do *DataObject) checkNotFrozen() error {
+	if do.frozen {
+		return fmt.Errorf("%w: %s", ErrFrozen, do.Get("id"))
+	}
+	return nil
+}
+
+// mustNotBeFrozen panics if the object is frozen
+func (do *DataObject) mustNotBeFrozen() {
+	if err := do.checkNotFrozen(); err != nil {
+		panic(err)
+	}
+}
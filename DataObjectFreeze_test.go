@@ -0,0 +1,73 @@
+package dataobject
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestDataObjectFreeze(t *testing.T) {
+	user := NewDataObjectFromExistingData(map[string]string{"id": "1", "first_name": "Jon"})
+	user.Freeze()
+
+	if !user.IsFrozen() {
+		t.Error("Expected object to be frozen")
+	}
+
+	// the returned data is a copy
+	user.Data()["first_name"] = "Jane"
+
+	if user.Get("first_name") != "Jon" {
+		t.Error("Expected: Jon, but found:", user.Get("first_name"))
+	}
+
+	mutations := map[string]func(){
+		"Set":            func() { user.Set("first_name", "Jane") },
+		"SetData":        func() { user.SetData(map[string]string{"first_name": "Jane"}) },
+		"Remove":         func() { user.Remove("first_name") },
+		"Hydrate":        func() { user.Hydrate(map[string]string{}) },
+		"MarkAsNotDirty": func() { user.MarkAsNotDirty() },
+	}
+
+	for name, mutation := range mutations {
+		func() {
+			defer func() {
+				err, _ := recover().(error)
+				if !errors.Is(err, ErrFrozen) {
+					t.Error(name, "expected to panic with ErrFrozen, but found:", err)
+				}
+			}()
+			mutation()
+		}()
+	}
+
+	if user.Get("first_name") != "Jon" {
+		t.Error("Expected: Jon, but found:", user.Get("first_name"))
+	}
+}
+
+func TestDataObjectFreezeTry(t *testing.T) {
+	user := NewDataObjectFromExistingData(map[string]string{"id": "1", "first_name": "Jon"})
+
+	if err := user.TrySet("first_name", "Jane"); err != nil {
+		t.Fatal("Error must be nil, but found:", err.Error())
+	}
+
+	user.Freeze()
+
+	errs := map[string]error{
+		"TrySet":     user.TrySet("first_name", "Tim"),
+		"TrySetData": user.TrySetData(map[string]string{"first_name": "Tim"}),
+		"TryRemove":  user.TryRemove("first_name"),
+		"TryHydrate": user.TryHydrate(map[string]string{}),
+	}
+
+	for name, err := range errs {
+		if !errors.Is(err, ErrFrozen) {
+			t.Error(name, "expected to return ErrFrozen, but found:", err)
+		}
+	}
+
+	if user.Get("first_name") != "Jane" || !user.IsDirty() {
+		t.Error("Expected: Jane, still dirty, but found:", user.Get("first_name"), user.IsDirty())
+	}
+}
@@ -0,0 +1,78 @@
+package dataobject
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"sort"
+	"strconv"
+)
+
+// HashKey is the default key holding the content hash of the data (see EnableHash)
+const HashKey = "hash"
+
+// HashOf returns the SHA-256 content hash (hex) of the data
+// over the sorted keys and values
+func HashOf(data map[string]string) string {
+	return hashExcluding(data, "")
+}
+
+// hashExcluding returns the content hash of the data without the key
+func hashExcluding(data map[string]string, excludedKey string) string {
+	keys := make([]string, 0, len(data))
+	for key := range data {
+		if key != excludedKey || excludedKey == "" {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+
+	hash := sha256.New()
+	for _, key := range keys {
+		// length prefixes keep "a"+"bc" and "ab"+"c" apart
+		value := data[key]
+		hash.Write([]byte(strconv.Itoa(len(key)) + ":" + key + strconv.Itoa(len(value)) + ":" + value))
+	}
+
+	return hex.EncodeToString(hash.Sum(nil))
+}
+
+// Hash returns the content hash of the data of the object (see HashOf),
+// excluding the key maintaining the hash, if it is enabled (see EnableHash)
+func (do *DataObject) Hash() string {
+	return hashExcluding(do.Data(), do.hashKey)
+}
+
+// EnableHash starts maintaining the hash key (see HashKey), updating
+// it on every change made via Set, SetData and Remove, so repositories
+// and sync tooling can skip writes when nothing actually changed
+//
+// The hash key is updated immediately if it is missing or stale
+func (do *DataObject) EnableHash() {
+	do.EnableHashKey(HashKey)
+}
+
+// EnableHashKey is EnableHash maintaining the hash in the key,
+// i.e. if the data has a field named "hash"
+func (do *DataObject) EnableHashKey(key string) {
+	if do.hashKey != "" {
+		return
+	}
+
+	do.hashKey = key
+
+	do.OnChange(func(changedKey string, oldValue string, newValue string) {
+		if changedKey == key {
+			return
+		}
+		do.refreshHash()
+	})
+
+	do.refreshHash()
+}
+
+// refreshHash sets the hash key, if it does not match the data
+func (do *DataObject) refreshHash() {
+	if hash := do.Hash(); do.Get(do.hashKey) != hash {
+		do.Set(do.hashKey, hash)
+	}
+}
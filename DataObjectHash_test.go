@@ -0,0 +1,66 @@
+package dataobject
+
+import "testing"
+
+func TestHashOf(t *testing.T) {
+	a := HashOf(map[string]string{"a": "bc", "id": "1"})
+	b := HashOf(map[string]string{"ab": "c", "id": "1"})
+
+	if a == b {
+		t.Error("Expected different hashes, but found:", a)
+	}
+
+	withHash := HashOf(map[string]string{"a": "bc", "id": "1", "hash": "user data"})
+
+	if a == withHash {
+		t.Error("Expected: a field named hash to be hashed, but found:", withHash)
+	}
+}
+
+func TestEnableHash(t *testing.T) {
+	do := NewDataObjectFromExistingData(map[string]string{"id": "1", "name": "Jon"})
+	do.EnableHash()
+
+	if do.Get(HashKey) != HashOf(map[string]string{"id": "1", "name": "Jon"}) || do.Hash() != do.Get(HashKey) {
+		t.Error("Expected the hash of the data, but found:", do.Get(HashKey))
+	}
+
+	hash := do.Get(HashKey)
+	do.MarkAsNotDirty()
+
+	do.Set("name", "Jane")
+
+	if do.Get(HashKey) == hash {
+		t.Error("Expected the hash to change, but found:", do.Get(HashKey))
+	}
+
+	if _, changed := do.DataChanged()[HashKey]; !changed {
+		t.Error("Expected: hash to be dirty, but found:", do.DataChanged())
+	}
+
+	do.Set("name", "Jon")
+
+	if do.Get(HashKey) != hash {
+		t.Error("Expected:", hash, "but found:", do.Get(HashKey))
+	}
+}
+
+func TestEnableHashKey(t *testing.T) {
+	do := NewDataObjectFromExistingData(map[string]string{"id": "1", "hash": "user data"})
+	do.EnableHashKey("content_hash")
+
+	if do.Get("content_hash") != HashOf(map[string]string{"id": "1", "hash": "user data"}) {
+		t.Error("Expected the hash of the data, but found:", do.Get("content_hash"))
+	}
+
+	if do.Get("hash") != "user data" {
+		t.Error("Expected: user data, but found:", do.Get("hash"))
+	}
+
+	hash := do.Get("content_hash")
+	do.Set("hash", "changed")
+
+	if do.Get("content_hash") == hash {
+		t.Error("Expected the hash to change, but found:", do.Get("content_hash"))
+	}
+}
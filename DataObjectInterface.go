@@ -1,6 +1,12 @@
 package dataobject
 
+import "time"
+
 // DataObjectInterface is an interface for a data object
+//
+// It is the canonical interface accepted throughout the package.
+// Optional capabilities are described by the smaller interfaces
+// Serializable, DirtyTrackable and TypedAccessor
 type DataObjectInterface interface {
 
 	// ID returns the ID of the object
@@ -9,12 +15,61 @@ type DataObjectInterface interface {
 	// SetID sets the ID of the object
 	SetID(id string)
 
-	// GetData returns the data for the object
+	// Data returns the data for the object
 	Data() map[string]string
 
-	// GetChangedData returns the data that has been changed since the last hydration
+	// DataChanged returns the data that has been changed since the last hydration
 	DataChanged() map[string]string
 
 	// Hydrates the data object with data
 	Hydrate(map[string]string)
 }
+
+// Serializable is implemented by objects, which can be serialized
+type Serializable interface {
+
+	// ToJSON converts the object to a JSON string
+	ToJSON() (string, error)
+}
+
+// DirtyTrackable is implemented by objects, which track their changes
+type DirtyTrackable interface {
+
+	// IsDirty returns if data has been modified
+	IsDirty() bool
+
+	// DataChanged returns only the modified data
+	DataChanged() map[string]string
+
+	// MarkAsNotDirty marks the object as not dirty
+	MarkAsNotDirty()
+}
+
+// TypedAccessor is implemented by objects, which provide typed access
+// to the string values
+type TypedAccessor interface {
+
+	// GetInt returns the value of the key as an integer
+	GetInt(key string) (int64, error)
+
+	// GetFloat returns the value of the key as a float
+	GetFloat(key string) (float64, error)
+
+	// GetBool returns the value of the key as a boolean
+	GetBool(key string) (bool, error)
+
+	// GetTime returns the value of the key as a time
+	GetTime(key string) (time.Time, error)
+
+	// SetInt sets the value of the key to an integer
+	SetInt(key string, value int64)
+
+	// SetFloat sets the value of the key to a float
+	SetFloat(key string, value float64)
+
+	// SetBool sets the value of the key to a boolean
+	SetBool(key string, value bool)
+
+	// SetTime sets the value of the key to a time
+	SetTime(key string, value time.Time)
+}
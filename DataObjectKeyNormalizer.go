@@ -0,0 +1,141 @@
+package dataobject
+
+import (
+	"sort"
+	"strings"
+)
+
+// WithKeyNormalizer normalizes the keys with the function on Set, Get,
+// Remove and Hydrate, so keys from sources with inconsistent casing or
+// formatting end up as a single field. The current data, including the
+// raw keys, the change tracking and the embedded objects, is normalized
+// right away
+//
+// If several keys normalize to the same key, the value of the key which
+// is already normalized wins, otherwise the first in sorted order.
+// It panics if the object is frozen (see Freeze)
+func (do *DataObject) WithKeyNormalizer(normalizer KeyMapper) *DataObject {
+	do.mustNotBeFrozen()
+	do.keyNormalizer = normalizer
+
+	do.data = do.normalizeKeys(do.data)
+	do.dataChanged = do.normalizeKeys(do.dataChanged)
+	do.view = nil
+
+	removed := map[string]struct{}{}
+	for key := range do.dataRemoved {
+		removed[normalizer(key)] = struct{}{}
+	}
+	do.dataRemoved = removed
+
+	aliases := map[string]string{}
+	for alias, key := range do.aliases {
+		aliases[normalizer(alias)] = normalizer(key)
+	}
+	do.aliases = aliases
+
+	rawKeys := map[string]struct{}{}
+	for key := range do.rawKeys {
+		rawKeys[normalizer(key)] = struct{}{}
+	}
+	do.rawKeys = rawKeys
+
+	do.changedAt = normalizeKeySeqs(do.changedAt, normalizer)
+	do.removedAt = normalizeKeySeqs(do.removedAt, normalizer)
+
+	do.normalizeEmbeddedKeys()
+
+	return do
+}
+
+// normalizeEmbeddedKeys embeds the cached embedded objects again in
+// their normalized keys, dropping the ones which no longer match the
+// value of their key, i.e. as several keys normalized to the same key
+func (do *DataObject) normalizeEmbeddedKeys() {
+	data := do.Data()
+
+	children := do.children
+	for _, key := range sortedKeys(children) {
+		child := children[key]
+		do.unembed(key)
+
+		if normalizedKey := do.normalizeKey(key); data[normalizedKey] == child.json {
+			do.embed(normalizedKey, child.object, child.json)
+		}
+	}
+
+	lists := do.childLists
+	for _, key := range sortedKeys(lists) {
+		list := lists[key]
+		do.unembedList(key)
+
+		if normalizedKey := do.normalizeKey(key); data[normalizedKey] == list.json {
+			do.embedList(normalizedKey, list.objects, list.json)
+		}
+	}
+}
+
+// normalizeKeySeqs returns a copy of the change sequences with the keys
+// normalized, keeping the latest sequence of the keys normalized to the
+// same key
+func normalizeKeySeqs(seqs map[string]uint64, normalizer KeyMapper) map[string]uint64 {
+	if seqs == nil {
+		return nil
+	}
+
+	normalized := make(map[string]uint64, len(seqs))
+	for key, seq := range seqs {
+		normalizedKey := normalizer(key)
+		if seq > normalized[normalizedKey] {
+			normalized[normalizedKey] = seq
+		}
+	}
+	return normalized
+}
+
+// CaseInsensitiveKeys makes the keys case insensitive, by lowercasing
+// them (see WithKeyNormalizer), so "Email" and "email" are the same field
+func (do *DataObject) CaseInsensitiveKeys() *DataObject {
+	return do.WithKeyNormalizer(strings.ToLower)
+}
+
+// normalizeKey returns the normalized key, if there is a key normalizer
+func (do *DataObject) normalizeKey(key string) string {
+	if do.keyNormalizer == nil {
+		return key
+	}
+	return do.keyNormalizer(key)
+}
+
+// normalizeKeys returns a copy of the data with the keys normalized,
+// or the data itself if there is no key normalizer
+func (do *DataObject) normalizeKeys(data map[string]string) map[string]string {
+	if do.keyNormalizer == nil || data == nil {
+		return data
+	}
+
+	keys := make([]string, 0, len(data))
+	for key := range data {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	normalized := make(map[string]string, len(data))
+	for _, key := range keys {
+		normalizedKey := do.keyNormalizer(key)
+		if _, exists := normalized[normalizedKey]; !exists || key == normalizedKey {
+			normalized[normalizedKey] = data[key]
+		}
+	}
+	return normalized
+}
+
+// sortedKeys returns the keys of the map, sorted
+func sortedKeys[V any](values map[string]V) []string {
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,134 @@
+package dataobject
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestDataObjectCaseInsensitiveKeys(t *testing.T) {
+	do := NewDataObjectFromExistingData(map[string]string{"ID": "1", "Email": "jon@example.com", "email": "jon@test.com"}).CaseInsensitiveKeys()
+
+	if do.Get("EMAIL") != "jon@test.com" || do.ID() != "1" || len(do.Data()) != 2 {
+		t.Error("Expected: the normalized keys, but found:", do.Data())
+	}
+
+	do.Set("Name", "Jon")
+	do.Set("NAME", "Tom")
+
+	if do.Get("name") != "Tom" || do.DataChanged()["name"] != "Tom" || len(do.DataChanged()) != 1 {
+		t.Error("Expected: a single name field, but found:", do.DataChanged())
+	}
+
+	do.Remove("EMAIL")
+
+	if do.Get("email") != "" || do.DataRemoved()[0] != "email" {
+		t.Error("Expected: email to be removed, but found:", do.Data())
+	}
+
+	do.Hydrate(map[string]string{"Id": "2", "Status": "active"})
+
+	if do.Get("status") != "active" || do.ID() != "2" {
+		t.Error("Expected: the hydrated keys to be normalized, but found:", do.Data())
+	}
+}
+
+func TestDataObjectKeyNormalizerWithAliases(t *testing.T) {
+	do := NewDataObject().WithAliases(map[string]string{"Mail": "user_email"}).WithKeyNormalizer(strings.ToUpper)
+
+	do.Set("mail", "jon@example.com")
+
+	if do.Get("USER_EMAIL") != "jon@example.com" || do.Data()["USER_EMAIL"] != "jon@example.com" {
+		t.Error("Expected: the alias to resolve to the normalized key, but found:", do.Data())
+	}
+}
+
+func TestDataObjectKeyNormalizerHydrateKeepsChanges(t *testing.T) {
+	do := NewDataObject().CaseInsensitiveKeys()
+	do.Set("Name", "Jon")
+	do.Set("Status", "new")
+	do.Remove("Email")
+
+	do.Hydrate(map[string]string{"NAME": "Tom", "EMAIL": "tom@example.com"})
+
+	if do.DataChanged()["name"] != "Tom" || len(do.DataChanged()) != 1 {
+		t.Error("Expected: name to stay changed with the hydrated value, but found:", do.DataChanged())
+	}
+
+	if len(do.DataRemoved()) != 0 || do.Get("email") != "tom@example.com" {
+		t.Error("Expected: the hydrated email not to be removed, but found:", do.DataRemoved())
+	}
+}
+
+func TestDataObjectKeyNormalizerFrozen(t *testing.T) {
+	do := NewDataObjectFromExistingData(map[string]string{"Name": "Jon"})
+	do.Freeze()
+
+	defer func() {
+		if recovered := recover(); recovered == nil {
+			t.Error("Expected: a panic on a frozen object, but found: none")
+		}
+		if do.Get("Name") != "Jon" {
+			t.Error("Expected: the data to be unchanged, but found:", do.Data())
+		}
+	}()
+
+	do.CaseInsensitiveKeys()
+}
+
+func TestDataObjectKeyNormalizerRawKeys(t *testing.T) {
+	do := NewDataObjectFromExistingData(map[string]string{"Body": "<b>Jon</b>"}).
+		WithRawKeys("Body").
+		CaseInsensitiveKeys().
+		WithValueNormalizer(strings.ToUpper)
+
+	if !do.IsRawKey("body") || !do.IsRawKey("BODY") {
+		t.Error("Expected: body to stay raw, but found:", do.RawKeys())
+	}
+
+	do.Set("body", "<i>Tom</i>")
+
+	if do.Get("body") != "<i>Tom</i>" {
+		t.Error("Expected: <i>Tom</i>, but found:", do.Get("body"))
+	}
+}
+
+func TestDataObjectKeyNormalizerCheckpoint(t *testing.T) {
+	do := NewDataObjectFromExistingData(map[string]string{"Name": "Jon", "Email": "jon@example.com"})
+	token := do.Checkpoint()
+
+	do.Set("Name", "Tom")
+	do.Remove("Email")
+	do.CaseInsensitiveKeys()
+
+	if do.DataChangedSince(token)["name"] != "Tom" {
+		t.Error("Expected: Tom, but found:", do.DataChangedSince(token))
+	}
+
+	if removed := do.DataRemovedSince(token); len(removed) != 1 || removed[0] != "email" {
+		t.Error("Expected: [email], but found:", removed)
+	}
+}
+
+func TestDataObjectKeyNormalizerEmbedded(t *testing.T) {
+	do := NewDataObjectFromExistingData(map[string]string{})
+	child := NewDataObjectFromExistingData(map[string]string{"city": "Paris"})
+	if err := do.SetObject("Address", child); err != nil {
+		t.Fatal("Error must be nil, but found:", err)
+	}
+
+	do.CaseInsensitiveKeys()
+	child.Set("city", "London")
+
+	address, err := do.GetObject("ADDRESS")
+	if err != nil {
+		t.Fatal("Error must be nil, but found:", err)
+	}
+
+	if address != child || address.Get("city") != "London" {
+		t.Error("Expected: the embedded child, but found:", address.Data())
+	}
+
+	if do.Get("address") != `{"city":"London"}` {
+		t.Error(`Expected: {"city":"London"}, but found:`, do.Get("address"))
+	}
+}
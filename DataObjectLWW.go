@@ -0,0 +1,123 @@
+package dataobject
+
+import (
+	"sort"
+	"time"
+)
+
+// lwwClock holds the last-write-wins timestamps of a data object
+type lwwClock struct {
+	// timestamps holds the timestamp of the last write per key,
+	// including removed keys (tombstones)
+	timestamps map[string]int64
+
+	// last holds the last issued timestamp
+	last int64
+
+	// merging holds the timestamp to record while merging, or 0
+	merging int64
+}
+
+// tick returns a new timestamp, which is the current time in nanoseconds,
+// but always greater than any previously issued or merged timestamp
+func (c *lwwClock) tick() int64 {
+	c.last = max(time.Now().UnixNano(), c.last+1)
+	return c.last
+}
+
+// EnableLWW starts recording a logical timestamp per key on every
+// change made via Set, SetData and Remove, so that replicas of the
+// object can be merged deterministically with MergeLWW
+func (do *DataObject) EnableLWW() {
+	if do.clock != nil {
+		return
+	}
+
+	clock := &lwwClock{timestamps: map[string]int64{}}
+	do.clock = clock
+
+	do.OnChange(func(key string, oldValue string, newValue string) {
+		if clock.merging > 0 {
+			clock.timestamps[key] = clock.merging
+			return
+		}
+		clock.timestamps[key] = clock.tick()
+	})
+}
+
+// FieldTimestamps returns a copy of the last-write timestamps per key,
+// to be persisted alongside the data for later merges
+func (do *DataObject) FieldTimestamps() map[string]int64 {
+	timestamps := map[string]int64{}
+	if do.clock == nil {
+		return timestamps
+	}
+	for key, timestamp := range do.clock.timestamps {
+		timestamps[key] = timestamp
+	}
+	return timestamps
+}
+
+// HydrateFieldTimestamps restores the previously persisted
+// last-write timestamps, enabling LWW if not already enabled
+func (do *DataObject) HydrateFieldTimestamps(timestamps map[string]int64) {
+	do.EnableLWW()
+	for key, timestamp := range timestamps {
+		do.clock.timestamps[key] = timestamp
+		do.clock.last = max(do.clock.last, timestamp)
+	}
+}
+
+// MergeLWW merges the other replica into the object, keeping the most
+// recently written value for each key. Ties are resolved by keeping
+// the greater value, so that all replicas converge to the same data
+//
+// Both objects must have LWW enabled, otherwise nothing is merged
+// Returns the keys, which have been updated from the other object
+func (do *DataObject) MergeLWW(other *DataObject) []string {
+	merged := []string{}
+	if do.clock == nil || other.clock == nil {
+		return merged
+	}
+
+	do.Init()
+	other.Init()
+
+	for key, otherTimestamp := range other.clock.timestamps {
+		timestamp := do.clock.timestamps[key]
+		otherValue, otherExists := other.lookup(key)
+		value, exists := do.lookup(key)
+
+		if otherTimestamp < timestamp {
+			continue
+		}
+
+		if otherTimestamp == timestamp && (otherExists == exists && otherValue <= value || exists && !otherExists) {
+			continue
+		}
+
+		do.clock.last = max(do.clock.last, otherTimestamp)
+
+		if !otherExists && !exists {
+			do.clock.timestamps[key] = otherTimestamp
+			continue
+		}
+
+		do.clock.merging = otherTimestamp
+		if otherExists {
+			do.Set(key, otherValue)
+		} else {
+			do.Remove(key)
+		}
+		do.clock.merging = 0
+
+		// the change may have been vetoed
+		if do.clock.timestamps[key] == otherTimestamp {
+			merged = append(merged, key)
+		}
+	}
+
+	sort.Strings(merged)
+
+	return merged
+}
@@ -0,0 +1,48 @@
+package dataobject
+
+import (
+	"testing"
+)
+
+func TestDataObjectMergeLWW(t *testing.T) {
+	replica1 := NewDataObjectFromExistingData(map[string]string{"id": "1"})
+	replica1.EnableLWW()
+
+	replica2 := NewDataObjectFromExistingData(map[string]string{"id": "1"})
+	replica2.EnableLWW()
+
+	replica1.Set("first_name", "Jon")
+	replica1.Set("last_name", "Doe")
+	replica2.Set("first_name", "Jane") // written later, wins
+	replica1.Remove("last_name")
+
+	replica1.MergeLWW(replica2)
+	replica2.MergeLWW(replica1)
+
+	for _, replica := range []*DataObject{replica1, replica2} {
+		if replica.Get("first_name") != "Jane" {
+			t.Error("Expected: Jane, but found:", replica.Get("first_name"))
+		}
+
+		if _, exists := replica.Data()["last_name"]; exists {
+			t.Error("Expected last_name to be removed, but found:", replica.Get("last_name"))
+		}
+	}
+}
+
+func TestDataObjectMergeLWWTie(t *testing.T) {
+	replica1 := NewDataObjectFromExistingData(map[string]string{"id": "1"})
+	replica1.HydrateFieldTimestamps(map[string]int64{"status": 100})
+	replica1.Hydrate(map[string]string{"id": "1", "status": "active"})
+
+	replica2 := NewDataObjectFromExistingData(map[string]string{"id": "1"})
+	replica2.HydrateFieldTimestamps(map[string]int64{"status": 100})
+	replica2.Hydrate(map[string]string{"id": "1", "status": "banned"})
+
+	replica1.MergeLWW(replica2)
+	replica2.MergeLWW(replica1)
+
+	if replica1.Get("status") != "banned" || replica2.Get("status") != "banned" {
+		t.Error("Expected: banned, but found:", replica1.Get("status"), replica2.Get("status"))
+	}
+}
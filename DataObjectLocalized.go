@@ -0,0 +1,58 @@
+package dataobject
+
+import (
+	"sort"
+	"strings"
+)
+
+// SetLocalized sets the value of the key for the locale,
+// stored under the key suffixed with the locale (i.e. "title.en")
+func (do *DataObject) SetLocalized(key string, locale string, value string) {
+	do.Set(key+"."+locale, value)
+}
+
+// GetLocalized returns the value of the key for the first locale
+// of the fallback chain, which has a non empty value
+//
+// Each locale falls back to its parent locales first ("en-GB" to "en"),
+// then to the fallback locales in order, and finally to the unlocalized key
+//
+// Example:
+//
+//	title := do.GetLocalized("title", "bg-BG", "en")
+//	// tries title.bg-BG, title.bg, title.en, title
+func (do *DataObject) GetLocalized(key string, locale string, fallbacks ...string) string {
+	for _, candidate := range append([]string{locale}, fallbacks...) {
+		for candidate != "" {
+			if value := do.Get(key + "." + candidate); value != "" {
+				return value
+			}
+			candidate = parentLocale(candidate)
+		}
+	}
+
+	return do.Get(key)
+}
+
+// Locales returns the locales, which the key has a value for, sorted
+func (do *DataObject) Locales(key string) []string {
+	prefix := key + "."
+	locales := []string{}
+	for k := range do.Data() {
+		if locale, found := strings.CutPrefix(k, prefix); found && locale != "" && !strings.Contains(locale, ".") {
+			locales = append(locales, locale)
+		}
+	}
+	sort.Strings(locales)
+	return locales
+}
+
+// parentLocale returns the parent of the locale ("en-GB" to "en"),
+// or an empty string for a locale without a parent
+func parentLocale(locale string) string {
+	index := strings.LastIndexAny(locale, "-_")
+	if index < 0 {
+		return ""
+	}
+	return locale[:index]
+}
@@ -0,0 +1,39 @@
+package dataobject
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestLocalized(t *testing.T) {
+	do := NewDataObject()
+	do.Set("title", "Default")
+	do.SetLocalized("title", "en", "Hello")
+	do.SetLocalized("title", "bg", "Здравей")
+	do.SetLocalized("title", "en-GB", "Hello, mate")
+
+	if do.Get("title.en") != "Hello" {
+		t.Error("Expected: Hello, but found:", do.Get("title.en"))
+	}
+
+	tests := []struct {
+		locale    string
+		fallbacks []string
+		expected  string
+	}{
+		{"en-GB", nil, "Hello, mate"},
+		{"bg-BG", nil, "Здравей"},
+		{"de", []string{"en"}, "Hello"},
+		{"de", nil, "Default"},
+	}
+
+	for _, test := range tests {
+		if value := do.GetLocalized("title", test.locale, test.fallbacks...); value != test.expected {
+			t.Error("Expected:", test.expected, "but found:", value)
+		}
+	}
+
+	if locales := do.Locales("title"); !reflect.DeepEqual(locales, []string{"bg", "en", "en-GB"}) {
+		t.Error("Expected: [bg en en-GB], but found:", locales)
+	}
+}
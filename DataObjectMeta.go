@@ -0,0 +1,27 @@
+package dataobject
+
+// Meta returns the metadata value of the key, or nil if not set
+//
+// Metadata is runtime bookkeeping kept separately from the data
+// (i.e. the source repository, the load time or a cache generation).
+// It is not part of Data, not tracked as changes, and never serialized
+func (do *DataObject) Meta(key string) any {
+	return do.meta[key]
+}
+
+// SetMeta sets the metadata value of the key (see Meta)
+func (do *DataObject) SetMeta(key string, value any) {
+	if do.meta == nil {
+		do.meta = map[string]any{}
+	}
+	do.meta[key] = value
+}
+
+// Metadata returns a copy of all the metadata (see Meta)
+func (do *DataObject) Metadata() map[string]any {
+	metadata := make(map[string]any, len(do.meta))
+	for key, value := range do.meta {
+		metadata[key] = value
+	}
+	return metadata
+}
@@ -0,0 +1,37 @@
+package dataobject
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestDataObjectMeta(t *testing.T) {
+	user := NewDataObjectFromExistingData(map[string]string{"id": "1"})
+
+	loadedAt := time.Now()
+	user.SetMeta("loaded_at", loadedAt)
+	user.SetMeta("source", "users_table")
+
+	if user.Meta("loaded_at") != loadedAt {
+		t.Error("Expected:", loadedAt, "but found:", user.Meta("loaded_at"))
+	}
+
+	if user.Meta("missing") != nil {
+		t.Error("Expected: nil, but found:", user.Meta("missing"))
+	}
+
+	if user.IsDirty() {
+		t.Error("Expected object NOT to be dirty")
+	}
+
+	json, _ := user.ToJSON()
+
+	if strings.Contains(json, "users_table") {
+		t.Error("Expected metadata NOT to be serialized, but found:", json)
+	}
+
+	if len(user.Metadata()) != 2 {
+		t.Error("Expected: 2, but found:", len(user.Metadata()))
+	}
+}
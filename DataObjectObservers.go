@@ -0,0 +1,73 @@
+package dataobject
+
+// ChangeHandler is called after the value of a key has been changed.
+// For removed keys the new value is empty
+type ChangeHandler func(key string, oldValue string, newValue string)
+
+// BeforeChangeHandler is called before the value of a key is changed.
+// Returning false vetoes the change
+type BeforeChangeHandler func(key string, oldValue string, newValue string) bool
+
+// OnChange subscribes the handler to the changes made
+// via Set, SetData and Remove. Hydrate does not fire handlers
+func (do *DataObject) OnChange(handler ChangeHandler) {
+	do.changeHandlers = append(do.changeHandlers, handler)
+}
+
+// OnBeforeChange subscribes the handler to the changes about to be
+// made via Set, SetData and Remove. If any of the handlers returns
+// false the change is not applied (i.e. to enforce invariants)
+func (do *DataObject) OnBeforeChange(handler BeforeChangeHandler) {
+	do.beforeChangeHandlers = append(do.beforeChangeHandlers, handler)
+}
+
+// beforeChange calls the before change handlers,
+// returns false if any of them vetoes the change
+func (do *DataObject) beforeChange(key string, oldValue string, newValue string) bool {
+	for _, handler := range do.beforeChangeHandlers {
+		if !handler(key, oldValue, newValue) {
+			return false
+		}
+	}
+	return true
+}
+
+// change calls the change handlers, or defers them until
+// the end of the batch (see Batch)
+func (do *DataObject) change(key string, oldValue string, newValue string) {
+	if do.batch != nil {
+		do.batch.record(key, oldValue, newValue)
+		return
+	}
+	for _, handler := range do.changeHandlers {
+		handler(key, oldValue, newValue)
+	}
+	// the handlers may unsubscribe themselves
+	for _, subscription := range append([]*subscription{}, do.subscriptions...) {
+		subscription.handler(key, oldValue, newValue)
+	}
+}
+
+// subscription is a change handler, which can be unsubscribed,
+// i.e. of a parent object embedding the object
+type subscription struct {
+	handler ChangeHandler
+}
+
+// subscribe subscribes the handler to the changes like OnChange,
+// returns the subscription to unsubscribe it
+func (do *DataObject) subscribe(handler ChangeHandler) *subscription {
+	s := &subscription{handler: handler}
+	do.subscriptions = append(do.subscriptions, s)
+	return s
+}
+
+// unsubscribe removes the subscription, if it is subscribed
+func (do *DataObject) unsubscribe(s *subscription) {
+	for i, subscribed := range do.subscriptions {
+		if subscribed == s {
+			do.subscriptions = append(do.subscriptions[:i:i], do.subscriptions[i+1:]...)
+			return
+		}
+	}
+}
@@ -0,0 +1,63 @@
+package dataobject
+
+import (
+	"testing"
+)
+
+func TestDataObjectOnChange(t *testing.T) {
+	user := NewDataObjectFromExistingData(map[string]string{"id": "1", "first_name": "Jon"})
+
+	changes := []string{}
+	user.OnChange(func(key string, oldValue string, newValue string) {
+		changes = append(changes, key+":"+oldValue+"->"+newValue)
+	})
+
+	user.Set("first_name", "Jane")
+	user.SetData(map[string]string{"last_name": "Doe"})
+	user.Remove("first_name")
+
+	expected := []string{"first_name:Jon->Jane", "last_name:->Doe", "first_name:Jane->"}
+
+	if len(changes) != len(expected) {
+		t.Fatal("Expected:", expected, "but found:", changes)
+	}
+
+	for i := range expected {
+		if changes[i] != expected[i] {
+			t.Error("Expected:", expected[i], "but found:", changes[i])
+		}
+	}
+}
+
+func TestDataObjectOnBeforeChangeVeto(t *testing.T) {
+	user := NewDataObjectFromExistingData(map[string]string{"id": "1", "status": "active"})
+
+	user.OnBeforeChange(func(key string, oldValue string, newValue string) bool {
+		return key != "id"
+	})
+
+	called := false
+	user.OnChange(func(key string, oldValue string, newValue string) {
+		called = true
+	})
+
+	user.SetID("2")
+
+	if user.ID() != "1" {
+		t.Error("Expected: 1, but found:", user.ID())
+	}
+
+	if user.IsDirty() {
+		t.Error("Expected object NOT to be dirty")
+	}
+
+	if called {
+		t.Error("Expected change handler NOT to be called")
+	}
+
+	user.Set("status", "inactive")
+
+	if user.Get("status") != "inactive" {
+		t.Error("Expected: inactive, but found:", user.Get("status"))
+	}
+}
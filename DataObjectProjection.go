@@ -0,0 +1,49 @@
+package dataobject
+
+import "encoding/json"
+
+// HydrateKeys sets only the specified keys of the data for the
+// object (a projection), without marking it as dirty. The passed
+// map is not retained, so the remaining keys can be released
+func (do *DataObject) HydrateKeys(data map[string]string, keys ...string) {
+	do.Hydrate(onlyKeys(data, keys))
+}
+
+// NewDataObjectFromExistingDataKeys creates a new data object
+// and hydrates it with only the specified keys of the passed data,
+// i.e. for list views, which need only a few of many columns
+func NewDataObjectFromExistingDataKeys(data map[string]string, keys ...string) *DataObject {
+	o := &DataObject{}
+	o.HydrateKeys(data, keys...)
+	return o
+}
+
+// ToJSONOnly converts only the specified keys of the DataObject to a JSON string
+func (do *DataObject) ToJSONOnly(keys ...string) (string, error) {
+	return mapToJSON(do.ToMapOnly(keys...))
+}
+
+// ToJSONExcept converts the DataObject without the specified keys to a JSON string
+func (do *DataObject) ToJSONExcept(keys ...string) (string, error) {
+	return mapToJSON(do.ToMapExcept(keys...))
+}
+
+// ToMapOnly returns a copy of the data with only the specified keys
+func (do *DataObject) ToMapOnly(keys ...string) map[string]string {
+	return onlyKeys(do.Data(), keys)
+}
+
+// ToMapExcept returns a copy of the data without the specified keys
+func (do *DataObject) ToMapExcept(keys ...string) map[string]string {
+	return exceptKeys(do.Data(), keys)
+}
+
+// mapToJSON converts the data to a JSON string
+func mapToJSON(data map[string]string) (string, error) {
+	jsonValue, err := json.Marshal(data)
+	if err != nil {
+		return "", err
+	}
+
+	return string(jsonValue), nil
+}
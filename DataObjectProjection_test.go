@@ -0,0 +1,60 @@
+package dataobject
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestHydrateKeys(t *testing.T) {
+	data := map[string]string{"id": "1", "name": "Jon", "email": "jon@test.com", "bio": "..."}
+
+	do := NewDataObjectFromExistingDataKeys(data, "id", "name", "missing")
+
+	expected := map[string]string{"id": "1", "name": "Jon"}
+
+	if !reflect.DeepEqual(do.Data(), expected) {
+		t.Error("Expected:", expected, "but found:", do.Data())
+	}
+
+	if do.IsDirty() {
+		t.Error("Expected: false, but found:", do.IsDirty())
+	}
+
+	do.Set("name", "Jane")
+
+	if data["name"] != "Jon" {
+		t.Error("Expected: Jon, but found:", data["name"])
+	}
+}
+
+func TestToJSONOnlyAndExcept(t *testing.T) {
+	do := NewDataObjectFromExistingData(map[string]string{"id": "1", "name": "Jon", "password": "secret"})
+
+	only, err := do.ToJSONOnly("id", "name")
+
+	if err != nil {
+		t.Fatal("Error must be nil, but found:", err.Error())
+	}
+
+	if only != `{"id":"1","name":"Jon"}` {
+		t.Error(`Expected: {"id":"1","name":"Jon"}, but found:`, only)
+	}
+
+	except, err := do.ToJSONExcept("password")
+
+	if err != nil {
+		t.Fatal("Error must be nil, but found:", err.Error())
+	}
+
+	if except != only {
+		t.Error("Expected:", only, "but found:", except)
+	}
+
+	if _, exists := do.ToMapExcept("password")["password"]; exists {
+		t.Error("Expected password NOT to be set")
+	}
+
+	if do.Get("password") != "secret" {
+		t.Error("Expected: secret, but found:", do.Get("password"))
+	}
+}
@@ -0,0 +1,105 @@
+package dataobject
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// Query returns the value at the path, supporting a JSONPath subset
+// of object keys and array indexes, i.e. "$.items[0].sku" or "items[0].sku"
+//
+// The path is resolved over the nested reconstruction of the data
+// (see Unflatten), decoding values holding JSON documents on the way,
+// so both flattened keys and embedded JSON (see SetObject) are queried
+//
+// Objects and arrays are returned as JSON. Returns an error wrapping
+// ErrNotFound if nothing is at the path, or ErrInvalidPath, also if the
+// keys under the first segment of the path have array indexes out of
+// range (see Unflatten)
+func (do *DataObject) Query(path string) (string, error) {
+	path = strings.TrimPrefix(strings.TrimPrefix(path, "$"), ".")
+
+	if value, exists := do.Data()[path]; exists {
+		return value, nil
+	}
+
+	segments, ok := parsePath(path)
+	if !ok {
+		return "", fmt.Errorf("%w: %s", ErrInvalidPath, path)
+	}
+
+	nested, err := Unflatten(querySubtree(do.Data(), segments[0].key))
+	if err != nil {
+		return "", err
+	}
+
+	var node any = nested
+	for _, segment := range segments {
+		node, ok = querySegment(node, segment)
+		if !ok {
+			return "", notFound(path)
+		}
+	}
+
+	switch v := node.(type) {
+	case nil:
+		return "", nil
+	case string:
+		return v, nil
+	case json.Number:
+		return v.String(), nil
+	case bool:
+		return toString(v), nil
+	default:
+		jsonValue, err := json.Marshal(v)
+		if err != nil {
+			return "", err
+		}
+		return string(jsonValue), nil
+	}
+}
+
+// querySegment returns the child of the node at the segment,
+// decoding the node first if it is a string holding a JSON document
+func querySegment(node any, segment pathSegment) (any, bool) {
+	if value, isString := node.(string); isString {
+		trimmed := strings.TrimSpace(value)
+		if !strings.HasPrefix(trimmed, "{") && !strings.HasPrefix(trimmed, "[") {
+			return nil, false
+		}
+
+		decoder := json.NewDecoder(strings.NewReader(trimmed))
+		decoder.UseNumber()
+		if err := decoder.Decode(&node); err != nil {
+			return nil, false
+		}
+	}
+
+	if segment.isIndex {
+		array, isArray := node.([]any)
+		if !isArray || segment.index >= len(array) {
+			return nil, false
+		}
+		return array[segment.index], true
+	}
+
+	object, isObject := node.(map[string]any)
+	if !isObject {
+		return nil, false
+	}
+	child, exists := object[segment.key]
+	return child, exists
+}
+
+// querySubtree returns the data of the keys, which are the top-level key
+// or nested under it, so only the queried subtree is unflattened
+func querySubtree(data map[string]string, topKey string) map[string]string {
+	subtree := map[string]string{}
+	for key, value := range data {
+		if key == topKey || strings.HasPrefix(key, topKey+".") || strings.HasPrefix(key, topKey+"[") {
+			subtree[key] = value
+		}
+	}
+	return subtree
+}
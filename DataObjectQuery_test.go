@@ -0,0 +1,67 @@
+package dataobject
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestQuery(t *testing.T) {
+	order := NewDataObject()
+	order.Set("customer.name", "Jon")
+	order.Set("lines[0].qty", "2")
+	order.Set("payload", `{"items":[{"sku":"A","price":9.5},{"sku":"B"}],"paid":true}`)
+
+	tests := map[string]string{
+		"customer.name":          "Jon",
+		"$.customer.name":        "Jon",
+		"customer":               `{"name":"Jon"}`,
+		"lines[0].qty":           "2",
+		"$.payload.items[1].sku": "B",
+		"payload.items[0].price": "9.5",
+		"payload.paid":           "true",
+		"payload.items[0]":       `{"price":9.5,"sku":"A"}`,
+	}
+
+	for path, expected := range tests {
+		value, err := order.Query(path)
+		if err != nil {
+			t.Fatal("Error must be nil, but found:", err.Error())
+		}
+		if value != expected {
+			t.Error("Expected:", expected, "but found:", value, "for", path)
+		}
+	}
+}
+
+func TestQueryErrors(t *testing.T) {
+	order := NewDataObject()
+	order.Set("payload", `{"items":[{"sku":"A"}]}`)
+
+	if _, err := order.Query("payload.items[5].sku"); !errors.Is(err, ErrNotFound) {
+		t.Error("Expected: ErrNotFound, but found:", err)
+	}
+
+	if _, err := order.Query("payload.items[x]"); !errors.Is(err, ErrInvalidPath) {
+		t.Error("Expected: ErrInvalidPath, but found:", err)
+	}
+}
+
+func TestQueryIndexOutOfRange(t *testing.T) {
+	do := NewDataObjectFromExistingData(map[string]string{
+		"customer.name":  "Jon",
+		"a[99999999999]": "1",
+		"b[100000000]":   "2",
+	})
+
+	if _, err := do.Query("a[0]"); !errors.Is(err, ErrInvalidPath) {
+		t.Error("Expected: ErrInvalidPath, but found:", err)
+	}
+
+	if _, err := do.Query("b[100000000]"); err != nil {
+		t.Error("Error must be nil for the key as is, but found:", err)
+	}
+
+	if value, err := do.Query("$.customer"); err != nil || value != `{"name":"Jon"}` {
+		t.Error("Expected: Jon regardless of the other keys, but found:", value, err)
+	}
+}
@@ -0,0 +1,41 @@
+package dataobject
+
+import "sort"
+
+// WithRawKeys marks the keys as raw (i.e. stored templates or code
+// snippets), so their values are kept byte for byte: they are never
+// changed by value normalizers (see WithValueNormalizer), by
+// ReplaceInValues, or by Pipeline.Transform
+//
+// Rendering still escapes raw values, as it does not change the data
+func (do *DataObject) WithRawKeys(keys ...string) *DataObject {
+	if do.rawKeys == nil {
+		do.rawKeys = map[string]struct{}{}
+	}
+	for _, key := range keys {
+		do.rawKeys[do.resolveAlias(key)] = struct{}{}
+	}
+	return do
+}
+
+// IsRawKey returns if the key has been marked as raw (see WithRawKeys)
+func (do *DataObject) IsRawKey(key string) bool {
+	_, isRaw := do.rawKeys[do.resolveAlias(key)]
+	return isRaw
+}
+
+// RawKeys returns the keys marked as raw, sorted
+func (do *DataObject) RawKeys() []string {
+	keys := make([]string, 0, len(do.rawKeys))
+	for key := range do.rawKeys {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// isRawKey returns if the object has marked the key as raw
+func isRawKey(do DataObjectInterface, key string) bool {
+	raw, hasRawKeys := do.(interface{ IsRawKey(key string) bool })
+	return hasRawKeys && raw.IsRawKey(key)
+}
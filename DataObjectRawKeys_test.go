@@ -0,0 +1,44 @@
+package dataobject
+
+import (
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestDataObjectRawKeys(t *testing.T) {
+	template := "  <p>Jose\u0301</p>\n\t"
+
+	do := NewDataObjectFromExistingData(map[string]string{"id": "1", "template": template, "name": "Jose\u0301"}).
+		WithRawKeys("template").
+		NormalizeUnicode()
+
+	if do.Get("template") != template || do.Get("name") != "Jos\u00e9" {
+		t.Error("Expected: the raw value unchanged, but found:", do.Data())
+	}
+
+	do.Set("template", template+"Jose\u0301")
+
+	if do.Get("template") != template+"Jose\u0301" {
+		t.Error("Expected: the raw value unchanged, but found:", do.Get("template"))
+	}
+
+	if replaced := do.ReplaceInValues("p>", "div>"); replaced != 0 {
+		t.Error("Expected: 0, but found:", replaced)
+	}
+
+	if !slices.Equal(do.RawKeys(), []string{"template"}) || !do.IsRawKey("template") {
+		t.Error("Expected: [template], but found:", do.RawKeys())
+	}
+}
+
+func TestPipelineTransformSkipsRawKeys(t *testing.T) {
+	raw := NewDataObjectFromExistingData(map[string]string{"code": "  x  "}).WithRawKeys("code")
+	plain := NewDataObjectFromExistingData(map[string]string{"code": "  x  "})
+
+	objects := NewPipeline().Transform("code", strings.TrimSpace).Run([]DataObjectInterface{raw, plain})
+
+	if objects[0].Data()["code"] != "  x  " || objects[1].Data()["code"] != "x" {
+		t.Error("Expected: only the plain value to be trimmed, but found:", objects[0].Data(), objects[1].Data())
+	}
+}
@@ -0,0 +1,56 @@
+package dataobject
+
+import (
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// FindKeysByValue returns the keys, which have exactly the value, sorted
+func (do *DataObject) FindKeysByValue(value string) []string {
+	keys := []string{}
+	for key, v := range do.Data() {
+		if v == value {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// FindKeysMatching returns the keys, which have a value
+// matching the regular expression, sorted
+func (do *DataObject) FindKeysMatching(pattern *regexp.Regexp) []string {
+	keys := []string{}
+	for key, v := range do.Data() {
+		if pattern.MatchString(v) {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// ReplaceInValues replaces all the occurrences of old with new in
+// all the values, except raw keys (see WithRawKeys), marking the modified
+// keys as dirty. Returns the number of modified keys
+//
+// Example:
+//
+//	replaced := do.ReplaceInValues("https://old.example.com", "https://example.com")
+func (do *DataObject) ReplaceInValues(old string, new string) int {
+	if old == "" {
+		return 0
+	}
+
+	replaced := map[string]string{}
+	for key, value := range do.Data() {
+		if strings.Contains(value, old) && !do.IsRawKey(key) {
+			replaced[key] = strings.ReplaceAll(value, old, new)
+		}
+	}
+
+	do.SetData(replaced)
+
+	return len(replaced)
+}
@@ -0,0 +1,48 @@
+package dataobject
+
+import (
+	"reflect"
+	"regexp"
+	"testing"
+)
+
+func TestFindKeys(t *testing.T) {
+	do := NewDataObjectFromExistingData(map[string]string{
+		"id":      "1",
+		"home":    "https://old.example.com",
+		"blog":    "https://old.example.com/blog",
+		"backup":  "https://old.example.com",
+		"comment": "none",
+	})
+
+	if keys := do.FindKeysByValue("https://old.example.com"); !reflect.DeepEqual(keys, []string{"backup", "home"}) {
+		t.Error("Expected: [backup home], but found:", keys)
+	}
+
+	keys := do.FindKeysMatching(regexp.MustCompile(`^https://old\.`))
+	if !reflect.DeepEqual(keys, []string{"backup", "blog", "home"}) {
+		t.Error("Expected: [backup blog home], but found:", keys)
+	}
+}
+
+func TestReplaceInValues(t *testing.T) {
+	do := NewDataObjectFromExistingData(map[string]string{
+		"id":   "1",
+		"home": "https://old.example.com",
+		"blog": "https://old.example.com/blog",
+	})
+
+	replaced := do.ReplaceInValues("old.example.com", "example.com")
+
+	if replaced != 2 {
+		t.Error("Expected: 2, but found:", replaced)
+	}
+
+	if do.Get("blog") != "https://example.com/blog" {
+		t.Error("Expected: https://example.com/blog, but found:", do.Get("blog"))
+	}
+
+	if len(do.DataChanged()) != 2 {
+		t.Error("Expected: 2, but found:", len(do.DataChanged()))
+	}
+}
@@ -0,0 +1,114 @@
+package dataobject
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+// DataObjectRepositoryInterface is an interface for a store of data objects
+type DataObjectRepositoryInterface interface {
+
+	// Create stores a new object
+	Create(ctx context.Context, do DataObjectInterface) error
+
+	// Find returns the object with the ID. If there is none, the returned
+	// error must wrap ErrNotFound (see IsNotFound)
+	Find(ctx context.Context, id string) (DataObjectInterface, error)
+
+	// Update stores the data of an existing object. If the object does not
+	// exist, the returned error must wrap ErrNotFound
+	Update(ctx context.Context, do DataObjectInterface) error
+
+	// Delete deletes the object with the ID. If the object does not
+	// exist, the returned error must wrap ErrNotFound
+	Delete(ctx context.Context, id string) error
+
+	// List returns up to limit objects (all if limit is 0) sorted by ID,
+	// skipping the first offset objects
+	List(ctx context.Context, offset int, limit int) ([]DataObjectInterface, error)
+
+	// Count returns the number of stored objects
+	Count(ctx context.Context) (int, error)
+}
+
+// ConditionalUpdater is implemented by repositories, which can update
+// an object only if it has not been modified since it was read
+type ConditionalUpdater interface {
+	// UpdateIf stores the data of an existing object, if the stored
+	// object matches the expected entity tag (see ETag). If it does not,
+	// the returned error must wrap ErrVersionConflict
+	UpdateIf(ctx context.Context, do DataObjectInterface, expectedETag string) error
+}
+
+// errQueriesNotSupported is returned when a repository,
+// which must be a Querier, does not support queries
+var errQueriesNotSupported = fmt.Errorf("%w: queries", ErrNotSupported)
+
+// forwardQuery runs the query on the decorated repository, or returns
+// an error wrapping ErrNotSupported if it does not support queries
+func forwardQuery(ctx context.Context, inner DataObjectRepositoryInterface, query *Query) ([]DataObjectInterface, error) {
+	querier, isQuerier := inner.(Querier)
+	if !isQuerier {
+		return nil, errQueriesNotSupported
+	}
+	return querier.Query(ctx, query)
+}
+
+// forwardUpdateIf updates the object in the decorated repository if it
+// matches the entity tag, or returns an error wrapping ErrNotSupported
+// if it does not support conditional updates
+func forwardUpdateIf(ctx context.Context, inner DataObjectRepositoryInterface, do DataObjectInterface, expectedETag string) error {
+	updater, isConditional := inner.(ConditionalUpdater)
+	if !isConditional {
+		return fmt.Errorf("%w: conditional updates", ErrNotSupported)
+	}
+	return updater.UpdateIf(ctx, do, expectedETag)
+}
+
+// forwardForceDelete deletes the object permanently in the decorated
+// repository, or returns an error wrapping ErrNotSupported if it does
+// not support soft delete
+func forwardForceDelete(ctx context.Context, inner DataObjectRepositoryInterface, id string) error {
+	deleter, isForceDeleter := inner.(forceDeleter)
+	if !isForceDeleter {
+		return fmt.Errorf("%w: force delete", ErrNotSupported)
+	}
+	return deleter.ForceDelete(ctx, id)
+}
+
+// forwardRestore restores the soft deleted object in the decorated
+// repository, or returns an error wrapping ErrNotSupported if it does
+// not support soft delete
+func forwardRestore(ctx context.Context, inner DataObjectRepositoryInterface, id string) error {
+	restorer, isRestorer := inner.(restorer)
+	if !isRestorer {
+		return fmt.Errorf("%w: restore", ErrNotSupported)
+	}
+	return restorer.Restore(ctx, id)
+}
+
+// queryRepository returns the objects matching the query, using the
+// repository queries if supported, or else filtering all the objects
+func queryRepository(ctx context.Context, repo DataObjectRepositoryInterface, query *Query) ([]DataObjectInterface, error) {
+	objects, err := forwardQuery(ctx, repo, query)
+	if !errors.Is(err, ErrNotSupported) {
+		return objects, err
+	}
+
+	all, err := repo.List(ctx, 0, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	return query.Apply(all), nil
+}
+
+// forceDelete deletes the object permanently, if the repository
+// supports soft delete, or else deletes it
+func forceDelete(ctx context.Context, repo DataObjectRepositoryInterface, id string) error {
+	if err := forwardForceDelete(ctx, repo, id); !errors.Is(err, ErrNotSupported) {
+		return err
+	}
+	return repo.Delete(ctx, id)
+}
@@ -0,0 +1,34 @@
+package dataobject
+
+// ToMap returns a copy of the data, which can be modified
+// without affecting the object
+func (do *DataObject) ToMap() map[string]string {
+	return copyData(do.Data())
+}
+
+// ToMapAny returns a copy of the data with the values of the schema
+// fields converted to Go values (int64, float64 or bool), so libraries
+// expecting map[string]any get real numbers and booleans
+//
+// Empty values of non string fields are returned as nil. Keys not
+// in the schema (or all keys, if the schema is nil) are kept as strings
+func (do *DataObject) ToMapAny(schema *Schema) map[string]any {
+	result := map[string]any{}
+
+	for key, value := range do.Data() {
+		result[key] = value
+
+		if schema == nil {
+			continue
+		}
+
+		field, exists := schema.Field(key)
+		if !exists || field.Type == "" || field.Type == FieldTypeString {
+			continue
+		}
+
+		result[key] = typedValue(field.Type, value)
+	}
+
+	return result
+}
@@ -0,0 +1,53 @@
+package dataobject
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestToMap(t *testing.T) {
+	do := NewDataObjectFromExistingData(map[string]string{"id": "1"})
+
+	data := do.ToMap()
+	data["id"] = "2"
+
+	if do.ID() != "1" {
+		t.Error("Expected: 1, but found:", do.ID())
+	}
+}
+
+func TestToMapAny(t *testing.T) {
+	schema := NewSchema(
+		SchemaField{Key: "name"},
+		SchemaField{Key: "age", Type: FieldTypeInt},
+		SchemaField{Key: "score", Type: FieldTypeFloat},
+		SchemaField{Key: "active", Type: FieldTypeBool},
+		SchemaField{Key: "deleted_at", Type: FieldTypeDateTime},
+	)
+
+	do := NewDataObjectFromExistingData(map[string]string{
+		"id":         "1",
+		"name":       "",
+		"age":        "30",
+		"score":      "9.5000",
+		"active":     "true",
+		"deleted_at": "",
+	})
+
+	expected := map[string]any{
+		"id":         "1",
+		"name":       "",
+		"age":        int64(30),
+		"score":      9.5,
+		"active":     true,
+		"deleted_at": nil,
+	}
+
+	if result := do.ToMapAny(schema); !reflect.DeepEqual(result, expected) {
+		t.Error("Expected:", expected, "but found:", result)
+	}
+
+	if result := do.ToMapAny(nil); result["age"] != "30" {
+		t.Error("Expected: 30, but found:", result["age"])
+	}
+}
@@ -0,0 +1,23 @@
+package dataobject
+
+// Touch marks the object as dirty without changing any data, so it is
+// persisted anyway (i.e. to refresh updated_at). Cleared by MarkAsNotDirty
+func (do *DataObject) Touch() {
+	do.mustNotBeFrozen()
+	do.touched = true
+}
+
+// MarkFieldDirty marks the key as changed with its current value, even
+// if it has not been modified (see SuppressNoOpChanges). Does nothing
+// if the key is not set
+func (do *DataObject) MarkFieldDirty(key string) {
+	do.mustNotBeFrozen()
+	do.Init()
+	key = do.resolveAlias(key)
+	value, exists := do.lookup(key)
+	if !exists {
+		return
+	}
+	do.dataChanged[key] = value
+	do.recordChange(key, false)
+}
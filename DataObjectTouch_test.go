@@ -0,0 +1,32 @@
+package dataobject
+
+import "testing"
+
+func TestDataObjectTouch(t *testing.T) {
+	do := NewDataObjectFromExistingData(map[string]string{"name": "Jon"})
+
+	do.Touch()
+
+	if !do.IsDirty() || len(do.DataChanged()) != 0 {
+		t.Error("Expected: dirty without changes, but found:", do.IsDirty(), do.DataChanged())
+	}
+
+	do.MarkAsNotDirty()
+
+	if do.IsDirty() {
+		t.Error("Expected: not dirty, but found: dirty")
+	}
+}
+
+func TestDataObjectMarkFieldDirty(t *testing.T) {
+	do := NewDataObjectFromExistingData(map[string]string{"name": "Jon"}).SuppressNoOpChanges()
+
+	do.Set("name", "Jon")
+	do.MarkFieldDirty("name")
+	do.MarkFieldDirty("missing")
+
+	changed := do.DataChanged()
+	if len(changed) != 1 || changed["name"] != "Jon" {
+		t.Error("Expected: name to be changed, but found:", changed)
+	}
+}
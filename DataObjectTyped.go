@@ -0,0 +1,66 @@
+package dataobject
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+)
+
+// GetInt returns the value of the key as an integer
+func (do *DataObject) GetInt(key string) (int64, error) {
+	value, err := strconv.ParseInt(do.Get(key), 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("dataobject: %s: %w", key, err)
+	}
+	return value, nil
+}
+
+// GetFloat returns the value of the key as a float
+func (do *DataObject) GetFloat(key string) (float64, error) {
+	value, err := strconv.ParseFloat(do.Get(key), 64)
+	if err != nil {
+		return 0, fmt.Errorf("dataobject: %s: %w", key, err)
+	}
+	return value, nil
+}
+
+// GetBool returns the value of the key as a boolean
+// (accepts 1, t, T, TRUE, true, True, 0, f, F, FALSE, false, False)
+func (do *DataObject) GetBool(key string) (bool, error) {
+	value, err := strconv.ParseBool(do.Get(key))
+	if err != nil {
+		return false, fmt.Errorf("dataobject: %s: %w", key, err)
+	}
+	return value, nil
+}
+
+// GetTime returns the value of the key as a time,
+// parsed in DateTimeFormat (as UTC) or RFC 3339
+func (do *DataObject) GetTime(key string) (time.Time, error) {
+	value, err := parseDateTime(do.Get(key))
+	if err != nil {
+		return time.Time{}, fmt.Errorf("dataobject: %s: %w", key, err)
+	}
+	return value, nil
+}
+
+// SetInt sets the value of the key to an integer
+func (do *DataObject) SetInt(key string, value int64) {
+	do.Set(key, strconv.FormatInt(value, 10))
+}
+
+// SetFloat sets the value of the key to a float with 4 decimals,
+// matching the conversion of numbers in the JSON constructors
+func (do *DataObject) SetFloat(key string, value float64) {
+	do.Set(key, toString(value))
+}
+
+// SetBool sets the value of the key to a boolean ("true" or "false")
+func (do *DataObject) SetBool(key string, value bool) {
+	do.Set(key, strconv.FormatBool(value))
+}
+
+// SetTime sets the value of the key to a time in DateTimeFormat in UTC
+func (do *DataObject) SetTime(key string, value time.Time) {
+	do.Set(key, value.UTC().Format(DateTimeFormat))
+}
@@ -0,0 +1,47 @@
+package dataobject
+
+import (
+	"testing"
+	"time"
+)
+
+func TestDataObjectTypedAccess(t *testing.T) {
+	user := NewDataObject()
+
+	user.SetInt("age", 30)
+	user.SetFloat("balance", 12.5)
+	user.SetBool("active", true)
+	user.SetTime("created_at", time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC))
+
+	if user.Get("balance") != "12.5000" {
+		t.Error("Expected: 12.5000, but found:", user.Get("balance"))
+	}
+
+	if user.Get("created_at") != "2024-01-02 03:04:05" {
+		t.Error("Expected: 2024-01-02 03:04:05, but found:", user.Get("created_at"))
+	}
+
+	age, err := user.GetInt("age")
+	if err != nil || age != 30 {
+		t.Error("Expected: 30, but found:", age, err)
+	}
+
+	balance, err := user.GetFloat("balance")
+	if err != nil || balance != 12.5 {
+		t.Error("Expected: 12.5, but found:", balance, err)
+	}
+
+	active, err := user.GetBool("active")
+	if err != nil || !active {
+		t.Error("Expected: true, but found:", active, err)
+	}
+
+	createdAt, err := user.GetTime("created_at")
+	if err != nil || createdAt.Day() != 2 {
+		t.Error("Expected: 2024-01-02 03:04:05, but found:", createdAt, err)
+	}
+
+	if _, err := user.GetInt("missing"); err == nil {
+		t.Error("Error must NOT be nil, but found:", err)
+	}
+}
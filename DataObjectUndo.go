@@ -0,0 +1,130 @@
+package dataobject
+
+// undoEntry is a single recorded change
+type undoEntry struct {
+	key       string
+	oldValue  string
+	oldExists bool
+	newValue  string
+	newExists bool
+}
+
+// undoHistory holds the undo and redo stacks of a data object
+type undoHistory struct {
+	depth int
+
+	undo []undoEntry
+	redo []undoEntry
+
+	// pendingExists holds if the keys being changed existed before the
+	// change, or before the batch for the keys changed in a batch
+	pendingExists map[string]bool
+
+	// applying is true while an undo or redo is being applied
+	applying bool
+}
+
+// EnableUndo starts recording the changes made via Set, SetData and
+// Remove, keeping up to depth most recent changes for Undo and Redo.
+// Calling it again resets the history with the new depth
+func (do *DataObject) EnableUndo(depth int) {
+	if do.history != nil {
+		do.history.depth = depth
+		do.history.undo = nil
+		do.history.redo = nil
+		return
+	}
+
+	history := &undoHistory{depth: depth, pendingExists: map[string]bool{}}
+	do.history = history
+
+	do.OnBeforeChange(func(key string, oldValue string, newValue string) bool {
+		// the handlers are notified of the change from before the batch
+		if do.batch != nil && do.batch.hasChange(key) {
+			return true
+		}
+		_, history.pendingExists[key] = do.lookup(key)
+		return true
+	})
+
+	do.OnChange(func(key string, oldValue string, newValue string) {
+		oldExists := history.pendingExists[key]
+		delete(history.pendingExists, key)
+
+		if history.applying || history.depth < 1 {
+			return
+		}
+
+		_, newExists := do.lookup(key)
+
+		history.undo = append(history.undo, undoEntry{
+			key:       key,
+			oldValue:  oldValue,
+			oldExists: oldExists,
+			newValue:  newValue,
+			newExists: newExists,
+		})
+
+		if len(history.undo) > history.depth {
+			history.undo = history.undo[len(history.undo)-history.depth:]
+		}
+
+		history.redo = nil
+	})
+}
+
+// CanUndo returns if there is a change to undo
+func (do *DataObject) CanUndo() bool {
+	return do.history != nil && len(do.history.undo) > 0
+}
+
+// CanRedo returns if there is an undone change to redo
+func (do *DataObject) CanRedo() bool {
+	return do.history != nil && len(do.history.redo) > 0
+}
+
+// Undo reverts the most recent change,
+// returns false if there is nothing to undo
+func (do *DataObject) Undo() bool {
+	if !do.CanUndo() {
+		return false
+	}
+
+	entry := do.history.undo[len(do.history.undo)-1]
+	do.history.undo = do.history.undo[:len(do.history.undo)-1]
+
+	do.applyUndoEntry(entry.key, entry.oldValue, entry.oldExists)
+
+	do.history.redo = append(do.history.redo, entry)
+
+	return true
+}
+
+// Redo re-applies the most recently undone change,
+// returns false if there is nothing to redo
+func (do *DataObject) Redo() bool {
+	if !do.CanRedo() {
+		return false
+	}
+
+	entry := do.history.redo[len(do.history.redo)-1]
+	do.history.redo = do.history.redo[:len(do.history.redo)-1]
+
+	do.applyUndoEntry(entry.key, entry.newValue, entry.newExists)
+
+	do.history.undo = append(do.history.undo, entry)
+
+	return true
+}
+
+// applyUndoEntry sets or removes the key without recording the change
+func (do *DataObject) applyUndoEntry(key string, value string, exists bool) {
+	do.history.applying = true
+	defer func() { do.history.applying = false }()
+
+	if exists {
+		do.Set(key, value)
+	} else {
+		do.Remove(key)
+	}
+}
@@ -0,0 +1,102 @@
+package dataobject
+
+import (
+	"testing"
+)
+
+func TestDataObjectUndoRedo(t *testing.T) {
+	user := NewDataObjectFromExistingData(map[string]string{"id": "1", "first_name": "Jon"})
+	user.EnableUndo(10)
+
+	user.Set("first_name", "Jane")
+	user.Set("last_name", "Doe")
+
+	if !user.Undo() {
+		t.Fatal("Expected undo to succeed")
+	}
+
+	if _, exists := user.Data()["last_name"]; exists {
+		t.Error("Expected last_name to be removed, but found:", user.Get("last_name"))
+	}
+
+	if !user.Undo() {
+		t.Fatal("Expected undo to succeed")
+	}
+
+	if user.Get("first_name") != "Jon" {
+		t.Error("Expected: Jon, but found:", user.Get("first_name"))
+	}
+
+	if user.Undo() {
+		t.Error("Expected nothing to undo")
+	}
+
+	if !user.Redo() {
+		t.Fatal("Expected redo to succeed")
+	}
+
+	if user.Get("first_name") != "Jane" {
+		t.Error("Expected: Jane, but found:", user.Get("first_name"))
+	}
+
+	// a new change clears the redo stack
+	user.Set("first_name", "Jim")
+
+	if user.CanRedo() {
+		t.Error("Expected nothing to redo")
+	}
+}
+
+func TestDataObjectUndoDepth(t *testing.T) {
+	user := NewDataObjectFromExistingData(map[string]string{"id": "1"})
+	user.EnableUndo(2)
+
+	user.Set("counter", "1")
+	user.Set("counter", "2")
+	user.Set("counter", "3")
+
+	user.Undo()
+	user.Undo()
+
+	if user.Undo() {
+		t.Error("Expected nothing to undo")
+	}
+
+	if user.Get("counter") != "1" {
+		t.Error("Expected: 1, but found:", user.Get("counter"))
+	}
+}
+
+func TestDataObjectUndoBatch(t *testing.T) {
+	user := NewDataObjectFromExistingData(map[string]string{"id": "1", "first_name": "Jon", "tmp": "x"})
+	user.EnableUndo(10)
+
+	user.Batch(func(b Setter) {
+		b.Set("last_name", "Doe")
+		b.Set("last_name", "Smith")
+		b.Remove("tmp")
+		b.Set("first_name", "Jane")
+	})
+
+	for user.Undo() {
+	}
+
+	if _, exists := user.Data()["last_name"]; exists {
+		t.Error("Expected: last_name to be removed, but found:", user.Data())
+	}
+
+	if user.Get("first_name") != "Jon" || user.Get("tmp") != "x" {
+		t.Error("Expected: Jon and x, but found:", user.Data())
+	}
+
+	for user.Redo() {
+	}
+
+	if user.Get("last_name") != "Smith" || user.Get("first_name") != "Jane" {
+		t.Error("Expected: Jane Smith, but found:", user.Data())
+	}
+
+	if _, exists := user.Data()["tmp"]; exists {
+		t.Error("Expected: tmp to be removed, but found:", user.Data())
+	}
+}
@@ -0,0 +1,56 @@
+package dataobject
+
+import "golang.org/x/text/unicode/norm"
+
+// ValueNormalizer normalizes a value before it is stored
+type ValueNormalizer func(value string) string
+
+// WithValueNormalizer normalizes the values with the function on Set
+// and Hydrate, except the values of raw keys (see WithRawKeys). The
+// current data is normalized right away, without marking it as dirty.
+// It panics if the object is frozen (see Freeze)
+func (do *DataObject) WithValueNormalizer(normalizer ValueNormalizer) *DataObject {
+	do.mustNotBeFrozen()
+	do.valueNormalizer = normalizer
+
+	do.data = do.normalizeValues(do.data)
+	do.dataChanged = do.normalizeValues(do.dataChanged)
+	do.view = nil
+
+	return do
+}
+
+// NormalizeUnicode normalizes the values to the Unicode NFC form (see
+// WithValueNormalizer), so text from clients sending NFC and NFD
+// (i.e. "é" as one or two code points) compares and deduplicates equal
+func (do *DataObject) NormalizeUnicode() *DataObject {
+	return do.WithValueNormalizer(NFC)
+}
+
+// NFC returns the value in the Unicode NFC form
+func NFC(value string) string {
+	return norm.NFC.String(value)
+}
+
+// normalizeValue returns the normalized value of the key,
+// if there is a value normalizer and the key is not raw
+func (do *DataObject) normalizeValue(key string, value string) string {
+	if _, isRaw := do.rawKeys[key]; isRaw || do.valueNormalizer == nil {
+		return value
+	}
+	return do.valueNormalizer(value)
+}
+
+// normalizeValues returns a copy of the data with the values of the keys,
+// which are not raw, normalized, or the data itself if there is no value normalizer
+func (do *DataObject) normalizeValues(data map[string]string) map[string]string {
+	if do.valueNormalizer == nil || data == nil {
+		return data
+	}
+
+	normalized := make(map[string]string, len(data))
+	for key, value := range data {
+		normalized[key] = do.normalizeValue(key, value)
+	}
+	return normalized
+}
@@ -0,0 +1,63 @@
+package dataobject
+
+import "testing"
+
+func TestDataObjectNormalizeUnicode(t *testing.T) {
+	nfd := "Jose\u0301"
+	nfc := "Jos\u00e9"
+
+	do := NewDataObjectFromExistingData(map[string]string{"id": "1", "name": nfd}).NormalizeUnicode()
+
+	if do.Get("name") != nfc || do.IsDirty() {
+		t.Error("Expected: the hydrated value in NFC, but found:", do.Get("name"), do.IsDirty())
+	}
+
+	do.Set("city", "Mu\u0308nchen")
+
+	if do.Get("city") != "M\u00fcnchen" {
+		t.Error("Expected: the set value in NFC, but found:", do.Get("city"))
+	}
+
+	do.Hydrate(map[string]string{"id": "1", "name": nfd})
+
+	if do.Get("name") != nfc {
+		t.Error("Expected: the hydrated value in NFC, but found:", do.Get("name"))
+	}
+}
+
+func TestDataObjectNormalizeUnicodeSuppressesNoOps(t *testing.T) {
+	do := NewDataObjectFromExistingData(map[string]string{"name": "Jos\u00e9"}).NormalizeUnicode().SuppressNoOpChanges()
+
+	do.Set("name", "Jose\u0301")
+
+	if do.IsDirty() {
+		t.Error("Expected: not dirty, but found:", do.DataChanged())
+	}
+}
+
+func TestDataObjectNormalizeUnicodeHydrateKeepsChanges(t *testing.T) {
+	do := NewDataObject().NormalizeUnicode()
+	do.Set("name", "Jon")
+
+	do.Hydrate(map[string]string{"name": "Jose\u0301"})
+
+	if do.Get("name") != "Jos\u00e9" || do.DataChanged()["name"] != "Jos\u00e9" {
+		t.Error("Expected: the changed value in NFC, but found:", do.DataChanged())
+	}
+}
+
+func TestDataObjectValueNormalizerFrozen(t *testing.T) {
+	do := NewDataObjectFromExistingData(map[string]string{"name": "José"})
+	do.Freeze()
+
+	defer func() {
+		if recovered := recover(); recovered == nil {
+			t.Error("Expected: a panic on a frozen object, but found: none")
+		}
+		if do.Get("name") != "José" {
+			t.Error("Expected: the data to be unchanged, but found:", do.Data())
+		}
+	}()
+
+	do.NormalizeUnicode()
+}
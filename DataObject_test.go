@@ -38,3 +38,139 @@ func TestDataObjectToJSON(t *testing.T) {
 		t.Error(`Expected to contain: "last_name":"Doe", but found:`, json)
 	}
 }
+
+func TestDataObjectRemove(t *testing.T) {
+	user := NewDataObjectFromExistingData(map[string]string{"id": "1", "first_name": "Jon"})
+
+	user.Remove("first_name")
+
+	if _, exists := user.Data()["first_name"]; exists {
+		t.Error("Expected first_name to be removed, but found:", user.Get("first_name"))
+	}
+
+	if !user.IsDirty() {
+		t.Error("Expected object to be dirty")
+	}
+
+	if len(user.DataRemoved()) != 1 || user.DataRemoved()[0] != "first_name" {
+		t.Error("Expected: [first_name], but found:", user.DataRemoved())
+	}
+
+	user.MarkAsNotDirty()
+
+	if user.IsDirty() {
+		t.Error("Expected object NOT to be dirty")
+	}
+}
+
+func TestDataObjectRenameKey(t *testing.T) {
+	user := NewDataObjectFromExistingData(map[string]string{"id": "1", "first_name": "Jon"})
+
+	user.RenameKey("first_name", "given_name")
+
+	if user.Get("given_name") != "Jon" {
+		t.Error("Expected: Jon, but found:", user.Get("given_name"))
+	}
+
+	if user.DataChanged()["given_name"] != "Jon" {
+		t.Error("Expected: Jon, but found:", user.DataChanged()["given_name"])
+	}
+
+	if len(user.DataRemoved()) != 1 || user.DataRemoved()[0] != "first_name" {
+		t.Error("Expected: [first_name], but found:", user.DataRemoved())
+	}
+}
+
+func TestDataObjectRegenerateID(t *testing.T) {
+	user := NewDataObjectFromExistingData(map[string]string{"id": "1", "first_name": "Jon"})
+
+	oldID, newID := user.RegenerateID()
+
+	if oldID != "1" {
+		t.Error("Expected: 1, but found:", oldID)
+	}
+
+	if newID == "" || newID == oldID || user.ID() != newID {
+		t.Error("Expected a new ID, but found:", newID)
+	}
+
+	if user.Get("previous_id") != "1" {
+		t.Error("Expected: 1, but found:", user.Get("previous_id"))
+	}
+
+	if user.DataChanged()["id"] != newID {
+		t.Error("Expected:", newID, "but found:", user.DataChanged()["id"])
+	}
+}
+
+func TestDataObjectOriginal(t *testing.T) {
+	user := NewDataObjectFromExistingData(map[string]string{"id": "1", "name": "Jon", "city": "Paris"})
+
+	user.Set("name", "Tom")
+	user.Remove("city")
+
+	if original, exists := user.Original("name"); !exists || original != "Jon" {
+		t.Error("Expected: Jon, but found:", original)
+	}
+
+	if original, exists := user.Original("city"); !exists || original != "Paris" {
+		t.Error("Expected: Paris, but found:", original)
+	}
+
+	if user.Get("name") != "Tom" || user.Get("city") != "" || len(user.Data()) != 2 {
+		t.Error("Expected: the changed data, but found:", user.Data())
+	}
+
+	user.MarkAsNotDirty()
+
+	if original, _ := user.Original("name"); original != "Tom" {
+		t.Error("Expected: Tom, but found:", original)
+	}
+
+	if _, exists := user.Original("city"); exists {
+		t.Error("Expected: city not to exist, but found:", user.Data())
+	}
+}
+
+func TestDataObjectDataFollowsChanges(t *testing.T) {
+	user := NewDataObjectFromExistingData(map[string]string{"id": "1", "name": "Jon"})
+	user.Set("name", "Tom")
+
+	data := user.Data()
+	user.Set("email", "tom@example.com")
+	user.Remove("name")
+
+	if data["email"] != "tom@example.com" || data["name"] != "" {
+		t.Error("Expected: the data to follow the changes, but found:", data)
+	}
+}
+
+func TestDataObjectHydrateWhileDirty(t *testing.T) {
+	user := NewDataObjectFromExistingData(map[string]string{"id": "1", "name": "Jon", "city": "Paris"})
+	user.Set("name", "Tom")
+	user.Set("email", "tom@example.com")
+	user.Remove("city")
+
+	user.Hydrate(map[string]string{"id": "1", "name": "Tim", "city": "Rome"})
+
+	if user.Get("name") != "Tim" || user.Get("city") != "Rome" || user.Get("email") != "" {
+		t.Error("Expected: the hydrated data, but found:", user.Data())
+	}
+
+	if changed := user.DataChanged(); len(changed) != 1 || changed["name"] != "Tim" {
+		t.Error("Expected: name to stay changed, but found:", changed)
+	}
+
+	if len(user.DataRemoved()) != 0 {
+		t.Error("Expected: no removed keys, but found:", user.DataRemoved())
+	}
+}
+
+func BenchmarkDataObjectSet(b *testing.B) {
+	user := NewDataObjectFromExistingData(map[string]string{"id": "1", "name": "Jon"})
+
+	for i := 0; i < b.N; i++ {
+		user.Set("name", "Tom")
+		_ = user.Get("name")
+	}
+}
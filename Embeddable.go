@@ -0,0 +1,136 @@
+package dataobject
+
+import (
+	"bytes"
+	"encoding/gob"
+	"encoding/json"
+	"fmt"
+)
+
+// Embeddable is implemented by DataObject, for domain types embedding
+// it (i.e. type User struct { dataobject.DataObject }). Besides the data
+// accessors it provides the extension points of the change handlers,
+// and marshals the embedded state, which is held in unexported fields
+//
+// JSON holds the data only (as ToJSON), while gob holds the changes
+// as well, so dirty objects survive a round trip (i.e. in a session)
+//
+// Note that the domain type is marshaled as the embedded data object,
+// so it should keep all its state in the data
+type Embeddable interface {
+	DataObjectInterface
+	DirtyTrackable
+
+	// OnChange subscribes the handler to the changes of the data
+	OnChange(handler ChangeHandler)
+
+	// OnBeforeChange subscribes the handler to the changes about to be made
+	OnBeforeChange(handler BeforeChangeHandler)
+
+	json.Marshaler
+	json.Unmarshaler
+	gob.GobEncoder
+	gob.GobDecoder
+}
+
+var _ Embeddable = (*DataObject)(nil) // verify it can be embedded
+
+// embeddedState is the gob encoded state of a data object
+type embeddedState struct {
+	// Data is the base data, without the changes
+	Data    map[string]string
+	Changed map[string]string
+	Removed []string
+}
+
+// MarshalJSON encodes the data as a JSON object. It has a value receiver,
+// so domain types embedding the data object by value are marshaled as well
+func (do DataObject) MarshalJSON() ([]byte, error) {
+	return json.Marshal(do.Data())
+}
+
+// UnmarshalJSON hydrates the object from a JSON object,
+// converting non string values as NewDataObjectFromJSON
+func (do *DataObject) UnmarshalJSON(jsonValue []byte) error {
+	object := map[string]any{}
+	if err := json.Unmarshal(jsonValue, &object); err != nil {
+		return fmt.Errorf("%w: %w", ErrInvalidJSON, err)
+	}
+
+	data := mapStringAnyToMapStringString(object)
+	if err := validateID(data); err != nil {
+		return err
+	}
+
+	data, _, err := migrateDecoded(data)
+	if err != nil {
+		return err
+	}
+
+	do.Hydrate(data)
+
+	return nil
+}
+
+// GobEncode encodes the data with the changed and removed keys,
+// followed by a CRC-32 checksum of the encoded state.
+// It has a value receiver, so domain types embedding the data
+// object by value are encoded as well
+func (do DataObject) GobEncode() ([]byte, error) {
+	defer observeSerialization("gob")()
+
+	state := embeddedState{Data: do.data, Changed: do.dataChanged}
+	for key := range do.dataRemoved {
+		state.Removed = append(state.Removed, key)
+	}
+
+	buffer := bytes.Buffer{}
+	if err := gob.NewEncoder(&buffer).Encode(state); err != nil {
+		return nil, err
+	}
+
+	return appendChecksum(buffer.Bytes()), nil
+}
+
+// GobDecode restores the data with the changed and removed keys,
+// upgrading the data if there is a package-level migrator (see
+// SetMigrator). Returns an error wrapping ErrCorrupted if the encoded
+// state is truncated or does not match its checksum
+func (do *DataObject) GobDecode(encoded []byte) error {
+	payload, err := verifyChecksum(encoded)
+	if err != nil {
+		return err
+	}
+
+	state := embeddedState{}
+	if err := gob.NewDecoder(bytes.NewReader(payload)).Decode(&state); err != nil {
+		return fmt.Errorf("%w: %w", ErrCorrupted, err)
+	}
+
+	restored := DataObject{data: state.Data, dataChanged: state.Changed, dataRemoved: map[string]struct{}{}}
+	for _, key := range state.Removed {
+		restored.dataRemoved[key] = struct{}{}
+	}
+
+	if err := validateID(restored.Data()); err != nil {
+		return err
+	}
+
+	migrated, changed, err := migrateDecoded(restored.Data())
+	if err != nil {
+		return err
+	}
+
+	do.mustNotBeFrozen()
+
+	do.data = restored.data
+	do.dataChanged = restored.dataChanged
+	do.dataRemoved = restored.dataRemoved
+	do.view = nil
+
+	if changed {
+		do.Hydrate(migrated)
+	}
+
+	return nil
+}
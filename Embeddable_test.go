@@ -0,0 +1,76 @@
+package dataobject
+
+import (
+	"bytes"
+	"encoding/gob"
+	"encoding/json"
+	"testing"
+)
+
+type testEmbeddingUser struct {
+	DataObject
+}
+
+func (u *testEmbeddingUser) Email() string {
+	return u.Get("email")
+}
+
+func TestEmbeddableJSON(t *testing.T) {
+	user := testEmbeddingUser{}
+	user.SetID("1")
+	user.Set("email", "jon@test.com")
+
+	jsonValue, err := json.Marshal(user)
+
+	if err != nil {
+		t.Fatal("Error must be nil, but found:", err.Error())
+	}
+
+	if string(jsonValue) != `{"email":"jon@test.com","id":"1"}` {
+		t.Error(`Expected: {"email":"jon@test.com","id":"1"}, but found:`, string(jsonValue))
+	}
+
+	decoded := testEmbeddingUser{}
+
+	if err := json.Unmarshal([]byte(`{"id":"2","email":"jane@test.com","age":30}`), &decoded); err != nil {
+		t.Fatal("Error must be nil, but found:", err.Error())
+	}
+
+	if decoded.Email() != "jane@test.com" || decoded.Get("age") != "30.0000" {
+		t.Error("Expected: jane@test.com 30.0000, but found:", decoded.Data())
+	}
+
+	if decoded.IsDirty() {
+		t.Error("Expected: false, but found:", decoded.IsDirty())
+	}
+}
+
+func TestEmbeddableGob(t *testing.T) {
+	user := testEmbeddingUser{DataObject: *NewDataObjectFromExistingData(map[string]string{"id": "1", "tmp": "x"})}
+	user.Set("email", "jon@test.com")
+	user.Remove("tmp")
+
+	buffer := bytes.Buffer{}
+
+	if err := gob.NewEncoder(&buffer).Encode(user); err != nil {
+		t.Fatal("Error must be nil, but found:", err.Error())
+	}
+
+	decoded := testEmbeddingUser{}
+
+	if err := gob.NewDecoder(&buffer).Decode(&decoded); err != nil {
+		t.Fatal("Error must be nil, but found:", err.Error())
+	}
+
+	if decoded.Email() != "jon@test.com" || decoded.ID() != "1" {
+		t.Error("Expected: 1 jon@test.com, but found:", decoded.Data())
+	}
+
+	if decoded.DataChanged()["email"] != "jon@test.com" {
+		t.Error("Expected: jon@test.com, but found:", decoded.DataChanged())
+	}
+
+	if removed := decoded.DataRemoved(); len(removed) != 1 || removed[0] != "tmp" {
+		t.Error("Expected: [tmp], but found:", removed)
+	}
+}
@@ -0,0 +1,100 @@
+package dataobject
+
+import (
+	"encoding/base64"
+	"strings"
+)
+
+// encryptedValuePurpose binds the encrypted values to their use
+const encryptedValuePurpose = "value"
+
+// encryptedValuePrefix is the base64 encoded magic of an encrypted value
+var encryptedValuePrefix = base64.StdEncoding.EncodeToString(encryptedBlobMagic)
+
+// EncryptValue encrypts the value with the current key of the provider,
+// i.e. to store a sensitive key encrypted. The result is base64 encoded
+// and tagged with the ID of the key (see KeyProvider)
+func EncryptValue(keys KeyProvider, value string) (string, error) {
+	blob, err := encryptBlob(keys, []byte(value), encryptedValuePurpose)
+	if err != nil {
+		return "", err
+	}
+	return base64.StdEncoding.EncodeToString(blob), nil
+}
+
+// DecryptValue decrypts the value encrypted by EncryptValue, with the key
+// of its ID from the provider. Returns ErrInvalidSealedData if the value
+// is not encrypted, or has been tampered with
+func DecryptValue(keys KeyProvider, encrypted string) (string, error) {
+	blob, err := base64.StdEncoding.DecodeString(encrypted)
+	if err != nil {
+		return "", ErrInvalidSealedData
+	}
+
+	plaintext, err := decryptBlob(keys, blob, encryptedValuePurpose)
+	if err != nil {
+		return "", err
+	}
+	return string(plaintext), nil
+}
+
+// IsEncryptedValue returns if the value has been encrypted by EncryptValue
+func IsEncryptedValue(value string) bool {
+	return strings.HasPrefix(value, encryptedValuePrefix)
+}
+
+// encryptedValueKeyID returns the ID of the key the value is encrypted with
+func encryptedValueKeyID(encrypted string) (string, bool) {
+	blob, err := base64.StdEncoding.DecodeString(encrypted)
+	if err != nil || !isEncryptedBlob(blob) || len(blob) < len(encryptedBlobMagic)+1 {
+		return "", false
+	}
+
+	headerLength := len(encryptedBlobMagic) + 1 + int(blob[len(encryptedBlobMagic)])
+	if len(blob) < headerLength {
+		return "", false
+	}
+
+	return string(blob[len(encryptedBlobMagic)+1 : headerLength]), true
+}
+
+// ReencryptValues returns a rewrite transformer encrypting the values of
+// the keys with the current key of the provider: the values encrypted
+// with a previous key are re-encrypted (i.e. after a key rotation), the
+// plain values are encrypted. The values already encrypted with the
+// current key are left as they are, so only the objects with other
+// values are written back (see Rewrite)
+func ReencryptValues(keys KeyProvider, dataKeys ...string) RewriteTransformer {
+	return func(do *DataObject) error {
+		currentID, _, err := keys.CurrentKey()
+		if err != nil {
+			return err
+		}
+
+		for _, key := range dataKeys {
+			value, exists := do.Data()[key]
+			if !exists || value == "" {
+				continue
+			}
+
+			if IsEncryptedValue(value) {
+				if keyID, _ := encryptedValueKeyID(value); keyID == currentID {
+					continue
+				}
+
+				if value, err = DecryptValue(keys, value); err != nil {
+					return err
+				}
+			}
+
+			encrypted, err := EncryptValue(keys, value)
+			if err != nil {
+				return err
+			}
+
+			do.Set(key, encrypted)
+		}
+
+		return nil
+	}
+}
@@ -0,0 +1,67 @@
+package dataobject
+
+import (
+	"bytes"
+	"fmt"
+)
+
+// encryptedBlobMagic prefixes the blobs encrypted at rest
+var encryptedBlobMagic = []byte("DOENC1")
+
+// encryptBlob encrypts the plaintext with the current key of the
+// provider, or rather its subkey (see EncryptionKeyLabel), bound to
+// the purpose (i.e. "snapshot"). The blob is the
+// magic, the length and the ID of the key, and the sealed plaintext
+func encryptBlob(keys KeyProvider, plaintext []byte, purpose string) ([]byte, error) {
+	id, key, err := keys.CurrentKey()
+	if err != nil {
+		return nil, err
+	}
+
+	if len(id) > 255 {
+		return nil, fmt.Errorf("dataobject: key id longer than 255 bytes: %q", id)
+	}
+
+	header := make([]byte, 0, len(encryptedBlobMagic)+1+len(id))
+	header = append(header, encryptedBlobMagic...)
+	header = append(header, byte(len(id)))
+	header = append(header, id...)
+
+	sealed, err := seal(deriveKey(key, EncryptionKeyLabel, len(key)), plaintext, append([]byte(purpose), header...))
+	if err != nil {
+		return nil, err
+	}
+
+	return append(header, sealed...), nil
+}
+
+// decryptBlob decrypts the blob produced by encryptBlob for the purpose,
+// with the key of its ID from the provider
+func decryptBlob(keys KeyProvider, blob []byte, purpose string) ([]byte, error) {
+	if !isEncryptedBlob(blob) || len(blob) < len(encryptedBlobMagic)+1 {
+		return nil, ErrInvalidSealedData
+	}
+
+	idLength := int(blob[len(encryptedBlobMagic)])
+	headerLength := len(encryptedBlobMagic) + 1 + idLength
+	if len(blob) < headerLength {
+		return nil, ErrInvalidSealedData
+	}
+
+	if keys == nil {
+		return nil, fmt.Errorf("%w: no key provider for the encrypted data", ErrUnknownKey)
+	}
+
+	key, err := keys.Key(string(blob[len(encryptedBlobMagic)+1 : headerLength]))
+	if err != nil {
+		return nil, err
+	}
+
+	header := blob[:headerLength]
+	return open(deriveKey(key, EncryptionKeyLabel, len(key)), blob[headerLength:], append([]byte(purpose), header...))
+}
+
+// isEncryptedBlob returns if the data starts as a blob produced by encryptBlob
+func isEncryptedBlob(data []byte) bool {
+	return bytes.HasPrefix(data, encryptedBlobMagic)
+}
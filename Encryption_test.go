@@ -0,0 +1,162 @@
+package dataobject
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+var testOldKey = []byte("fedcba9876543210fedcba9876543210")
+
+func TestKeyRingRotation(t *testing.T) {
+	keys := NewKeyRing("v1", testOldKey)
+
+	blob, err := encryptBlob(keys, []byte("secret"), "test")
+	if err != nil {
+		t.Fatal("Error must be nil, but found:", err.Error())
+	}
+
+	if bytes.Contains(blob, []byte("secret")) {
+		t.Error("Expected: the data to be encrypted, but found:", string(blob))
+	}
+
+	keys.Rotate("v2", testSealKey)
+
+	if id, _, _ := keys.CurrentKey(); id != "v2" {
+		t.Error("Expected: v2, but found:", id)
+	}
+
+	plaintext, err := decryptBlob(keys, blob, "test")
+	if err != nil {
+		t.Fatal("Error must be nil, but found:", err.Error())
+	}
+
+	if string(plaintext) != "secret" {
+		t.Error("Expected: secret, but found:", string(plaintext))
+	}
+
+	if _, err := decryptBlob(keys, blob, "other"); !errors.Is(err, ErrInvalidSealedData) {
+		t.Error("Expected: ErrInvalidSealedData for another purpose, but found:", err)
+	}
+
+	if _, err := decryptBlob(NewKeyRing("v2", testSealKey), blob, "test"); !errors.Is(err, ErrUnknownKey) {
+		t.Error("Expected: ErrUnknownKey, but found:", err)
+	}
+}
+
+func TestJournalWithEncryption(t *testing.T) {
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "users.journal")
+	keys := NewKeyRing("v1", testOldKey)
+
+	journal, err := OpenJournal(path)
+	if err != nil {
+		t.Fatal("Error must be nil, but found:", err.Error())
+	}
+
+	// written before enabling the encryption
+	_ = journal.Append(JournalEntry{Op: JournalOpCreate, ID: "1", Changed: map[string]string{"id": "1", "email": "jon@test.com"}})
+
+	journal.WithEncryption(keys)
+
+	if err := journal.Replay(func(JournalEntry) error { return nil }); !errors.Is(err, ErrNotEncrypted) {
+		t.Error("Expected: ErrNotEncrypted for the plaintext entry, but found:", err)
+	}
+
+	// migrate by loading without the encryption, and compacting with it
+	journal.WithEncryption(nil)
+	repo := NewJournaledRepository(NewMemoryRepository(), journal)
+	if err := repo.Load(ctx); err != nil {
+		t.Fatal("Error must be nil, but found:", err.Error())
+	}
+
+	journal.WithEncryption(keys)
+	if err := repo.Compact(ctx); err != nil {
+		t.Fatal("Error must be nil, but found:", err.Error())
+	}
+
+	_ = repo.Create(ctx, NewDataObjectFromExistingData(map[string]string{"id": "2", "email": "jane@test.com"}))
+
+	keys.Rotate("v2", testSealKey)
+	_ = repo.Delete(ctx, "1")
+
+	content, _ := os.ReadFile(path)
+	if bytes.Contains(content, []byte("jane@test.com")) || bytes.Contains(content, []byte("jon@test.com")) {
+		t.Error("Expected: the entries to be encrypted, but found:", string(content))
+	}
+
+	replayed := NewJournaledRepository(NewMemoryRepository(), journal)
+	if err := replayed.Load(ctx); err != nil {
+		t.Fatal("Error must be nil, but found:", err.Error())
+	}
+
+	if count, _ := replayed.Count(ctx); count != 1 {
+		t.Error("Expected: 1, but found:", count)
+	}
+
+	if err := repo.Compact(ctx); err != nil {
+		t.Fatal("Error must be nil, but found:", err.Error())
+	}
+
+	content, _ = os.ReadFile(path)
+	if bytes.Contains(content, []byte("DOENC1")) || bytes.Contains(content, []byte("jane@test.com")) {
+		t.Error("Expected: the compacted entries to be base64 encoded and encrypted, but found:", string(content))
+	}
+
+	_ = journal.Close()
+
+	reopened, _ := OpenJournal(path)
+	defer reopened.Close()
+
+	if err := reopened.Replay(func(JournalEntry) error { return nil }); !errors.Is(err, ErrUnknownKey) {
+		t.Error("Expected: ErrUnknownKey without keys, but found:", err)
+	}
+
+	reopened.WithEncryption(NewKeyRing("v2", testSealKey))
+	if err := reopened.Replay(func(JournalEntry) error { return nil }); err != nil {
+		t.Error("Error must be nil after compacting with the current key, but found:", err.Error())
+	}
+}
+
+func TestSnapshotWithEncryption(t *testing.T) {
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "users.snapshot")
+	keys := NewKeyRing("v1", testSealKey)
+
+	repo := NewMemoryRepository().WithSnapshotEncryption(keys)
+	_ = repo.Create(ctx, NewDataObjectFromExistingData(map[string]string{"id": "1", "email": "jon@test.com"}))
+
+	if err := repo.SaveSnapshot(path); err != nil {
+		t.Fatal("Error must be nil, but found:", err.Error())
+	}
+
+	if err := NewMemoryRepository().LoadSnapshot(path); !errors.Is(err, ErrUnknownKey) {
+		t.Error("Expected: ErrUnknownKey when loading without keys, but found:", err)
+	}
+
+	restored := NewMemoryRepository().WithSnapshotEncryption(NewKeyRing("v2", testOldKey).Add("v1", testSealKey))
+	if err := restored.LoadSnapshot(path); err != nil {
+		t.Fatal("Error must be nil, but found:", err.Error())
+	}
+
+	found, err := restored.Find(ctx, "1")
+	if err != nil || found.Data()["email"] != "jon@test.com" {
+		t.Error("Expected: jon@test.com, but found:", found, err)
+	}
+
+	plaintext := filepath.Join(t.TempDir(), "forged.snapshot")
+	forged := NewMemoryRepository()
+	_ = forged.Create(ctx, NewDataObjectFromExistingData(map[string]string{"id": "2", "role": "admin"}))
+	_ = forged.SaveSnapshot(plaintext)
+
+	if err := restored.LoadSnapshot(plaintext); !errors.Is(err, ErrNotEncrypted) {
+		t.Error("Expected: ErrNotEncrypted, but found:", err)
+	}
+
+	if count, _ := restored.Count(ctx); count != 1 {
+		t.Error("Expected: the data to be unchanged, but found:", count)
+	}
+}
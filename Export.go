@@ -0,0 +1,149 @@
+package dataobject
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+)
+
+// ExportFormat is the format of an export
+type ExportFormat string
+
+const (
+	// ExportNDJSON exports one JSON object per line
+	ExportNDJSON ExportFormat = "ndjson"
+
+	// ExportCSV exports CSV with a header row
+	ExportCSV ExportFormat = "csv"
+)
+
+// ExportOptions configures Export
+type ExportOptions struct {
+	// Format is the format of the export, defaults to ExportNDJSON
+	Format ExportFormat
+
+	// Columns are the CSV columns, default to all the keys sorted
+	Columns []Column
+
+	// Anonymization anonymizes the values per key. If set, only the
+	// keys of the anonymization are exported, the other keys are
+	// dropped (see Anonymization.ApplyAllowlist and Keep)
+	Anonymization Anonymization
+
+	// KeepUnlisted exports the keys without an anonymizer as well,
+	// redacting only the values of the sensitive keys
+	// (see Anonymization.Apply)
+	KeepUnlisted bool
+}
+
+// Export writes all the objects of the repository to the writer as
+// NDJSON or CSV, reading them in batches, and anonymizing the values
+// if the options have an anonymization, so production data can be
+// shared with analysts. Anonymized exports contain only the keys of
+// the anonymization, unless KeepUnlisted is set. Returns the number
+// of written objects
+//
+// CSV exports without columns read all the objects before writing,
+// to find the keys
+//
+// Example:
+//
+//	n, err := Export(ctx, repo, w, ExportOptions{
+//		Format: ExportCSV,
+//		Anonymization: Anonymization{
+//			"id":         Keep(),
+//			"email":      MustHashWithSalt(salt),
+//			"birth_date": GeneralizeToMonth(),
+//			"notes":      Redact(),
+//		},
+//	})
+func Export(ctx context.Context, repo DataObjectRepositoryInterface, w io.Writer, opts ExportOptions) (int, error) {
+	switch opts.Format {
+	case "", ExportNDJSON:
+		encoder := json.NewEncoder(w)
+		return exportEach(ctx, repo, opts, func(data map[string]string) error {
+			return encoder.Encode(data)
+		})
+	case ExportCSV:
+		return exportCSV(ctx, repo, w, opts)
+	}
+
+	return 0, fmt.Errorf("dataobject: invalid export format: %s", opts.Format)
+}
+
+// exportCSV writes the objects as CSV
+func exportCSV(ctx context.Context, repo DataObjectRepositoryInterface, w io.Writer, opts ExportOptions) (int, error) {
+	objects := []DataObjectInterface{}
+	written, err := exportEach(ctx, repo, opts, func(data map[string]string) error {
+		objects = append(objects, NewDataObjectFromExistingData(data))
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	columns := opts.Columns
+	if len(columns) < 1 {
+		columns = keyColumns(objects)
+	}
+
+	return written, WriteCSV(w, objects, columns)
+}
+
+// exportEach calls fn with the anonymized data of each object, reading
+// the objects in batches. Returns the number of objects
+func exportEach(ctx context.Context, repo DataObjectRepositoryInterface, opts ExportOptions, fn func(data map[string]string) error) (int, error) {
+	count := 0
+
+	for offset := 0; ; offset += VerifyDefaultBatchSize {
+		if err := ctx.Err(); err != nil {
+			return count, err
+		}
+
+		objects, err := repo.List(ctx, offset, VerifyDefaultBatchSize)
+		if err != nil {
+			return count, err
+		}
+
+		for _, do := range objects {
+			data := do.Data()
+			if opts.Anonymization != nil && opts.KeepUnlisted {
+				data = opts.Anonymization.Apply(data)
+			} else if opts.Anonymization != nil {
+				data = opts.Anonymization.ApplyAllowlist(data)
+			}
+
+			if err := fn(data); err != nil {
+				return count, err
+			}
+			count++
+		}
+
+		if len(objects) < VerifyDefaultBatchSize {
+			return count, nil
+		}
+	}
+}
+
+// keyColumns returns a column per key of the objects, sorted
+func keyColumns(objects []DataObjectInterface) []Column {
+	keys := map[string]struct{}{}
+	for _, do := range objects {
+		for key := range do.Data() {
+			keys[key] = struct{}{}
+		}
+	}
+
+	columns := make([]Column, 0, len(keys))
+	for key := range keys {
+		columns = append(columns, Column{Key: key})
+	}
+
+	sort.Slice(columns, func(i, j int) bool {
+		return columns[i].Key < columns[j].Key
+	})
+
+	return columns
+}
@@ -0,0 +1,108 @@
+package dataobject
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"sort"
+	"time"
+)
+
+// UpdatedAtKey is the key holding the time an object has last been
+// updated at in DateTimeFormat (UTC), see MemoryRepository.WithChangeTracking
+const UpdatedAtKey = "updated_at"
+
+// Delta operations
+const (
+	DeltaOpUpsert = "upsert"
+	DeltaOpDelete = "delete"
+)
+
+// DeltaEntry is a single line of an incremental export
+type DeltaEntry struct {
+	// Op is DeltaOpUpsert for changed objects, or DeltaOpDelete for tombstones
+	Op string `json:"op"`
+
+	// ID is the ID of the object
+	ID string `json:"id"`
+
+	// At is the time of the change in DateTimeFormat (UTC)
+	At string `json:"at"`
+
+	// Data is the data of the changed object, empty for tombstones
+	Data map[string]string `json:"data,omitempty"`
+}
+
+// DeletionTracker is implemented by repositories, which record
+// the IDs of the deleted objects (see MemoryRepository.WithChangeTracking)
+type DeletionTracker interface {
+	DeletedSince(ctx context.Context, since time.Time) (map[string]time.Time, error)
+}
+
+// ExportChangedSince writes the objects updated since the time (per the
+// updated_at key) to the writer as NDJSON delta entries, followed by
+// tombstones for the objects soft deleted since the time, and for the
+// deleted objects if the repository is a DeletionTracker. The entries
+// are sorted by time, so the feed can be applied in order
+//
+// Returns the number of written entries
+func ExportChangedSince(ctx context.Context, repo DataObjectRepositoryInterface, since time.Time, w io.Writer) (int, error) {
+	since = since.UTC().Truncate(time.Second)
+
+	objects, err := listWithDeleted(ctx, repo)
+	if err != nil {
+		return 0, err
+	}
+
+	entries := []DeltaEntry{}
+
+	for _, do := range objects {
+		data := do.Data()
+
+		if deletedAt, err := parseDateTime(data[SoftDeletedAtKey]); err == nil && IsSoftDeleted(do) {
+			if !deletedAt.Before(since) {
+				entries = append(entries, DeltaEntry{Op: DeltaOpDelete, ID: do.ID(), At: deletedAt.UTC().Format(DateTimeFormat)})
+			}
+			continue
+		}
+
+		updatedAt, err := parseDateTime(data[UpdatedAtKey])
+		if err != nil || updatedAt.Before(since) {
+			continue
+		}
+
+		entries = append(entries, DeltaEntry{Op: DeltaOpUpsert, ID: do.ID(), At: updatedAt.UTC().Format(DateTimeFormat), Data: data})
+	}
+
+	if tracker, isTracker := repo.(DeletionTracker); isTracker {
+		deleted, err := tracker.DeletedSince(ctx, since)
+		if err != nil {
+			return 0, err
+		}
+		for id, deletedAt := range deleted {
+			entries = append(entries, DeltaEntry{Op: DeltaOpDelete, ID: id, At: deletedAt.UTC().Format(DateTimeFormat)})
+		}
+	}
+
+	sort.SliceStable(entries, func(i, j int) bool {
+		if entries[i].At != entries[j].At {
+			return entries[i].At < entries[j].At
+		}
+		return entries[i].ID < entries[j].ID
+	})
+
+	encoder := json.NewEncoder(w)
+	for i, entry := range entries {
+		if err := encoder.Encode(entry); err != nil {
+			return i, err
+		}
+	}
+
+	return len(entries), nil
+}
+
+// listWithDeleted lists all the objects including the soft deleted ones,
+// if the repository supports queries
+func listWithDeleted(ctx context.Context, repo DataObjectRepositoryInterface) ([]DataObjectInterface, error) {
+	return queryRepository(ctx, repo, NewQuery().WithDeleted())
+}
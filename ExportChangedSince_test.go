@@ -0,0 +1,57 @@
+package dataobject
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+func TestExportChangedSince(t *testing.T) {
+	ctx := context.Background()
+	repo := NewMemoryRepository().WithSoftDelete().WithChangeTracking()
+
+	// stored directly to keep the old updated_at
+	repo.collection.Add(NewDataObjectFromExistingData(map[string]string{"id": "old", UpdatedAtKey: "2000-01-01 00:00:00"}))
+
+	since := time.Now()
+
+	_ = repo.Create(ctx, NewDataObjectFromExistingData(map[string]string{"id": "1", "name": "Jon"}))
+	_ = repo.Create(ctx, NewDataObjectFromExistingData(map[string]string{"id": "2"}))
+	_ = repo.Create(ctx, NewDataObjectFromExistingData(map[string]string{"id": "3"}))
+	_ = repo.Delete(ctx, "2")
+	_ = repo.ForceDelete(ctx, "3")
+
+	buffer := &bytes.Buffer{}
+	written, err := ExportChangedSince(ctx, repo, since, buffer)
+
+	if err != nil {
+		t.Fatal("Error must be nil, but found:", err.Error())
+	}
+
+	if written != 3 {
+		t.Fatal("Expected: 3, but found:", written, buffer.String())
+	}
+
+	ops := map[string]string{}
+	scanner := bufio.NewScanner(buffer)
+	for scanner.Scan() {
+		entry := DeltaEntry{}
+		_ = json.Unmarshal(scanner.Bytes(), &entry)
+		ops[entry.ID] = entry.Op
+
+		if entry.ID == "1" && entry.Data["name"] != "Jon" {
+			t.Error("Expected: Jon, but found:", entry.Data)
+		}
+	}
+
+	expected := map[string]string{"1": DeltaOpUpsert, "2": DeltaOpDelete, "3": DeltaOpDelete}
+
+	for id, op := range expected {
+		if ops[id] != op {
+			t.Error("Expected:", op, "but found:", ops[id], "for", id)
+		}
+	}
+}
@@ -0,0 +1,101 @@
+package dataobject
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+)
+
+func newExportTestRepository() *MemoryRepository {
+	repo := NewMemoryRepository()
+	_ = repo.Create(context.Background(), NewDataObjectFromExistingData(map[string]string{
+		"id":         "1",
+		"email":      "jon@test.com",
+		"birth_date": "1990-12-24",
+		"password":   "secret",
+	}))
+	_ = repo.Create(context.Background(), NewDataObjectFromExistingData(map[string]string{
+		"id":    "2",
+		"email": "jane@test.com",
+	}))
+	return repo
+}
+
+func TestExportNDJSON(t *testing.T) {
+	buffer := bytes.Buffer{}
+
+	written, err := Export(context.Background(), newExportTestRepository(), &buffer, ExportOptions{
+		Anonymization: Anonymization{
+			"id":         Keep(),
+			"email":      MustHashWithSalt([]byte("0123456789abcdef")),
+			"birth_date": GeneralizeToMonth(),
+		},
+	})
+
+	if err != nil {
+		t.Fatal("Error must be nil, but found:", err.Error())
+	}
+
+	if written != 2 || strings.Count(buffer.String(), "\n") != 2 {
+		t.Error("Expected: 2 lines, but found:", buffer.String())
+	}
+
+	for _, leaked := range []string{"jon@test.com", "1990-12-24", "secret"} {
+		if strings.Contains(buffer.String(), leaked) {
+			t.Error("Expected:", leaked, "to be anonymized, but found:", buffer.String())
+		}
+	}
+
+	if !strings.Contains(buffer.String(), `"birth_date":"1990-12"`) || !strings.Contains(buffer.String(), `"id":"1"`) {
+		t.Error("Expected: the generalized birth date and the id, but found:", buffer.String())
+	}
+
+	if strings.Contains(buffer.String(), "password") {
+		t.Error("Expected: the unlisted keys to be dropped, but found:", buffer.String())
+	}
+}
+
+func TestExportCSV(t *testing.T) {
+	buffer := bytes.Buffer{}
+
+	_, err := Export(context.Background(), newExportTestRepository(), &buffer, ExportOptions{
+		Format:        ExportCSV,
+		Anonymization: Anonymization{"email": Redact()},
+		KeepUnlisted:  true,
+	})
+
+	if err != nil {
+		t.Fatal("Error must be nil, but found:", err.Error())
+	}
+
+	expected := "birth_date,email,id,password\n1990-12-24,[REDACTED],1,[REDACTED]\n,[REDACTED],2,\n"
+	if buffer.String() != expected {
+		t.Error("Expected:", expected, "but found:", buffer.String())
+	}
+
+	if _, err := Export(context.Background(), newExportTestRepository(), &buffer, ExportOptions{Format: "xml"}); err == nil {
+		t.Error("Error must NOT be nil for an invalid format")
+	}
+}
+
+func TestExportCSVNeutralizesFormulas(t *testing.T) {
+	repo := NewMemoryRepository()
+	_ = repo.Create(context.Background(), NewDataObjectFromExistingData(map[string]string{
+		"id":   "1",
+		"name": "=cmd|' /C calc'!A0",
+	}))
+
+	buffer := bytes.Buffer{}
+
+	_, err := Export(context.Background(), repo, &buffer, ExportOptions{Format: ExportCSV, KeepUnlisted: true})
+
+	if err != nil {
+		t.Fatal("Error must be nil, but found:", err.Error())
+	}
+
+	expected := "id,name\n1,'=cmd|' /C calc'!A0\n"
+	if buffer.String() != expected {
+		t.Error("Expected:", expected, "but found:", buffer.String())
+	}
+}
@@ -0,0 +1,175 @@
+package dataobject
+
+import (
+	"math"
+	"math/rand"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ValueGenerator generates a value for the n-th built object (starting
+// from 1), with the random source of the factory (see Factory.Seed)
+type ValueGenerator func(n int, random *rand.Rand) string
+
+// Factory builds data objects pre-filled with default and generated
+// values, to remove the repetitive setup of data objects in tests
+//
+// Example:
+//
+//	users := NewFactory(map[string]string{"status": "active"}).
+//		Generate("email", Sequence("user%d@test.com")).
+//		Generate("first_name", FakeFirstName())
+//
+//	user := users.Build(map[string]string{"status": "inactive"})
+//	manyUsers := users.BuildMany(10)
+type Factory struct {
+	// mu serializes the builds, as the random source is not safe
+	// for concurrent use
+	mu sync.Mutex
+
+	defaults   map[string]string
+	generators map[string]ValueGenerator
+	random     *rand.Rand
+	count      int
+}
+
+// NewFactory creates a new factory with the default values,
+// and a random source seeded with the current time
+func NewFactory(defaults map[string]string) *Factory {
+	return &Factory{
+		defaults:   copyData(defaults),
+		generators: map[string]ValueGenerator{},
+		random:     rand.New(rand.NewSource(time.Now().UnixNano())),
+	}
+}
+
+// Seed seeds the random source of the generators,
+// so the factory builds the same values on every run
+func (f *Factory) Seed(seed int64) *Factory {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.random = rand.New(rand.NewSource(seed))
+	return f
+}
+
+// Generate registers a generator for the value of the key.
+// Generated values take precedence over the default values
+func (f *Factory) Generate(key string, generator ValueGenerator) *Factory {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.generators[key] = generator
+	return f
+}
+
+// Build builds a new data object with a generated ID,
+// the default and generated values, and the overrides applied in order
+func (f *Factory) Build(overrides ...map[string]string) *DataObject {
+	do := NewDataObject()
+	do.SetData(f.defaults)
+
+	f.mu.Lock()
+	f.count++
+	for _, key := range sortedKeys(f.generators) {
+		do.Set(key, f.generators[key](f.count, f.random))
+	}
+	f.mu.Unlock()
+
+	for _, override := range overrides {
+		do.SetData(override)
+	}
+
+	return do
+}
+
+// BuildMany builds n new data objects, applying the overrides to each
+func (f *Factory) BuildMany(n int, overrides ...map[string]string) []*DataObject {
+	objects := make([]*DataObject, 0, n)
+	for i := 0; i < n; i++ {
+		objects = append(objects, f.Build(overrides...))
+	}
+	return objects
+}
+
+// Sequence returns a generator replacing %d in the format
+// with the sequence number (i.e. "user%d@test.com")
+func Sequence(format string) ValueGenerator {
+	return func(n int, random *rand.Rand) string {
+		return strings.ReplaceAll(format, "%d", strconv.Itoa(n))
+	}
+}
+
+// OneOf returns a generator picking a random value from the values
+func OneOf(values ...string) ValueGenerator {
+	return func(n int, random *rand.Rand) string {
+		if len(values) < 1 {
+			return ""
+		}
+		return values[random.Intn(len(values))]
+	}
+}
+
+// FakeFirstName returns a generator of random first names
+func FakeFirstName() ValueGenerator {
+	return OneOf(fakeFirstNames...)
+}
+
+// FakeLastName returns a generator of random last names
+func FakeLastName() ValueGenerator {
+	return OneOf(fakeLastNames...)
+}
+
+// FakeEmail returns a generator of random, yet unique per factory, emails
+func FakeEmail() ValueGenerator {
+	firstName := FakeFirstName()
+	lastName := FakeLastName()
+	domain := OneOf(fakeDomains...)
+
+	return func(n int, random *rand.Rand) string {
+		return strings.ToLower(firstName(n, random)+"."+lastName(n, random)) + strconv.Itoa(n) + "@" + domain(n, random)
+	}
+}
+
+// FakePhone returns a generator of random phone numbers
+func FakePhone() ValueGenerator {
+	return func(n int, random *rand.Rand) string {
+		return "+1555" + strconv.Itoa(1000000+random.Intn(9000000))
+	}
+}
+
+// FakeInt returns a generator of random integers in the range [min, max].
+// The bounds are swapped if max is less than min
+func FakeInt(min int, max int) ValueGenerator {
+	if max < min {
+		min, max = max, min
+	}
+
+	// the span wraps around as the bounds, so it
+	// is correct even if it overflows an int
+	span := uint64(max) - uint64(min)
+
+	return func(n int, random *rand.Rand) string {
+		offset := random.Uint64()
+		if span < math.MaxUint64 {
+			offset %= span + 1
+		}
+		return strconv.Itoa(min + int(offset))
+	}
+}
+
+var fakeFirstNames = []string{
+	"James", "Mary", "John", "Patricia", "Robert", "Jennifer", "Michael", "Linda",
+	"William", "Elizabeth", "David", "Barbara", "Richard", "Susan", "Joseph", "Jessica",
+}
+
+var fakeLastNames = []string{
+	"Smith", "Johnson", "Williams", "Brown", "Jones", "Garcia", "Miller", "Davis",
+	"Rodriguez", "Martinez", "Hernandez", "Lopez", "Gonzalez", "Wilson", "Anderson", "Thomas",
+}
+
+var fakeDomains = []string{
+	"example.com", "example.org", "example.net", "test.com",
+}
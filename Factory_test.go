@@ -0,0 +1,110 @@
+package dataobject
+
+import (
+	"math"
+	"math/rand"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+)
+
+func TestFactoryBuild(t *testing.T) {
+	users := NewFactory(map[string]string{"status": "active", "role": "user"}).
+		Generate("email", Sequence("user%d@test.com")).
+		Generate("first_name", FakeFirstName())
+
+	user := users.Build(map[string]string{"role": "admin"})
+
+	if user.ID() == "" {
+		t.Error("ID must NOT be empty, but found:", user.ID())
+	}
+
+	if user.Get("status") != "active" {
+		t.Error("Expected: active, but found:", user.Get("status"))
+	}
+
+	if user.Get("role") != "admin" {
+		t.Error("Expected: admin, but found:", user.Get("role"))
+	}
+
+	if user.Get("email") != "user1@test.com" {
+		t.Error("Expected: user1@test.com, but found:", user.Get("email"))
+	}
+
+	if user.Get("first_name") == "" {
+		t.Error("First name must NOT be empty, but found:", user.Get("first_name"))
+	}
+}
+
+func TestFactoryBuildMany(t *testing.T) {
+	users := NewFactory(nil).Generate("email", FakeEmail())
+
+	many := users.BuildMany(3)
+
+	if len(many) != 3 {
+		t.Fatal("Expected: 3, but found:", len(many))
+	}
+
+	if !strings.HasSuffix(strings.Split(many[2].Get("email"), "@")[0], "3") {
+		t.Error("Expected email to end with the sequence number, but found:", many[2].Get("email"))
+	}
+}
+
+func TestFactorySeed(t *testing.T) {
+	build := func() []*DataObject {
+		return NewFactory(nil).Seed(42).
+			Generate("email", FakeEmail()).
+			Generate("age", FakeInt(18, 90)).
+			BuildMany(5)
+	}
+
+	first, second := build(), build()
+
+	for i := range first {
+		if first[i].Get("email") != second[i].Get("email") || first[i].Get("age") != second[i].Get("age") {
+			t.Error("Expected: the same values, but found:", first[i].Data(), second[i].Data())
+		}
+	}
+}
+
+func TestFakeInt(t *testing.T) {
+	random := rand.New(rand.NewSource(1))
+
+	for i := 0; i < 100; i++ {
+		value, _ := strconv.Atoi(FakeInt(10, 5)(1, random))
+		if value < 5 || value > 10 {
+			t.Fatal("Expected: a value in [5, 10], but found:", value)
+		}
+	}
+
+	if value := FakeInt(7, 7)(1, random); value != "7" {
+		t.Error("Expected: 7, but found:", value)
+	}
+
+	if _, err := strconv.Atoi(FakeInt(math.MinInt, math.MaxInt)(1, random)); err != nil {
+		t.Error("Error must be nil, but found:", err)
+	}
+}
+
+func TestFactoryConcurrentBuilds(t *testing.T) {
+	users := NewFactory(nil).Generate("first_name", FakeFirstName())
+
+	wg := sync.WaitGroup{}
+	for i := 0; i < 10; i++ {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			users.Build()
+		}()
+		go func() {
+			defer wg.Done()
+			users.Generate("last_name", FakeLastName())
+		}()
+	}
+	wg.Wait()
+
+	if users.Build().Get("last_name") == "" {
+		t.Error("Last name must NOT be empty")
+	}
+}
@@ -0,0 +1,441 @@
+package dataobject
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net"
+	"sync"
+	"syscall"
+	"time"
+)
+
+// FailoverDefaultRetryInterval is the default interval between
+// the attempts to recover the primary repository
+const FailoverDefaultRetryInterval = 5 * time.Second
+
+// FailoverDefaultMaxQueued is the default maximum number of queued writes
+const FailoverDefaultMaxQueued = 10_000
+
+var _ DataObjectRepositoryInterface = (*FailoverRepository)(nil) // verify it extends the repository interface
+var _ ConditionalUpdater = (*FailoverRepository)(nil)            // verify it forwards conditional updates
+var _ Querier = (*FailoverRepository)(nil)                       // verify it forwards queries
+
+// FailoverRepository decorates a primary repository falling back to a
+// read-only replica or cache, when the primary is down
+//
+// The primary is considered down after an operation fails with an error
+// meaning its backend is unavailable (see IsUnavailableError and
+// SetUnavailable). Any other error is returned as is. While down, reads
+// are routed to the fallback, and writes are queued in memory to be
+// replayed in order on recovery. Queued writes are not visible to reads
+// until replayed. Conditional updates (see ConditionalUpdater) are not
+// queued, as they must be checked against the primary, and fail with
+// ErrUnavailable while it is down
+//
+// Recovery is attempted by the first operation after the retry interval
+// (see SetRetryInterval), or explicitly with Recover. Queued writes
+// failing on replay for another reason are reported (see SetOnDropped),
+// as they have already been acknowledged to their callers
+//
+// Example:
+//
+//	repo := NewFailoverRepository(sqlRepo, redisReplica)
+type FailoverRepository struct {
+	primary  DataObjectRepositoryInterface
+	fallback DataObjectRepositoryInterface
+
+	retryInterval time.Duration
+	maxQueued     int
+	isUnavailable func(err error) bool
+	onDropped     func(dropped FailoverDroppedWrite)
+
+	// replayMu serializes the recoveries
+	replayMu sync.Mutex
+
+	mu        sync.Mutex
+	down      bool
+	attempted time.Time
+	queue     []failoverWrite
+}
+
+// failoverWrite is a write queued while the primary is down
+type failoverWrite struct {
+	op string
+	id string
+	do DataObjectInterface
+}
+
+// FailoverDroppedWrite is a queued write dropped on recovery, as
+// the primary rejected it with an error other than an unavailability
+type FailoverDroppedWrite struct {
+	// Op is JournalOpCreate, JournalOpUpdate, JournalOpDelete,
+	// JournalOpForceDelete or JournalOpRestore
+	Op string
+
+	// ID is the ID of the object
+	ID string
+
+	// Object is the written object, nil for deletes
+	Object DataObjectInterface
+
+	// Err is the error of the primary
+	Err error
+}
+
+// NewFailoverRepository creates a new failover decorator of the primary
+// repository, reading from the fallback repository when it is down
+func NewFailoverRepository(primary DataObjectRepositoryInterface, fallback DataObjectRepositoryInterface) *FailoverRepository {
+	return &FailoverRepository{
+		primary:       primary,
+		fallback:      fallback,
+		retryInterval: FailoverDefaultRetryInterval,
+		maxQueued:     FailoverDefaultMaxQueued,
+		isUnavailable: IsUnavailableError,
+	}
+}
+
+// SetRetryInterval sets the minimum interval between
+// the attempts to recover the primary repository
+func (repo *FailoverRepository) SetRetryInterval(interval time.Duration) *FailoverRepository {
+	repo.retryInterval = interval
+	return repo
+}
+
+// SetMaxQueued sets the maximum number of queued writes, after which
+// writes fail with ErrUnavailable while the primary is down (0 for no limit)
+func (repo *FailoverRepository) SetMaxQueued(maxQueued int) *FailoverRepository {
+	repo.maxQueued = maxQueued
+	return repo
+}
+
+// SetUnavailable sets the function, which returns if an error of the
+// primary means its backend is unavailable, i.e. to recognize the errors
+// of a specific driver. Defaults to IsUnavailableError
+func (repo *FailoverRepository) SetUnavailable(isUnavailable func(err error) bool) *FailoverRepository {
+	if isUnavailable == nil {
+		isUnavailable = IsUnavailableError
+	}
+	repo.isUnavailable = isUnavailable
+	return repo
+}
+
+// SetOnDropped sets the function called with each queued write dropped
+// on recovery (i.e. ErrAlreadyExists), i.e. to store it in a dead letter
+// queue. Without it the dropped writes are logged with slog.Default
+func (repo *FailoverRepository) SetOnDropped(onDropped func(dropped FailoverDroppedWrite)) *FailoverRepository {
+	repo.onDropped = onDropped
+	return repo
+}
+
+// IsDown returns if the primary repository is considered down
+func (repo *FailoverRepository) IsDown() bool {
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+
+	return repo.down
+}
+
+// Queued returns the number of writes queued while the primary is down
+func (repo *FailoverRepository) Queued() int {
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+
+	return len(repo.queue)
+}
+
+// Recover pings the primary repository and replays the queued writes in
+// order. The primary is considered up again once all of them are replayed
+//
+// Queued writes, which fail with an error other than an unavailability
+// (i.e. ErrAlreadyExists), are dropped and reported (see SetOnDropped),
+// and their errors are returned joined
+func (repo *FailoverRepository) Recover(ctx context.Context) error {
+	repo.replayMu.Lock()
+	defer repo.replayMu.Unlock()
+
+	repo.mu.Lock()
+	repo.attempted = time.Now()
+	repo.mu.Unlock()
+
+	if err := PingRepository(ctx, repo.primary); err != nil {
+		repo.markDown()
+		return fmt.Errorf("%w: %w", ErrUnavailable, err)
+	}
+
+	errs := []error{}
+
+	for {
+		repo.mu.Lock()
+		if len(repo.queue) == 0 {
+			repo.down = false
+			repo.mu.Unlock()
+			return errors.Join(errs...)
+		}
+		write := repo.queue[0]
+		repo.mu.Unlock()
+
+		err := repo.replay(ctx, write)
+		if err != nil && repo.isUnavailable(err) {
+			repo.markDown()
+			errs = append(errs, fmt.Errorf("%w: %w", ErrUnavailable, err))
+			return errors.Join(errs...)
+		}
+		if err != nil {
+			errs = append(errs, err)
+			repo.dropped(write, err)
+		}
+
+		repo.mu.Lock()
+		repo.queue = repo.queue[1:]
+		repo.mu.Unlock()
+	}
+}
+
+// Create creates the object in the primary, or queues it if down
+func (repo *FailoverRepository) Create(ctx context.Context, do DataObjectInterface) error {
+	if do.ID() == "" {
+		return ErrMissingID
+	}
+
+	return repo.write(ctx, failoverWrite{op: JournalOpCreate, id: do.ID(), do: do}, func() error {
+		return repo.primary.Create(ctx, do)
+	})
+}
+
+// Find returns the object from the primary, or from the fallback if down
+func (repo *FailoverRepository) Find(ctx context.Context, id string) (DataObjectInterface, error) {
+	var do DataObjectInterface
+	err := repo.read(ctx, func(source DataObjectRepositoryInterface) (err error) {
+		do, err = source.Find(ctx, id)
+		return err
+	})
+	return do, err
+}
+
+// Update updates the object in the primary, or queues it if down
+func (repo *FailoverRepository) Update(ctx context.Context, do DataObjectInterface) error {
+	return repo.write(ctx, failoverWrite{op: JournalOpUpdate, id: do.ID(), do: do}, func() error {
+		return repo.primary.Update(ctx, do)
+	})
+}
+
+// Delete deletes the object in the primary, or queues it if down
+func (repo *FailoverRepository) Delete(ctx context.Context, id string) error {
+	return repo.write(ctx, failoverWrite{op: JournalOpDelete, id: id}, func() error {
+		return repo.primary.Delete(ctx, id)
+	})
+}
+
+// UpdateIf updates the object in the primary, if it matches the entity
+// tag, or returns an error wrapping ErrUnavailable if the primary is down
+func (repo *FailoverRepository) UpdateIf(ctx context.Context, do DataObjectInterface, expectedETag string) error {
+	if !repo.isAvailable(ctx) {
+		return fmt.Errorf("%w: conditional update of %s", ErrUnavailable, do.ID())
+	}
+
+	err := forwardUpdateIf(ctx, repo.primary, do, expectedETag)
+	if err != nil && repo.isUnavailable(err) {
+		repo.markDown()
+		return fmt.Errorf("%w: %w", ErrUnavailable, err)
+	}
+
+	return err
+}
+
+// ForceDelete deletes the object permanently in the primary, or queues it if down
+func (repo *FailoverRepository) ForceDelete(ctx context.Context, id string) error {
+	return repo.write(ctx, failoverWrite{op: JournalOpForceDelete, id: id}, func() error {
+		return forwardForceDelete(ctx, repo.primary, id)
+	})
+}
+
+// Restore restores the soft deleted object in the primary, or queues it if down
+func (repo *FailoverRepository) Restore(ctx context.Context, id string) error {
+	return repo.write(ctx, failoverWrite{op: JournalOpRestore, id: id}, func() error {
+		return forwardRestore(ctx, repo.primary, id)
+	})
+}
+
+// Query returns the objects matching the query from the primary,
+// or from the fallback if down
+func (repo *FailoverRepository) Query(ctx context.Context, query *Query) ([]DataObjectInterface, error) {
+	var objects []DataObjectInterface
+	err := repo.read(ctx, func(source DataObjectRepositoryInterface) (err error) {
+		objects, err = forwardQuery(ctx, source, query)
+		return err
+	})
+	return objects, err
+}
+
+// List returns the objects from the primary, or from the fallback if down
+func (repo *FailoverRepository) List(ctx context.Context, offset int, limit int) ([]DataObjectInterface, error) {
+	var objects []DataObjectInterface
+	err := repo.read(ctx, func(source DataObjectRepositoryInterface) (err error) {
+		objects, err = source.List(ctx, offset, limit)
+		return err
+	})
+	return objects, err
+}
+
+// Count returns the number of objects from the primary, or from the fallback if down
+func (repo *FailoverRepository) Count(ctx context.Context) (int, error) {
+	var count int
+	err := repo.read(ctx, func(source DataObjectRepositoryInterface) (err error) {
+		count, err = source.Count(ctx)
+		return err
+	})
+	return count, err
+}
+
+// read runs the read on the primary, or on the fallback
+// if the primary is down or fails with an unavailability
+func (repo *FailoverRepository) read(ctx context.Context, fn func(source DataObjectRepositoryInterface) error) error {
+	if repo.isAvailable(ctx) {
+		err := fn(repo.primary)
+		if err == nil || !repo.isUnavailable(err) {
+			return err
+		}
+		repo.markDown()
+	}
+
+	return fn(repo.fallback)
+}
+
+// write runs the write on the primary, or queues it
+// if the primary is down or fails with an unavailability
+func (repo *FailoverRepository) write(ctx context.Context, write failoverWrite, fn func() error) error {
+	if repo.isAvailable(ctx) {
+		err := fn()
+		if err == nil || !repo.isUnavailable(err) {
+			return err
+		}
+		repo.markDown()
+	}
+
+	return repo.enqueue(write)
+}
+
+// isAvailable returns if the primary is up, attempting
+// to recover it first if down and the retry interval elapsed
+func (repo *FailoverRepository) isAvailable(ctx context.Context) bool {
+	repo.mu.Lock()
+	down := repo.down
+	due := time.Since(repo.attempted) >= repo.retryInterval
+	repo.mu.Unlock()
+
+	if !down {
+		return true
+	}
+
+	if due {
+		_ = repo.Recover(ctx)
+	}
+
+	return !repo.IsDown()
+}
+
+// markDown marks the primary as down
+func (repo *FailoverRepository) markDown() {
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+
+	if !repo.down {
+		repo.down = true
+		repo.attempted = time.Now()
+	}
+}
+
+// enqueue queues the write with a copy of the object,
+// or returns ErrUnavailable if the queue is full
+func (repo *FailoverRepository) enqueue(write failoverWrite) error {
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+
+	if repo.maxQueued > 0 && len(repo.queue) >= repo.maxQueued {
+		return fmt.Errorf("%w: %d writes queued", ErrUnavailable, len(repo.queue))
+	}
+
+	if write.do != nil {
+		write.do = cloneDirty(write.do)
+	}
+
+	repo.queue = append(repo.queue, write)
+
+	return nil
+}
+
+// dropped reports the queued write dropped with the error
+func (repo *FailoverRepository) dropped(write failoverWrite, err error) {
+	dropped := FailoverDroppedWrite{Op: write.op, ID: write.id, Object: write.do, Err: err}
+
+	if repo.onDropped != nil {
+		repo.onDropped(dropped)
+		return
+	}
+
+	slog.Default().Error("dataobject: dropped queued write", slog.String("op", dropped.Op), slog.String("id", dropped.ID), slog.String("error", err.Error()))
+}
+
+// replay runs the queued write on the primary
+func (repo *FailoverRepository) replay(ctx context.Context, write failoverWrite) error {
+	switch write.op {
+	case JournalOpCreate:
+		return repo.primary.Create(ctx, write.do)
+	case JournalOpUpdate:
+		return repo.primary.Update(ctx, write.do)
+	case JournalOpForceDelete:
+		return forwardForceDelete(ctx, repo.primary, write.id)
+	case JournalOpRestore:
+		return forwardRestore(ctx, repo.primary, write.id)
+	default:
+		return repo.primary.Delete(ctx, write.id)
+	}
+}
+
+// cloneDirty returns a copy of the object keeping
+// its changed keys marked as dirty
+func cloneDirty(do DataObjectInterface) *DataObject {
+	clone := cloneDataObject(do)
+	for key, value := range do.DataChanged() {
+		clone.Set(key, value)
+	}
+	return clone
+}
+
+// IsUnavailableError returns if the error means the backend of a
+// repository is unavailable: ErrUnavailable, network errors, refused,
+// reset or broken connections, and closed or bad database connections.
+// Errors of the operation itself (i.e. ErrNotFound), and of the context
+// of the caller, are not
+func IsUnavailableError(err error) bool {
+	if err == nil || errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return false
+	}
+
+	unavailabilities := []error{
+		ErrUnavailable,
+		driver.ErrBadConn,
+		sql.ErrConnDone,
+		net.ErrClosed,
+		syscall.ECONNREFUSED,
+		syscall.ECONNRESET,
+		syscall.ECONNABORTED,
+		syscall.EPIPE,
+		syscall.ETIMEDOUT,
+		syscall.EHOSTUNREACH,
+		syscall.ENETUNREACH,
+	}
+
+	for _, unavailability := range unavailabilities {
+		if errors.Is(err, unavailability) {
+			return true
+		}
+	}
+
+	var netError net.Error
+	return errors.As(err, &netError)
+}
@@ -0,0 +1,238 @@
+package dataobject
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"sync/atomic"
+	"syscall"
+	"testing"
+)
+
+// flakyRepository is a repository, which backend can be taken down
+type flakyRepository struct {
+	*MemoryRepository
+	down atomic.Bool
+}
+
+var errConnectionRefused = &net.OpError{Op: "dial", Net: "tcp", Err: syscall.ECONNREFUSED}
+
+func (repo *flakyRepository) Create(ctx context.Context, do DataObjectInterface) error {
+	if repo.down.Load() {
+		return errConnectionRefused
+	}
+	return repo.MemoryRepository.Create(ctx, do)
+}
+
+func (repo *flakyRepository) Find(ctx context.Context, id string) (DataObjectInterface, error) {
+	if repo.down.Load() {
+		return nil, errConnectionRefused
+	}
+	return repo.MemoryRepository.Find(ctx, id)
+}
+
+func (repo *flakyRepository) Update(ctx context.Context, do DataObjectInterface) error {
+	if repo.down.Load() {
+		return errConnectionRefused
+	}
+	return repo.MemoryRepository.Update(ctx, do)
+}
+
+func (repo *flakyRepository) Delete(ctx context.Context, id string) error {
+	if repo.down.Load() {
+		return errConnectionRefused
+	}
+	return repo.MemoryRepository.Delete(ctx, id)
+}
+
+func (repo *flakyRepository) Ping(ctx context.Context) error {
+	if repo.down.Load() {
+		return errConnectionRefused
+	}
+	return nil
+}
+
+func TestFailoverRepository(t *testing.T) {
+	ctx := context.Background()
+	primary := &flakyRepository{MemoryRepository: NewMemoryRepository()}
+	replica := NewMemoryRepository()
+	repo := NewFailoverRepository(primary, replica).SetRetryInterval(0)
+
+	user := NewDataObjectFromExistingData(map[string]string{"id": "1", "name": "Jon"})
+	_ = replica.Create(ctx, user)
+	if err := repo.Create(ctx, user); err != nil {
+		t.Fatal("Error must be nil, but found:", err.Error())
+	}
+
+	// not found is an outcome, not a failure
+	if _, err := repo.Find(ctx, "missing"); !IsNotFound(err) || repo.IsDown() {
+		t.Fatal("Expected: not found with the primary up, but found:", err, repo.IsDown())
+	}
+
+	primary.down.Store(true)
+	repo.SetRetryInterval(FailoverDefaultRetryInterval)
+
+	found, err := repo.Find(ctx, "1")
+	if err != nil {
+		t.Fatal("Error must be nil, but found:", err.Error())
+	}
+
+	if !repo.IsDown() || found.Data()["name"] != "Jon" {
+		t.Error("Expected: Jon from the replica, but found:", found.Data())
+	}
+
+	user.Set("name", "Jane")
+	if err := repo.Update(ctx, user); err != nil {
+		t.Fatal("Error must be nil, but found:", err.Error())
+	}
+	user.Set("name", "Joe")
+	_ = repo.Create(ctx, NewDataObjectFromExistingData(map[string]string{"id": "2"}))
+
+	if repo.Queued() != 2 {
+		t.Error("Expected: 2, but found:", repo.Queued())
+	}
+
+	if err := repo.Recover(ctx); !errors.Is(err, ErrUnavailable) {
+		t.Error("Expected: ErrUnavailable, but found:", err)
+	}
+
+	primary.down.Store(false)
+
+	if err := repo.Recover(ctx); err != nil {
+		t.Fatal("Error must be nil, but found:", err.Error())
+	}
+
+	if repo.IsDown() || repo.Queued() != 0 {
+		t.Error("Expected: up with nothing queued, but found:", repo.IsDown(), repo.Queued())
+	}
+
+	found, _ = primary.MemoryRepository.Find(ctx, "1")
+	if found.Data()["name"] != "Jane" {
+		t.Error("Expected: the queued update Jane, but found:", found.Data()["name"])
+	}
+
+	if count, _ := primary.MemoryRepository.Count(ctx); count != 2 {
+		t.Error("Expected: 2, but found:", count)
+	}
+}
+
+func TestFailoverRepositoryRecoversOnRetry(t *testing.T) {
+	ctx := context.Background()
+	primary := &flakyRepository{MemoryRepository: NewMemoryRepository()}
+	repo := NewFailoverRepository(primary, NewMemoryRepository()).SetRetryInterval(0)
+
+	primary.down.Store(true)
+	_ = repo.Create(ctx, NewDataObjectFromExistingData(map[string]string{"id": "1"}))
+	primary.down.Store(false)
+
+	if _, err := repo.Find(ctx, "1"); err != nil {
+		t.Fatal("Error must be nil, but found:", err.Error())
+	}
+
+	if repo.IsDown() {
+		t.Error("Expected: the primary to be recovered")
+	}
+}
+
+func TestFailoverRepositoryQueueFull(t *testing.T) {
+	ctx := context.Background()
+	primary := &flakyRepository{MemoryRepository: NewMemoryRepository()}
+	repo := NewFailoverRepository(primary, NewMemoryRepository()).SetMaxQueued(1)
+
+	primary.down.Store(true)
+
+	if err := repo.Delete(ctx, "1"); err != nil {
+		t.Fatal("Error must be nil, but found:", err.Error())
+	}
+
+	if err := repo.Delete(ctx, "2"); !errors.Is(err, ErrUnavailable) {
+		t.Error("Expected: ErrUnavailable, but found:", err)
+	}
+}
+
+func TestFailoverRepositoryReturnsOtherErrors(t *testing.T) {
+	ctx := context.Background()
+	primary := &flakyRepository{MemoryRepository: NewMemoryRepository()}
+	repo := NewFailoverRepository(primary, NewMemoryRepository())
+
+	invalid := NewDataObjectFromExistingData(map[string]string{"id": "1"})
+	invalid.Set("name", "Jon")
+
+	// not stored, so the update is an outcome of the operation
+	if err := repo.Update(ctx, invalid); !errors.Is(err, ErrNotFound) {
+		t.Error("Expected: ErrNotFound, but found:", err)
+	}
+
+	for _, err := range []error{ErrTooLarge, ErrCorrupted, ErrUnknownKey, context.DeadlineExceeded, errors.New("syntax error")} {
+		if IsUnavailableError(err) {
+			t.Error("Expected: not an unavailability, but found:", err)
+		}
+	}
+
+	for _, err := range []error{errConnectionRefused, fmt.Errorf("query: %w", ErrUnavailable), syscall.ECONNRESET} {
+		if !IsUnavailableError(err) {
+			t.Error("Expected: an unavailability, but found:", err)
+		}
+	}
+
+	if repo.IsDown() || repo.Queued() != 0 {
+		t.Error("Expected: the primary to be up, but found:", repo.IsDown(), repo.Queued())
+	}
+}
+
+func TestFailoverRepositorySetUnavailable(t *testing.T) {
+	ctx := context.Background()
+	errMaintenance := errors.New("maintenance")
+
+	primary := &failingRepository{MemoryRepository: NewMemoryRepository(), err: errMaintenance}
+	repo := NewFailoverRepository(primary, NewMemoryRepository()).SetUnavailable(func(err error) bool {
+		return errors.Is(err, errMaintenance)
+	})
+
+	if err := repo.Delete(ctx, "1"); err != nil {
+		t.Error("Error must be nil as the delete is queued, but found:", err)
+	}
+
+	if !repo.IsDown() || repo.Queued() != 1 {
+		t.Error("Expected: the primary to be down with a queued write, but found:", repo.IsDown(), repo.Queued())
+	}
+}
+
+// failingRepository fails the deletes with the error
+type failingRepository struct {
+	*MemoryRepository
+	err error
+}
+
+func (repo *failingRepository) Delete(ctx context.Context, id string) error {
+	return repo.err
+}
+
+func TestFailoverRepositoryReportsDroppedWrites(t *testing.T) {
+	ctx := context.Background()
+	primary := &flakyRepository{MemoryRepository: NewMemoryRepository()}
+	_ = primary.Create(ctx, NewDataObjectFromExistingData(map[string]string{"id": "1"}))
+
+	dropped := []FailoverDroppedWrite{}
+	repo := NewFailoverRepository(primary, NewMemoryRepository()).
+		SetRetryInterval(0).
+		SetOnDropped(func(write FailoverDroppedWrite) { dropped = append(dropped, write) })
+
+	primary.down.Store(true)
+
+	if err := repo.Create(ctx, NewDataObjectFromExistingData(map[string]string{"id": "1"})); err != nil {
+		t.Fatal("Error must be nil, but found:", err.Error())
+	}
+
+	primary.down.Store(false)
+
+	// recovered by the next operation, which discards the replay errors
+	if _, err := repo.Find(ctx, "1"); err != nil {
+		t.Fatal("Error must be nil, but found:", err.Error())
+	}
+
+	if len(dropped) != 1 || dropped[0].ID != "1" || dropped[0].Op != JournalOpCreate || !errors.Is(dropped[0].Err, ErrAlreadyExists) {
+		t.Error("Expected: the dropped create of 1, but found:", dropped)
+	}
+}
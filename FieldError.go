@@ -0,0 +1,15 @@
+package dataobject
+
+// FieldError is a validation error of a single field
+type FieldError struct {
+	// Key is the key of the invalid field
+	Key string `json:"key"`
+
+	// Message describes the problem (i.e. "is required")
+	Message string `json:"message"`
+}
+
+// Error returns the error as "<key> <message>"
+func (e FieldError) Error() string {
+	return e.Key + " " + e.Message
+}
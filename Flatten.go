@@ -0,0 +1,199 @@
+package dataobject
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Flatten converts a nested map (i.e. a decoded JSON object) to a flat
+// map with dot keys for objects and bracket keys for arrays, i.e.
+//
+//	{"user": {"name": "Jon", "tags": ["a", "b"]}}
+//
+// becomes
+//
+//	{"user.name": "Jon", "user.tags[0]": "a", "user.tags[1]": "b"}
+//
+// The values are converted to strings. Empty objects and arrays are omitted
+func Flatten(data map[string]any) map[string]string {
+	result := map[string]string{}
+	for key, value := range data {
+		flattenValue(result, key, value)
+	}
+	return result
+}
+
+// flattenValue adds the value to the result under the key
+func flattenValue(result map[string]string, key string, value any) {
+	switch v := value.(type) {
+	case map[string]any:
+		for childKey, childValue := range v {
+			flattenValue(result, key+"."+childKey, childValue)
+		}
+	case []any:
+		for i, childValue := range v {
+			flattenValue(result, key+"["+strconv.Itoa(i)+"]", childValue)
+		}
+	default:
+		result[key] = toString(v)
+	}
+}
+
+// Unflatten converts a flat map with dot and bracket keys (see Flatten)
+// back to a nested map of map[string]any, []any and string values
+//
+// Where a key is both a value and a parent of other keys, the nested
+// keys take precedence. Keys, which are not valid paths, are kept as they are
+//
+// Arrays may be sparse, the missing elements are nil. As the keys often
+// come from untrusted input, the total length of the arrays is limited
+// to the number of keys, which any flattened data is within. Returns an
+// error wrapping ErrInvalidPath if an index is beyond the limit
+func Unflatten(data map[string]string) (map[string]any, error) {
+	keys := make([]string, 0, len(data))
+	for key := range data {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	var root any = map[string]any{}
+
+	for _, key := range keys {
+		path, ok := parsePath(key)
+		if !ok {
+			path = []pathSegment{{key: key}}
+		}
+		root = assignPath(root, path, data[key])
+	}
+
+	budget := len(data)
+	result, err := finalizePath(root, &budget)
+	if err != nil {
+		return nil, err
+	}
+
+	return result.(map[string]any), nil
+}
+
+// pathSegment is a single segment of a flat key path,
+// either an object key or an array index
+type pathSegment struct {
+	key     string
+	index   int
+	isIndex bool
+}
+
+// parsePath parses a key like "items[0].sku" into its segments,
+// returns false if the key is not a valid path
+func parsePath(key string) ([]pathSegment, bool) {
+	path := []pathSegment{}
+
+	for _, part := range strings.Split(key, ".") {
+		name, rest, hasIndex := strings.Cut(part, "[")
+		if name == "" {
+			return nil, false
+		}
+
+		path = append(path, pathSegment{key: name})
+
+		for hasIndex {
+			var indexString string
+			var found bool
+			indexString, rest, found = strings.Cut(rest, "]")
+			if !found {
+				return nil, false
+			}
+
+			index, err := strconv.Atoi(indexString)
+			if err != nil || index < 0 {
+				return nil, false
+			}
+
+			path = append(path, pathSegment{index: index, isIndex: true})
+
+			if rest == "" {
+				break
+			}
+
+			if rest[0] != '[' {
+				return nil, false
+			}
+			rest = rest[1:]
+		}
+	}
+
+	return path, true
+}
+
+// assignPath sets the value at the path of the node, returns the node.
+// Arrays are built as map[int]any and converted by finalizePath
+func assignPath(node any, path []pathSegment, value string) any {
+	if len(path) < 1 {
+		switch node.(type) {
+		case map[string]any, map[int]any:
+			return node // nested keys take precedence
+		}
+		return value
+	}
+
+	segment := path[0]
+
+	if segment.isIndex {
+		array, isArray := node.(map[int]any)
+		if !isArray {
+			array = map[int]any{}
+		}
+		array[segment.index] = assignPath(array[segment.index], path[1:], value)
+		return array
+	}
+
+	object, isObject := node.(map[string]any)
+	if !isObject {
+		object = map[string]any{}
+	}
+	object[segment.key] = assignPath(object[segment.key], path[1:], value)
+	return object
+}
+
+// finalizePath converts the arrays built by assignPath to []any,
+// deducting their lengths from the budget, so an index out of range
+// of the input cannot cause a huge allocation
+func finalizePath(node any, budget *int) (any, error) {
+	switch v := node.(type) {
+	case map[string]any:
+		for key, child := range v {
+			finalized, err := finalizePath(child, budget)
+			if err != nil {
+				return nil, err
+			}
+			v[key] = finalized
+		}
+		return v, nil
+	case map[int]any:
+		length := 0
+		for index := range v {
+			if index >= *budget {
+				return nil, fmt.Errorf("%w: array index %d out of range", ErrInvalidPath, index)
+			}
+			length = max(length, index+1)
+		}
+		if length > *budget {
+			return nil, fmt.Errorf("%w: array index %d out of range", ErrInvalidPath, length-1)
+		}
+		*budget -= length
+
+		array := make([]any, length)
+		for index, child := range v {
+			finalized, err := finalizePath(child, budget)
+			if err != nil {
+				return nil, err
+			}
+			array[index] = finalized
+		}
+		return array, nil
+	default:
+		return v, nil
+	}
+}
@@ -0,0 +1,112 @@
+package dataobject
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestFlattenUnflatten(t *testing.T) {
+	nested := map[string]any{
+		"name": "Jon",
+		"address": map[string]any{
+			"city": "London",
+			"geo":  map[string]any{"lat": "51.5"},
+		},
+		"items": []any{
+			map[string]any{"sku": "A"},
+			map[string]any{"sku": "B", "tags": []any{"x", "y"}},
+		},
+	}
+
+	flat := Flatten(nested)
+
+	expected := map[string]string{
+		"name":             "Jon",
+		"address.city":     "London",
+		"address.geo.lat":  "51.5",
+		"items[0].sku":     "A",
+		"items[1].sku":     "B",
+		"items[1].tags[0]": "x",
+		"items[1].tags[1]": "y",
+	}
+
+	if !reflect.DeepEqual(flat, expected) {
+		t.Error("Expected:", expected, "but found:", flat)
+	}
+
+	unflattened, err := Unflatten(flat)
+	if err != nil {
+		t.Fatal("Error must be nil, but found:", err.Error())
+	}
+
+	if !reflect.DeepEqual(unflattened, nested) {
+		t.Error("Expected:", nested, "but found:", unflattened)
+	}
+}
+
+func TestUnflattenConflictsAndInvalidKeys(t *testing.T) {
+	result, err := Unflatten(map[string]string{
+		"a":       "scalar",
+		"a.b":     "nested",
+		"x[":      "invalid",
+		"list[2]": "c",
+	})
+
+	if err != nil {
+		t.Fatal("Error must be nil, but found:", err.Error())
+	}
+
+	expected := map[string]any{
+		"a":    map[string]any{"b": "nested"},
+		"x[":   "invalid",
+		"list": []any{nil, nil, "c"},
+	}
+
+	if !reflect.DeepEqual(result, expected) {
+		t.Error("Expected:", expected, "but found:", result)
+	}
+}
+
+func TestUnflattenIndexOutOfRange(t *testing.T) {
+	for _, data := range []map[string]string{
+		{"a[99999999999]": "1"},
+		{"a[9223372036854775807]": "1"},
+		{"a[1000000]": "1", "b": "2"},
+		{"a[0][1]": "1", "b[1][0]": "2"},
+	} {
+		if _, err := Unflatten(data); !errors.Is(err, ErrInvalidPath) {
+			t.Error("Expected: ErrInvalidPath, but found:", err, "for", data)
+		}
+	}
+}
+
+func TestNewDataObjectFromNestedJSON(t *testing.T) {
+	jsonString := `{"id":"1","total":30,"customer":{"name":"Jon"},"items":[{"sku":"A"}]}`
+
+	order, err := NewDataObjectFromNestedJSON(jsonString)
+
+	if err != nil {
+		t.Fatal("Error must be nil, but found:", err.Error())
+	}
+
+	if order.Get("customer.name") != "Jon" || order.Get("items[0].sku") != "A" || order.Get("total") != "30" {
+		t.Error("Expected flattened keys, but found:", order.Data())
+	}
+
+	nested, err := order.ToNestedJSON()
+
+	if err != nil {
+		t.Fatal("Error must be nil, but found:", err.Error())
+	}
+
+	expected := `{"customer":{"name":"Jon"},"id":"1","items":[{"sku":"A"}],"total":"30"}`
+
+	if nested != expected {
+		t.Error("Expected:", expected, "but found:", nested)
+	}
+
+	if _, err := NewDataObjectFromNestedJSON(`[1]`); err == nil {
+		t.Error("Error must NOT be nil, but found:", err)
+	}
+}
@@ -0,0 +1,138 @@
+package dataobject
+
+import (
+	"html"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// dateTimeLocalFormat is the value format of datetime-local inputs
+const dateTimeLocalFormat = "2006-01-02T15:04"
+
+// RenderFormFields renders the HTML inputs for the schema fields,
+// pre-filled with the values of the object, and with the errors (if any)
+// displayed below the invalid fields
+//
+// The <form> element itself is left to the caller,
+// so that the action, method and CSRF tokens can be set as needed
+//
+// Each field is rendered as:
+//
+//	<div class="form-group">
+//		<label for="key">Label</label>
+//		<input id="key" name="key" ...>
+//		<div class="form-error">message</div>
+//	</div>
+func RenderFormFields(schema *Schema, do DataObjectInterface, errs []FieldError) string {
+	data := do.Data()
+	var b strings.Builder
+
+	for _, field := range schema.Fields() {
+		key := html.EscapeString(field.Key)
+		label := field.Label
+		if label == "" {
+			label = field.Key
+		}
+
+		b.WriteString(`<div class="form-group">`)
+		b.WriteString(`<label for="` + key + `">` + html.EscapeString(label) + `</label>`)
+		b.WriteString(renderFormInput(field, data[field.Key]))
+
+		for _, err := range errs {
+			if err.Key == field.Key {
+				b.WriteString(`<div class="form-error">` + html.EscapeString(err.Message) + `</div>`)
+			}
+		}
+
+		b.WriteString(`</div>`)
+	}
+
+	return b.String()
+}
+
+// renderFormInput renders the input element for the field
+func renderFormInput(field SchemaField, value string) string {
+	attributes := ` id="` + html.EscapeString(field.Key) + `" name="` + html.EscapeString(field.Key) + `"`
+	if field.Required && field.Type != FieldTypeBool {
+		attributes += ` required`
+	}
+
+	if len(field.Options) > 0 {
+		var b strings.Builder
+		b.WriteString(`<select` + attributes + `>`)
+		if !field.Required {
+			b.WriteString(`<option value=""></option>`)
+		}
+		for _, option := range field.Options {
+			selected := ""
+			if option == value {
+				selected = ` selected`
+			}
+			b.WriteString(`<option value="` + html.EscapeString(option) + `"` + selected + `>` + html.EscapeString(option) + `</option>`)
+		}
+		b.WriteString(`</select>`)
+		return b.String()
+	}
+
+	switch field.Type {
+	case FieldTypeBool:
+		checked := ""
+		if value == "true" || value == "1" {
+			checked = ` checked`
+		}
+		return `<input type="checkbox"` + attributes + ` value="true"` + checked + `>`
+	case FieldTypeInt:
+		return `<input type="number" step="1"` + attributes + ` value="` + html.EscapeString(value) + `">`
+	case FieldTypeFloat:
+		return `<input type="number" step="any"` + attributes + ` value="` + html.EscapeString(value) + `">`
+	case FieldTypeDateTime:
+		if t, err := parseDateTime(value); err == nil {
+			value = t.Format(dateTimeLocalFormat)
+		}
+		return `<input type="datetime-local"` + attributes + ` value="` + html.EscapeString(value) + `">`
+	default:
+		return `<input type="text"` + attributes + ` value="` + html.EscapeString(value) + `">`
+	}
+}
+
+// BindForm applies the values submitted with a form rendered by
+// RenderFormFields to the object. Only the schema keys are accepted
+//
+// The values are validated against the schema first, and if there are
+// any errors the object is left unchanged and the errors are returned
+func BindForm(r *http.Request, schema *Schema, do *DataObject) ([]FieldError, error) {
+	values, err := requestValues(r)
+	if err != nil {
+		return nil, err
+	}
+
+	values = onlyKeys(values, schema.Keys())
+
+	for _, field := range schema.Fields() {
+		switch field.Type {
+		case FieldTypeBool:
+			// unchecked checkboxes are not submitted
+			if values[field.Key] == "" {
+				values[field.Key] = "false"
+			}
+		case FieldTypeDateTime:
+			if t, err := time.Parse(dateTimeLocalFormat, values[field.Key]); err == nil {
+				values[field.Key] = t.Format(DateTimeFormat)
+			}
+		}
+	}
+
+	merged := copyData(do.Data())
+	for key, value := range values {
+		merged[key] = value
+	}
+
+	if errs := schema.Validate(merged); len(errs) > 0 {
+		return errs, nil
+	}
+
+	do.SetData(values)
+
+	return nil, nil
+}
@@ -0,0 +1,90 @@
+package dataobject
+
+import (
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+)
+
+var testFormSchema = NewSchema(
+	SchemaField{Key: "name", Label: "Name", Required: true},
+	SchemaField{Key: "status", Options: []string{"active", "inactive"}},
+	SchemaField{Key: "newsletter", Type: FieldTypeBool},
+	SchemaField{Key: "born_at", Type: FieldTypeDateTime},
+)
+
+func TestRenderFormFields(t *testing.T) {
+	user := NewDataObjectFromExistingData(map[string]string{
+		"id":         "1",
+		"name":       `Jon "The Man"`,
+		"status":     "inactive",
+		"newsletter": "true",
+		"born_at":    "2000-01-02 03:04:05",
+	})
+
+	form := RenderFormFields(testFormSchema, user, []FieldError{{Key: "name", Message: "is too long"}})
+
+	expected := []string{
+		`<label for="name">Name</label>`,
+		`<input type="text" id="name" name="name" required value="Jon &#34;The Man&#34;">`,
+		`<option value="inactive" selected>inactive</option>`,
+		`<input type="checkbox" id="newsletter" name="newsletter" value="true" checked>`,
+		`value="2000-01-02T03:04"`,
+		`<div class="form-error">is too long</div>`,
+	}
+
+	for _, e := range expected {
+		if !strings.Contains(form, e) {
+			t.Error("Expected to contain:", e, "but found:", form)
+		}
+	}
+}
+
+func TestBindForm(t *testing.T) {
+	user := NewDataObjectFromExistingData(map[string]string{"id": "1", "name": "Jon", "newsletter": "true"})
+
+	form := url.Values{}
+	form.Set("name", "Jane")
+	form.Set("born_at", "2000-01-02T03:04")
+	form.Set("id", "2")
+
+	r := httptest.NewRequest("POST", "/", strings.NewReader(form.Encode()))
+	r.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	errs, err := BindForm(r, testFormSchema, user)
+
+	if err != nil || len(errs) > 0 {
+		t.Fatal("Errors must be empty, but found:", err, errs)
+	}
+
+	if user.Get("name") != "Jane" || user.ID() != "1" {
+		t.Error("Expected: Jane 1, but found:", user.Get("name"), user.ID())
+	}
+
+	if user.Get("newsletter") != "false" {
+		t.Error("Expected: false, but found:", user.Get("newsletter"))
+	}
+
+	if user.Get("born_at") != "2000-01-02 03:04:00" {
+		t.Error("Expected: 2000-01-02 03:04:00, but found:", user.Get("born_at"))
+	}
+
+	// invalid values leave the object unchanged
+	form = url.Values{}
+	form.Set("name", "")
+	form.Set("status", "banned")
+
+	r = httptest.NewRequest("POST", "/", strings.NewReader(form.Encode()))
+	r.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	errs, _ = BindForm(r, testFormSchema, user)
+
+	if len(errs) != 2 {
+		t.Error("Expected: 2 errors, but found:", errs)
+	}
+
+	if user.Get("name") != "Jane" {
+		t.Error("Expected: Jane, but found:", user.Get("name"))
+	}
+}
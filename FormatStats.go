@@ -0,0 +1,70 @@
+package dataobject
+
+import "time"
+
+// FormatStatsDefaultIterations is the default number of
+// encode and decode rounds measured by FormatStats
+const FormatStatsDefaultIterations = 1000
+
+// FormatStat is the size and the speed of a codec for some data
+type FormatStat struct {
+	// Codec is the name of the codec
+	Codec string `json:"codec"`
+
+	// Size is the encoded size in bytes
+	Size int `json:"size"`
+
+	// EncodeTime is the average time to encode the data
+	EncodeTime time.Duration `json:"encode_time"`
+
+	// DecodeTime is the average time to decode the data
+	DecodeTime time.Duration `json:"decode_time"`
+}
+
+// FormatStats measures the encoded size and the average encode and
+// decode times of the data with each codec (JSON, gob, msgpack and
+// CBOR if none are passed), to help choosing a codec for a use case
+//
+// The data should be representative of the stored objects. Timings
+// are measured over the iterations (FormatStatsDefaultIterations if 0)
+func FormatStats(data map[string]string, iterations int, codecs ...Codec) ([]FormatStat, error) {
+	if iterations <= 0 {
+		iterations = FormatStatsDefaultIterations
+	}
+
+	if len(codecs) < 1 {
+		codecs = []Codec{JSONCodec{}, GobCodec{}, MsgpackCodec{}, CBORCodec{}}
+	}
+
+	stats := make([]FormatStat, 0, len(codecs))
+
+	for _, codec := range codecs {
+		var encoded []byte
+		var err error
+
+		start := time.Now()
+		for i := 0; i < iterations; i++ {
+			if encoded, err = codec.Encode(data); err != nil {
+				return nil, err
+			}
+		}
+		encodeTime := time.Since(start) / time.Duration(iterations)
+
+		start = time.Now()
+		for i := 0; i < iterations; i++ {
+			if _, err = codec.Decode(encoded); err != nil {
+				return nil, err
+			}
+		}
+		decodeTime := time.Since(start) / time.Duration(iterations)
+
+		stats = append(stats, FormatStat{
+			Codec:      codec.Name(),
+			Size:       len(encoded),
+			EncodeTime: encodeTime,
+			DecodeTime: decodeTime,
+		})
+	}
+
+	return stats, nil
+}
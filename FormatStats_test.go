@@ -0,0 +1,68 @@
+package dataobject
+
+import (
+	"strings"
+	"testing"
+)
+
+// benchmarkShapes are representative object shapes for comparing the codecs
+var benchmarkShapes = map[string]map[string]string{
+	"small": {"id": "20240102030405123456789012345678", "name": "Jon", "email": "jon@example.com"},
+	"wide":  wideBenchmarkData(100),
+	"large": {"id": "1", "body": strings.Repeat("lorem ipsum ", 10000)},
+}
+
+func wideBenchmarkData(keys int) map[string]string {
+	data := map[string]string{}
+	for i := 0; i < keys; i++ {
+		data["key_"+toString(i)] = toString(i * 1000)
+	}
+	return data
+}
+
+func TestFormatStats(t *testing.T) {
+	stats, err := FormatStats(benchmarkShapes["small"], 10)
+	if err != nil {
+		t.Fatal("Error must be nil, but found:", err.Error())
+	}
+
+	if len(stats) != 4 || stats[0].Codec != "json" || stats[3].Codec != "cbor" {
+		t.Fatal("Expected: stats for 4 codecs, but found:", stats)
+	}
+
+	for _, stat := range stats {
+		if stat.Size <= 0 {
+			t.Error("Expected: a positive size, but found:", stat)
+		}
+	}
+
+	if stats, _ := FormatStats(benchmarkShapes["small"], 1, MsgpackCodec{}); len(stats) != 1 {
+		t.Error("Expected: stats for 1 codec, but found:", stats)
+	}
+}
+
+func BenchmarkCodecEncode(b *testing.B) {
+	for _, codec := range []Codec{JSONCodec{}, GobCodec{}, MsgpackCodec{}, CBORCodec{}} {
+		for shape, data := range benchmarkShapes {
+			b.Run(codec.Name()+"/"+shape, func(b *testing.B) {
+				for i := 0; i < b.N; i++ {
+					_, _ = codec.Encode(data)
+				}
+			})
+		}
+	}
+}
+
+func BenchmarkCodecDecode(b *testing.B) {
+	for _, codec := range []Codec{JSONCodec{}, GobCodec{}, MsgpackCodec{}, CBORCodec{}} {
+		for shape, data := range benchmarkShapes {
+			encoded, _ := codec.Encode(data)
+			b.Run(codec.Name()+"/"+shape, func(b *testing.B) {
+				b.SetBytes(int64(len(encoded)))
+				for i := 0; i < b.N; i++ {
+					_, _ = codec.Decode(encoded)
+				}
+			})
+		}
+	}
+}
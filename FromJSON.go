@@ -0,0 +1,70 @@
+package dataobject
+
+import (
+	"bytes"
+	"encoding/gob"
+	"errors"
+	"fmt"
+)
+
+// FromJSON creates a new data object from a JSON object string
+// (see NewDataObjectFromJSON) and wraps it into the derived type
+//
+// Example:
+//
+//	user, err := FromJSON(jsonString, func(do *DataObject) *User { return &User{DataObject: do} })
+func FromJSON[T any](jsonString string, wrap func(do *DataObject) T) (T, error) {
+	do, err := NewDataObjectFromJSON(jsonString)
+	if err != nil {
+		var zero T
+		return zero, err
+	}
+
+	return wrap(do), nil
+}
+
+// FromExistingData creates a new data object hydrated with
+// the data and wraps it into the derived type
+func FromExistingData[T any](data map[string]string, wrap func(do *DataObject) T) T {
+	return wrap(NewDataObjectFromExistingData(data))
+}
+
+// FromGob creates a new data object from the object encoded with
+// a gob.Encoder (see DataObject.GobEncode) and wraps it into the
+// derived type. Migrator.NewDataObjectFromGob reads the same format.
+// Returns an error wrapping ErrCorrupted if the data is truncated or damaged,
+// or ErrTooLarge if it is larger than MaxEncodedSize
+func FromGob[T any](encoded []byte, wrap func(do *DataObject) T) (T, error) {
+	do, err := decodeGob(encoded)
+	if err != nil {
+		var zero T
+		return zero, err
+	}
+
+	return wrap(do), nil
+}
+
+// decodeGob decodes the gob encoded object. Decoding failures wrap
+// ErrCorrupted (gob panics on some malformed input are recovered)
+func decodeGob(encoded []byte) (do *DataObject, err error) {
+	defer func() {
+		if recovered := recover(); recovered != nil {
+			do, err = nil, fmt.Errorf("%w: %v", ErrCorrupted, recovered)
+		}
+	}()
+
+	if err := checkEncodedSize(len(encoded)); err != nil {
+		return nil, err
+	}
+
+	do = &DataObject{}
+
+	if err := gob.NewDecoder(bytes.NewReader(encoded)).Decode(do); err != nil {
+		if errors.Is(err, ErrCorrupted) || errors.Is(err, ErrInvalidID) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("%w: %w", ErrCorrupted, err)
+	}
+
+	return do, nil
+}
@@ -0,0 +1,92 @@
+package dataobject
+
+import (
+	"bytes"
+	"encoding/gob"
+	"errors"
+	"maps"
+	"testing"
+)
+
+type testWrappedUser struct {
+	*DataObject
+}
+
+func wrapTestUser(do *DataObject) *testWrappedUser {
+	return &testWrappedUser{DataObject: do}
+}
+
+func TestFromJSON(t *testing.T) {
+	user, err := FromJSON(`{"id":"1","name":"Jon"}`, wrapTestUser)
+
+	if err != nil {
+		t.Fatal("Error must be nil, but found:", err.Error())
+	}
+
+	if user.Get("name") != "Jon" {
+		t.Error("Expected: Jon, but found:", user.Get("name"))
+	}
+
+	if user, err := FromJSON(`[]`, wrapTestUser); err == nil || user != nil {
+		t.Error("Error must NOT be nil, but found:", err, user)
+	}
+}
+
+func TestFromExistingDataAndGob(t *testing.T) {
+	user := FromExistingData(map[string]string{"id": "1"}, wrapTestUser)
+	user.Set("name", "Jon")
+
+	buffer := bytes.Buffer{}
+	_ = gob.NewEncoder(&buffer).Encode(user.DataObject)
+
+	decoded, err := FromGob(buffer.Bytes(), wrapTestUser)
+
+	if err != nil {
+		t.Fatal("Error must be nil, but found:", err.Error())
+	}
+
+	if decoded.Get("name") != "Jon" || !decoded.IsDirty() {
+		t.Error("Expected: Jon and dirty, but found:", decoded.Data(), decoded.IsDirty())
+	}
+
+	if _, err := FromGob([]byte("invalid"), wrapTestUser); err == nil {
+		t.Error("Error must NOT be nil, but found:", err)
+	}
+}
+
+func FuzzFromGob(f *testing.F) {
+	for _, data := range []map[string]string{
+		{"id": "1", "name": "Jon"},
+		{"nul": "a\x00b", "bad": "\xff\xfe"},
+		{},
+	} {
+		buffer := bytes.Buffer{}
+		_ = gob.NewEncoder(&buffer).Encode(NewDataObjectFromExistingData(data))
+		f.Add(buffer.Bytes())
+	}
+	f.Add([]byte{})
+
+	f.Fuzz(func(t *testing.T, encoded []byte) {
+		decoded, err := FromGob(encoded, wrapTestUser)
+		if err != nil {
+			if !errors.Is(err, ErrCorrupted) && !errors.Is(err, ErrTooLarge) {
+				t.Fatal("Expected: ErrCorrupted or ErrTooLarge, but found:", err)
+			}
+			return
+		}
+
+		buffer := bytes.Buffer{}
+		if err := gob.NewEncoder(&buffer).Encode(decoded.DataObject); err != nil {
+			t.Fatal("Error must be nil, but found:", err.Error())
+		}
+
+		again, err := FromGob(buffer.Bytes(), wrapTestUser)
+		if err != nil {
+			t.Fatal("Error must be nil, but found:", err.Error())
+		}
+
+		if !maps.Equal(decoded.Data(), again.Data()) {
+			t.Error("Expected:", decoded.Data(), "but found:", again.Data())
+		}
+	})
+}
@@ -0,0 +1,133 @@
+package dataobject
+
+import (
+	"math/rand"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var (
+	// FakeTimeFrom is the earliest date time generated by GenerateFake
+	FakeTimeFrom = time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	// FakeTimeTo is the latest date time generated by GenerateFake
+	FakeTimeTo = time.Date(2025, 12, 31, 23, 59, 59, 0, time.UTC)
+)
+
+// GenerateFake generates an object with plausible random values for
+// the schema fields, i.e. to seed demo environments and load tests.
+// The same seed always generates the same object
+//
+// Values are picked from the options if there are any, otherwise by
+// type, and for strings by key: names, emails, cities, countries, phone
+// numbers, URLs and IDs are recognized, other keys get lorem ipsum words.
+// Date times are between FakeTimeFrom and FakeTimeTo. The object gets a
+// random UUID as ID, unless the schema has an id field
+func GenerateFake(schema *Schema, seed int64) *DataObject {
+	return generateFake(schema, rand.New(rand.NewSource(seed)))
+}
+
+// GenerateFakeMany generates n objects (see GenerateFake)
+func GenerateFakeMany(schema *Schema, n int, seed int64) []*DataObject {
+	random := rand.New(rand.NewSource(seed))
+
+	objects := make([]*DataObject, 0, n)
+	for i := 0; i < n; i++ {
+		objects = append(objects, generateFake(schema, random))
+	}
+	return objects
+}
+
+// generateFake generates an object with the random source
+func generateFake(schema *Schema, random *rand.Rand) *DataObject {
+	data := map[string]string{"id": fakeUUID(random)}
+
+	for _, field := range schema.Fields() {
+		data[field.Key] = fakeValue(field, random)
+	}
+
+	return NewDataObjectFromExistingData(data)
+}
+
+// fakeValue returns a random value valid for the field
+func fakeValue(field SchemaField, random *rand.Rand) string {
+	if len(field.Options) > 0 {
+		return pick(random, field.Options)
+	}
+
+	key := strings.ToLower(field.Key)
+
+	switch field.Type {
+	case FieldTypeInt:
+		if strings.Contains(key, "age") {
+			return strconv.Itoa(18 + random.Intn(72))
+		}
+		return strconv.Itoa(random.Intn(1000))
+	case FieldTypeFloat:
+		return toString(float64(random.Intn(100000)) / 100)
+	case FieldTypeBool:
+		return strconv.FormatBool(random.Intn(2) == 1)
+	case FieldTypeDateTime:
+		span := FakeTimeTo.Unix() - FakeTimeFrom.Unix()
+		return time.Unix(FakeTimeFrom.Unix()+random.Int63n(span+1), 0).UTC().Format(DateTimeFormat)
+	}
+
+	switch {
+	case key == "id" || strings.HasSuffix(key, "_id"):
+		return fakeUUID(random)
+	case strings.Contains(key, "email"):
+		return strings.ToLower(pick(random, fakeFirstNames)+"."+pick(random, fakeLastNames)) + strconv.Itoa(random.Intn(100)) + "@" + pick(random, fakeDomains)
+	case strings.Contains(key, "first_name"):
+		return pick(random, fakeFirstNames)
+	case strings.Contains(key, "last_name"):
+		return pick(random, fakeLastNames)
+	case strings.Contains(key, "name"):
+		return pick(random, fakeFirstNames) + " " + pick(random, fakeLastNames)
+	case strings.Contains(key, "city"):
+		return pick(random, fakeCities)
+	case strings.Contains(key, "country"):
+		return pick(random, fakeCountries)
+	case strings.Contains(key, "phone"):
+		return "+1555" + strconv.Itoa(1000000+random.Intn(9000000))
+	case strings.Contains(key, "url") || strings.Contains(key, "website"):
+		return "https://example.com/" + pick(random, fakeWords)
+	}
+
+	words := make([]string, 2+random.Intn(5))
+	for i := range words {
+		words[i] = pick(random, fakeWords)
+	}
+	return strings.Join(words, " ")
+}
+
+// pick returns a random value
+func pick(random *rand.Rand, values []string) string {
+	return values[random.Intn(len(values))]
+}
+
+// fakeUUID returns a random version 4 UUID from the random source
+func fakeUUID(random *rand.Rand) string {
+	var b [16]byte
+	_, _ = random.Read(b[:])
+
+	b[6] = (b[6] & 0x0f) | 0x40
+	b[8] = (b[8] & 0x3f) | 0x80
+
+	return formatUUID(b)
+}
+
+var fakeCities = []string{
+	"London", "Paris", "Berlin", "Madrid", "Rome", "Tokyo", "Lagos", "Mumbai",
+	"Toronto", "Sydney", "New York", "Chicago",
+}
+
+var fakeCountries = []string{
+	"United Kingdom", "France", "Germany", "Spain", "Italy", "Japan", "Nigeria", "India",
+	"Canada", "Australia", "United States", "Brazil",
+}
+
+var fakeWords = []string{
+	"lorem", "ipsum", "dolor", "sit", "amet", "consectetur", "adipiscing", "elit",
+	"sed", "do", "eiusmod", "tempor", "incididunt", "labore", "magna", "aliqua",
+}
@@ -0,0 +1,55 @@
+package dataobject
+
+import (
+	"maps"
+	"strings"
+	"testing"
+)
+
+func TestGenerateFake(t *testing.T) {
+	schema := NewSchema(
+		SchemaField{Key: "first_name", Required: true},
+		SchemaField{Key: "email", Required: true},
+		SchemaField{Key: "age", Type: FieldTypeInt},
+		SchemaField{Key: "balance", Type: FieldTypeFloat},
+		SchemaField{Key: "active", Type: FieldTypeBool},
+		SchemaField{Key: "created_at", Type: FieldTypeDateTime},
+		SchemaField{Key: "role", Options: []string{"admin", "user"}},
+		SchemaField{Key: "bio"},
+	)
+
+	do := GenerateFake(schema, 42)
+
+	if errs := schema.Validate(do.Data()); len(errs) > 0 {
+		t.Error("Expected: a valid object, but found:", errs)
+	}
+
+	if ValidUUID(do.ID()) != nil || !strings.Contains(do.Get("email"), "@") {
+		t.Error("Expected: an ID and an email, but found:", do.Data())
+	}
+
+	if age, _ := do.GetInt("age"); age < 18 || age > 89 {
+		t.Error("Expected: an age between 18 and 89, but found:", age)
+	}
+
+	if createdAt, _ := do.GetTime("created_at"); createdAt.Before(FakeTimeFrom) || createdAt.After(FakeTimeTo) {
+		t.Error("Expected: a date time within the range, but found:", createdAt)
+	}
+
+	if !maps.Equal(GenerateFake(schema, 42).Data(), do.Data()) {
+		t.Error("Expected: the same object for the same seed")
+	}
+}
+
+func TestGenerateFakeMany(t *testing.T) {
+	objects := GenerateFakeMany(NewSchema(SchemaField{Key: "name"}), 100, 1)
+
+	ids := map[string]bool{}
+	for _, do := range objects {
+		ids[do.ID()] = true
+	}
+
+	if len(objects) != 100 || len(ids) != 100 {
+		t.Error("Expected: 100 distinct objects, but found:", len(objects), len(ids))
+	}
+}
@@ -0,0 +1,77 @@
+package dataobject
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+)
+
+// TestingT is the part of testing.TB used by the assertions, so the
+// package does not import testing outside of tests
+type TestingT interface {
+	Helper()
+	Errorf(format string, args ...any)
+	Fatalf(format string, args ...any)
+}
+
+// GoldenJSON returns the indented JSON representation of the object
+// data used for golden files. The keys are sorted, and the excluded
+// keys (i.e. IDs and timestamps) are left out
+func GoldenJSON(do DataObjectInterface, excludeKeys ...string) (string, error) {
+	data := copyData(do.Data())
+	for _, key := range excludeKeys {
+		delete(data, key)
+	}
+
+	jsonValue, err := json.MarshalIndent(data, "", "  ")
+	if err != nil {
+		return "", err
+	}
+
+	return string(jsonValue) + "\n", nil
+}
+
+// DumpGolden writes the golden JSON of the object to the golden file,
+// creating the parent directories if needed
+func DumpGolden(do DataObjectInterface, goldenPath string, excludeKeys ...string) error {
+	golden, err := GoldenJSON(do, excludeKeys...)
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(goldenPath), 0o755); err != nil {
+		return err
+	}
+
+	return os.WriteFile(goldenPath, []byte(golden), 0o644)
+}
+
+// AssertEqualJSON fails the test if the golden JSON of the object
+// does not match the contents of the golden file
+//
+// Setting the UPDATE_GOLDEN environment variable to a non-empty
+// value (re)writes the golden file instead
+func AssertEqualJSON(t TestingT, do DataObjectInterface, goldenPath string, excludeKeys ...string) {
+	t.Helper()
+
+	if os.Getenv("UPDATE_GOLDEN") != "" {
+		if err := DumpGolden(do, goldenPath, excludeKeys...); err != nil {
+			t.Fatalf("Error updating golden file: %s", err.Error())
+		}
+		return
+	}
+
+	actual, err := GoldenJSON(do, excludeKeys...)
+	if err != nil {
+		t.Fatalf("Error converting to JSON: %s", err.Error())
+	}
+
+	expected, err := os.ReadFile(goldenPath)
+	if err != nil {
+		t.Fatalf("Error reading golden file (run with UPDATE_GOLDEN=1 to create it): %s", err.Error())
+	}
+
+	if actual != string(expected) {
+		t.Errorf("Expected: %s but found: %s", string(expected), actual)
+	}
+}
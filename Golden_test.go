@@ -0,0 +1,76 @@
+package dataobject
+
+import (
+	"fmt"
+	"path/filepath"
+	"testing"
+)
+
+func TestDumpGoldenAndAssertEqualJSON(t *testing.T) {
+	user := NewDataObject()
+	user.Set("first_name", "Jon")
+	user.Set("last_name", "Doe")
+
+	goldenPath := filepath.Join(t.TempDir(), "testdata", "user.golden.json")
+
+	err := DumpGolden(user, goldenPath, "id")
+
+	if err != nil {
+		t.Fatal("Error must be nil, but found:", err.Error())
+	}
+
+	// a different ID must not affect the comparison
+	other := NewDataObject()
+	other.Set("last_name", "Doe")
+	other.Set("first_name", "Jon")
+
+	AssertEqualJSON(t, other, goldenPath, "id")
+}
+
+func TestGoldenJSON(t *testing.T) {
+	user := NewDataObjectFromExistingData(map[string]string{"id": "1", "b": "2", "a": "1"})
+
+	golden, err := GoldenJSON(user, "id")
+
+	if err != nil {
+		t.Fatal("Error must be nil, but found:", err.Error())
+	}
+
+	expected := "{\n  \"a\": \"1\",\n  \"b\": \"2\"\n}\n"
+
+	if golden != expected {
+		t.Error("Expected:", expected, "but found:", golden)
+	}
+}
+
+var _ TestingT = (testing.TB)(nil) // verify the tests can be passed
+
+// recordingT records the failures of the assertions
+type recordingT struct {
+	errors []string
+}
+
+func (r *recordingT) Helper() {}
+
+func (r *recordingT) Errorf(format string, args ...any) {
+	r.errors = append(r.errors, fmt.Sprintf(format, args...))
+}
+
+func (r *recordingT) Fatalf(format string, args ...any) {
+	r.errors = append(r.errors, fmt.Sprintf(format, args...))
+}
+
+func TestAssertEqualJSONMismatch(t *testing.T) {
+	goldenPath := filepath.Join(t.TempDir(), "user.golden.json")
+
+	if err := DumpGolden(NewDataObjectFromExistingData(map[string]string{"name": "Jon"}), goldenPath); err != nil {
+		t.Fatal("Error must be nil, but found:", err.Error())
+	}
+
+	recorder := &recordingT{}
+	AssertEqualJSON(recorder, NewDataObjectFromExistingData(map[string]string{"name": "Jane"}), goldenPath)
+
+	if len(recorder.errors) != 1 {
+		t.Error("Expected: 1 failure, but found:", recorder.errors)
+	}
+}
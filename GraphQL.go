@@ -0,0 +1,278 @@
+package dataobject
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"regexp"
+	"strings"
+)
+
+// graphQLNameRegexp matches the names allowed by the GraphQL specification
+var graphQLNameRegexp = regexp.MustCompile(`^[_A-Za-z][_0-9A-Za-z]*$`)
+
+// GraphQLResolveFunc resolves a GraphQL query or mutation field from its
+// arguments, matching the resolver signature of most GraphQL libraries
+type GraphQLResolveFunc func(ctx context.Context, args map[string]any) (any, error)
+
+// GraphQLSDL generates the GraphQL schema definition for the type
+// described by the schema, with the queries and mutations served by
+// GraphQLResolvers. For a type named "User" the definition is:
+//
+//	type User { id: ID! ...fields }
+//	input UserInput { ...fields }
+//	type Query {
+//		user(id: ID!): User
+//		users(offset: Int, limit: Int): [User!]!
+//	}
+//	type Mutation {
+//		createUser(input: UserInput!): User!
+//		updateUser(id: ID!, input: UserInput!): User!
+//		deleteUser(id: ID!): Boolean!
+//	}
+//
+// Returns an error wrapping ErrInvalidName, if the type name or
+// a schema key is not a valid GraphQL name
+func GraphQLSDL(typeName string, schema *Schema) (string, error) {
+	if err := validateGraphQLNames(typeName, schema); err != nil {
+		return "", err
+	}
+
+	single, plural := graphQLFieldNames(typeName)
+	var b strings.Builder
+
+	b.WriteString("type " + typeName + " {\n  id: ID!\n")
+	for _, field := range schema.Fields() {
+		if field.Key == "id" {
+			continue
+		}
+		required := ""
+		if field.Required {
+			required = "!"
+		}
+		b.WriteString("  " + field.Key + ": " + graphQLType(field.Type) + required + "\n")
+	}
+	b.WriteString("}\n\n")
+
+	b.WriteString("input " + typeName + "Input {\n")
+	for _, field := range schema.Fields() {
+		if field.Key == "id" {
+			continue
+		}
+		b.WriteString("  " + field.Key + ": " + graphQLType(field.Type) + "\n")
+	}
+	b.WriteString("}\n\n")
+
+	b.WriteString("type Query {\n")
+	b.WriteString("  " + single + "(id: ID!): " + typeName + "\n")
+	b.WriteString("  " + plural + "(offset: Int, limit: Int): [" + typeName + "!]!\n")
+	b.WriteString("}\n\n")
+
+	b.WriteString("type Mutation {\n")
+	b.WriteString("  create" + typeName + "(input: " + typeName + "Input!): " + typeName + "!\n")
+	b.WriteString("  update" + typeName + "(id: ID!, input: " + typeName + "Input!): " + typeName + "!\n")
+	b.WriteString("  delete" + typeName + "(id: ID!): Boolean!\n")
+	b.WriteString("}\n")
+
+	return b.String(), nil
+}
+
+// GraphQLResolvers returns the resolvers of the queries and mutations
+// defined by GraphQLSDL, keyed by field name (i.e. "user", "users",
+// "createUser", "updateUser", "deleteUser"), backed by the repository
+//
+// The objects are resolved as map[string]any with the values converted
+// to the schema field types. Inputs are validated against the schema.
+// Numeric arguments are accepted as any integer type or as whole
+// float64 numbers, as decoded from JSON by most GraphQL libraries
+//
+// Returns an error wrapping ErrInvalidName, if the type name or
+// a schema key is not a valid GraphQL name
+func GraphQLResolvers(typeName string, schema *Schema, repo DataObjectRepositoryInterface) (map[string]GraphQLResolveFunc, error) {
+	if err := validateGraphQLNames(typeName, schema); err != nil {
+		return nil, err
+	}
+
+	single, plural := graphQLFieldNames(typeName)
+
+	toResult := func(do DataObjectInterface) map[string]any {
+		result := map[string]any{"id": do.ID()}
+		for _, field := range schema.Fields() {
+			result[field.Key] = typedValue(field.Type, do.Data()[field.Key])
+		}
+		return result
+	}
+
+	apply := func(do *DataObject, args map[string]any) error {
+		input, _ := args["input"].(map[string]any)
+		values := onlyKeys(graphQLInputValues(input), schema.Keys())
+		delete(values, "id")
+
+		merged := copyData(do.Data())
+		for key, value := range values {
+			merged[key] = value
+		}
+
+		if errs := schema.Validate(merged); len(errs) > 0 {
+			return &ValidationError{Fields: errs}
+		}
+
+		do.SetData(values)
+		return nil
+	}
+
+	return map[string]GraphQLResolveFunc{
+		single: func(ctx context.Context, args map[string]any) (any, error) {
+			do, err := repo.Find(ctx, toString(args["id"]))
+			if IsNotFound(err) {
+				return nil, nil
+			}
+			if err != nil {
+				return nil, err
+			}
+			return toResult(do), nil
+		},
+
+		plural: func(ctx context.Context, args map[string]any) (any, error) {
+			offset, err := graphQLIntArg(args, "offset")
+			if err != nil {
+				return nil, err
+			}
+			limit, err := graphQLIntArg(args, "limit")
+			if err != nil {
+				return nil, err
+			}
+
+			objects, err := repo.List(ctx, offset, limit)
+			if err != nil {
+				return nil, err
+			}
+
+			result := make([]map[string]any, 0, len(objects))
+			for _, do := range objects {
+				result = append(result, toResult(do))
+			}
+			return result, nil
+		},
+
+		"create" + typeName: func(ctx context.Context, args map[string]any) (any, error) {
+			do := NewDataObject()
+			if err := apply(do, args); err != nil {
+				return nil, err
+			}
+			if err := repo.Create(ctx, do); err != nil {
+				return nil, err
+			}
+			return toResult(do), nil
+		},
+
+		"update" + typeName: func(ctx context.Context, args map[string]any) (any, error) {
+			found, err := repo.Find(ctx, toString(args["id"]))
+			if err != nil {
+				return nil, err
+			}
+			do := NewDataObjectFromExistingData(copyData(found.Data()))
+			if err := apply(do, args); err != nil {
+				return nil, err
+			}
+			if err := repo.Update(ctx, do); err != nil {
+				return nil, err
+			}
+			return toResult(do), nil
+		},
+
+		"delete" + typeName: func(ctx context.Context, args map[string]any) (any, error) {
+			if err := repo.Delete(ctx, toString(args["id"])); err != nil {
+				return false, err
+			}
+			return true, nil
+		},
+	}, nil
+}
+
+// validateGraphQLNames returns an error wrapping ErrInvalidName, if the
+// type name or a schema key is not a valid GraphQL name. Names starting
+// with "__" are reserved for introspection
+func validateGraphQLNames(typeName string, schema *Schema) error {
+	names := []string{typeName}
+	for _, field := range schema.Fields() {
+		names = append(names, field.Key)
+	}
+
+	for _, name := range names {
+		if !graphQLNameRegexp.MatchString(name) || strings.HasPrefix(name, "__") {
+			return fmt.Errorf("%w: %q is not a valid GraphQL name", ErrInvalidName, name)
+		}
+	}
+
+	return nil
+}
+
+// graphQLIntArg returns the non-negative integer argument,
+// 0 if missing, or a ValidationError for any other value
+func graphQLIntArg(args map[string]any, key string) (int, error) {
+	invalid := &ValidationError{Fields: []FieldError{{Key: key, Message: "must be a non-negative integer"}}}
+
+	var value float64
+	switch v := args[key].(type) {
+	case nil:
+		return 0, nil
+	case int:
+		value = float64(v)
+	case int32:
+		value = float64(v)
+	case int64:
+		value = float64(v)
+	case float32:
+		value = float64(v)
+	case float64:
+		value = v
+	case json.Number:
+		f, err := v.Float64()
+		if err != nil {
+			return 0, invalid
+		}
+		value = f
+	default:
+		return 0, invalid
+	}
+
+	if value < 0 || value > math.MaxInt32 || value != math.Trunc(value) {
+		return 0, invalid
+	}
+
+	return int(value), nil
+}
+
+// graphQLInputValues converts the input values to strings,
+// with whole float64 numbers without decimals (i.e. 30 as "30")
+func graphQLInputValues(input map[string]any) map[string]string {
+	values := make(map[string]string, len(input))
+	for key, value := range input {
+		values[key] = queryOperand(value)
+	}
+	return values
+}
+
+// graphQLFieldNames returns the single and plural query field names
+// for the type name (i.e. "user" and "users" for "User"), which must
+// not be empty (see validateGraphQLNames)
+func graphQLFieldNames(typeName string) (string, string) {
+	single := strings.ToLower(typeName[:1]) + typeName[1:]
+	return single, single + "s"
+}
+
+// graphQLType returns the GraphQL scalar for the field type
+func graphQLType(fieldType FieldType) string {
+	switch fieldType {
+	case FieldTypeInt:
+		return "Int"
+	case FieldTypeFloat:
+		return "Float"
+	case FieldTypeBool:
+		return "Boolean"
+	default:
+		return "String"
+	}
+}
@@ -0,0 +1,150 @@
+package dataobject
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+)
+
+var testGraphQLSchema = NewSchema(
+	SchemaField{Key: "email", Required: true},
+	SchemaField{Key: "age", Type: FieldTypeInt},
+)
+
+func TestGraphQLSDL(t *testing.T) {
+	sdl, err := GraphQLSDL("User", testGraphQLSchema)
+
+	if err != nil {
+		t.Fatal("Error must be nil, but found:", err.Error())
+	}
+
+	expected := []string{
+		"type User {\n  id: ID!\n  email: String!\n  age: Int\n}",
+		"input UserInput {\n  email: String\n  age: Int\n}",
+		"user(id: ID!): User\n",
+		"users(offset: Int, limit: Int): [User!]!\n",
+		"createUser(input: UserInput!): User!\n",
+		"deleteUser(id: ID!): Boolean!\n",
+	}
+
+	for _, e := range expected {
+		if !strings.Contains(sdl, e) {
+			t.Error("Expected to contain:", e, "but found:", sdl)
+		}
+	}
+}
+
+func TestGraphQLResolvers(t *testing.T) {
+	ctx := context.Background()
+	repo := NewMemoryRepository()
+	resolvers, err := GraphQLResolvers("User", testGraphQLSchema, repo)
+
+	if err != nil {
+		t.Fatal("Error must be nil, but found:", err.Error())
+	}
+
+	created, err := resolvers["createUser"](ctx, map[string]any{
+		"input": map[string]any{"email": "jon@test.com", "age": float64(30)},
+	})
+
+	if err != nil {
+		t.Fatal("Error must be nil, but found:", err.Error())
+	}
+
+	user := created.(map[string]any)
+
+	if user["age"] != int64(30) {
+		t.Error("Expected: 30, but found:", user["age"])
+	}
+
+	found, err := resolvers["user"](ctx, map[string]any{"id": user["id"]})
+
+	if err != nil {
+		t.Fatal("Error must be nil, but found:", err.Error())
+	}
+
+	if found.(map[string]any)["email"] != "jon@test.com" {
+		t.Error("Expected: jon@test.com, but found:", found)
+	}
+
+	_, err = resolvers["updateUser"](ctx, map[string]any{"id": user["id"], "input": map[string]any{"email": ""}})
+
+	if err == nil {
+		t.Error("Error must NOT be nil, but found:", err)
+	}
+
+	deleted, _ := resolvers["deleteUser"](ctx, map[string]any{"id": user["id"]})
+
+	if deleted != true {
+		t.Error("Expected: true, but found:", deleted)
+	}
+
+	missing, err := resolvers["user"](ctx, map[string]any{"id": user["id"]})
+
+	if err != nil || missing != nil {
+		t.Error("Expected: nil, but found:", missing, err)
+	}
+}
+
+func TestGraphQLInvalidNames(t *testing.T) {
+	invalid := map[string]*Schema{
+		"":      testGraphQLSchema,
+		"__Foo": testGraphQLSchema,
+		"User!": testGraphQLSchema,
+		"User":  NewSchema(SchemaField{Key: "first-name"}),
+	}
+
+	for typeName, schema := range invalid {
+		if _, err := GraphQLSDL(typeName, schema); !errors.Is(err, ErrInvalidName) {
+			t.Error("Expected: ErrInvalidName for", typeName, "but found:", err)
+		}
+
+		if _, err := GraphQLResolvers(typeName, schema, NewMemoryRepository()); !errors.Is(err, ErrInvalidName) {
+			t.Error("Expected: ErrInvalidName for", typeName, "but found:", err)
+		}
+	}
+}
+
+func TestGraphQLResolversNumericArguments(t *testing.T) {
+	ctx := context.Background()
+	repo := NewMemoryRepository()
+	resolvers, err := GraphQLResolvers("User", testGraphQLSchema, repo)
+
+	if err != nil {
+		t.Fatal("Error must be nil, but found:", err.Error())
+	}
+
+	for _, email := range []string{"a@test.com", "b@test.com", "c@test.com"} {
+		if _, err := resolvers["createUser"](ctx, map[string]any{"input": map[string]any{"email": email}}); err != nil {
+			t.Fatal("Error must be nil, but found:", err.Error())
+		}
+	}
+
+	for _, args := range []map[string]any{
+		{"offset": 1, "limit": 1},
+		{"offset": int32(1), "limit": int64(1)},
+		{"offset": float64(1), "limit": float64(1)},
+	} {
+		users, err := resolvers["users"](ctx, args)
+
+		if err != nil {
+			t.Fatal("Error must be nil, but found:", err.Error())
+		}
+
+		if len(users.([]map[string]any)) != 1 {
+			t.Error("Expected: 1, but found:", len(users.([]map[string]any)), "for", args)
+		}
+	}
+
+	for _, args := range []map[string]any{
+		{"offset": -1},
+		{"limit": 1.5},
+		{"limit": "1"},
+	} {
+		var validationErr *ValidationError
+		if _, err := resolvers["users"](ctx, args); !errors.As(err, &validationErr) {
+			t.Error("Expected: ValidationError for", args, "but found:", err)
+		}
+	}
+}
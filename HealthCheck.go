@@ -0,0 +1,325 @@
+package dataobject
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// HealthCheckDefaultTimeout is the default timeout of each check
+// of a composite health checker
+const HealthCheckDefaultTimeout = 5 * time.Second
+
+// HealthChecker is implemented by repositories, which can report
+// if their backend is reachable, i.e. for readiness probes
+type HealthChecker interface {
+	// Ping returns an error if the backend is not reachable
+	Ping(ctx context.Context) error
+
+	// Healthy returns the health status of the backend, and the
+	// error of the check if the backend is down
+	Healthy(ctx context.Context) (HealthStatus, error)
+}
+
+// HealthState is the state of a health status
+type HealthState string
+
+const (
+	// HealthUp is the state of a working backend
+	HealthUp HealthState = "up"
+
+	// HealthDegraded is the state of a working backend,
+	// which needs attention (i.e. a growing backlog)
+	HealthDegraded HealthState = "degraded"
+
+	// HealthDown is the state of a backend, which is not working
+	HealthDown HealthState = "down"
+)
+
+// HealthStatus is the result of a health check
+type HealthStatus struct {
+	State HealthState `json:"state"`
+
+	// Latency is the duration of the check
+	Latency time.Duration `json:"latency"`
+
+	// Details are backend specific details (i.e. the number of objects)
+	Details map[string]string `json:"details,omitempty"`
+
+	// Error is the error of the check, if any
+	Error string `json:"error,omitempty"`
+
+	// Checks are the statuses of the checks of a composite checker
+	Checks map[string]HealthStatus `json:"checks,omitempty"`
+}
+
+// CheckHealth returns the health status of the repository. Repositories,
+// which are not health checkers, are checked by counting their objects
+func CheckHealth(ctx context.Context, repo DataObjectRepositoryInterface) (HealthStatus, error) {
+	start := time.Now()
+
+	var status HealthStatus
+	var err error
+
+	if checker, isChecker := repo.(HealthChecker); isChecker {
+		status, err = checker.Healthy(ctx)
+	} else {
+		_, err = repo.Count(ctx)
+		status = HealthStatus{State: HealthUp}
+	}
+
+	if err != nil {
+		status.State = HealthDown
+		status.Error = err.Error()
+	}
+
+	if status.Latency == 0 {
+		status.Latency = time.Since(start)
+	}
+
+	return status, err
+}
+
+// PingRepository returns an error if the repository is not reachable.
+// Repositories, which are not health checkers, are pinged by counting
+// their objects
+func PingRepository(ctx context.Context, repo DataObjectRepositoryInterface) error {
+	if checker, isChecker := repo.(HealthChecker); isChecker {
+		return checker.Ping(ctx)
+	}
+
+	_, err := repo.Count(ctx)
+	return err
+}
+
+var _ HealthChecker = (*CompositeHealthChecker)(nil) // verify it is a health checker
+var _ http.Handler = (*CompositeHealthChecker)(nil)  // verify it can serve readiness probes
+
+// CompositeHealthChecker checks several named repositories concurrently.
+// It is down if any repository is down, and degraded if any is degraded
+//
+// It serves readiness probes as an HTTP handler, responding with the
+// statuses as JSON, and 503 Service Unavailable if down
+//
+// Example:
+//
+//	checker := NewCompositeHealthChecker().
+//		Add("users", usersRepo).
+//		Add("orders", ordersRepo)
+//	mux.Handle("GET /readyz", checker)
+type CompositeHealthChecker struct {
+	names   []string
+	repos   map[string]DataObjectRepositoryInterface
+	timeout time.Duration
+}
+
+// NewCompositeHealthChecker creates a new composite health checker
+func NewCompositeHealthChecker() *CompositeHealthChecker {
+	return &CompositeHealthChecker{
+		repos:   map[string]DataObjectRepositoryInterface{},
+		timeout: HealthCheckDefaultTimeout,
+	}
+}
+
+// Add adds the repository with the name, replacing any with the same name
+func (c *CompositeHealthChecker) Add(name string, repo DataObjectRepositoryInterface) *CompositeHealthChecker {
+	if _, exists := c.repos[name]; !exists {
+		c.names = append(c.names, name)
+		sort.Strings(c.names)
+	}
+	c.repos[name] = repo
+	return c
+}
+
+// SetTimeout sets the timeout of each check (no timeout if 0)
+func (c *CompositeHealthChecker) SetTimeout(timeout time.Duration) *CompositeHealthChecker {
+	c.timeout = timeout
+	return c
+}
+
+// Ping pings the repositories concurrently, returns
+// the errors of the unreachable ones joined
+func (c *CompositeHealthChecker) Ping(ctx context.Context) error {
+	errs := make([]error, len(c.names))
+
+	c.each(ctx, func(ctx context.Context, i int, repo DataObjectRepositoryInterface) {
+		if err := PingRepository(ctx, repo); err != nil {
+			errs[i] = fmt.Errorf("%s: %w", c.names[i], err)
+		}
+	})
+
+	return errors.Join(errs...)
+}
+
+// Healthy checks the repositories concurrently, returns the composite
+// status, and the errors of the repositories, which are down, joined
+func (c *CompositeHealthChecker) Healthy(ctx context.Context) (HealthStatus, error) {
+	start := time.Now()
+
+	statuses := make([]HealthStatus, len(c.names))
+	errs := make([]error, len(c.names))
+
+	c.each(ctx, func(ctx context.Context, i int, repo DataObjectRepositoryInterface) {
+		statuses[i], errs[i] = CheckHealth(ctx, repo)
+		if errs[i] != nil {
+			errs[i] = fmt.Errorf("%s: %w", c.names[i], errs[i])
+		}
+	})
+
+	status := HealthStatus{State: HealthUp, Checks: map[string]HealthStatus{}}
+	for i, name := range c.names {
+		status.Checks[name] = statuses[i]
+		status.State = worseHealthState(status.State, statuses[i].State)
+	}
+	status.Latency = time.Since(start)
+
+	err := errors.Join(errs...)
+	if err != nil {
+		status.Error = err.Error()
+	}
+
+	return status, err
+}
+
+// ServeHTTP responds with the composite status as JSON,
+// with 503 Service Unavailable if down
+func (c *CompositeHealthChecker) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	status, _ := c.Healthy(r.Context())
+
+	code := http.StatusOK
+	if status.State == HealthDown {
+		code = http.StatusServiceUnavailable
+	}
+
+	w.Header().Set("Cache-Control", "no-store")
+	_ = writeJSON(w, writeJSONOptions{status: code}, status)
+}
+
+// each runs the function for each repository concurrently,
+// with the timeout applied to the context
+func (c *CompositeHealthChecker) each(ctx context.Context, fn func(ctx context.Context, i int, repo DataObjectRepositoryInterface)) {
+	wg := sync.WaitGroup{}
+
+	for i, name := range c.names {
+		wg.Add(1)
+		go func(i int, repo DataObjectRepositoryInterface) {
+			defer wg.Done()
+
+			ctx := ctx
+			if c.timeout > 0 {
+				var cancel context.CancelFunc
+				ctx, cancel = context.WithTimeout(ctx, c.timeout)
+				defer cancel()
+			}
+
+			fn(ctx, i, repo)
+		}(i, c.repos[name])
+	}
+
+	wg.Wait()
+}
+
+// worseHealthState returns the worse of the states
+func worseHealthState(a HealthState, b HealthState) HealthState {
+	rank := map[HealthState]int{HealthUp: 0, HealthDegraded: 1, HealthDown: 2}
+	if rank[b] > rank[a] {
+		return b
+	}
+	return a
+}
+
+var _ HealthChecker = (*MemoryRepository)(nil)      // verify it is a health checker
+var _ HealthChecker = (*JournaledRepository)(nil)   // verify it is a health checker
+var _ HealthChecker = (*WriteBehindRepository)(nil) // verify it is a health checker
+
+// Ping succeeds unless the context is done, the memory repository is always reachable
+func (repo *MemoryRepository) Ping(ctx context.Context) error {
+	return ctx.Err()
+}
+
+// Healthy returns the status with the number of objects
+func (repo *MemoryRepository) Healthy(ctx context.Context) (HealthStatus, error) {
+	if err := repo.Ping(ctx); err != nil {
+		return HealthStatus{State: HealthDown, Error: err.Error()}, err
+	}
+
+	return HealthStatus{
+		State:   HealthUp,
+		Details: map[string]string{"objects": strconv.Itoa(repo.collection.Len())},
+	}, nil
+}
+
+// Ping returns an error if the journal file is not accessible
+// (i.e. after being closed), or the decorated repository is not reachable
+func (repo *JournaledRepository) Ping(ctx context.Context) error {
+	if err := repo.journal.ping(); err != nil {
+		return err
+	}
+	return PingRepository(ctx, repo.DataObjectRepositoryInterface)
+}
+
+// Healthy returns the status of the decorated repository,
+// with the size of the journal
+func (repo *JournaledRepository) Healthy(ctx context.Context) (HealthStatus, error) {
+	if err := repo.journal.ping(); err != nil {
+		return HealthStatus{State: HealthDown, Error: err.Error()}, err
+	}
+
+	status, err := CheckHealth(ctx, repo.DataObjectRepositoryInterface)
+	if status.Details == nil {
+		status.Details = map[string]string{}
+	}
+	status.Details["journal_size"] = strconv.FormatInt(repo.journal.size(), 10)
+
+	return status, err
+}
+
+// ping returns an error if the journal file is not accessible
+func (j *Journal) ping() error {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	_, err := j.file.Stat()
+	return err
+}
+
+// size returns the size of the journal file in bytes, 0 if not accessible
+func (j *Journal) size() int64 {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	info, err := j.file.Stat()
+	if err != nil {
+		return 0
+	}
+	return info.Size()
+}
+
+// Ping returns an error if the decorated repository is not reachable
+func (repo *WriteBehindRepository) Ping(ctx context.Context) error {
+	return PingRepository(ctx, repo.inner)
+}
+
+// Healthy returns the status of the decorated repository with the number
+// of objects with buffered changes. It is degraded if the maximum number
+// of pending objects has been reached (see SetMaxPending)
+func (repo *WriteBehindRepository) Healthy(ctx context.Context) (HealthStatus, error) {
+	status, err := CheckHealth(ctx, repo.inner)
+
+	pending := repo.Pending()
+	if status.Details == nil {
+		status.Details = map[string]string{}
+	}
+	status.Details["pending"] = strconv.Itoa(pending)
+
+	if repo.maxPending > 0 && pending >= repo.maxPending {
+		status.State = worseHealthState(status.State, HealthDegraded)
+	}
+
+	return status, err
+}
@@ -0,0 +1,147 @@
+package dataobject
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+)
+
+// downRepository is a repository, which backend is down
+type downRepository struct {
+	DataObjectRepositoryInterface
+}
+
+func (downRepository) Count(ctx context.Context) (int, error) {
+	return 0, errors.New("connection refused")
+}
+
+func TestMemoryRepositoryHealthy(t *testing.T) {
+	repo := NewMemoryRepository()
+	_ = repo.Create(context.Background(), NewDataObject())
+
+	if err := repo.Ping(context.Background()); err != nil {
+		t.Fatal("Error must be nil, but found:", err.Error())
+	}
+
+	status, err := repo.Healthy(context.Background())
+	if err != nil {
+		t.Fatal("Error must be nil, but found:", err.Error())
+	}
+
+	if status.State != HealthUp || status.Details["objects"] != "1" {
+		t.Error("Expected: up with 1 object, but found:", status)
+	}
+}
+
+func TestJournaledRepositoryHealthy(t *testing.T) {
+	journal, err := OpenJournal(filepath.Join(t.TempDir(), "test.journal"))
+	if err != nil {
+		t.Fatal("Error must be nil, but found:", err.Error())
+	}
+
+	repo := NewJournaledRepository(NewMemoryRepository(), journal)
+	_ = repo.Create(context.Background(), NewDataObject())
+
+	status, err := repo.Healthy(context.Background())
+	if err != nil {
+		t.Fatal("Error must be nil, but found:", err.Error())
+	}
+
+	if status.State != HealthUp || status.Details["journal_size"] == "0" {
+		t.Error("Expected: up with a journal, but found:", status)
+	}
+
+	_ = journal.Close()
+
+	if err := repo.Ping(context.Background()); err == nil {
+		t.Error("Error must NOT be nil after closing the journal")
+	}
+}
+
+func TestWriteBehindRepositoryHealthy(t *testing.T) {
+	ctx := context.Background()
+	inner := NewMemoryRepository()
+	repo := NewWriteBehindRepository(inner, 0).SetMaxPending(2)
+
+	do := NewDataObject()
+	_ = inner.Create(ctx, do)
+	do.Set("name", "Jon")
+	_ = repo.Update(ctx, do)
+
+	status, err := repo.Healthy(ctx)
+	if err != nil {
+		t.Fatal("Error must be nil, but found:", err.Error())
+	}
+
+	if status.State != HealthUp || status.Details["pending"] != "1" {
+		t.Error("Expected: up with 1 pending, but found:", status)
+	}
+}
+
+func TestCompositeHealthChecker(t *testing.T) {
+	ctx := context.Background()
+	checker := NewCompositeHealthChecker().
+		Add("users", NewMemoryRepository()).
+		Add("orders", NewIdempotentRepository(NewMemoryRepository(), 0))
+
+	if err := checker.Ping(ctx); err != nil {
+		t.Fatal("Error must be nil, but found:", err.Error())
+	}
+
+	status, err := checker.Healthy(ctx)
+	if err != nil {
+		t.Fatal("Error must be nil, but found:", err.Error())
+	}
+
+	if status.State != HealthUp || len(status.Checks) != 2 {
+		t.Error("Expected: up with 2 checks, but found:", status)
+	}
+
+	checker.Add("legacy", downRepository{})
+
+	if err := checker.Ping(ctx); err == nil {
+		t.Error("Error must NOT be nil, when a repository is down")
+	}
+
+	status, err = checker.Healthy(ctx)
+	if err == nil {
+		t.Error("Error must NOT be nil, when a repository is down")
+	}
+
+	if status.State != HealthDown || status.Checks["legacy"].State != HealthDown || status.Checks["users"].State != HealthUp {
+		t.Error("Expected: down because of legacy, but found:", status)
+	}
+}
+
+func TestCompositeHealthCheckerServeHTTP(t *testing.T) {
+	checker := NewCompositeHealthChecker().Add("users", NewMemoryRepository())
+
+	recorder := httptest.NewRecorder()
+	checker.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/readyz", nil))
+
+	if recorder.Code != http.StatusOK {
+		t.Error("Expected: 200, but found:", recorder.Code)
+	}
+
+	checker.Add("legacy", downRepository{})
+
+	recorder = httptest.NewRecorder()
+	checker.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/readyz", nil))
+
+	if recorder.Code != http.StatusServiceUnavailable {
+		t.Error("Expected: 503, but found:", recorder.Code)
+	}
+
+	status := HealthStatus{}
+	if err := json.Unmarshal(recorder.Body.Bytes(), &status); err != nil {
+		t.Fatal("Error must be nil, but found:", err.Error())
+	}
+
+	if status.Checks["legacy"].Error != "connection refused" {
+		t.Error("Expected: connection refused, but found:", status.Checks["legacy"].Error)
+	}
+}
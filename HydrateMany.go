@@ -0,0 +1,23 @@
+package dataobject
+
+// HydrateMany creates the data objects hydrated with the rows, like
+// calling NewDataObjectFromExistingData for each row, but allocating
+// all the objects at once, i.e. when scanning many database rows
+//
+// The rows are used as the data of the objects, not copied. The objects
+// share a single backing array, which is kept in memory as long as any
+// of the objects is referenced
+func HydrateMany(rows []map[string]string) []*DataObject {
+	objects := make([]DataObject, len(rows))
+	result := make([]*DataObject, len(rows))
+
+	for i, row := range rows {
+		if row == nil {
+			row = map[string]string{}
+		}
+		objects[i].data = row
+		result[i] = &objects[i]
+	}
+
+	return result
+}
@@ -0,0 +1,52 @@
+package dataobject
+
+import "testing"
+
+func TestHydrateMany(t *testing.T) {
+	objects := HydrateMany([]map[string]string{{"id": "1", "name": "Jon"}, nil, {"id": "3"}})
+
+	if len(objects) != 3 || objects[0].Get("name") != "Jon" || objects[2].ID() != "3" {
+		t.Fatal("Expected: the hydrated objects, but found:", objects)
+	}
+
+	if objects[0].IsDirty() || len(objects[1].Data()) != 0 {
+		t.Error("Expected: not dirty objects, but found:", objects[0].DataChanged(), objects[1].Data())
+	}
+
+	objects[1].Set("id", "2")
+
+	if objects[0].IsDirty() || objects[1].ID() != "2" || !objects[1].IsDirty() {
+		t.Error("Expected: independent objects, but found:", objects[0].DataChanged(), objects[1].Data())
+	}
+}
+
+func benchmarkRows(n int) []map[string]string {
+	rows := make([]map[string]string, n)
+	for i := range rows {
+		rows[i] = map[string]string{"id": toString(i), "status": "active"}
+	}
+	return rows
+}
+
+func BenchmarkHydrateMany(b *testing.B) {
+	rows := benchmarkRows(10000)
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		for _, do := range HydrateMany(rows) {
+			_ = do.Get("status")
+		}
+	}
+}
+
+func BenchmarkHydrateLoop(b *testing.B) {
+	rows := benchmarkRows(10000)
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		for _, row := range rows {
+			do, _ := NewDataObjectFromData(row)
+			_ = do.Get("status")
+		}
+	}
+}
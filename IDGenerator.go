@@ -0,0 +1,130 @@
+package dataobject
+
+import (
+	"crypto/rand"
+	"encoding/binary"
+	"encoding/hex"
+	"sync"
+	"time"
+
+	"github.com/gouniverse/uid"
+)
+
+// IDGenerator generates a new unique ID
+type IDGenerator func() string
+
+var (
+	idGeneratorMu sync.RWMutex
+	idGenerator   IDGenerator = HumanUID
+)
+
+// SetIDGenerator sets the ID generator used by NewDataObject,
+// defaults to HumanUID. Passing nil restores the default
+//
+// Built-in generators are HumanUID, UUIDv4, UUIDv7 and ULID
+func SetIDGenerator(generator IDGenerator) {
+	if generator == nil {
+		generator = HumanUID
+	}
+
+	idGeneratorMu.Lock()
+	defer idGeneratorMu.Unlock()
+
+	idGenerator = generator
+}
+
+// generateID generates a new ID with the package-level generator
+func generateID() string {
+	idGeneratorMu.RLock()
+	defer idGeneratorMu.RUnlock()
+
+	return idGenerator()
+}
+
+// HumanUID generates a human readable, time ordered ID
+// (i.e. 20240102030405123456789012345678)
+func HumanUID() string {
+	return uid.HumanUid()
+}
+
+// UUIDv4 generates a random RFC 4122 version 4 UUID
+func UUIDv4() string {
+	var b [16]byte
+	_, _ = rand.Read(b[:])
+
+	b[6] = (b[6] & 0x0f) | 0x40 // version 4
+	b[8] = (b[8] & 0x3f) | 0x80 // variant RFC 4122
+
+	return formatUUID(b)
+}
+
+// UUIDv7 generates a time ordered RFC 9562 version 7 UUID,
+// starting with the Unix timestamp in milliseconds
+func UUIDv7() string {
+	var b [16]byte
+	_, _ = rand.Read(b[6:])
+
+	putUint48(b[:6], uint64(time.Now().UnixMilli()))
+
+	b[6] = (b[6] & 0x0f) | 0x70 // version 7
+	b[8] = (b[8] & 0x3f) | 0x80 // variant RFC 4122
+
+	return formatUUID(b)
+}
+
+// ULID generates a time ordered, lexicographically sortable ULID
+// (26 characters, Crockford base32), starting with the Unix timestamp
+// in milliseconds
+func ULID() string {
+	var b [16]byte
+	_, _ = rand.Read(b[6:])
+
+	putUint48(b[:6], uint64(time.Now().UnixMilli()))
+
+	return encodeCrockford(b)
+}
+
+// crockfordAlphabet is the Crockford base32 alphabet used by ULIDs
+const crockfordAlphabet = "0123456789ABCDEFGHJKMNPQRSTVWXYZ"
+
+// encodeCrockford encodes the 128 bits as 26 Crockford base32 characters
+func encodeCrockford(b [16]byte) string {
+	high := binary.BigEndian.Uint64(b[:8])
+	low := binary.BigEndian.Uint64(b[8:])
+
+	var out [26]byte
+	for i := 25; i >= 0; i-- {
+		out[i] = crockfordAlphabet[low&0x1f]
+		low = low>>5 | high<<59
+		high >>= 5
+	}
+
+	return string(out[:])
+}
+
+// formatUUID formats the 16 bytes in the canonical 8-4-4-4-12 form
+func formatUUID(b [16]byte) string {
+	var out [36]byte
+
+	hex.Encode(out[0:8], b[0:4])
+	out[8] = '-'
+	hex.Encode(out[9:13], b[4:6])
+	out[13] = '-'
+	hex.Encode(out[14:18], b[6:8])
+	out[18] = '-'
+	hex.Encode(out[19:23], b[8:10])
+	out[23] = '-'
+	hex.Encode(out[24:], b[10:])
+
+	return string(out[:])
+}
+
+// putUint48 writes the lower 48 bits of the value big endian
+func putUint48(b []byte, value uint64) {
+	b[0] = byte(value >> 40)
+	b[1] = byte(value >> 32)
+	b[2] = byte(value >> 24)
+	b[3] = byte(value >> 16)
+	b[4] = byte(value >> 8)
+	b[5] = byte(value)
+}
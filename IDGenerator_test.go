@@ -0,0 +1,57 @@
+package dataobject
+
+import (
+	"regexp"
+	"testing"
+	"time"
+)
+
+func TestIDGenerators(t *testing.T) {
+	inputs := []struct {
+		name      string
+		generator IDGenerator
+		pattern   *regexp.Regexp
+	}{
+		{"UUIDv4", UUIDv4, regexp.MustCompile(`^[0-9a-f]{8}-[0-9a-f]{4}-4[0-9a-f]{3}-[89ab][0-9a-f]{3}-[0-9a-f]{12}$`)},
+		{"UUIDv7", UUIDv7, regexp.MustCompile(`^[0-9a-f]{8}-[0-9a-f]{4}-7[0-9a-f]{3}-[89ab][0-9a-f]{3}-[0-9a-f]{12}$`)},
+		{"ULID", ULID, regexp.MustCompile(`^[0-7][0-9A-HJKMNP-TV-Z]{25}$`)},
+	}
+
+	for _, input := range inputs {
+		id := input.generator()
+
+		if !input.pattern.MatchString(id) {
+			t.Error(input.name, "does not match the expected format:", id)
+		}
+
+		if id == input.generator() {
+			t.Error(input.name, "generated the same ID twice:", id)
+		}
+	}
+}
+
+func TestIDGeneratorsTimeOrdered(t *testing.T) {
+	for _, generator := range []IDGenerator{UUIDv7, ULID} {
+		first := generator()
+		time.Sleep(2 * time.Millisecond)
+		second := generator()
+
+		if first >= second {
+			t.Error("Expected:", first, "to sort before:", second)
+		}
+	}
+}
+
+func TestSetIDGenerator(t *testing.T) {
+	defer SetIDGenerator(nil)
+
+	SetIDGenerator(func() string { return "fixed" })
+
+	if NewDataObject().ID() != "fixed" {
+		t.Error("Expected: fixed, but found:", NewDataObject().ID())
+	}
+
+	if NewDataObjectWithIDGenerator(func() string { return "other" }).ID() != "other" {
+		t.Error("Expected: other")
+	}
+}
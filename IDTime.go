@@ -0,0 +1,93 @@
+package dataobject
+
+import (
+	"encoding/hex"
+	"strings"
+	"time"
+)
+
+// NewDataObjectWithULID creates a new data object with a ULID,
+// which is time ordered and thus index friendly as a primary key
+func NewDataObjectWithULID() *DataObject {
+	return NewDataObjectWithIDGenerator(ULID)
+}
+
+// IDTime returns the creation time embedded in the ID of the object,
+// and false if the ID is not time ordered (see IDTimeOf)
+func (do *DataObject) IDTime() (time.Time, bool) {
+	return IDTimeOf(do.ID())
+}
+
+// IDTimeOf returns the creation time embedded in a time ordered ID
+// generated by ULID, UUIDv7 (millisecond precision) or HumanUID
+// (second precision), and false for any other ID
+func IDTimeOf(id string) (time.Time, bool) {
+	// checked first, as all digit IDs are valid ULID characters as well
+	if t, ok := humanUIDTime(id); ok {
+		return t, true
+	}
+
+	if t, ok := ulidTime(id); ok {
+		return t, true
+	}
+
+	return uuidv7Time(id)
+}
+
+// ulidTime returns the time of a ULID
+func ulidTime(id string) (time.Time, bool) {
+	if len(id) != 26 || id[0] > '7' {
+		return time.Time{}, false
+	}
+
+	var milliseconds uint64
+	for i := 0; i < 10; i++ {
+		index := strings.IndexByte(crockfordAlphabet, id[i])
+		if index < 0 {
+			return time.Time{}, false
+		}
+		milliseconds = milliseconds<<5 | uint64(index)
+	}
+
+	for i := 10; i < 26; i++ {
+		if strings.IndexByte(crockfordAlphabet, id[i]) < 0 {
+			return time.Time{}, false
+		}
+	}
+
+	return time.UnixMilli(int64(milliseconds)).UTC(), true
+}
+
+// uuidv7Time returns the time of a version 7 UUID
+func uuidv7Time(id string) (time.Time, bool) {
+	if len(id) != 36 || id[8] != '-' || id[13] != '-' || id[18] != '-' || id[23] != '-' || id[14] != '7' {
+		return time.Time{}, false
+	}
+
+	b, err := hex.DecodeString(strings.ReplaceAll(id, "-", ""))
+	if err != nil {
+		return time.Time{}, false
+	}
+
+	var milliseconds uint64
+	for _, v := range b[:6] {
+		milliseconds = milliseconds<<8 | uint64(v)
+	}
+
+	return time.UnixMilli(int64(milliseconds)).UTC(), true
+}
+
+// humanUIDTime returns the time of a HumanUID, which starts
+// with the UTC timestamp in the format 20060102150405
+func humanUIDTime(id string) (time.Time, bool) {
+	if len(id) < 14 || strings.Trim(id, "0123456789") != "" {
+		return time.Time{}, false
+	}
+
+	t, err := time.Parse("20060102150405", id[:14])
+	if err != nil {
+		return time.Time{}, false
+	}
+
+	return t, true
+}
@@ -0,0 +1,41 @@
+package dataobject
+
+import (
+	"testing"
+	"time"
+)
+
+func TestIDTimeOf(t *testing.T) {
+	before := time.Now().Add(-time.Second)
+
+	for _, id := range []string{ULID(), UUIDv7(), HumanUID()} {
+		created, ok := IDTimeOf(id)
+
+		if !ok {
+			t.Error("Expected time to be extracted from:", id)
+			continue
+		}
+
+		if created.Before(before.Truncate(time.Second)) || created.After(time.Now()) {
+			t.Error("Expected time close to now, but found:", created, "for:", id)
+		}
+	}
+
+	for _, id := range []string{UUIDv4(), "1", "user-123", ""} {
+		if _, ok := IDTimeOf(id); ok {
+			t.Error("Expected no time for:", id)
+		}
+	}
+}
+
+func TestNewDataObjectWithULID(t *testing.T) {
+	do := NewDataObjectWithULID()
+
+	if len(do.ID()) != 26 {
+		t.Error("Expected: 26, but found:", len(do.ID()))
+	}
+
+	if _, ok := do.IDTime(); !ok {
+		t.Error("Expected time to be extracted from:", do.ID())
+	}
+}
@@ -0,0 +1,112 @@
+package dataobject
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"sync"
+	"unicode"
+)
+
+// IDValidator returns an error if the ID is malformed
+type IDValidator func(id string) error
+
+var (
+	idValidatorMu sync.RWMutex
+	idValidator   IDValidator
+)
+
+// SetIDValidator sets the ID validator applied when objects are decoded
+// (NewDataObjectFromJSON, NewDataObjectFromNestedJSON, NewDataObjectFromData,
+// UnmarshalJSON and GobDecode), so malformed or injected IDs are rejected
+// early with an error wrapping ErrInvalidID. Objects without an ID are
+// not validated. Passing nil disables the validation (the default)
+//
+// Built-in validators are SafeID, IDPattern, ValidHumanUID, ValidUUID and ValidULID
+func SetIDValidator(validator IDValidator) {
+	idValidatorMu.Lock()
+	defer idValidatorMu.Unlock()
+
+	idValidator = validator
+}
+
+// validateID validates the ID of the data with the package-level validator
+func validateID(data map[string]string) error {
+	idValidatorMu.RLock()
+	validator := idValidator
+	idValidatorMu.RUnlock()
+
+	id, exists := data["id"]
+	if validator == nil || !exists {
+		return nil
+	}
+
+	if err := validator(id); err != nil {
+		return fmt.Errorf("%w: %q: %w", ErrInvalidID, id, err)
+	}
+
+	return nil
+}
+
+// SafeID rejects empty IDs, and IDs with path separators,
+// dot segments or control characters
+func SafeID(id string) error {
+	switch {
+	case id == "":
+		return fmt.Errorf("empty")
+	case id == "." || id == "..":
+		return fmt.Errorf("dot segment")
+	case strings.ContainsAny(id, `/\`):
+		return fmt.Errorf("path separator")
+	case strings.ContainsFunc(id, unicode.IsControl):
+		return fmt.Errorf("control character")
+	}
+	return nil
+}
+
+// IDPattern returns a validator accepting only the IDs fully matching the pattern
+func IDPattern(pattern *regexp.Regexp) IDValidator {
+	return func(id string) error {
+		if match := pattern.FindString(id); match == "" || match != id {
+			return fmt.Errorf("does not match %s", pattern)
+		}
+		return nil
+	}
+}
+
+var (
+	humanUIDPattern = regexp.MustCompile(`^[0-9]{32}$`)
+	uuidPattern     = regexp.MustCompile(`^[0-9a-f]{8}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{12}$`)
+	ulidPattern     = regexp.MustCompile(`^[0-7][0-9A-HJKMNP-TV-Z]{25}$`)
+)
+
+// ValidHumanUID accepts only the IDs generated by HumanUID
+func ValidHumanUID(id string) error {
+	return IDPattern(humanUIDPattern)(id)
+}
+
+// ValidUUID accepts only lowercase canonical UUIDs (see UUIDv4 and UUIDv7)
+func ValidUUID(id string) error {
+	return IDPattern(uuidPattern)(id)
+}
+
+// ValidULID accepts only the IDs generated by ULID
+func ValidULID(id string) error {
+	return IDPattern(ulidPattern)(id)
+}
+
+// NewDataObjectFromData creates a new data object hydrated with the data
+// like NewDataObjectFromExistingData, but validates the ID first
+// (see SetIDValidator), and upgrades the data (see SetMigrator)
+func NewDataObjectFromData(data map[string]string) (*DataObject, error) {
+	if err := validateID(data); err != nil {
+		return nil, err
+	}
+
+	data, _, err := migrateDecoded(data)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewDataObjectFromExistingData(data), nil
+}
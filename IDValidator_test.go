@@ -0,0 +1,70 @@
+package dataobject
+
+import (
+	"encoding/json"
+	"errors"
+	"regexp"
+	"testing"
+)
+
+func TestSetIDValidator(t *testing.T) {
+	defer SetIDValidator(nil)
+
+	if _, err := NewDataObjectFromJSON(`{"id":"../etc/passwd"}`); err != nil {
+		t.Fatal("Error must be nil without a validator, but found:", err.Error())
+	}
+
+	SetIDValidator(SafeID)
+
+	if _, err := NewDataObjectFromJSON(`{"id":"../etc/passwd"}`); !errors.Is(err, ErrInvalidID) {
+		t.Error("Expected: ErrInvalidID, but found:", err)
+	}
+
+	if _, err := NewDataObjectFromNestedJSON(`{"id":"a/b"}`); !errors.Is(err, ErrInvalidID) {
+		t.Error("Expected: ErrInvalidID, but found:", err)
+	}
+
+	if _, err := NewDataObjectFromData(map[string]string{"id": ".."}); !errors.Is(err, ErrInvalidID) {
+		t.Error("Expected: ErrInvalidID, but found:", err)
+	}
+
+	do := &DataObject{}
+	if err := json.Unmarshal([]byte(`{"id":"a\\b"}`), do); !errors.Is(err, ErrInvalidID) {
+		t.Error("Expected: ErrInvalidID, but found:", err)
+	}
+
+	encoded, err := NewDataObjectFromExistingData(map[string]string{"id": "a\nb"}).GobEncode()
+	if err != nil {
+		t.Fatal("Error must be nil, but found:", err.Error())
+	}
+	if err := do.GobDecode(encoded); !errors.Is(err, ErrInvalidID) {
+		t.Error("Expected: ErrInvalidID, but found:", err)
+	}
+
+	if _, err := NewDataObjectFromJSON(`{"name":"no id"}`); err != nil {
+		t.Error("Error must be nil without an ID, but found:", err.Error())
+	}
+}
+
+func TestIDValidators(t *testing.T) {
+	tests := []struct {
+		validator IDValidator
+		valid     string
+		invalid   string
+	}{
+		{SafeID, "abc-123", "a/b"},
+		{IDPattern(regexp.MustCompile(`[a-z]+`)), "abc", "abc1"},
+		{ValidHumanUID, "20240102030405123456789012345678", "2024010203040512345678901234567x"},
+		{ValidUUID, UUIDv4(), "not-a-uuid"},
+		{ValidULID, ULID(), "8ZZZZZZZZZZZZZZZZZZZZZZZZZ"},
+	}
+
+	for _, test := range tests {
+		if err := test.validator(test.valid); err != nil {
+			t.Error("Error must be nil, but found:", err.Error())
+		}
+		if err := test.validator(test.invalid); err == nil {
+			t.Error("Error must NOT be nil for", test.invalid)
+		}
+	}
+}
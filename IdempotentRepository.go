@@ -0,0 +1,127 @@
+package dataobject
+
+import (
+	"context"
+	"crypto/subtle"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// IdempotencyKeyKey is the key holding the idempotency key of an object
+const IdempotencyKeyKey = "idempotency_key"
+
+// StampIdempotencyKey sets the idempotency key (i.e. from a hidden form
+// field), or generates a new one if empty. Returns the key
+func (do *DataObject) StampIdempotencyKey(key string) string {
+	if key == "" {
+		key = generateID()
+	}
+	do.Set(IdempotencyKeyKey, key)
+	return key
+}
+
+// IdempotencyKey returns the idempotency key of the object, if any
+func (do *DataObject) IdempotencyKey() string {
+	return do.Get(IdempotencyKeyKey)
+}
+
+// VerifyIdempotencyKey returns if the object has the idempotency key
+func (do *DataObject) VerifyIdempotencyKey(key string) bool {
+	stored := do.IdempotencyKey()
+	return stored != "" && subtle.ConstantTimeCompare([]byte(stored), []byte(key)) == 1
+}
+
+var _ DataObjectRepositoryInterface = (*IdempotentRepository)(nil) // verify it extends the repository interface
+var _ ConditionalUpdater = (*IdempotentRepository)(nil)            // verify it forwards conditional updates
+var _ Querier = (*IdempotentRepository)(nil)                       // verify it forwards queries
+
+// IdempotentRepository decorates a repository rejecting Creates with an
+// idempotency key, which has already been used within the window, with
+// ErrDuplicateRequest (i.e. double form submissions). Objects without
+// an idempotency key are created as usual, and all the other
+// operations, including the queries, are passed through
+type IdempotentRepository struct {
+	DataObjectRepositoryInterface
+
+	window time.Duration
+
+	mu   sync.Mutex
+	seen map[string]time.Time
+}
+
+// NewIdempotentRepository creates a new idempotent decorator of the repository,
+// remembering the idempotency keys for the window
+func NewIdempotentRepository(inner DataObjectRepositoryInterface, window time.Duration) *IdempotentRepository {
+	return &IdempotentRepository{
+		DataObjectRepositoryInterface: inner,
+		window:                        window,
+		seen:                          map[string]time.Time{},
+	}
+}
+
+// Create creates the object, unless its idempotency key has been used
+func (repo *IdempotentRepository) Create(ctx context.Context, do DataObjectInterface) error {
+	key := do.Data()[IdempotencyKeyKey]
+	if key == "" {
+		return repo.DataObjectRepositoryInterface.Create(ctx, do)
+	}
+
+	if !repo.reserve(key) {
+		return fmt.Errorf("%w: %s", ErrDuplicateRequest, key)
+	}
+
+	if err := repo.DataObjectRepositoryInterface.Create(ctx, do); err != nil {
+		repo.release(key)
+		return err
+	}
+
+	return nil
+}
+
+// Query returns the objects matching the query
+func (repo *IdempotentRepository) Query(ctx context.Context, query *Query) ([]DataObjectInterface, error) {
+	return forwardQuery(ctx, repo.DataObjectRepositoryInterface, query)
+}
+
+// UpdateIf updates the object, if it matches the entity tag
+func (repo *IdempotentRepository) UpdateIf(ctx context.Context, do DataObjectInterface, expectedETag string) error {
+	return forwardUpdateIf(ctx, repo.DataObjectRepositoryInterface, do, expectedETag)
+}
+
+// ForceDelete deletes the object with the ID permanently
+func (repo *IdempotentRepository) ForceDelete(ctx context.Context, id string) error {
+	return forwardForceDelete(ctx, repo.DataObjectRepositoryInterface, id)
+}
+
+// Restore restores the soft deleted object with the ID
+func (repo *IdempotentRepository) Restore(ctx context.Context, id string) error {
+	return forwardRestore(ctx, repo.DataObjectRepositoryInterface, id)
+}
+
+// reserve marks the key as used, returns false if it is already used
+func (repo *IdempotentRepository) reserve(key string) bool {
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+
+	now := time.Now()
+	for seenKey, seenAt := range repo.seen {
+		if now.Sub(seenAt) >= repo.window {
+			delete(repo.seen, seenKey)
+		}
+	}
+
+	if _, used := repo.seen[key]; used {
+		return false
+	}
+
+	repo.seen[key] = now
+	return true
+}
+
+// release forgets the key, so a failed Create can be retried
+func (repo *IdempotentRepository) release(key string) {
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+	delete(repo.seen, key)
+}
@@ -0,0 +1,64 @@
+package dataobject
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestIdempotentRepositoryCreate(t *testing.T) {
+	ctx := context.Background()
+	repo := NewIdempotentRepository(NewMemoryRepository(), 50*time.Millisecond)
+
+	first := NewDataObject()
+	key := first.StampIdempotencyKey("")
+
+	if err := repo.Create(ctx, first); err != nil {
+		t.Fatal("Error must be nil, but found:", err.Error())
+	}
+
+	second := NewDataObject()
+	second.StampIdempotencyKey(key)
+
+	if err := repo.Create(ctx, second); !errors.Is(err, ErrDuplicateRequest) {
+		t.Error("Expected: ErrDuplicateRequest, but found:", err)
+	}
+
+	time.Sleep(60 * time.Millisecond)
+
+	if err := repo.Create(ctx, second); err != nil {
+		t.Error("Error must be nil after the window, but found:", err.Error())
+	}
+
+	if err := repo.Create(ctx, NewDataObject()); err != nil {
+		t.Error("Error must be nil without a key, but found:", err.Error())
+	}
+}
+
+func TestIdempotentRepositoryReleasesFailedCreates(t *testing.T) {
+	ctx := context.Background()
+	repo := NewIdempotentRepository(NewMemoryRepository(), time.Minute)
+
+	do := NewDataObjectFromExistingData(map[string]string{})
+	do.StampIdempotencyKey("abc")
+
+	if err := repo.Create(ctx, do); !errors.Is(err, ErrMissingID) {
+		t.Fatal("Expected: ErrMissingID, but found:", err)
+	}
+
+	do.SetID("1")
+
+	if err := repo.Create(ctx, do); err != nil {
+		t.Error("Error must be nil, but found:", err.Error())
+	}
+}
+
+func TestDataObjectVerifyIdempotencyKey(t *testing.T) {
+	do := NewDataObject()
+	do.StampIdempotencyKey("abc")
+
+	if !do.VerifyIdempotencyKey("abc") || do.VerifyIdempotencyKey("abd") || NewDataObject().VerifyIdempotencyKey("") {
+		t.Error("Expected: only abc to verify, but found:", do.IdempotencyKey())
+	}
+}
@@ -0,0 +1,223 @@
+package dataobject
+
+import (
+	"sort"
+	"sync"
+)
+
+// IndexedCollection is an in-memory collection of data objects,
+// which maintains hash indexes on the declared keys, so that
+// lookups by these keys (FindBy) run in constant time
+//
+// The indexes are maintained automatically as objects are added,
+// updated and removed. On update only the indexed keys, which values
+// differ from the indexed ones, are re-indexed
+//
+// The collection is safe for concurrent use
+type IndexedCollection struct {
+	mu sync.RWMutex
+
+	// objects holds the objects by their ID
+	objects map[string]DataObjectInterface
+
+	// indexKeys holds the keys which are indexed
+	indexKeys []string
+
+	// indexes maps key -> value -> set of object IDs
+	indexes map[string]map[string]map[string]struct{}
+
+	// indexed maps object ID -> key -> value as currently indexed
+	indexed map[string]map[string]string
+}
+
+// NewIndexedCollection creates a new collection indexed on the passed keys
+func NewIndexedCollection(indexKeys ...string) *IndexedCollection {
+	c := &IndexedCollection{
+		objects:   map[string]DataObjectInterface{},
+		indexKeys: indexKeys,
+		indexes:   map[string]map[string]map[string]struct{}{},
+		indexed:   map[string]map[string]string{},
+	}
+
+	for _, key := range indexKeys {
+		c.indexes[key] = map[string]map[string]struct{}{}
+	}
+
+	return c
+}
+
+// IndexKeys returns the keys the collection is indexed on
+func (c *IndexedCollection) IndexKeys() []string {
+	return append([]string{}, c.indexKeys...)
+}
+
+// Add adds an object to the collection and indexes it.
+// If an object with the same ID already exists, it is replaced
+func (c *IndexedCollection) Add(do DataObjectInterface) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.add(do)
+}
+
+// Update re-indexes an object already in the collection.
+//
+// The indexed keys, which values differ from the indexed ones, are
+// re-indexed, including removed keys. If the object is not in the
+// collection it is added
+func (c *IndexedCollection) Update(do DataObjectInterface) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	id := do.ID()
+
+	if _, exists := c.objects[id]; !exists {
+		c.add(do)
+		return
+	}
+
+	c.objects[id] = do
+
+	data := do.Data()
+
+	for _, key := range c.indexKeys {
+		if c.indexed[id][key] == data[key] {
+			continue
+		}
+
+		c.unindexKey(id, key)
+		c.index(id, key, data[key])
+	}
+}
+
+// Remove removes the object with the specified ID from the collection
+func (c *IndexedCollection) Remove(id string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if _, exists := c.objects[id]; !exists {
+		return
+	}
+
+	c.unindex(id)
+	delete(c.objects, id)
+}
+
+// Get returns the object with the specified ID, or nil if not found
+func (c *IndexedCollection) Get(id string) DataObjectInterface {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	return c.objects[id]
+}
+
+// FindBy returns the objects which have the specified value for the key,
+// sorted by ID
+//
+// Indexed keys are looked up in constant time, any other key falls
+// back to a full scan of the collection
+func (c *IndexedCollection) FindBy(key string, value string) []DataObjectInterface {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	ids := []string{}
+
+	if index, isIndexed := c.indexes[key]; isIndexed {
+		for id := range index[value] {
+			ids = append(ids, id)
+		}
+	} else {
+		for id, do := range c.objects {
+			if do.Data()[key] == value {
+				ids = append(ids, id)
+			}
+		}
+	}
+
+	return c.objectsByIDs(ids)
+}
+
+// All returns all the objects in the collection, sorted by ID
+func (c *IndexedCollection) All() []DataObjectInterface {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	ids := make([]string, 0, len(c.objects))
+	for id := range c.objects {
+		ids = append(ids, id)
+	}
+
+	return c.objectsByIDs(ids)
+}
+
+// Len returns the number of objects in the collection
+func (c *IndexedCollection) Len() int {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	return len(c.objects)
+}
+
+// add adds the object to the collection and indexes all the indexed keys
+func (c *IndexedCollection) add(do DataObjectInterface) {
+	id := do.ID()
+
+	if _, exists := c.objects[id]; exists {
+		c.unindex(id)
+	}
+
+	c.objects[id] = do
+
+	data := do.Data()
+	for _, key := range c.indexKeys {
+		c.index(id, key, data[key])
+	}
+}
+
+// index adds the object ID to the index of the key for the value
+func (c *IndexedCollection) index(id string, key string, value string) {
+	if c.indexes[key][value] == nil {
+		c.indexes[key][value] = map[string]struct{}{}
+	}
+	c.indexes[key][value][id] = struct{}{}
+
+	if c.indexed[id] == nil {
+		c.indexed[id] = map[string]string{}
+	}
+	c.indexed[id][key] = value
+}
+
+// unindexKey removes the object ID from the index of the key
+func (c *IndexedCollection) unindexKey(id string, key string) {
+	value, isIndexed := c.indexed[id][key]
+	if !isIndexed {
+		return
+	}
+
+	delete(c.indexes[key][value], id)
+	if len(c.indexes[key][value]) < 1 {
+		delete(c.indexes[key], value)
+	}
+
+	delete(c.indexed[id], key)
+}
+
+// unindex removes the object ID from all the indexes
+func (c *IndexedCollection) unindex(id string) {
+	for _, key := range c.indexKeys {
+		c.unindexKey(id, key)
+	}
+	delete(c.indexed, id)
+}
+
+// objectsByIDs returns the objects for the IDs, sorted by ID
+func (c *IndexedCollection) objectsByIDs(ids []string) []DataObjectInterface {
+	sort.Strings(ids)
+
+	result := make([]DataObjectInterface, 0, len(ids))
+	for _, id := range ids {
+		result = append(result, c.objects[id])
+	}
+
+	return result
+}
@@ -0,0 +1,114 @@
+package dataobject
+
+import (
+	"testing"
+)
+
+func TestIndexedCollectionFindBy(t *testing.T) {
+	collection := NewIndexedCollection("status")
+
+	user1 := NewDataObject()
+	user1.Set("status", "active")
+	user1.Set("first_name", "Jon")
+	collection.Add(user1)
+
+	user2 := NewDataObject()
+	user2.Set("status", "inactive")
+	user2.Set("first_name", "Jane")
+	collection.Add(user2)
+
+	active := collection.FindBy("status", "active")
+
+	if len(active) != 1 {
+		t.Fatal("Expected: 1, but found:", len(active))
+	}
+
+	if active[0].ID() != user1.ID() {
+		t.Error("Expected:", user1.ID(), "but found:", active[0].ID())
+	}
+
+	// non-indexed key falls back to a full scan
+	janes := collection.FindBy("first_name", "Jane")
+
+	if len(janes) != 1 {
+		t.Fatal("Expected: 1, but found:", len(janes))
+	}
+
+	if janes[0].ID() != user2.ID() {
+		t.Error("Expected:", user2.ID(), "but found:", janes[0].ID())
+	}
+}
+
+func TestIndexedCollectionUpdate(t *testing.T) {
+	collection := NewIndexedCollection("status")
+
+	user := NewDataObject()
+	user.Set("status", "active")
+	collection.Add(user)
+	user.MarkAsNotDirty()
+
+	user.Set("status", "inactive")
+	collection.Update(user)
+
+	if len(collection.FindBy("status", "active")) != 0 {
+		t.Error("Expected: 0, but found:", len(collection.FindBy("status", "active")))
+	}
+
+	if len(collection.FindBy("status", "inactive")) != 1 {
+		t.Error("Expected: 1, but found:", len(collection.FindBy("status", "inactive")))
+	}
+
+	// re-hydrated objects are fully re-indexed
+	user.Hydrate(map[string]string{"id": user.ID(), "status": "banned"})
+	user.MarkAsNotDirty()
+	collection.Update(user)
+
+	if len(collection.FindBy("status", "banned")) != 1 {
+		t.Error("Expected: 1, but found:", len(collection.FindBy("status", "banned")))
+	}
+
+	if len(collection.FindBy("status", "inactive")) != 0 {
+		t.Error("Expected: 0, but found:", len(collection.FindBy("status", "inactive")))
+	}
+}
+
+func TestIndexedCollectionUpdateRemovedKey(t *testing.T) {
+	collection := NewIndexedCollection("status", "role")
+
+	user := NewDataObjectFromExistingData(map[string]string{"id": "1", "status": "active", "role": "admin"})
+	collection.Add(user)
+
+	user.Remove("status")
+	user.Set("name", "Jon")
+	collection.Update(user)
+
+	if len(collection.FindBy("status", "active")) != 0 {
+		t.Error("Expected: 0, but found:", len(collection.FindBy("status", "active")))
+	}
+
+	if len(collection.FindBy("role", "admin")) != 1 {
+		t.Error("Expected: 1, but found:", len(collection.FindBy("role", "admin")))
+	}
+}
+
+func TestIndexedCollectionRemove(t *testing.T) {
+	collection := NewIndexedCollection("status")
+
+	user := NewDataObject()
+	user.Set("status", "active")
+	collection.Add(user)
+
+	collection.Remove(user.ID())
+
+	if collection.Len() != 0 {
+		t.Error("Expected: 0, but found:", collection.Len())
+	}
+
+	if collection.Get(user.ID()) != nil {
+		t.Error("Expected: nil, but found:", collection.Get(user.ID()))
+	}
+
+	if len(collection.FindBy("status", "active")) != 0 {
+		t.Error("Expected: 0, but found:", len(collection.FindBy("status", "active")))
+	}
+}
@@ -0,0 +1,94 @@
+package dataobject
+
+import "sync"
+
+// InternerDefaultMaxLength is the default maximum length of the interned values
+const InternerDefaultMaxLength = 64
+
+// InternerDefaultMaxEntries is the default maximum number of interned strings
+const InternerDefaultMaxEntries = 100_000
+
+// Interner deduplicates strings, so the common keys (i.e. "status")
+// and the repeated small values (i.e. "active") of many objects share
+// memory, cutting the heap usage of large caches. It is safe for
+// concurrent use
+//
+// Keys are always interned, values only up to the maximum length.
+// Once the maximum number of entries is reached, new strings are
+// returned as they are
+//
+// Example:
+//
+//	repo := NewMemoryRepository().WithInterner(NewInterner())
+type Interner struct {
+	mu         sync.RWMutex
+	strings    map[string]string
+	maxLength  int
+	maxEntries int
+}
+
+// NewInterner creates a new interner
+func NewInterner() *Interner {
+	return &Interner{
+		strings:    map[string]string{},
+		maxLength:  InternerDefaultMaxLength,
+		maxEntries: InternerDefaultMaxEntries,
+	}
+}
+
+// SetMaxLength sets the maximum length of the interned values
+func (i *Interner) SetMaxLength(maxLength int) *Interner {
+	i.maxLength = maxLength
+	return i
+}
+
+// SetMaxEntries sets the maximum number of interned strings
+func (i *Interner) SetMaxEntries(maxEntries int) *Interner {
+	i.maxEntries = maxEntries
+	return i
+}
+
+// Len returns the number of interned strings
+func (i *Interner) Len() int {
+	i.mu.RLock()
+	defer i.mu.RUnlock()
+	return len(i.strings)
+}
+
+// Intern returns the shared copy of the string
+func (i *Interner) Intern(s string) string {
+	i.mu.RLock()
+	interned, exists := i.strings[s]
+	i.mu.RUnlock()
+
+	if exists {
+		return interned
+	}
+
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	if interned, exists := i.strings[s]; exists {
+		return interned
+	}
+
+	if len(i.strings) >= i.maxEntries {
+		return s
+	}
+
+	i.strings[s] = s
+	return s
+}
+
+// InternData returns a copy of the data with the keys
+// and the values up to the maximum length interned
+func (i *Interner) InternData(data map[string]string) map[string]string {
+	interned := make(map[string]string, len(data))
+	for key, value := range data {
+		if len(value) <= i.maxLength {
+			value = i.Intern(value)
+		}
+		interned[i.Intern(key)] = value
+	}
+	return interned
+}
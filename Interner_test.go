@@ -0,0 +1,58 @@
+package dataobject
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"unsafe"
+)
+
+// sameString returns if the strings share memory
+func sameString(a string, b string) bool {
+	return unsafe.StringData(a) == unsafe.StringData(b)
+}
+
+func TestInterner(t *testing.T) {
+	interner := NewInterner().SetMaxLength(10)
+
+	first := interner.InternData(map[string]string{"status": strings.Clone("active"), "bio": strings.Repeat("x", 11)})
+	second := interner.InternData(map[string]string{"status": strings.Clone("active"), "bio": strings.Repeat("x", 11)})
+
+	if !sameString(first["status"], second["status"]) {
+		t.Error("Expected: the values to be interned")
+	}
+
+	if sameString(first["bio"], second["bio"]) {
+		t.Error("Expected: the long values not to be interned")
+	}
+
+	if interner.Len() != 3 {
+		t.Error("Expected: 3, but found:", interner.Len())
+	}
+}
+
+func TestInternerMaxEntries(t *testing.T) {
+	interner := NewInterner().SetMaxEntries(1)
+
+	interner.Intern("a")
+	b := strings.Clone("b")
+
+	if !sameString(interner.Intern(b), b) || interner.Len() != 1 {
+		t.Error("Expected: no more entries, but found:", interner.Len())
+	}
+}
+
+func TestMemoryRepositoryWithInterner(t *testing.T) {
+	ctx := context.Background()
+	repo := NewMemoryRepository().WithInterner(NewInterner())
+
+	_ = repo.Create(ctx, NewDataObjectFromExistingData(map[string]string{"id": "1", "status": strings.Clone("active")}))
+	_ = repo.Create(ctx, NewDataObjectFromExistingData(map[string]string{"id": "2", "status": strings.Clone("active")}))
+
+	first := repo.collection.Get("1").Data()["status"]
+	second := repo.collection.Get("2").Data()["status"]
+
+	if !sameString(first, second) {
+		t.Error("Expected: the stored values to be interned")
+	}
+}
@@ -0,0 +1,20 @@
+package dataobject
+
+import "encoding/json"
+
+// IsValidObjectJSON returns if the string is a JSON object with an "id" key,
+// i.e. a serialized data object (see ToJSON). Whitespace around the
+// object is allowed, and a "id" text in the values is not mistaken for a key
+func IsValidObjectJSON(jsonString string) bool {
+	if !json.Valid([]byte(jsonString)) {
+		return false
+	}
+
+	var object map[string]json.RawMessage
+	if err := json.Unmarshal([]byte(jsonString), &object); err != nil || object == nil {
+		return false
+	}
+
+	_, hasID := object["id"]
+	return hasID
+}
@@ -0,0 +1,23 @@
+package dataobject
+
+import "testing"
+
+func TestIsValidObjectJSON(t *testing.T) {
+	tests := map[string]bool{
+		`{"id":"1","name":"Jon"}`:     true,
+		" \n\t{\"id\":\"1\"}\n":       true,
+		`{"id":1}`:                    true,
+		`{"name":"\"id\""}`:           false,
+		`{"name":"Jon","\"id\"":"1"}`: false,
+		`{"id":"1"`:                   false,
+		`["id"]`:                      false,
+		`null`:                        false,
+		``:                            false,
+	}
+
+	for jsonString, expected := range tests {
+		if IsValidObjectJSON(jsonString) != expected {
+			t.Error("Expected:", expected, "for", jsonString, "but found:", !expected)
+		}
+	}
+}
@@ -0,0 +1,494 @@
+package dataobject
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+// Journal operations
+const (
+	JournalOpCreate = "create"
+	JournalOpUpdate = "update"
+	JournalOpDelete = "delete"
+
+	// JournalOpForceDelete deletes a soft deleted object permanently
+	JournalOpForceDelete = "force_delete"
+
+	// JournalOpRestore restores a soft deleted object
+	JournalOpRestore = "restore"
+)
+
+// JournalEntry is a single change recorded in a journal. An update
+// entry either has the full data of the object, replacing the stored
+// data on replay, or the changed and removed keys, patching it
+type JournalEntry struct {
+	Op      string            `json:"op"`
+	ID      string            `json:"id"`
+	Data    map[string]string `json:"data,omitempty"`
+	Changed map[string]string `json:"changed,omitempty"`
+	Removed []string          `json:"removed,omitempty"`
+}
+
+// journalPurpose binds the encrypted journal entries to the journal
+const journalPurpose = "journal"
+
+// Journal is an append-only log file of changes (a write-ahead log),
+// one JSON entry per line, which can be replayed to reconstruct state
+type Journal struct {
+	mu   sync.Mutex
+	path string
+	file *os.File
+	keys KeyProvider
+}
+
+// OpenJournal opens the journal file, creating it if it does not exist.
+// An incomplete last entry (i.e. after a crash while appending) is truncated
+func OpenJournal(path string) (*Journal, error) {
+	if err := truncateIncompleteEntry(path); err != nil {
+		return nil, err
+	}
+
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0600)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Journal{path: path, file: file}, nil
+}
+
+// truncateIncompleteEntry truncates the file after its last newline,
+// so new entries are not appended to an incomplete one
+func truncateIncompleteEntry(path string) error {
+	content, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	if len(content) < 1 || content[len(content)-1] == '\n' {
+		return nil
+	}
+
+	return os.Truncate(path, int64(bytes.LastIndexByte(content, '\n')+1))
+}
+
+// WithEncryption encrypts the entries appended from now on with AES-GCM,
+// using the current key of the provider, one base64 encoded entry per line.
+// Compact re-encrypts all the entries with the current key, i.e. after
+// a key rotation
+//
+// Replaying entries, which are not encrypted, fails with ErrNotEncrypted,
+// so a journal cannot be replaced with forged plaintext. To encrypt an
+// existing journal, load it before enabling the encryption and compact it
+func (j *Journal) WithEncryption(keys KeyProvider) *Journal {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	j.keys = keys
+	return j
+}
+
+// Append appends the entry to the journal and syncs it to disk
+func (j *Journal) Append(entry JournalEntry) error {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	line, err := j.encodeEntry(entry)
+	if err != nil {
+		return err
+	}
+
+	if _, err := j.file.Write(append(line, '\n')); err != nil {
+		return err
+	}
+
+	return j.file.Sync()
+}
+
+// Replay calls fn for each entry of the journal in order. An incomplete
+// last entry (i.e. after a crash while appending) is ignored
+func (j *Journal) Replay(fn func(entry JournalEntry) error) error {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	file, err := os.Open(j.path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	reader := bufio.NewReader(file)
+
+	for n := 1; ; n++ {
+		line, err := reader.ReadBytes('\n')
+		if errors.Is(err, io.EOF) {
+			// a line without a newline has not been fully appended
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		line = bytes.TrimSpace(line)
+		if len(line) < 1 {
+			continue
+		}
+
+		entry, err := j.decodeEntry(line)
+		if err != nil {
+			return fmt.Errorf("dataobject: invalid journal entry %d: %w", n, err)
+		}
+
+		if err := fn(entry); err != nil {
+			return err
+		}
+	}
+}
+
+// Compact atomically replaces the journal with a create entry per object,
+// discarding the history of the changes
+func (j *Journal) Compact(objects []DataObjectInterface) (err error) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	file, err := os.CreateTemp(filepath.Dir(j.path), filepath.Base(j.path)+".*.tmp")
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		if err != nil {
+			_ = file.Close()
+			_ = os.Remove(file.Name())
+		}
+	}()
+
+	writer := bufio.NewWriter(file)
+	for _, do := range objects {
+		line, err := j.encodeEntry(JournalEntry{Op: JournalOpCreate, ID: do.ID(), Changed: do.Data()})
+		if err != nil {
+			return err
+		}
+		if _, err := writer.Write(append(line, '\n')); err != nil {
+			return err
+		}
+	}
+
+	if err = writer.Flush(); err != nil {
+		return err
+	}
+
+	if err = file.Sync(); err != nil {
+		return err
+	}
+
+	if err = os.Rename(file.Name(), j.path); err != nil {
+		return err
+	}
+
+	_ = j.file.Close()
+	j.file = file
+
+	return nil
+}
+
+// encodeEntry returns the line of the entry as JSON,
+// or base64 encoded and encrypted if encrypting
+func (j *Journal) encodeEntry(entry JournalEntry) ([]byte, error) {
+	line, err := json.Marshal(entry)
+	if err != nil || j.keys == nil {
+		return line, err
+	}
+
+	blob, err := encryptBlob(j.keys, line, journalPurpose)
+	if err != nil {
+		return nil, err
+	}
+
+	return base64.StdEncoding.AppendEncode(nil, blob), nil
+}
+
+// decodeEntry decodes the line written by encodeEntry. Lines, which are
+// not JSON objects, are decrypted. JSON objects are rejected if encrypting
+func (j *Journal) decodeEntry(line []byte) (JournalEntry, error) {
+	if line[0] == '{' && j.keys != nil {
+		return JournalEntry{}, ErrNotEncrypted
+	}
+
+	if line[0] != '{' {
+		blob, err := base64.StdEncoding.AppendDecode(nil, line)
+		if err != nil {
+			return JournalEntry{}, err
+		}
+
+		line, err = decryptBlob(j.keys, blob, journalPurpose)
+		if err != nil {
+			return JournalEntry{}, err
+		}
+	}
+
+	entry := JournalEntry{}
+	err := json.Unmarshal(line, &entry)
+	return entry, err
+}
+
+// Close closes the journal file
+func (j *Journal) Close() error {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	return j.file.Close()
+}
+
+var _ DataObjectRepositoryInterface = (*JournaledRepository)(nil) // verify it extends the repository interface
+var _ ConditionalUpdater = (*JournaledRepository)(nil)            // verify it journals conditional updates
+var _ Querier = (*JournaledRepository)(nil)                       // verify it forwards queries
+
+// JournaledRepository decorates a repository recording every change
+// in a journal, i.e. to make a MemoryRepository durable
+//
+// Example:
+//
+//	journal, err := OpenJournal("users.journal")
+//	repo := NewJournaledRepository(NewMemoryRepository(), journal)
+//	err = repo.Load(ctx) // replays the journal into the memory repository
+type JournaledRepository struct {
+	DataObjectRepositoryInterface
+	journal *Journal
+
+	// mu serializes the writes and the compaction, so the journal
+	// records the changes in the order they are applied
+	mu sync.Mutex
+}
+
+// NewJournaledRepository creates a new journaled decorator of the repository
+func NewJournaledRepository(inner DataObjectRepositoryInterface, journal *Journal) *JournaledRepository {
+	return &JournaledRepository{DataObjectRepositoryInterface: inner, journal: journal}
+}
+
+// Load replays the journal into the decorated repository
+func (repo *JournaledRepository) Load(ctx context.Context) error {
+	return repo.journal.Replay(func(entry JournalEntry) error {
+		switch entry.Op {
+		case JournalOpCreate:
+			return repo.DataObjectRepositoryInterface.Create(ctx, NewDataObjectFromExistingData(entry.Changed))
+		case JournalOpUpdate:
+			if entry.Data != nil {
+				return repo.DataObjectRepositoryInterface.Update(ctx, NewDataObjectFromExistingData(entry.Data))
+			}
+			found, err := repo.DataObjectRepositoryInterface.Find(ctx, entry.ID)
+			if err != nil {
+				return err
+			}
+			data := copyData(found.Data())
+			for key, value := range entry.Changed {
+				data[key] = value
+			}
+			for _, key := range entry.Removed {
+				delete(data, key)
+			}
+			return repo.DataObjectRepositoryInterface.Update(ctx, NewDataObjectFromExistingData(data))
+		case JournalOpDelete:
+			return repo.DataObjectRepositoryInterface.Delete(ctx, entry.ID)
+		case JournalOpForceDelete:
+			return forceDelete(ctx, repo.DataObjectRepositoryInterface, entry.ID)
+		case JournalOpRestore:
+			return forwardRestore(ctx, repo.DataObjectRepositoryInterface, entry.ID)
+		}
+		return fmt.Errorf("dataobject: invalid journal operation: %s", entry.Op)
+	})
+}
+
+// Compact replaces the journal with the current objects of the
+// repository, including the soft deleted objects, if the decorated
+// repository is a Querier, so they can still be restored after a reload
+func (repo *JournaledRepository) Compact(ctx context.Context) error {
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+
+	objects, err := repo.all(ctx)
+	if err != nil {
+		return err
+	}
+
+	return repo.journal.Compact(objects)
+}
+
+// all returns all the objects of the decorated repository,
+// with the soft deleted objects if it supports queries
+func (repo *JournaledRepository) all(ctx context.Context) ([]DataObjectInterface, error) {
+	return listWithDeleted(ctx, repo.DataObjectRepositoryInterface)
+}
+
+// Create stores the object and records it in the journal. If the entry
+// cannot be appended, the object is deleted again, so the repository
+// does not hold a change, which would be lost on restart
+func (repo *JournaledRepository) Create(ctx context.Context, do DataObjectInterface) error {
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+
+	if err := repo.DataObjectRepositoryInterface.Create(ctx, do); err != nil {
+		return err
+	}
+
+	err := repo.journal.Append(JournalEntry{Op: JournalOpCreate, ID: do.ID(), Changed: copyData(do.Data())})
+	if err != nil {
+		return rollback(err, func() error {
+			return forceDelete(ctx, repo.DataObjectRepositoryInterface, do.ID())
+		})
+	}
+
+	return nil
+}
+
+// Update stores the object and records its full data in the journal,
+// as the decorated repository replaces the stored data. If the entry
+// cannot be appended, the previous data is stored again
+func (repo *JournaledRepository) Update(ctx context.Context, do DataObjectInterface) error {
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+
+	previous, err := repo.DataObjectRepositoryInterface.Find(ctx, do.ID())
+	if err != nil {
+		return err
+	}
+
+	if err := repo.DataObjectRepositoryInterface.Update(ctx, do); err != nil {
+		return err
+	}
+
+	err = repo.journal.Append(JournalEntry{Op: JournalOpUpdate, ID: do.ID(), Data: copyData(do.Data())})
+	if err != nil {
+		return rollback(err, func() error {
+			return repo.DataObjectRepositoryInterface.Update(ctx, previous)
+		})
+	}
+
+	return nil
+}
+
+// Delete deletes the object and records it in the journal. If the entry
+// cannot be appended, the object is restored again
+func (repo *JournaledRepository) Delete(ctx context.Context, id string) error {
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+
+	previous, err := repo.DataObjectRepositoryInterface.Find(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	if err := repo.DataObjectRepositoryInterface.Delete(ctx, id); err != nil {
+		return err
+	}
+
+	err = repo.journal.Append(JournalEntry{Op: JournalOpDelete, ID: id})
+	if err != nil {
+		return rollback(err, func() error {
+			// soft deleted objects are restored, others created again
+			if forwardRestore(ctx, repo.DataObjectRepositoryInterface, id) == nil {
+				return nil
+			}
+			return repo.DataObjectRepositoryInterface.Create(ctx, previous)
+		})
+	}
+
+	return nil
+}
+
+// UpdateIf stores the object, if it matches the entity tag, and records
+// its full data in the journal. If the entry cannot be appended, the
+// previous data is stored again
+func (repo *JournaledRepository) UpdateIf(ctx context.Context, do DataObjectInterface, expectedETag string) error {
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+
+	previous, err := repo.DataObjectRepositoryInterface.Find(ctx, do.ID())
+	if err != nil {
+		return err
+	}
+
+	if err := forwardUpdateIf(ctx, repo.DataObjectRepositoryInterface, do, expectedETag); err != nil {
+		return err
+	}
+
+	err = repo.journal.Append(JournalEntry{Op: JournalOpUpdate, ID: do.ID(), Data: copyData(do.Data())})
+	if err != nil {
+		return rollback(err, func() error {
+			return repo.DataObjectRepositoryInterface.Update(ctx, previous)
+		})
+	}
+
+	return nil
+}
+
+// ForceDelete deletes the object permanently, even if it is soft
+// deleted, and records it in the journal. If the entry cannot be
+// appended, the object is created again
+func (repo *JournaledRepository) ForceDelete(ctx context.Context, id string) error {
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+
+	previous, err := findWithDeleted(ctx, repo.DataObjectRepositoryInterface, id)
+	if err != nil {
+		return err
+	}
+
+	if err := forwardForceDelete(ctx, repo.DataObjectRepositoryInterface, id); err != nil {
+		return err
+	}
+
+	err = repo.journal.Append(JournalEntry{Op: JournalOpForceDelete, ID: id})
+	if err != nil {
+		return rollback(err, func() error {
+			return repo.DataObjectRepositoryInterface.Create(ctx, previous)
+		})
+	}
+
+	return nil
+}
+
+// Restore restores the soft deleted object and records it in the
+// journal. If the entry cannot be appended, the object is deleted again
+func (repo *JournaledRepository) Restore(ctx context.Context, id string) error {
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+
+	if err := forwardRestore(ctx, repo.DataObjectRepositoryInterface, id); err != nil {
+		return err
+	}
+
+	err := repo.journal.Append(JournalEntry{Op: JournalOpRestore, ID: id})
+	if err != nil {
+		return rollback(err, func() error {
+			return repo.DataObjectRepositoryInterface.Delete(ctx, id)
+		})
+	}
+
+	return nil
+}
+
+// Query returns the objects matching the query
+func (repo *JournaledRepository) Query(ctx context.Context, query *Query) ([]DataObjectInterface, error) {
+	return forwardQuery(ctx, repo.DataObjectRepositoryInterface, query)
+}
+
+// rollback undoes a write, which failed with the error after it was
+// applied, returns the error with the error of the undo, if it fails
+func rollback(err error, undo func() error) error {
+	if undoErr := undo(); undoErr != nil {
+		return errors.Join(err, fmt.Errorf("dataobject: rollback failed: %w", undoErr))
+	}
+	return err
+}
@@ -0,0 +1,256 @@
+package dataobject
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strconv"
+	"sync"
+	"testing"
+)
+
+func TestJournaledRepository(t *testing.T) {
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "users.journal")
+
+	journal, err := OpenJournal(path)
+
+	if err != nil {
+		t.Fatal("Error must be nil, but found:", err.Error())
+	}
+
+	repo := NewJournaledRepository(NewMemoryRepository(), journal)
+
+	_ = repo.Create(ctx, NewDataObjectFromExistingData(map[string]string{"id": "1", "name": "Jon", "tmp": "x"}))
+	_ = repo.Create(ctx, NewDataObjectFromExistingData(map[string]string{"id": "2", "name": "Jane"}))
+
+	user := NewDataObjectFromExistingData(map[string]string{"id": "1", "name": "Jon", "tmp": "x"})
+	user.Set("name", "Joe")
+	user.Remove("tmp")
+	_ = repo.Update(ctx, user)
+
+	_ = repo.Delete(ctx, "2")
+	_ = journal.Close()
+
+	// simulate a crash while appending
+	file, _ := os.OpenFile(path, os.O_APPEND|os.O_WRONLY, 0600)
+	_, _ = file.WriteString(`{"op":"delete","id":"1"`)
+	_ = file.Close()
+
+	journal, _ = OpenJournal(path)
+	_ = journal.Append(JournalEntry{Op: JournalOpUpdate, ID: "1", Changed: map[string]string{"name": "Joe"}})
+	restored := NewJournaledRepository(NewMemoryRepository(), journal)
+
+	if err := restored.Load(ctx); err != nil {
+		t.Fatal("Error must be nil, but found:", err.Error())
+	}
+
+	if count, _ := restored.Count(ctx); count != 1 {
+		t.Error("Expected: 1, but found:", count)
+	}
+
+	found, err := restored.Find(ctx, "1")
+
+	if err != nil {
+		t.Fatal("Error must be nil, but found:", err.Error())
+	}
+
+	if found.Data()["name"] != "Joe" {
+		t.Error("Expected: Joe, but found:", found.Data()["name"])
+	}
+
+	if _, exists := found.Data()["tmp"]; exists {
+		t.Error("Expected tmp to be removed, but found:", found.Data())
+	}
+
+	if err := restored.Compact(ctx); err != nil {
+		t.Fatal("Error must be nil, but found:", err.Error())
+	}
+
+	_ = restored.Create(ctx, NewDataObjectFromExistingData(map[string]string{"id": "3"}))
+	_ = journal.Close()
+
+	journal, _ = OpenJournal(path)
+	compacted := NewJournaledRepository(NewMemoryRepository(), journal)
+	defer journal.Close()
+
+	entries := 0
+	_ = journal.Replay(func(entry JournalEntry) error {
+		entries++
+		return nil
+	})
+
+	if entries != 2 {
+		t.Error("Expected: 2, but found:", entries)
+	}
+
+	if err := compacted.Load(ctx); err != nil {
+		t.Fatal("Error must be nil, but found:", err.Error())
+	}
+
+	if count, _ := compacted.Count(ctx); count != 2 {
+		t.Error("Expected: 2, but found:", count)
+	}
+}
+
+func TestJournaledRepositoryReplaysFullUpdates(t *testing.T) {
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "users.journal")
+
+	journal, _ := OpenJournal(path)
+	repo := NewJournaledRepository(NewMemoryRepository(), journal)
+
+	_ = repo.Create(ctx, NewDataObjectFromExistingData(map[string]string{"id": "1", "v": "a"}))
+
+	// not marked as changed, but stored as the memory repository replaces the data
+	_ = repo.Update(ctx, NewDataObjectFromExistingData(map[string]string{"id": "1", "v": "b"}))
+	_ = journal.Close()
+
+	journal, _ = OpenJournal(path)
+	defer journal.Close()
+
+	restored := NewJournaledRepository(NewMemoryRepository(), journal)
+	if err := restored.Load(ctx); err != nil {
+		t.Fatal("Error must be nil, but found:", err.Error())
+	}
+
+	found, err := restored.Find(ctx, "1")
+	if err != nil || found.Data()["v"] != "b" {
+		t.Error("Expected: b as live, but found:", found, err)
+	}
+}
+
+func TestJournaledRepositoryCompactKeepsConcurrentWrites(t *testing.T) {
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "users.journal")
+
+	journal, _ := OpenJournal(path)
+	repo := NewJournaledRepository(NewMemoryRepository(), journal)
+
+	wg := sync.WaitGroup{}
+	for i := 0; i < 50; i++ {
+		wg.Add(2)
+		go func(i int) {
+			defer wg.Done()
+			_ = repo.Create(ctx, NewDataObjectFromExistingData(map[string]string{"id": strconv.Itoa(i)}))
+		}(i)
+		go func() {
+			defer wg.Done()
+			_ = repo.Compact(ctx)
+		}()
+	}
+	wg.Wait()
+	_ = journal.Close()
+
+	journal, _ = OpenJournal(path)
+	defer journal.Close()
+
+	restored := NewJournaledRepository(NewMemoryRepository(), journal)
+	if err := restored.Load(ctx); err != nil {
+		t.Fatal("Error must be nil, but found:", err.Error())
+	}
+
+	if count, _ := restored.Count(ctx); count != 50 {
+		t.Error("Expected: 50, but found:", count)
+	}
+}
+
+func TestJournaledRepositoryCompactKeepsSoftDeleted(t *testing.T) {
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "users.journal")
+
+	journal, _ := OpenJournal(path)
+	repo := NewJournaledRepository(NewMemoryRepository().WithSoftDelete(), journal)
+
+	_ = repo.Create(ctx, NewDataObjectFromExistingData(map[string]string{"id": "1"}))
+	_ = repo.Delete(ctx, "1")
+
+	if err := repo.Compact(ctx); err != nil {
+		t.Fatal("Error must be nil, but found:", err.Error())
+	}
+	_ = journal.Close()
+
+	journal, _ = OpenJournal(path)
+	defer journal.Close()
+
+	memory := NewMemoryRepository().WithSoftDelete()
+	if err := NewJournaledRepository(memory, journal).Load(ctx); err != nil {
+		t.Fatal("Error must be nil, but found:", err.Error())
+	}
+
+	if err := memory.Restore(ctx, "1"); err != nil {
+		t.Error("Error must be nil, but found:", err.Error())
+	}
+}
+
+func TestJournaledRepositoryReplaysTrash(t *testing.T) {
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "users.journal")
+
+	journal, _ := OpenJournal(path)
+	repo := NewJournaledRepository(NewMemoryRepository().WithSoftDelete(), journal)
+
+	_ = repo.Create(ctx, NewDataObjectFromExistingData(map[string]string{"id": "1"}))
+	_ = repo.Create(ctx, NewDataObjectFromExistingData(map[string]string{"id": "2"}))
+	_ = repo.Delete(ctx, "1")
+	_ = repo.Delete(ctx, "2")
+
+	if err := repo.Restore(ctx, "1"); err != nil {
+		t.Fatal("Error must be nil, but found:", err.Error())
+	}
+
+	if err := repo.ForceDelete(ctx, "2"); err != nil {
+		t.Fatal("Error must be nil, but found:", err.Error())
+	}
+	_ = journal.Close()
+
+	journal, _ = OpenJournal(path)
+	defer journal.Close()
+
+	memory := NewMemoryRepository().WithSoftDelete()
+	if err := NewJournaledRepository(memory, journal).Load(ctx); err != nil {
+		t.Fatal("Error must be nil, but found:", err.Error())
+	}
+
+	all, _ := memory.Query(ctx, NewQuery().WithDeleted())
+	if len(all) != 1 || all[0].ID() != "1" || IsSoftDeleted(all[0]) {
+		t.Error("Expected: only 1, restored, but found:", all)
+	}
+}
+
+func TestJournaledRepositoryRollsBackFailedAppends(t *testing.T) {
+	ctx := context.Background()
+
+	journal, _ := OpenJournal(filepath.Join(t.TempDir(), "users.journal"))
+	memory := NewMemoryRepository()
+	repo := NewJournaledRepository(memory, journal)
+
+	_ = repo.Create(ctx, NewDataObjectFromExistingData(map[string]string{"id": "1", "name": "Jon"}))
+	_ = journal.Close()
+
+	if err := repo.Create(ctx, NewDataObjectFromExistingData(map[string]string{"id": "2"})); err == nil {
+		t.Error("Error must NOT be nil, but found:", err)
+	}
+
+	if _, err := memory.Find(ctx, "2"); !IsNotFound(err) {
+		t.Error("Expected: the create to be rolled back, but found:", err)
+	}
+
+	user := NewDataObjectFromExistingData(map[string]string{"id": "1", "name": "Jon"})
+	user.Set("name", "Jane")
+	if err := repo.Update(ctx, user); err == nil {
+		t.Error("Error must NOT be nil, but found:", err)
+	}
+
+	if found, _ := memory.Find(ctx, "1"); found.Data()["name"] != "Jon" {
+		t.Error("Expected: the update to be rolled back, but found:", found.Data())
+	}
+
+	if err := repo.Delete(ctx, "1"); err == nil {
+		t.Error("Error must NOT be nil, but found:", err)
+	}
+
+	if _, err := memory.Find(ctx, "1"); err != nil {
+		t.Error("Expected: the delete to be rolled back, but found:", err)
+	}
+}
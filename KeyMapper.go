@@ -0,0 +1,89 @@
+package dataobject
+
+import (
+	"strings"
+	"unicode"
+)
+
+// KeyMapper maps a key to the key used by an external system
+type KeyMapper func(key string) string
+
+// SnakeToCamel maps snake_case keys to camelCase keys (i.e. "first_name" to "firstName")
+func SnakeToCamel(key string) string {
+	parts := strings.Split(key, "_")
+	result := strings.Builder{}
+
+	for i, part := range parts {
+		if part == "" {
+			continue
+		}
+		if i == 0 || result.Len() == 0 {
+			result.WriteString(part)
+			continue
+		}
+		runes := []rune(part)
+		result.WriteRune(unicode.ToUpper(runes[0]))
+		result.WriteString(string(runes[1:]))
+	}
+
+	return result.String()
+}
+
+// CamelToSnake maps camelCase keys to snake_case keys (i.e. "firstName"
+// to "first_name"). Acronyms are kept together ("userID" to "user_id")
+func CamelToSnake(key string) string {
+	runes := []rune(key)
+	result := strings.Builder{}
+
+	for i, r := range runes {
+		if unicode.IsUpper(r) {
+			previousLower := i > 0 && !unicode.IsUpper(runes[i-1]) && runes[i-1] != '_'
+			acronymEnd := i > 0 && unicode.IsUpper(runes[i-1]) && i+1 < len(runes) && unicode.IsLower(runes[i+1])
+			if previousLower || acronymEnd {
+				result.WriteRune('_')
+			}
+			r = unicode.ToLower(r)
+		}
+		result.WriteRune(r)
+	}
+
+	return result.String()
+}
+
+// ToJSONMapped converts the DataObject to a JSON string
+// with the keys mapped by the mapper
+func (do *DataObject) ToJSONMapped(mapper KeyMapper) (string, error) {
+	return mapToJSON(mapKeys(do.Data(), mapper))
+}
+
+// ToJSONCamelCase converts the DataObject to a JSON string
+// with the snake_case keys converted to camelCase
+func (do *DataObject) ToJSONCamelCase() (string, error) {
+	return do.ToJSONMapped(SnakeToCamel)
+}
+
+// NewDataObjectFromJSONMapped creates a new data object from
+// a JSON object string with the keys mapped by the mapper
+func NewDataObjectFromJSONMapped(jsonString string, mapper KeyMapper) (*DataObject, error) {
+	do, err := NewDataObjectFromJSON(jsonString)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewDataObjectFromExistingData(mapKeys(do.Data(), mapper)), nil
+}
+
+// NewDataObjectFromJSONCamelCase creates a new data object from a JSON
+// object string with the camelCase keys converted to snake_case
+func NewDataObjectFromJSONCamelCase(jsonString string) (*DataObject, error) {
+	return NewDataObjectFromJSONMapped(jsonString, CamelToSnake)
+}
+
+// mapKeys returns a copy of the data with the keys mapped by the mapper
+func mapKeys(data map[string]string, mapper KeyMapper) map[string]string {
+	result := make(map[string]string, len(data))
+	for key, value := range data {
+		result[mapper(key)] = value
+	}
+	return result
+}
@@ -0,0 +1,51 @@
+package dataobject
+
+import "testing"
+
+func TestSnakeToCamelAndBack(t *testing.T) {
+	tests := map[string]string{
+		"id":              "id",
+		"first_name":      "firstName",
+		"user_id":         "userId",
+		"created_at_time": "createdAtTime",
+	}
+
+	for snake, camel := range tests {
+		if result := SnakeToCamel(snake); result != camel {
+			t.Error("Expected:", camel, "but found:", result)
+		}
+		if result := CamelToSnake(camel); result != snake {
+			t.Error("Expected:", snake, "but found:", result)
+		}
+	}
+
+	if result := CamelToSnake("userID"); result != "user_id" {
+		t.Error("Expected: user_id, but found:", result)
+	}
+
+	if result := CamelToSnake("HTTPStatus"); result != "http_status" {
+		t.Error("Expected: http_status, but found:", result)
+	}
+}
+
+func TestJSONCamelCase(t *testing.T) {
+	do, err := NewDataObjectFromJSONCamelCase(`{"id":"1","firstName":"Jon"}`)
+
+	if err != nil {
+		t.Fatal("Error must be nil, but found:", err.Error())
+	}
+
+	if do.Get("first_name") != "Jon" {
+		t.Error("Expected: Jon, but found:", do.Get("first_name"))
+	}
+
+	jsonString, err := do.ToJSONCamelCase()
+
+	if err != nil {
+		t.Fatal("Error must be nil, but found:", err.Error())
+	}
+
+	if jsonString != `{"firstName":"Jon","id":"1"}` {
+		t.Error(`Expected: {"firstName":"Jon","id":"1"}, but found:`, jsonString)
+	}
+}
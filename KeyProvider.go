@@ -0,0 +1,316 @@
+package dataobject
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+// KeyProvider provides the secret keys, so the features encrypting or
+// signing data handle them consistently, instead of taking raw keys:
+// the encrypted stores (see Journal.WithEncryption and
+// MemoryRepository.WithSnapshotEncryption), the sealed data (see
+// ToSealedWithKeys) and the webhook signatures (see
+// WebhookEmitter.WithKeyProvider). Keys used for encryption must be
+// AES keys (16, 24 or 32 bytes)
+//
+// The keys are not used directly: a subkey is derived for each use with
+// HKDF-SHA256 and a distinct label (see EncryptionKeyLabel and
+// WebhookKeyLabel), so the same keys can encrypt and sign safely
+//
+// Built-in providers are KeyRing (see StaticKey), the environment
+// (see NewEnvKeyProvider), a file (see NewFileKeyProvider) and a
+// callback, i.e. to a key management service (see NewCallbackKeyProvider)
+//
+// Data is encrypted with the current key and tagged with its ID, so after
+// a key rotation the data encrypted with the previous keys can still be
+// decrypted, as long as the provider returns them by their IDs
+type KeyProvider interface {
+	// CurrentKey returns the ID and the key to encrypt with
+	CurrentKey() (id string, key []byte, err error)
+
+	// Key returns the key with the ID to decrypt with. If there is none,
+	// the returned error must wrap ErrUnknownKey
+	Key(id string) ([]byte, error)
+}
+
+var _ KeyProvider = (*KeyRing)(nil) // verify it provides keys
+
+// KeyRing is an in-memory key provider supporting key rotation
+//
+// Example:
+//
+//	keys := NewKeyRing("2024-01", key1)
+//	keys.Rotate("2024-07", key2) // new data is encrypted with key2
+type KeyRing struct {
+	mu      sync.RWMutex
+	keys    map[string][]byte
+	current string
+}
+
+// NewKeyRing creates a new key ring with the current key
+func NewKeyRing(id string, key []byte) *KeyRing {
+	return (&KeyRing{keys: map[string][]byte{}}).Rotate(id, key)
+}
+
+// Add adds a key, i.e. a previous key, which is
+// only used to decrypt the data encrypted with it
+func (r *KeyRing) Add(id string, key []byte) *KeyRing {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.keys[id] = bytes.Clone(key)
+	return r
+}
+
+// Rotate adds the key and makes it the current key. The previous
+// keys are kept to decrypt the data encrypted with them
+func (r *KeyRing) Rotate(id string, key []byte) *KeyRing {
+	r.Add(id, key)
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.current = id
+	return r
+}
+
+// CurrentKey returns the ID and the key to encrypt with
+func (r *KeyRing) CurrentKey() (string, []byte, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return r.current, bytes.Clone(r.keys[r.current]), nil
+}
+
+// Key returns the key with the ID, or an error wrapping ErrUnknownKey
+func (r *KeyRing) Key(id string) ([]byte, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	key, exists := r.keys[id]
+	if !exists {
+		return nil, fmt.Errorf("%w: %q", ErrUnknownKey, id)
+	}
+	return bytes.Clone(key), nil
+}
+
+// StaticKeyID is the ID of the key of a static key provider
+const StaticKeyID = "static"
+
+// StaticKey returns a key provider with the single key, which
+// can be rotated later (see KeyRing.Rotate)
+func StaticKey(key []byte) *KeyRing {
+	return NewKeyRing(StaticKeyID, key)
+}
+
+// NewEnvKeyProvider returns a key ring with the keys
+// of the environment variable (see ParseKeys)
+//
+// Example:
+//
+//	// DATAOBJECT_KEYS="2024-07:<base64 key>,2024-01:<base64 key>"
+//	keys, err := NewEnvKeyProvider("DATAOBJECT_KEYS")
+func NewEnvKeyProvider(name string) (*KeyRing, error) {
+	value, exists := os.LookupEnv(name)
+	if !exists {
+		return nil, fmt.Errorf("%w: environment variable %s is not set", ErrUnknownKey, name)
+	}
+
+	keys, err := ParseKeys(value)
+	if err != nil {
+		return nil, fmt.Errorf("dataobject: environment variable %s: %w", name, err)
+	}
+	return keys, nil
+}
+
+// NewFileKeyProvider returns a key ring with the keys
+// of the file (see ParseKeys), i.e. a mounted secret
+func NewFileKeyProvider(path string) (*KeyRing, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	keys, err := ParseKeys(string(content))
+	if err != nil {
+		return nil, fmt.Errorf("dataobject: key file %s: %w", path, err)
+	}
+	return keys, nil
+}
+
+// ParseKeys returns a key ring with the keys separated by commas or
+// new lines, each as "<id>:<base64 key>", or only "<base64 key>" for
+// a single key with StaticKeyID. The first key is the current key,
+// the others are the previous keys
+func ParseKeys(text string) (*KeyRing, error) {
+	var ring *KeyRing
+
+	entries := strings.FieldsFunc(text, func(r rune) bool {
+		return r == ',' || r == '\n' || r == '\r'
+	})
+
+	for _, entry := range entries {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+
+		id, encoded, hasID := strings.Cut(entry, ":")
+		if !hasID {
+			id, encoded = StaticKeyID, entry
+		}
+
+		key, err := base64.StdEncoding.DecodeString(strings.TrimSpace(encoded))
+		if err != nil {
+			return nil, fmt.Errorf("invalid key %q: %w", id, err)
+		}
+
+		if ring == nil {
+			ring = NewKeyRing(strings.TrimSpace(id), key)
+		} else {
+			ring.Add(strings.TrimSpace(id), key)
+		}
+	}
+
+	if ring == nil {
+		return nil, fmt.Errorf("%w: no keys", ErrUnknownKey)
+	}
+
+	return ring, nil
+}
+
+// KeyFetcher fetches the key with the ID, i.e. from a key management
+// service. If there is no key with the ID, the returned error must wrap
+// ErrUnknownKey, other errors are considered transient
+type KeyFetcher func(id string) ([]byte, error)
+
+var _ KeyProvider = (*CallbackKeyProvider)(nil) // verify it provides keys
+
+// CallbackKeyProvider provides the keys fetched with a callback,
+// i.e. from a key management service, caching them for a TTL
+//
+// Example:
+//
+//	keys := NewCallbackKeyProvider("projects/app/keys/data/1", func(id string) ([]byte, error) {
+//		return kms.Decrypt(ctx, wrappedKeys[id])
+//	})
+type CallbackKeyProvider struct {
+	fetch KeyFetcher
+	ttl   time.Duration
+
+	mu      sync.Mutex
+	current string
+	cache   map[string]cachedKey
+}
+
+// cachedKey is a fetched key
+type cachedKey struct {
+	key       []byte
+	fetchedAt time.Time
+}
+
+// NewCallbackKeyProvider creates a new key provider fetching the keys
+// with the callback, with the ID of the current key. The fetched keys
+// are cached forever, see SetCacheTTL to expire them
+func NewCallbackKeyProvider(currentID string, fetch KeyFetcher) *CallbackKeyProvider {
+	return &CallbackKeyProvider{
+		fetch:   fetch,
+		current: currentID,
+		cache:   map[string]cachedKey{},
+	}
+}
+
+// SetCacheTTL sets how long the fetched keys are cached (forever if 0)
+func (p *CallbackKeyProvider) SetCacheTTL(ttl time.Duration) *CallbackKeyProvider {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.ttl = ttl
+	return p
+}
+
+// SetCurrent sets the ID of the current key, i.e. after a key rotation
+func (p *CallbackKeyProvider) SetCurrent(id string) *CallbackKeyProvider {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.current = id
+	return p
+}
+
+// CurrentKey returns the ID and the current key
+func (p *CallbackKeyProvider) CurrentKey() (string, []byte, error) {
+	p.mu.Lock()
+	id := p.current
+	p.mu.Unlock()
+
+	key, err := p.Key(id)
+	return id, key, err
+}
+
+// Key returns the cached key with the ID, or fetches it. Returns an
+// error wrapping ErrUnknownKey if the fetcher does not know the key,
+// or ErrKeyUnavailable if it fails otherwise, so it can be retried
+func (p *CallbackKeyProvider) Key(id string) ([]byte, error) {
+	p.mu.Lock()
+	cached, exists := p.cache[id]
+	ttl := p.ttl
+	p.mu.Unlock()
+
+	if exists && (ttl == 0 || time.Since(cached.fetchedAt) < ttl) {
+		return bytes.Clone(cached.key), nil
+	}
+
+	key, err := p.fetch(id)
+	if errors.Is(err, ErrUnknownKey) {
+		return nil, err
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%w: %q: %w", ErrKeyUnavailable, id, err)
+	}
+
+	p.mu.Lock()
+	p.cache[id] = cachedKey{key: bytes.Clone(key), fetchedAt: time.Now()}
+	p.mu.Unlock()
+
+	return key, nil
+}
+
+// Labels of the subkeys derived from the keys of a provider (see
+// deriveKey), i.e. for webhook receivers not written in Go
+const (
+	// EncryptionKeyLabel is the HKDF info of the AES-GCM subkeys
+	EncryptionKeyLabel = "dataobject aes-gcm"
+
+	// WebhookKeyLabel is the HKDF info of the webhook HMAC subkeys
+	WebhookKeyLabel = "dataobject webhook hmac"
+)
+
+// deriveKey derives a subkey of the length from the key with
+// HKDF-SHA256 (RFC 5869), without a salt, and the label as the info
+func deriveKey(key []byte, label string, length int) []byte {
+	extract := hmac.New(sha256.New, make([]byte, sha256.Size))
+	extract.Write(key)
+	pseudoRandomKey := extract.Sum(nil)
+
+	derived := make([]byte, 0, length+sha256.Size)
+	block := []byte{}
+	for counter := byte(1); len(derived) < length; counter++ {
+		expand := hmac.New(sha256.New, pseudoRandomKey)
+		expand.Write(block)
+		expand.Write([]byte(label))
+		expand.Write([]byte{counter})
+		block = expand.Sum(nil)
+		derived = append(derived, block...)
+	}
+
+	return derived[:length]
+}
@@ -0,0 +1,153 @@
+package dataobject
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestStaticKey(t *testing.T) {
+	id, key, err := StaticKey(testSealKey).CurrentKey()
+	if err != nil {
+		t.Fatal("Error must be nil, but found:", err.Error())
+	}
+
+	if id != StaticKeyID || string(key) != string(testSealKey) {
+		t.Error("Expected: the static key, but found:", id, key)
+	}
+}
+
+func TestParseKeys(t *testing.T) {
+	newKey := base64.StdEncoding.EncodeToString(testSealKey)
+	oldKey := base64.StdEncoding.EncodeToString(testOldKey)
+
+	keys, err := ParseKeys("v2:" + newKey + ",\nv1:" + oldKey + "\n")
+	if err != nil {
+		t.Fatal("Error must be nil, but found:", err.Error())
+	}
+
+	if id, key, _ := keys.CurrentKey(); id != "v2" || string(key) != string(testSealKey) {
+		t.Error("Expected: the current key v2, but found:", id)
+	}
+
+	if key, err := keys.Key("v1"); err != nil || string(key) != string(testOldKey) {
+		t.Error("Expected: the previous key v1, but found:", key, err)
+	}
+
+	if keys, _ := ParseKeys(newKey); keys == nil {
+		t.Error("Expected: a single static key")
+	}
+
+	if _, err := ParseKeys("v1:not base64!"); err == nil {
+		t.Error("Error must NOT be nil for an invalid key")
+	}
+
+	if _, err := ParseKeys(" \n"); !errors.Is(err, ErrUnknownKey) {
+		t.Error("Expected: ErrUnknownKey without keys, but found:", err)
+	}
+}
+
+func TestEnvKeyProvider(t *testing.T) {
+	t.Setenv("DATAOBJECT_TEST_KEYS", "v1:"+base64.StdEncoding.EncodeToString(testSealKey))
+
+	keys, err := NewEnvKeyProvider("DATAOBJECT_TEST_KEYS")
+	if err != nil {
+		t.Fatal("Error must be nil, but found:", err.Error())
+	}
+
+	if id, _, _ := keys.CurrentKey(); id != "v1" {
+		t.Error("Expected: v1, but found:", id)
+	}
+
+	if _, err := NewEnvKeyProvider("DATAOBJECT_TEST_MISSING"); !errors.Is(err, ErrUnknownKey) {
+		t.Error("Expected: ErrUnknownKey, but found:", err)
+	}
+}
+
+func TestFileKeyProvider(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "keys")
+	_ = os.WriteFile(path, []byte(base64.StdEncoding.EncodeToString(testSealKey)+"\n"), 0600)
+
+	keys, err := NewFileKeyProvider(path)
+	if err != nil {
+		t.Fatal("Error must be nil, but found:", err.Error())
+	}
+
+	if key, _ := keys.Key(StaticKeyID); string(key) != string(testSealKey) {
+		t.Error("Expected: the key of the file, but found:", key)
+	}
+}
+
+func TestCallbackKeyProvider(t *testing.T) {
+	fetches := 0
+	keys := NewCallbackKeyProvider("v1", func(id string) ([]byte, error) {
+		fetches++
+		switch id {
+		case "v1":
+			return testSealKey, nil
+		case "v2":
+			return nil, errors.New("connection refused")
+		}
+		return nil, ErrUnknownKey
+	})
+
+	_, _, _ = keys.CurrentKey()
+	_, _ = keys.Key("v1")
+
+	if fetches != 1 {
+		t.Error("Expected: the key to be cached, but found fetches:", fetches)
+	}
+
+	if _, err := keys.Key("v9"); !errors.Is(err, ErrUnknownKey) {
+		t.Error("Expected: ErrUnknownKey, but found:", err)
+	}
+
+	if _, err := keys.Key("v2"); !errors.Is(err, ErrKeyUnavailable) || errors.Is(err, ErrUnknownKey) {
+		t.Error("Expected: ErrKeyUnavailable, but found:", err)
+	}
+
+	keys.SetCacheTTL(time.Nanosecond)
+	time.Sleep(time.Millisecond)
+	_, _ = keys.Key("v1")
+
+	if fetches != 4 {
+		t.Error("Expected: the expired key to be fetched again, but found fetches:", fetches)
+	}
+}
+
+func TestKeyRingReturnsCopies(t *testing.T) {
+	keys := NewKeyRing("v1", []byte("secret"))
+
+	_, key, _ := keys.CurrentKey()
+	key[0] = 'X'
+
+	if _, key, _ := keys.CurrentKey(); string(key) != "secret" {
+		t.Error("Expected: secret, but found:", string(key))
+	}
+
+	key, _ = keys.Key("v1")
+	key[0] = 'X'
+
+	if key, _ := keys.Key("v1"); string(key) != "secret" {
+		t.Error("Expected: secret, but found:", string(key))
+	}
+}
+
+func TestDeriveKey(t *testing.T) {
+	// RFC 5869, test case 3
+	key := bytes.Repeat([]byte{0x0b}, 22)
+	expected := "8da4e775a563c18f715f802a063c5a31b8a11f5c5ee1879ec3454e5f3c738d2d9d201395faa4b61a96c8"
+
+	if derived := hex.EncodeToString(deriveKey(key, "", 42)); derived != expected {
+		t.Error("Expected:", expected, "but found:", derived)
+	}
+
+	if bytes.Equal(deriveKey(testSealKey, EncryptionKeyLabel, 32), deriveKey(testSealKey, WebhookKeyLabel, 32)) {
+		t.Error("Expected: distinct subkeys for encryption and signing")
+	}
+}
@@ -0,0 +1,54 @@
+package dataobject
+
+import "fmt"
+
+// MaxEncodedSize is the maximum size in bytes of the JSON or gob input
+// accepted by the decoding constructors (NewDataObjectFromJSON,
+// NewDataObjectFromNestedJSON and FromGob), which often receive
+// untrusted input
+const MaxEncodedSize = 10 << 20
+
+// MaxNestingDepth is the maximum nesting depth of the arrays and objects
+// in the JSON accepted by the decoding constructors (the top level object
+// has depth 1)
+const MaxNestingDepth = 32
+
+// checkEncodedSize returns an error wrapping ErrTooLarge
+// if the input exceeds MaxEncodedSize
+func checkEncodedSize(size int) error {
+	if size > MaxEncodedSize {
+		return fmt.Errorf("%w: %d bytes, maximum %d", ErrTooLarge, size, MaxEncodedSize)
+	}
+	return nil
+}
+
+// checkNestingDepth returns an error wrapping ErrInvalidJSON
+// if the decoded JSON value is nested deeper than MaxNestingDepth
+func checkNestingDepth(value any) error {
+	if nestingDepth(value, 0) > MaxNestingDepth {
+		return fmt.Errorf("%w: nested deeper than %d", ErrInvalidJSON, MaxNestingDepth)
+	}
+	return nil
+}
+
+// nestingDepth returns the depth of the value, stopping past MaxNestingDepth
+func nestingDepth(value any, depth int) int {
+	if depth > MaxNestingDepth {
+		return depth
+	}
+
+	deepest := depth
+	switch value := value.(type) {
+	case map[string]any:
+		deepest++
+		for _, child := range value {
+			deepest = max(deepest, nestingDepth(child, depth+1))
+		}
+	case []any:
+		deepest++
+		for _, child := range value {
+			deepest = max(deepest, nestingDepth(child, depth+1))
+		}
+	}
+	return deepest
+}
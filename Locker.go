@@ -0,0 +1,92 @@
+package dataobject
+
+import (
+	"context"
+	"errors"
+	"sync"
+)
+
+// Locker serializes the work on the same object ID across workers
+// (advisory locks, which are only respected by the callers of the locker)
+type Locker interface {
+	// Lock blocks until the lock on the ID is acquired or the context
+	// is done. The returned function releases the lock
+	Lock(ctx context.Context, id string) (unlock func() error, err error)
+}
+
+// WithLock runs fn while holding the lock on the ID, releasing it
+// afterwards. The errors of fn and of releasing the lock are joined
+//
+// Example:
+//
+//	err := WithLock(ctx, locker, user.ID(), func(ctx context.Context) error {
+//		found, err := repo.Find(ctx, user.ID())
+//		...
+//		return repo.Update(ctx, found)
+//	})
+func WithLock(ctx context.Context, locker Locker, id string, fn func(ctx context.Context) error) (err error) {
+	unlock, err := locker.Lock(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		if unlockErr := unlock(); unlockErr != nil {
+			err = errors.Join(err, unlockErr)
+		}
+	}()
+
+	return fn(ctx)
+}
+
+var _ Locker = (*MemoryLocker)(nil) // verify it extends the locker interface
+
+// MemoryLocker is a locker for the workers of a single process
+type MemoryLocker struct {
+	mu sync.Mutex
+
+	// held holds a channel per locked ID, closed on unlock
+	held map[string]chan struct{}
+}
+
+// NewMemoryLocker creates a new in-memory locker
+func NewMemoryLocker() *MemoryLocker {
+	return &MemoryLocker{held: map[string]chan struct{}{}}
+}
+
+// Lock blocks until the lock on the ID is acquired or the context is done
+func (l *MemoryLocker) Lock(ctx context.Context, id string) (func() error, error) {
+	for {
+		l.mu.Lock()
+		released, locked := l.held[id]
+		if !locked {
+			released = make(chan struct{})
+			l.held[id] = released
+			l.mu.Unlock()
+			return l.unlocker(id, released), nil
+		}
+		l.mu.Unlock()
+
+		select {
+		case <-released:
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+}
+
+// unlocker returns the function releasing the lock on the ID, once
+func (l *MemoryLocker) unlocker(id string, released chan struct{}) func() error {
+	var once sync.Once
+
+	return func() error {
+		once.Do(func() {
+			l.mu.Lock()
+			defer l.mu.Unlock()
+
+			delete(l.held, id)
+			close(released)
+		})
+		return nil
+	}
+}
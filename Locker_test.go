@@ -0,0 +1,76 @@
+package dataobject
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestMemoryLocker(t *testing.T) {
+	locker := NewMemoryLocker()
+	ctx := context.Background()
+
+	counter := 0
+	wg := sync.WaitGroup{}
+
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_ = WithLock(ctx, locker, "1", func(ctx context.Context) error {
+				value := counter
+				time.Sleep(time.Millisecond)
+				counter = value + 1
+				return nil
+			})
+		}()
+	}
+
+	wg.Wait()
+
+	if counter != 10 {
+		t.Error("Expected: 10, but found:", counter)
+	}
+}
+
+func TestMemoryLockerContext(t *testing.T) {
+	locker := NewMemoryLocker()
+
+	unlock, err := locker.Lock(context.Background(), "1")
+
+	if err != nil {
+		t.Fatal("Error must be nil, but found:", err.Error())
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	if _, err := locker.Lock(ctx, "1"); !errors.Is(err, context.DeadlineExceeded) {
+		t.Error("Expected: context.DeadlineExceeded, but found:", err)
+	}
+
+	if _, err := locker.Lock(ctx, "2"); err != nil {
+		t.Error("Error must be nil, but found:", err.Error())
+	}
+
+	_ = unlock()
+	_ = unlock()
+
+	if _, err := locker.Lock(context.Background(), "1"); err != nil {
+		t.Error("Error must be nil, but found:", err.Error())
+	}
+}
+
+func TestWithLockReturnsError(t *testing.T) {
+	expected := errors.New("failed")
+
+	err := WithLock(context.Background(), NewMemoryLocker(), "1", func(ctx context.Context) error {
+		return expected
+	})
+
+	if !errors.Is(err, expected) {
+		t.Error("Expected:", expected, "but found:", err)
+	}
+}
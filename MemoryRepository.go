@@ -0,0 +1,333 @@
+package dataobject
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"sync"
+	"time"
+)
+
+var _ DataObjectRepositoryInterface = (*MemoryRepository)(nil) // verify it extends the repository interface
+var _ ConditionalUpdater = (*MemoryRepository)(nil)            // verify it supports conditional updates
+var _ Querier = (*MemoryRepository)(nil)                       // verify it supports queries
+
+// MemoryRepository is an in-memory repository of data objects,
+// backed by an IndexedCollection
+//
+// The repository stores copies of the objects, so changes to an object
+// are only visible to other callers after it has been updated
+type MemoryRepository struct {
+	// mu serializes the writes, so the existence and
+	// version checks are atomic with the writes
+	mu sync.Mutex
+
+	collection *IndexedCollection
+	logger     *slog.Logger
+	softDelete bool
+	interner   *Interner
+
+	// snapshotKeys encrypts the snapshots, if set
+	snapshotKeys KeyProvider
+
+	// tombstones holds the deletion times per ID, if tracking changes
+	tombstones map[string]time.Time
+}
+
+// NewMemoryRepository creates a new in-memory repository
+// indexed on the passed keys (see FindBy)
+func NewMemoryRepository(indexKeys ...string) *MemoryRepository {
+	return &MemoryRepository{
+		collection: NewIndexedCollection(indexKeys...),
+	}
+}
+
+// WithLogger sets the logger, which logs the operations at debug level
+// with the sensitive values redacted (see LogRedactedKeys)
+func (repo *MemoryRepository) WithLogger(logger *slog.Logger) *MemoryRepository {
+	repo.logger = logger
+	return repo
+}
+
+// WithSoftDelete enables soft delete. Delete then marks the objects
+// as soft deleted (see SoftDeletedAtKey), and the soft deleted objects
+// are excluded from Find, FindBy, List, Count and from queries
+// without an explicit scope (see Query.WithDeleted)
+//
+// Update, UpdateIf and Delete of a soft deleted object return an error
+// wrapping ErrNotFound. Its ID stays taken, so Create returns an error
+// wrapping ErrAlreadyExists, until it is restored (see Restore) or
+// deleted permanently (see ForceDelete)
+func (repo *MemoryRepository) WithSoftDelete() *MemoryRepository {
+	repo.softDelete = true
+	return repo
+}
+
+// WithInterner sets the interner deduplicating the keys and
+// the small values of the stored objects (see Interner)
+func (repo *MemoryRepository) WithInterner(interner *Interner) *MemoryRepository {
+	repo.interner = interner
+	return repo
+}
+
+// WithChangeTracking enables maintaining the updated_at key (see
+// UpdatedAtKey) on Create and Update, and recording the deleted IDs,
+// so the changes can be exported incrementally (see ExportChangedSince)
+func (repo *MemoryRepository) WithChangeTracking() *MemoryRepository {
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+
+	if repo.tombstones == nil {
+		repo.tombstones = map[string]time.Time{}
+	}
+	return repo
+}
+
+// DeletedSince returns the IDs of the objects deleted since the time,
+// with their deletion times. Only deletions made while tracking changes
+// are recorded (see WithChangeTracking)
+func (repo *MemoryRepository) DeletedSince(ctx context.Context, since time.Time) (map[string]time.Time, error) {
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+
+	deleted := map[string]time.Time{}
+	for id, deletedAt := range repo.tombstones {
+		if !deletedAt.Before(since) {
+			deleted[id] = deletedAt
+		}
+	}
+	return deleted, nil
+}
+
+// stamp returns the copy of the object to store, with updated_at
+// set if tracking changes, and interned if there is an interner
+func (repo *MemoryRepository) stamp(do DataObjectInterface) *DataObject {
+	stored := cloneDataObject(do)
+	if repo.tombstones != nil {
+		stored.SetTime(UpdatedAtKey, time.Now())
+		delete(repo.tombstones, stored.ID())
+	}
+	if repo.interner != nil {
+		stored = NewDataObjectFromExistingData(repo.interner.InternData(stored.Data()))
+	}
+	return stored
+}
+
+// Create stores a copy of the object. The existence check and the insert
+// are atomic, so of concurrent creates of an ID only one succeeds, the
+// others return ErrAlreadyExists
+func (repo *MemoryRepository) Create(ctx context.Context, do DataObjectInterface) error {
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+
+	if do.ID() == "" {
+		return ErrMissingID
+	}
+
+	if stored := repo.collection.Get(do.ID()); stored != nil {
+		if repo.softDelete && IsSoftDeleted(stored) {
+			return fmt.Errorf("%w: %s is soft deleted", ErrAlreadyExists, do.ID())
+		}
+		return fmt.Errorf("%w: %s", ErrAlreadyExists, do.ID())
+	}
+
+	repo.collection.Add(repo.stamp(do))
+
+	logDebug(repo.logger, "dataobject: create", slog.String("id", do.ID()), logPayload(do.Data()))
+
+	return nil
+}
+
+// Find returns a copy of the object with the ID, or ErrNotFound
+func (repo *MemoryRepository) Find(ctx context.Context, id string) (DataObjectInterface, error) {
+	do := repo.stored(id)
+
+	logDebug(repo.logger, "dataobject: find", slog.String("id", id), slog.Bool("found", do != nil))
+
+	if do == nil {
+		return nil, notFound(id)
+	}
+
+	return cloneDataObject(do), nil
+}
+
+// FindBy returns copies of the objects, which have the value for the key.
+// If no object matches, an empty list is returned rather than ErrNotFound
+func (repo *MemoryRepository) FindBy(ctx context.Context, key string, value string) ([]DataObjectInterface, error) {
+	return cloneDataObjects(repo.visible(repo.collection.FindBy(key, value))), nil
+}
+
+// Query returns copies of the objects matching the query (see Query)
+func (repo *MemoryRepository) Query(ctx context.Context, query *Query) ([]DataObjectInterface, error) {
+	if repo.softDelete {
+		query = query.withDefaultScope(QueryScopeWithoutDeleted)
+	}
+
+	return cloneDataObjects(query.Apply(repo.collection.All())), nil
+}
+
+// Update replaces the stored data of an existing object
+func (repo *MemoryRepository) Update(ctx context.Context, do DataObjectInterface) error {
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+
+	if repo.stored(do.ID()) == nil {
+		return notFound(do.ID())
+	}
+
+	repo.collection.Add(repo.stamp(do))
+
+	logDebug(repo.logger, "dataobject: update", slog.String("id", do.ID()), logPayload(do.DataChanged()))
+
+	return nil
+}
+
+// UpdateIf replaces the stored data of an existing object, if the stored
+// object matches the expected entity tag (see ETag), or returns an error
+// wrapping ErrVersionConflict. The expected entity tag may be a list of
+// entity tags or "*" as in an If-Match header
+func (repo *MemoryRepository) UpdateIf(ctx context.Context, do DataObjectInterface, expectedETag string) error {
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+
+	stored := repo.stored(do.ID())
+	if stored == nil {
+		return notFound(do.ID())
+	}
+
+	if !etagMatches(expectedETag, ETagOf(stored.Data())) {
+		return fmt.Errorf("%w: %s", ErrVersionConflict, do.ID())
+	}
+
+	repo.collection.Add(repo.stamp(do))
+
+	logDebug(repo.logger, "dataobject: update", slog.String("id", do.ID()), logPayload(do.DataChanged()))
+
+	return nil
+}
+
+// Delete deletes the object with the ID, or marks it
+// as soft deleted if soft delete is enabled
+func (repo *MemoryRepository) Delete(ctx context.Context, id string) error {
+	if !repo.softDelete {
+		return repo.ForceDelete(ctx, id)
+	}
+
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+
+	stored := repo.stored(id)
+	if stored == nil {
+		return notFound(id)
+	}
+
+	deleted := repo.stamp(stored)
+	deleted.SoftDelete()
+	repo.collection.Add(deleted)
+
+	logDebug(repo.logger, "dataobject: soft delete", slog.String("id", id))
+
+	return nil
+}
+
+// Restore marks the soft deleted object with the ID as not deleted,
+// or returns an error wrapping ErrNotFound, if it is not soft deleted
+func (repo *MemoryRepository) Restore(ctx context.Context, id string) error {
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+
+	stored := repo.collection.Get(id)
+	if stored == nil || !IsSoftDeleted(stored) {
+		return notFound(id)
+	}
+
+	restored := repo.stamp(stored)
+	restored.Undelete()
+	repo.collection.Add(restored)
+
+	logDebug(repo.logger, "dataobject: restore", slog.String("id", id))
+
+	return nil
+}
+
+// ForceDelete deletes the object with the ID, even if soft delete is enabled
+func (repo *MemoryRepository) ForceDelete(ctx context.Context, id string) error {
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+
+	if repo.collection.Get(id) == nil {
+		return notFound(id)
+	}
+
+	repo.collection.Remove(id)
+
+	if repo.tombstones != nil {
+		repo.tombstones[id] = time.Now()
+	}
+
+	logDebug(repo.logger, "dataobject: delete", slog.String("id", id))
+
+	return nil
+}
+
+// List returns copies of up to limit objects (all if limit is 0)
+// sorted by ID, skipping the first offset objects
+func (repo *MemoryRepository) List(ctx context.Context, offset int, limit int) ([]DataObjectInterface, error) {
+	all := repo.visible(repo.collection.All())
+
+	offset = min(max(offset, 0), len(all))
+	end := len(all)
+	if limit > 0 {
+		end = min(offset+limit, len(all))
+	}
+
+	return cloneDataObjects(all[offset:end]), nil
+}
+
+// Count returns the number of stored objects
+func (repo *MemoryRepository) Count(ctx context.Context) (int, error) {
+	if repo.softDelete {
+		return len(repo.visible(repo.collection.All())), nil
+	}
+	return repo.collection.Len(), nil
+}
+
+// stored returns the stored object with the ID, nil if there is none,
+// or if it is soft deleted and soft delete is enabled
+func (repo *MemoryRepository) stored(id string) DataObjectInterface {
+	do := repo.collection.Get(id)
+	if do == nil || (repo.softDelete && IsSoftDeleted(do)) {
+		return nil
+	}
+	return do
+}
+
+// visible returns the objects, which are not soft deleted,
+// or all the objects if soft delete is not enabled
+func (repo *MemoryRepository) visible(objects []DataObjectInterface) []DataObjectInterface {
+	if !repo.softDelete {
+		return objects
+	}
+
+	result := make([]DataObjectInterface, 0, len(objects))
+	for _, do := range objects {
+		if !IsSoftDeleted(do) {
+			result = append(result, do)
+		}
+	}
+	return result
+}
+
+// cloneDataObject returns a not dirty copy of the object data
+func cloneDataObject(do DataObjectInterface) *DataObject {
+	return NewDataObjectFromExistingData(copyData(do.Data()))
+}
+
+// cloneDataObjects returns not dirty copies of the objects
+func cloneDataObjects(objects []DataObjectInterface) []DataObjectInterface {
+	result := make([]DataObjectInterface, 0, len(objects))
+	for _, do := range objects {
+		result = append(result, cloneDataObject(do))
+	}
+	return result
+}
@@ -0,0 +1,137 @@
+package dataobject
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/gob"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+)
+
+// snapshotPurpose binds the encrypted snapshots to their use
+const snapshotPurpose = "snapshot"
+
+// WithSnapshotEncryption encrypts the snapshots with AES-GCM, using the
+// current key of the provider (see SaveSnapshot). Loading a snapshot,
+// which is not encrypted, fails with ErrNotEncrypted, so the data cannot
+// be replaced with a forged snapshot. To encrypt an existing snapshot,
+// load it before enabling the encryption and save it
+func (repo *MemoryRepository) WithSnapshotEncryption(keys KeyProvider) *MemoryRepository {
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+
+	repo.snapshotKeys = keys
+	return repo
+}
+
+// SaveSnapshot writes all the objects of the repository to the file
+// as gzip compressed gob, so the repository can be reloaded at startup
+// with LoadSnapshot. The file is replaced atomically, and encrypted
+// if the repository has snapshot encryption (see WithSnapshotEncryption)
+func (repo *MemoryRepository) SaveSnapshot(path string) (err error) {
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+
+	all := repo.collection.All()
+	snapshot := make([]map[string]string, 0, len(all))
+	for _, do := range all {
+		snapshot = append(snapshot, do.Data())
+	}
+
+	file, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		if err != nil {
+			_ = file.Close()
+			_ = os.Remove(file.Name())
+		}
+	}()
+
+	var target io.Writer = file
+	buffer := bytes.Buffer{}
+	if repo.snapshotKeys != nil {
+		target = &buffer
+	}
+
+	writer := gzip.NewWriter(target)
+
+	if err = gob.NewEncoder(writer).Encode(snapshot); err != nil {
+		return err
+	}
+
+	if err = writer.Close(); err != nil {
+		return err
+	}
+
+	if repo.snapshotKeys != nil {
+		var blob []byte
+		if blob, err = encryptBlob(repo.snapshotKeys, buffer.Bytes(), snapshotPurpose); err != nil {
+			return err
+		}
+		if _, err = file.Write(blob); err != nil {
+			return err
+		}
+	}
+
+	if err = file.Sync(); err != nil {
+		return err
+	}
+
+	if err = file.Close(); err != nil {
+		return err
+	}
+
+	return os.Rename(file.Name(), path)
+}
+
+// LoadSnapshot replaces all the objects of the repository with
+// the objects of the file written by SaveSnapshot. Encrypted snapshots
+// are decrypted with the key provider, and must be encrypted if there is
+// one (see WithSnapshotEncryption)
+func (repo *MemoryRepository) LoadSnapshot(path string) error {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+
+	repo.mu.Lock()
+	keys := repo.snapshotKeys
+	repo.mu.Unlock()
+
+	if isEncryptedBlob(content) {
+		content, err = decryptBlob(keys, content, snapshotPurpose)
+		if err != nil {
+			return fmt.Errorf("dataobject: invalid snapshot: %w", err)
+		}
+	} else if keys != nil {
+		return fmt.Errorf("dataobject: invalid snapshot: %w", ErrNotEncrypted)
+	}
+
+	reader, err := gzip.NewReader(bytes.NewReader(content))
+	if err != nil {
+		return fmt.Errorf("dataobject: invalid snapshot: %w", err)
+	}
+
+	snapshot := []map[string]string{}
+	if err := gob.NewDecoder(reader).Decode(&snapshot); err != nil {
+		return fmt.Errorf("dataobject: invalid snapshot: %w", err)
+	}
+
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+
+	for _, do := range repo.collection.All() {
+		repo.collection.Remove(do.ID())
+	}
+
+	for _, data := range snapshot {
+		repo.collection.Add(NewDataObjectFromExistingData(data))
+	}
+
+	return nil
+}
@@ -0,0 +1,47 @@
+package dataobject
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+)
+
+func TestMemoryRepositorySnapshot(t *testing.T) {
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "snapshot.gob.gz")
+
+	repo := NewMemoryRepository("email")
+	for i := 0; i < 3; i++ {
+		_ = repo.Create(ctx, NewDataObjectFromExistingData(map[string]string{
+			"id":    strconv.Itoa(i),
+			"email": "user" + strconv.Itoa(i) + "@test.com",
+		}))
+	}
+
+	if err := repo.SaveSnapshot(path); err != nil {
+		t.Fatal("Error must be nil, but found:", err.Error())
+	}
+
+	loaded := NewMemoryRepository("email")
+	_ = loaded.Create(ctx, NewDataObjectFromExistingData(map[string]string{"id": "stale"}))
+
+	if err := loaded.LoadSnapshot(path); err != nil {
+		t.Fatal("Error must be nil, but found:", err.Error())
+	}
+
+	if count, _ := loaded.Count(ctx); count != 3 {
+		t.Error("Expected: 3, but found:", count)
+	}
+
+	if found, _ := loaded.FindBy(ctx, "email", "user1@test.com"); len(found) != 1 {
+		t.Error("Expected: 1, but found:", len(found))
+	}
+
+	_ = os.WriteFile(path, []byte("not a snapshot"), 0600)
+
+	if err := loaded.LoadSnapshot(path); err == nil {
+		t.Error("Error must NOT be nil, but found:", err)
+	}
+}
@@ -0,0 +1,115 @@
+package dataobject
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+)
+
+func TestMemoryRepositoryCRUD(t *testing.T) {
+	ctx := context.Background()
+	repo := NewMemoryRepository("email")
+
+	user := NewDataObject()
+	user.Set("email", "jon@test.com")
+
+	if err := repo.Create(ctx, user); err != nil {
+		t.Fatal("Error must be nil, but found:", err.Error())
+	}
+
+	if err := repo.Create(ctx, user); err == nil {
+		t.Error("Error must NOT be nil, but found:", err)
+	}
+
+	found, err := repo.Find(ctx, user.ID())
+
+	if err != nil {
+		t.Fatal("Error must be nil, but found:", err.Error())
+	}
+
+	if found.Data()["email"] != "jon@test.com" {
+		t.Error("Expected: jon@test.com, but found:", found.Data()["email"])
+	}
+
+	// changes are not visible until updated
+	user.Set("email", "jane@test.com")
+
+	byEmail, _ := repo.FindBy(ctx, "email", "jane@test.com")
+	if len(byEmail) != 0 {
+		t.Error("Expected: 0, but found:", len(byEmail))
+	}
+
+	if err := repo.Update(ctx, user); err != nil {
+		t.Fatal("Error must be nil, but found:", err.Error())
+	}
+
+	byEmail, _ = repo.FindBy(ctx, "email", "jane@test.com")
+	if len(byEmail) != 1 {
+		t.Error("Expected: 1, but found:", len(byEmail))
+	}
+
+	if err := repo.Delete(ctx, user.ID()); err != nil {
+		t.Fatal("Error must be nil, but found:", err.Error())
+	}
+
+	if _, err := repo.Find(ctx, user.ID()); !errors.Is(err, ErrNotFound) {
+		t.Error("Expected: ErrNotFound, but found:", err)
+	}
+}
+
+func TestMemoryRepositoryList(t *testing.T) {
+	ctx := context.Background()
+	repo := NewMemoryRepository()
+
+	for _, id := range []string{"3", "1", "2"} {
+		_ = repo.Create(ctx, NewDataObjectFromExistingData(map[string]string{"id": id}))
+	}
+
+	list, err := repo.List(ctx, 1, 5)
+
+	if err != nil {
+		t.Fatal("Error must be nil, but found:", err.Error())
+	}
+
+	if len(list) != 2 || list[0].ID() != "2" || list[1].ID() != "3" {
+		t.Error("Expected: [2 3], but found:", list)
+	}
+
+	count, _ := repo.Count(ctx)
+	if count != 3 {
+		t.Error("Expected: 3, but found:", count)
+	}
+}
+
+func TestMemoryRepositoryCreateConcurrently(t *testing.T) {
+	ctx := context.Background()
+	repo := NewMemoryRepository()
+
+	var wg sync.WaitGroup
+	errs := make(chan error, 50)
+
+	for i := 0; i < 50; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			errs <- repo.Create(ctx, NewDataObjectFromExistingData(map[string]string{"id": "1"}))
+		}()
+	}
+
+	wg.Wait()
+	close(errs)
+
+	created := 0
+	for err := range errs {
+		if err == nil {
+			created++
+		} else if !errors.Is(err, ErrAlreadyExists) {
+			t.Error("Expected: ErrAlreadyExists, but found:", err)
+		}
+	}
+
+	if created != 1 {
+		t.Error("Expected: 1 successful create, but found:", created)
+	}
+}
@@ -0,0 +1,189 @@
+package dataobject
+
+import (
+	"context"
+	"sync/atomic"
+	"time"
+)
+
+// Metrics receives the measurements of the package, i.e. to export
+// them to a monitoring system (see SetMetrics, and the prommetrics
+// module for Prometheus, so the package itself does not depend on it)
+type Metrics interface {
+	// ObjectCreated is called after an object is created
+	// in a metered repository (see MeteredRepository)
+	ObjectCreated()
+
+	// ObserveSerialization is called after an object
+	// is serialized with the format (i.e. "json")
+	ObserveSerialization(format string, duration time.Duration)
+
+	// ObserveRepositoryOperation is called after each operation
+	// (i.e. "find") of a metered repository, with its error if any
+	ObserveRepositoryOperation(operation string, duration time.Duration, err error)
+
+	// ObserveCacheLookup is called after each cache lookup
+	// of a cached finder (see CachedFinder)
+	ObserveCacheLookup(hit bool)
+}
+
+// metricsHolder holds the package-level metrics, so they can be swapped atomically
+type metricsHolder struct {
+	Metrics
+}
+
+// metrics holds the package-level metrics, nil if they are disabled,
+// so measuring costs a single atomic load without metrics
+var metrics atomic.Pointer[metricsHolder]
+
+// SetMetrics sets the metrics receiving the measurements of the package,
+// which are not measured by default. Passing nil disables the metrics
+func SetMetrics(m Metrics) {
+	if m == nil {
+		metrics.Store(nil)
+		return
+	}
+
+	metrics.Store(&metricsHolder{Metrics: m})
+}
+
+// currentMetrics returns the package-level metrics
+func currentMetrics() Metrics {
+	if holder := metrics.Load(); holder != nil {
+		return holder.Metrics
+	}
+	return noMetrics{}
+}
+
+// observeSerialization starts timing a serialization with the format,
+// returns the func reporting it, i.e. defer observeSerialization("json")().
+// Without metrics the time is not read
+func observeSerialization(format string) func() {
+	holder := metrics.Load()
+	if holder == nil {
+		return noObservation
+	}
+
+	start := time.Now()
+	return func() {
+		holder.ObserveSerialization(format, time.Since(start))
+	}
+}
+
+// noObservation is returned by observeSerialization without metrics
+func noObservation() {}
+
+// noMetrics discards the measurements
+type noMetrics struct{}
+
+func (noMetrics) ObjectCreated()                                          {}
+func (noMetrics) ObserveSerialization(string, time.Duration)              {}
+func (noMetrics) ObserveRepositoryOperation(string, time.Duration, error) {}
+func (noMetrics) ObserveCacheLookup(bool)                                 {}
+
+var _ DataObjectRepositoryInterface = (*MeteredRepository)(nil) // verify it extends the repository interface
+var _ ConditionalUpdater = (*MeteredRepository)(nil)            // verify it forwards conditional updates
+var _ Querier = (*MeteredRepository)(nil)                       // verify it forwards queries
+
+// MeteredRepository decorates a repository reporting the latency and the
+// errors of its operations, and the created objects (see SetMetrics)
+//
+// Queries, conditional updates, and the force deletes and restores of
+// soft deleted objects are forwarded, and return an error wrapping
+// ErrNotSupported if the decorated repository does not support them
+type MeteredRepository struct {
+	DataObjectRepositoryInterface
+}
+
+// NewMeteredRepository creates a new metered decorator of the repository
+func NewMeteredRepository(inner DataObjectRepositoryInterface) *MeteredRepository {
+	return &MeteredRepository{DataObjectRepositoryInterface: inner}
+}
+
+// observe reports the operation started at the time
+func (repo *MeteredRepository) observe(operation string, start time.Time, err error) {
+	currentMetrics().ObserveRepositoryOperation(operation, time.Since(start), err)
+}
+
+// Create creates the object
+func (repo *MeteredRepository) Create(ctx context.Context, do DataObjectInterface) error {
+	start := time.Now()
+	err := repo.DataObjectRepositoryInterface.Create(ctx, do)
+	repo.observe("create", start, err)
+	if err == nil {
+		currentMetrics().ObjectCreated()
+	}
+	return err
+}
+
+// Find returns the object with the ID
+func (repo *MeteredRepository) Find(ctx context.Context, id string) (DataObjectInterface, error) {
+	start := time.Now()
+	do, err := repo.DataObjectRepositoryInterface.Find(ctx, id)
+	repo.observe("find", start, err)
+	return do, err
+}
+
+// Update updates the object
+func (repo *MeteredRepository) Update(ctx context.Context, do DataObjectInterface) error {
+	start := time.Now()
+	err := repo.DataObjectRepositoryInterface.Update(ctx, do)
+	repo.observe("update", start, err)
+	return err
+}
+
+// Delete deletes the object with the ID
+func (repo *MeteredRepository) Delete(ctx context.Context, id string) error {
+	start := time.Now()
+	err := repo.DataObjectRepositoryInterface.Delete(ctx, id)
+	repo.observe("delete", start, err)
+	return err
+}
+
+// List returns up to limit objects, skipping the first offset objects
+func (repo *MeteredRepository) List(ctx context.Context, offset int, limit int) ([]DataObjectInterface, error) {
+	start := time.Now()
+	objects, err := repo.DataObjectRepositoryInterface.List(ctx, offset, limit)
+	repo.observe("list", start, err)
+	return objects, err
+}
+
+// Count returns the number of objects
+func (repo *MeteredRepository) Count(ctx context.Context) (int, error) {
+	start := time.Now()
+	count, err := repo.DataObjectRepositoryInterface.Count(ctx)
+	repo.observe("count", start, err)
+	return count, err
+}
+
+// Query returns the objects matching the query
+func (repo *MeteredRepository) Query(ctx context.Context, query *Query) ([]DataObjectInterface, error) {
+	start := time.Now()
+	objects, err := forwardQuery(ctx, repo.DataObjectRepositoryInterface, query)
+	repo.observe("query", start, err)
+	return objects, err
+}
+
+// UpdateIf updates the object, if it matches the entity tag
+func (repo *MeteredRepository) UpdateIf(ctx context.Context, do DataObjectInterface, expectedETag string) error {
+	start := time.Now()
+	err := forwardUpdateIf(ctx, repo.DataObjectRepositoryInterface, do, expectedETag)
+	repo.observe("update_if", start, err)
+	return err
+}
+
+// ForceDelete deletes the object with the ID permanently
+func (repo *MeteredRepository) ForceDelete(ctx context.Context, id string) error {
+	start := time.Now()
+	err := forwardForceDelete(ctx, repo.DataObjectRepositoryInterface, id)
+	repo.observe("force_delete", start, err)
+	return err
+}
+
+// Restore restores the soft deleted object with the ID
+func (repo *MeteredRepository) Restore(ctx context.Context, id string) error {
+	start := time.Now()
+	err := forwardRestore(ctx, repo.DataObjectRepositoryInterface, id)
+	repo.observe("restore", start, err)
+	return err
+}
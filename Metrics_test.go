@@ -0,0 +1,111 @@
+package dataobject
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+)
+
+type recordingMetrics struct {
+	mu         sync.Mutex
+	created    int
+	formats    []string
+	operations []string
+	hits       int
+	misses     int
+}
+
+func (m *recordingMetrics) ObjectCreated() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.created++
+}
+
+func (m *recordingMetrics) ObserveSerialization(format string, duration time.Duration) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.formats = append(m.formats, format)
+}
+
+func (m *recordingMetrics) ObserveRepositoryOperation(operation string, duration time.Duration, err error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if err != nil {
+		operation += " error"
+	}
+	m.operations = append(m.operations, operation)
+}
+
+func (m *recordingMetrics) ObserveCacheLookup(hit bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if hit {
+		m.hits++
+	} else {
+		m.misses++
+	}
+}
+
+func TestMetrics(t *testing.T) {
+	metrics := &recordingMetrics{}
+	SetMetrics(metrics)
+	defer SetMetrics(nil)
+
+	ctx := context.Background()
+	repo := NewMeteredRepository(NewMemoryRepository())
+
+	do := NewDataObject()
+	if err := repo.Create(ctx, do); err != nil {
+		t.Fatal("Error must be nil, but found:", err.Error())
+	}
+	_ = repo.Create(ctx, do)
+	_, _ = repo.Find(ctx, "missing")
+
+	finder := NewCachedFinder(repo, NewMemoryCache())
+	_, _ = finder.Find(ctx, do.ID())
+	_, _ = finder.Find(ctx, do.ID())
+
+	_, _ = do.ToJSON()
+	_, _ = do.EncodeWith(CBORCodec{})
+
+	if metrics.created != 1 {
+		t.Error("Expected: 1, but found:", metrics.created)
+	}
+
+	expected := []string{"create", "create error", "find error", "find"}
+	if len(metrics.operations) != len(expected) {
+		t.Fatal("Expected:", expected, "but found:", metrics.operations)
+	}
+	for i := range expected {
+		if metrics.operations[i] != expected[i] {
+			t.Error("Expected:", expected[i], "but found:", metrics.operations[i])
+		}
+	}
+
+	if metrics.hits != 1 || metrics.misses != 1 {
+		t.Error("Expected: 1 hit and 1 miss, but found:", metrics.hits, metrics.misses)
+	}
+
+	if len(metrics.formats) != 2 || metrics.formats[0] != "json" || metrics.formats[1] != "cbor" {
+		t.Error("Expected: [json cbor], but found:", metrics.formats)
+	}
+}
+
+func TestSetMetricsNil(t *testing.T) {
+	SetMetrics(nil)
+
+	if _, isNoop := currentMetrics().(noMetrics); !isNoop {
+		t.Error("Expected: the metrics to be disabled, but found:", currentMetrics())
+	}
+}
+
+func BenchmarkToJSONWithoutMetrics(b *testing.B) {
+	SetMetrics(nil)
+	do := NewDataObjectFromExistingData(map[string]string{"id": "1", "name": "Jon"})
+
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		_, _ = do.ToJSON()
+	}
+}
@@ -0,0 +1,63 @@
+package dataobject
+
+// MigrateKeys renames the keys of each of the objects according to
+// the mapping (old key -> new key). All the keys of an object are
+// renamed at once, so chained mappings (a -> b, b -> c) are safe
+//
+// Objects supporting Set and Remove (i.e. DataObject) track the
+// renamed keys as removed and the new keys as changed, any other
+// objects are re-hydrated with the migrated data
+//
+// Returns the number of objects, which have been modified
+func MigrateKeys(objects []DataObjectInterface, mapping map[string]string) int {
+	modified := 0
+
+	for _, do := range objects {
+		if migrateObjectKeys(do, mapping) {
+			modified++
+		}
+	}
+
+	return modified
+}
+
+// migrateObjectKeys renames the keys of a single object,
+// returns true if any key has been renamed
+func migrateObjectKeys(do DataObjectInterface, mapping map[string]string) bool {
+	data := do.Data()
+	values := map[string]string{}
+
+	for oldKey, newKey := range mapping {
+		if value, exists := data[oldKey]; exists && oldKey != newKey {
+			values[oldKey] = value
+		}
+	}
+
+	if len(values) < 1 {
+		return false
+	}
+
+	if mutable, ok := do.(interface {
+		Set(key string, value string)
+		Remove(key string)
+	}); ok {
+		for oldKey := range values {
+			mutable.Remove(oldKey)
+		}
+		for oldKey, value := range values {
+			mutable.Set(mapping[oldKey], value)
+		}
+		return true
+	}
+
+	migrated := copyData(data)
+	for oldKey := range values {
+		delete(migrated, oldKey)
+	}
+	for oldKey, value := range values {
+		migrated[mapping[oldKey]] = value
+	}
+	do.Hydrate(migrated)
+
+	return true
+}
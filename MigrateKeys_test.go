@@ -0,0 +1,35 @@
+package dataobject
+
+import (
+	"testing"
+)
+
+func TestMigrateKeys(t *testing.T) {
+	user1 := NewDataObjectFromExistingData(map[string]string{"id": "1", "first_name": "Jon", "name": "Jon Doe"})
+	user2 := NewDataObjectFromExistingData(map[string]string{"id": "2"})
+
+	modified := MigrateKeys([]DataObjectInterface{user1, user2}, map[string]string{
+		"first_name": "given_name",
+		"name":       "first_name",
+	})
+
+	if modified != 1 {
+		t.Error("Expected: 1, but found:", modified)
+	}
+
+	if user1.Get("given_name") != "Jon" {
+		t.Error("Expected: Jon, but found:", user1.Get("given_name"))
+	}
+
+	if user1.Get("first_name") != "Jon Doe" {
+		t.Error("Expected: Jon Doe, but found:", user1.Get("first_name"))
+	}
+
+	if _, exists := user1.Data()["name"]; exists {
+		t.Error("Expected name to be removed, but found:", user1.Get("name"))
+	}
+
+	if len(user1.DataRemoved()) != 1 || user1.DataRemoved()[0] != "name" {
+		t.Error("Expected: [name], but found:", user1.DataRemoved())
+	}
+}
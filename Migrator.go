@@ -0,0 +1,207 @@
+package dataobject
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"sort"
+	"strconv"
+	"sync"
+)
+
+// SchemaVersionKey is the key holding the schema version of the data
+const SchemaVersionKey = "schema_version"
+
+// MigrationFunc upgrades the data from the previous schema version
+type MigrationFunc func(data map[string]string) (map[string]string, error)
+
+// Migrator upgrades serialized data to the latest schema version
+// by running the registered migrations in sequence (v1 -> v2 -> v3)
+//
+// The schema version is read from the schema_version key,
+// data without the key is considered to be at version 0
+//
+// Example:
+//
+//	migrator := NewMigrator()
+//	migrator.Register(1, func(data map[string]string) (map[string]string, error) {
+//		data["given_name"] = data["first_name"]
+//		delete(data, "first_name")
+//		return data, nil
+//	})
+//
+//	user, err := migrator.NewDataObjectFromJSON(jsonString)
+//
+// To upgrade all the decoded objects, set it as the package-level
+// migrator (see SetMigrator)
+type Migrator struct {
+	mu         sync.RWMutex
+	migrations map[int]MigrationFunc
+	logger     *slog.Logger
+}
+
+var (
+	migratorMu sync.RWMutex
+	migrator   *Migrator
+)
+
+// SetMigrator sets the migrator upgrading the data of the decoded objects
+// (NewDataObjectFromJSON, NewDataObjectFromNestedJSON, NewDataObjectFromData,
+// UnmarshalJSON and GobDecode) to the latest schema version, so old
+// payloads are upgraded wherever they are loaded. Passing nil disables
+// the migrations (the default)
+func SetMigrator(m *Migrator) {
+	migratorMu.Lock()
+	defer migratorMu.Unlock()
+
+	migrator = m
+}
+
+// migrateDecoded upgrades the decoded data with the package-level
+// migrator, returns false if there is none or nothing was migrated
+func migrateDecoded(data map[string]string) (map[string]string, bool, error) {
+	migratorMu.RLock()
+	m := migrator
+	migratorMu.RUnlock()
+
+	if m == nil {
+		return data, false, nil
+	}
+
+	return m.migrate(data)
+}
+
+// errNilMigratedData is returned by Migrate if a migration returns nil data
+var errNilMigratedData = errors.New("migration returned nil data")
+
+// NewMigrator creates a new migrator with no migrations
+func NewMigrator() *Migrator {
+	return &Migrator{
+		migrations: map[int]MigrationFunc{},
+	}
+}
+
+// Register registers the migration upgrading the data to the version.
+// Registering a version twice replaces the previous migration
+func (m *Migrator) Register(version int, migration MigrationFunc) *Migrator {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.migrations[version] = migration
+	return m
+}
+
+// WithLogger sets the logger, which logs the applied migrations at debug level
+func (m *Migrator) WithLogger(logger *slog.Logger) *Migrator {
+	m.logger = logger
+	return m
+}
+
+// LatestVersion returns the highest registered version
+func (m *Migrator) LatestVersion() int {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	latest := 0
+	for version := range m.migrations {
+		latest = max(latest, version)
+	}
+	return latest
+}
+
+// Migrate upgrades the data to the latest version, setting the
+// schema_version key. The passed map is not modified
+func (m *Migrator) Migrate(data map[string]string) (map[string]string, error) {
+	migrated, _, err := m.migrate(data)
+	if err != nil {
+		return nil, err
+	}
+	return migrated, nil
+}
+
+// migrate upgrades the data to the latest version,
+// returns false if there was no migration to run
+func (m *Migrator) migrate(data map[string]string) (map[string]string, bool, error) {
+	current := 0
+
+	if value, exists := data[SchemaVersionKey]; exists && value != "" {
+		version, err := strconv.Atoi(value)
+		if err != nil {
+			return nil, false, fmt.Errorf("%w: %s", ErrInvalidSchemaVersion, value)
+		}
+		current = version
+	}
+
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	versions := make([]int, 0, len(m.migrations))
+	for version := range m.migrations {
+		if version > current {
+			versions = append(versions, version)
+		}
+	}
+	sort.Ints(versions)
+
+	migrated := copyData(data)
+
+	for _, version := range versions {
+		var err error
+		migrated, err = m.migrations[version](migrated)
+		if err == nil && migrated == nil {
+			err = errNilMigratedData
+		}
+		if err != nil {
+			return nil, false, &MigrationError{Version: version, ID: data["id"], Err: err}
+		}
+		migrated[SchemaVersionKey] = strconv.Itoa(version)
+
+		logDebug(m.logger, "dataobject: migrated", slog.String("id", migrated["id"]), slog.Int("version", version))
+	}
+
+	return migrated, len(versions) > 0, nil
+}
+
+// NewDataObjectFromExistingData creates a new data object
+// and hydrates it with the passed data upgraded to the latest version
+func (m *Migrator) NewDataObjectFromExistingData(data map[string]string) (*DataObject, error) {
+	migrated, err := m.Migrate(data)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewDataObjectFromExistingData(migrated), nil
+}
+
+// NewDataObjectFromJSON creates a new data object from the JSON
+// string with the data upgraded to the latest version
+func (m *Migrator) NewDataObjectFromJSON(jsonString string) (*DataObject, error) {
+	do, err := NewDataObjectFromJSON(jsonString)
+	if err != nil {
+		return nil, err
+	}
+
+	return m.NewDataObjectFromExistingData(do.Data())
+}
+
+// NewDataObjectFromGob creates a new data object from the gob encoded
+// object like FromGob, with the data upgraded to the latest version.
+// Keys changed before encoding, which are still present after the
+// migration, stay marked as changed (see Hydrate)
+func (m *Migrator) NewDataObjectFromGob(encoded []byte) (*DataObject, error) {
+	do, err := decodeGob(encoded)
+	if err != nil {
+		return nil, err
+	}
+
+	migrated, changed, err := m.migrate(do.Data())
+	if err != nil {
+		return nil, err
+	}
+
+	if changed {
+		do.Hydrate(migrated)
+	}
+
+	return do, nil
+}
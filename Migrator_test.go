@@ -0,0 +1,173 @@
+package dataobject
+
+import (
+	"bytes"
+	"encoding/gob"
+	"errors"
+	"testing"
+)
+
+func TestMigratorNewDataObjectFromJSON(t *testing.T) {
+	migrator := NewMigrator()
+
+	migrator.Register(2, func(data map[string]string) (map[string]string, error) {
+		data["full_name"] = data["given_name"] + " " + data["last_name"]
+		return data, nil
+	})
+
+	migrator.Register(1, func(data map[string]string) (map[string]string, error) {
+		data["given_name"] = data["first_name"]
+		delete(data, "first_name")
+		return data, nil
+	})
+
+	user, err := migrator.NewDataObjectFromJSON(`{"id":"1","first_name":"Jon","last_name":"Doe"}`)
+
+	if err != nil {
+		t.Fatal("Error must be nil, but found:", err.Error())
+	}
+
+	if user.Get("full_name") != "Jon Doe" {
+		t.Error("Expected: Jon Doe, but found:", user.Get("full_name"))
+	}
+
+	if user.Get(SchemaVersionKey) != "2" {
+		t.Error("Expected: 2, but found:", user.Get(SchemaVersionKey))
+	}
+
+	// already at version 1, only migration 2 must run
+	user, err = migrator.NewDataObjectFromJSON(`{"id":"1","schema_version":"1","given_name":"Jane","last_name":"Doe"}`)
+
+	if err != nil {
+		t.Fatal("Error must be nil, but found:", err.Error())
+	}
+
+	if user.Get("full_name") != "Jane Doe" {
+		t.Error("Expected: Jane Doe, but found:", user.Get("full_name"))
+	}
+}
+
+func TestMigratorMigrateError(t *testing.T) {
+	migrator := NewMigrator()
+
+	migrator.Register(1, func(data map[string]string) (map[string]string, error) {
+		return nil, errors.New("boom")
+	})
+
+	_, err := migrator.Migrate(map[string]string{"id": "1"})
+
+	if err == nil {
+		t.Error("Error must NOT be nil, but found:", err)
+	}
+
+	_, err = migrator.Migrate(map[string]string{"id": "1", "schema_version": "abc"})
+
+	if err == nil {
+		t.Error("Error must NOT be nil, but found:", err)
+	}
+}
+
+func TestMigratorMigrateNilData(t *testing.T) {
+	migrator := NewMigrator()
+
+	migrator.Register(1, func(data map[string]string) (map[string]string, error) {
+		return nil, nil
+	})
+
+	_, err := migrator.Migrate(map[string]string{"id": "1"})
+
+	var migrationErr *MigrationError
+	if !errors.As(err, &migrationErr) || migrationErr.Version != 1 {
+		t.Error("Expected: MigrationError of version 1, but found:", err)
+	}
+}
+
+func TestMigratorNewDataObjectFromGob(t *testing.T) {
+	old := NewDataObjectFromExistingData(map[string]string{"id": "1", "first_name": "Jon"})
+	old.Set("last_name", "Doe")
+
+	var buffer bytes.Buffer
+	if err := gob.NewEncoder(&buffer).Encode(old); err != nil {
+		t.Fatal("Error must be nil, but found:", err.Error())
+	}
+	encoded := buffer.Bytes()
+
+	migrator := NewMigrator().Register(1, func(data map[string]string) (map[string]string, error) {
+		data["full_name"] = data["first_name"] + " " + data["last_name"]
+		return data, nil
+	})
+
+	user, err := migrator.NewDataObjectFromGob(encoded)
+	if err != nil {
+		t.Fatal("Error must be nil, but found:", err.Error())
+	}
+
+	if user.Get("full_name") != "Jon Doe" || user.Get(SchemaVersionKey) != "1" {
+		t.Error("Expected: Jon Doe at version 1, but found:", user.Data())
+	}
+
+	if _, isChanged := user.DataChanged()["last_name"]; !isChanged {
+		t.Error("Expected: last_name to stay changed, but found:", user.DataChanged())
+	}
+
+	// the same format as FromGob
+	if _, err := FromGob(encoded, func(do *DataObject) *DataObject { return do }); err != nil {
+		t.Error("Error must be nil, but found:", err.Error())
+	}
+
+	if _, err := migrator.NewDataObjectFromGob(encoded[:len(encoded)-3]); !errors.Is(err, ErrCorrupted) {
+		t.Error("Expected: ErrCorrupted, but found:", err)
+	}
+
+	if _, err := migrator.NewDataObjectFromGob(make([]byte, MaxEncodedSize+1)); !errors.Is(err, ErrTooLarge) {
+		t.Error("Expected: ErrTooLarge, but found:", err)
+	}
+}
+
+func TestSetMigrator(t *testing.T) {
+	old := NewDataObjectFromExistingData(map[string]string{"id": "1", "name": "Jon"})
+	encoded, err := old.GobEncode()
+	if err != nil {
+		t.Fatal("Error must be nil, but found:", err.Error())
+	}
+
+	SetMigrator(NewMigrator().Register(1, func(data map[string]string) (map[string]string, error) {
+		data["full_name"] = data["name"]
+		delete(data, "name")
+		return data, nil
+	}))
+	defer SetMigrator(nil)
+
+	user, err := NewDataObjectFromJSON(`{"id":"1","name":"Jon"}`)
+	if err != nil {
+		t.Fatal("Error must be nil, but found:", err.Error())
+	}
+
+	if user.Get("full_name") != "Jon" || user.Get(SchemaVersionKey) != "1" {
+		t.Error("Expected: Jon at version 1, but found:", user.Data())
+	}
+
+	decoded := &DataObject{}
+	if err := decoded.GobDecode(encoded); err != nil {
+		t.Fatal("Error must be nil, but found:", err.Error())
+	}
+
+	if decoded.Get("full_name") != "Jon" || decoded.Get("name") != "" {
+		t.Error("Expected: the decoded data to be migrated, but found:", decoded.Data())
+	}
+
+	unmarshaled := &DataObject{}
+	if err := unmarshaled.UnmarshalJSON([]byte(`{"id":"1","name":"Jon"}`)); err != nil {
+		t.Fatal("Error must be nil, but found:", err.Error())
+	}
+
+	if unmarshaled.Get("full_name") != "Jon" {
+		t.Error("Expected: the unmarshaled data to be migrated, but found:", unmarshaled.Data())
+	}
+
+	// at the latest version, nothing is migrated
+	current, _ := NewDataObjectFromJSON(`{"id":"1","schema_version":"1","name":"Tim"}`)
+	if current.Get("name") != "Tim" {
+		t.Error("Expected: Tim, but found:", current.Data())
+	}
+}
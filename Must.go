@@ -0,0 +1,52 @@
+package dataobject
+
+import "time"
+
+// must returns the value, panicking if there is an error
+func must[T any](value T, err error) T {
+	if err != nil {
+		panic(err)
+	}
+	return value
+}
+
+// MustNewDataObjectFromJSON is like NewDataObjectFromJSON, but panics on error.
+// For scripts, tests and initialization code
+func MustNewDataObjectFromJSON(jsonString string) *DataObject {
+	return must(NewDataObjectFromJSON(jsonString))
+}
+
+// MustToJSON is like ToJSON, but panics on error
+func (do *DataObject) MustToJSON() string {
+	return must(do.ToJSON())
+}
+
+// MustGetInt is like GetInt, but panics on error
+func (do *DataObject) MustGetInt(key string) int64 {
+	return must(do.GetInt(key))
+}
+
+// MustGetFloat is like GetFloat, but panics on error
+func (do *DataObject) MustGetFloat(key string) float64 {
+	return must(do.GetFloat(key))
+}
+
+// MustGetBool is like GetBool, but panics on error
+func (do *DataObject) MustGetBool(key string) bool {
+	return must(do.GetBool(key))
+}
+
+// MustGetTime is like GetTime, but panics on error
+func (do *DataObject) MustGetTime(key string) time.Time {
+	return must(do.GetTime(key))
+}
+
+// MustGetObject is like GetObject, but panics on error
+func (do *DataObject) MustGetObject(key string) *DataObject {
+	return must(do.GetObject(key))
+}
+
+// MustQuery is like Query, but panics on error
+func (do *DataObject) MustQuery(path string) string {
+	return must(do.Query(path))
+}
@@ -0,0 +1,29 @@
+package dataobject
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestMust(t *testing.T) {
+	do := MustNewDataObjectFromJSON(`{"id":"1","age":"30","active":"true"}`)
+
+	if do.MustGetInt("age") != 30 || !do.MustGetBool("active") {
+		t.Error("Expected: 30 true, but found:", do.Data())
+	}
+
+	if do.MustToJSON() != `{"active":"true","age":"30","id":"1"}` {
+		t.Error("Expected the JSON of the object, but found:", do.MustToJSON())
+	}
+}
+
+func TestMustPanics(t *testing.T) {
+	defer func() {
+		err, isError := recover().(error)
+		if !isError || !errors.Is(err, ErrInvalidJSON) {
+			t.Error("Expected: ErrInvalidJSON, but found:", err)
+		}
+	}()
+
+	MustNewDataObjectFromJSON(`invalid`)
+}
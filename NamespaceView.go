@@ -0,0 +1,112 @@
+package dataobject
+
+import (
+	"sort"
+	"strings"
+)
+
+var _ DataObjectInterface = (*NamespaceView)(nil) // verify it extends the data object interface
+
+// NamespaceView is a view over the keys of a data object, which start
+// with a prefix, so that a sub-object (i.e. "billing_address.*") can be
+// read and modified as an object on its own without a separate record
+//
+// All the reads and writes go to the parent object, including the dirty
+// tracking. See DataObject.Namespace
+type NamespaceView struct {
+	parent *DataObject
+	prefix string
+}
+
+// Namespace returns a view over the keys of the object starting with
+// the prefix followed by a dot, i.e. Namespace("billing_address")
+// reads and writes the "billing_address.<key>" keys
+func (do *DataObject) Namespace(prefix string) *NamespaceView {
+	return &NamespaceView{parent: do, prefix: prefix + "."}
+}
+
+// Namespace returns a nested view (i.e. "billing_address.geo.<key>")
+func (v *NamespaceView) Namespace(prefix string) *NamespaceView {
+	return &NamespaceView{parent: v.parent, prefix: v.prefix + prefix + "."}
+}
+
+// ID returns the "id" key of the namespace
+func (v *NamespaceView) ID() string {
+	return v.Get("id")
+}
+
+// SetID sets the "id" key of the namespace
+func (v *NamespaceView) SetID(id string) {
+	v.Set("id", id)
+}
+
+// Get returns the value of the key in the namespace
+func (v *NamespaceView) Get(key string) string {
+	return v.parent.Get(v.prefix + key)
+}
+
+// Set sets the value of the key in the namespace, marking it as dirty
+func (v *NamespaceView) Set(key string, value string) {
+	v.parent.Set(v.prefix+key, value)
+}
+
+// SetData sets the values of the keys in the namespace, marking them as dirty
+func (v *NamespaceView) SetData(data map[string]string) {
+	for key, value := range data {
+		v.Set(key, value)
+	}
+}
+
+// Remove removes the key from the namespace
+func (v *NamespaceView) Remove(key string) {
+	v.parent.Remove(v.prefix + key)
+}
+
+// Keys returns the keys in the namespace (without the prefix), sorted
+func (v *NamespaceView) Keys() []string {
+	keys := []string{}
+	for key := range v.Data() {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// Data returns a copy of the data in the namespace, without the prefix
+func (v *NamespaceView) Data() map[string]string {
+	return v.strip(v.parent.Data())
+}
+
+// DataChanged returns a copy of the changed data in the namespace,
+// without the prefix
+func (v *NamespaceView) DataChanged() map[string]string {
+	return v.strip(v.parent.DataChanged())
+}
+
+// Hydrate replaces the data in the namespace without marking it as dirty
+func (v *NamespaceView) Hydrate(data map[string]string) {
+	hydrated := map[string]string{}
+
+	for key, value := range v.parent.Data() {
+		if !strings.HasPrefix(key, v.prefix) {
+			hydrated[key] = value
+		}
+	}
+
+	for key, value := range data {
+		hydrated[v.prefix+key] = value
+	}
+
+	v.parent.Hydrate(hydrated)
+}
+
+// strip returns the keys of the data in the namespace without the prefix
+func (v *NamespaceView) strip(data map[string]string) map[string]string {
+	result := map[string]string{}
+	for key, value := range data {
+		if strings.HasPrefix(key, v.prefix) {
+			result[strings.TrimPrefix(key, v.prefix)] = value
+		}
+	}
+	return result
+}
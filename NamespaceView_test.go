@@ -0,0 +1,50 @@
+package dataobject
+
+import (
+	"testing"
+)
+
+func TestDataObjectNamespace(t *testing.T) {
+	order := NewDataObjectFromExistingData(map[string]string{
+		"id":                    "1",
+		"billing_address.city":  "London",
+		"shipping_address.city": "Paris",
+	})
+
+	billing := order.Namespace("billing_address")
+
+	if billing.Get("city") != "London" {
+		t.Error("Expected: London, but found:", billing.Get("city"))
+	}
+
+	billing.Set("postcode", "SW1")
+
+	if order.Get("billing_address.postcode") != "SW1" {
+		t.Error("Expected: SW1, but found:", order.Get("billing_address.postcode"))
+	}
+
+	if billing.DataChanged()["postcode"] != "SW1" || len(billing.DataChanged()) != 1 {
+		t.Error("Expected: map[postcode:SW1], but found:", billing.DataChanged())
+	}
+
+	if len(billing.Data()) != 2 {
+		t.Error("Expected: 2, but found:", billing.Data())
+	}
+
+	billing.Hydrate(map[string]string{"city": "Leeds"})
+
+	if order.Get("billing_address.city") != "Leeds" || order.Get("shipping_address.city") != "Paris" {
+		t.Error("Expected: Leeds Paris, but found:", order.Data())
+	}
+
+	if _, exists := order.Data()["billing_address.postcode"]; exists {
+		t.Error("Expected billing_address.postcode to be replaced")
+	}
+
+	geo := billing.Namespace("geo")
+	geo.Set("lat", "51.5")
+
+	if order.Get("billing_address.geo.lat") != "51.5" {
+		t.Error("Expected: 51.5, but found:", order.Get("billing_address.geo.lat"))
+	}
+}
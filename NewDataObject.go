@@ -1,10 +1,15 @@
 package dataobject
 
-import "github.com/gouniverse/uid"
-
 // NewDataObject creates a new data object and generates an ID
+// with the package-level ID generator (see SetIDGenerator)
 func NewDataObject() *DataObject {
+	return NewDataObjectWithIDGenerator(generateID)
+}
+
+// NewDataObjectWithIDGenerator creates a new data object
+// and generates an ID with the passed generator
+func NewDataObjectWithIDGenerator(generator IDGenerator) *DataObject {
 	o := &DataObject{}
-	o.SetID(uid.HumanUid())
+	o.SetID(generator())
 	return o
 }
@@ -0,0 +1,10 @@
+package dataobject
+
+// NewDataObjectFromExistingID creates a new data object with the ID
+// of an already existing object, without marking it as dirty
+//
+// Useful for building an object shell for a partial update,
+// where only the fields set afterwards are reported as changed
+func NewDataObjectFromExistingID(id string) *DataObject {
+	return NewDataObjectFromExistingData(map[string]string{"id": id})
+}
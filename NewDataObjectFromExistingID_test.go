@@ -0,0 +1,25 @@
+package dataobject
+
+import (
+	"testing"
+)
+
+func TestNewDataObjectFromExistingID(t *testing.T) {
+	user := NewDataObjectFromExistingID("1")
+
+	if user.ID() != "1" {
+		t.Error("Expected: 1, but found:", user.ID())
+	}
+
+	if user.IsDirty() {
+		t.Error("Expected object NOT to be dirty")
+	}
+
+	user.Set("status", "inactive")
+
+	changed := user.DataChanged()
+
+	if len(changed) != 1 || changed["status"] != "inactive" {
+		t.Error("Expected: map[status:inactive], but found:", changed)
+	}
+}
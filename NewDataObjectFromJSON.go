@@ -1,19 +1,38 @@
 package dataobject
 
-import "encoding/json"
+import (
+	"encoding/json"
+	"fmt"
+)
 
+// NewDataObjectFromJSON creates a new data object from a JSON object string.
+// Returns an error wrapping ErrInvalidJSON if the string is not a JSON object
+// (or is nested deeper than MaxNestingDepth), ErrTooLarge if it is larger
+// than MaxEncodedSize, or ErrInvalidID if the ID is rejected (see SetIDValidator)
 func NewDataObjectFromJSON(jsonString string) (do *DataObject, err error) {
+	if err := checkEncodedSize(len(jsonString)); err != nil {
+		return do, err
+	}
+
 	var e interface{}
 
 	jsonError := json.Unmarshal([]byte(jsonString), &e)
 
 	if jsonError != nil {
-		return do, jsonError
+		return do, fmt.Errorf("%w: %w", ErrInvalidJSON, jsonError)
+	}
+
+	if err := checkNestingDepth(e); err != nil {
+		return do, err
 	}
 
-	data := mapStringAnyToMapStringString(e.(map[string]any))
+	object, isObject := e.(map[string]any)
+
+	if !isObject {
+		return do, fmt.Errorf("%w: not an object", ErrInvalidJSON)
+	}
 
-	do = NewDataObjectFromExistingData(data)
+	data := mapStringAnyToMapStringString(object)
 
-	return do, nil
+	return NewDataObjectFromData(data)
 }
@@ -0,0 +1,83 @@
+package dataobject
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+)
+
+// JSONReaderDefaultMaxSize is the default maximum size
+// of the JSON read by NewDataObjectFromJSONReader (10 MiB)
+const JSONReaderDefaultMaxSize = MaxEncodedSize
+
+// NewDataObjectFromJSONReader creates a new data object from a JSON object
+// streamed from the reader (i.e. a file or an HTTP body), converting the
+// values as NewDataObjectFromJSON, without reading it into a string first
+//
+// Reads at most maxSize bytes (JSONReaderDefaultMaxSize if 0), returns an
+// error wrapping ErrTooLarge if the JSON is larger, or ErrInvalidJSON if
+// it is not a single JSON object
+func NewDataObjectFromJSONReader(r io.Reader, maxSize int64) (*DataObject, error) {
+	if maxSize <= 0 {
+		maxSize = JSONReaderDefaultMaxSize
+	}
+
+	limited := &limitedReader{r: r, limit: maxSize, remaining: maxSize}
+	decoder := json.NewDecoder(limited)
+
+	var object map[string]any
+	if err := decoder.Decode(&object); err != nil {
+		return nil, limited.wrap(err)
+	}
+
+	if object == nil {
+		return nil, fmt.Errorf("%w: not an object", ErrInvalidJSON)
+	}
+
+	if err := checkNestingDepth(object); err != nil {
+		return nil, err
+	}
+
+	if err := decoder.Decode(&struct{}{}); err != io.EOF {
+		return nil, limited.wrap(errors.New("unexpected data after the object"))
+	}
+
+	return NewDataObjectFromData(mapStringAnyToMapStringString(object))
+}
+
+// limitedReader reads up to the remaining bytes, remembering if there were more
+type limitedReader struct {
+	r         io.Reader
+	limit     int64
+	remaining int64
+	exceeded  bool
+}
+
+func (l *limitedReader) Read(p []byte) (int, error) {
+	if l.remaining <= 0 {
+		// probe for more data beyond the limit
+		n, _ := l.r.Read(make([]byte, 1))
+		if n > 0 {
+			l.exceeded = true
+		}
+		return 0, io.EOF
+	}
+
+	if int64(len(p)) > l.remaining {
+		p = p[:l.remaining]
+	}
+
+	n, err := l.r.Read(p)
+	l.remaining -= int64(n)
+	return n, err
+}
+
+// wrap wraps the decoding error with ErrTooLarge if the limit has been
+// exceeded, otherwise with ErrInvalidJSON
+func (l *limitedReader) wrap(err error) error {
+	if l.exceeded {
+		return fmt.Errorf("%w: more than %d bytes", ErrTooLarge, l.limit)
+	}
+	return fmt.Errorf("%w: %w", ErrInvalidJSON, err)
+}
@@ -0,0 +1,42 @@
+package dataobject
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestNewDataObjectFromJSONReader(t *testing.T) {
+	do, err := NewDataObjectFromJSONReader(strings.NewReader(` {"id":"1","price":12.5,"active":true} `), 0)
+	if err != nil {
+		t.Fatal("Error must be nil, but found:", err.Error())
+	}
+
+	if do.ID() != "1" || do.Get("price") != "12.5000" || do.Get("active") != "true" {
+		t.Error("Expected: the converted values, but found:", do.Data())
+	}
+
+	if do.IsDirty() {
+		t.Error("Expected: not dirty, but found:", do.DataChanged())
+	}
+}
+
+func TestNewDataObjectFromJSONReaderMaxSize(t *testing.T) {
+	json := `{"id":"1","name":"` + strings.Repeat("x", 100) + `"}`
+
+	if _, err := NewDataObjectFromJSONReader(strings.NewReader(json), 50); !errors.Is(err, ErrTooLarge) {
+		t.Error("Expected: ErrTooLarge, but found:", err)
+	}
+
+	if _, err := NewDataObjectFromJSONReader(strings.NewReader(json), int64(len(json))); err != nil {
+		t.Error("Error must be nil, but found:", err.Error())
+	}
+}
+
+func TestNewDataObjectFromJSONReaderInvalid(t *testing.T) {
+	for _, json := range []string{`{"id":"1"`, `["id"]`, `null`, `{"id":"1"} {"id":"2"}`, ``} {
+		if _, err := NewDataObjectFromJSONReader(strings.NewReader(json), 0); !errors.Is(err, ErrInvalidJSON) {
+			t.Error("Expected: ErrInvalidJSON for", json, "but found:", err)
+		}
+	}
+}
@@ -1,6 +1,9 @@
 package dataobject
 
 import (
+	"errors"
+	"maps"
+	"strings"
 	"testing"
 )
 
@@ -29,3 +32,77 @@ func TestNewDataObjectFromJSON(t *testing.T) {
 		t.Error("Expected: Doe, but found:", do.Get("last_name"))
 	}
 }
+
+func TestNewDataObjectFromJSONInvalid(t *testing.T) {
+	inputs := []string{`{invalid`, `[1,2,3]`, `"string"`, `null`}
+
+	for _, input := range inputs {
+		do, err := NewDataObjectFromJSON(input)
+
+		if !errors.Is(err, ErrInvalidJSON) {
+			t.Error("Expected: ErrInvalidJSON, but found:", err)
+		}
+
+		if do != nil {
+			t.Error("DataObject must be nil, but found:", do)
+		}
+	}
+}
+
+func TestNewDataObjectFromJSONLimits(t *testing.T) {
+	deep := strings.Repeat(`{"a":`, MaxNestingDepth) + `1` + strings.Repeat(`}`, MaxNestingDepth)
+	if _, err := NewDataObjectFromJSON(deep); err != nil {
+		t.Error("Error must be nil at the maximum depth, but found:", err.Error())
+	}
+
+	tooDeep := `{"a":` + deep + `}`
+	if _, err := NewDataObjectFromJSON(tooDeep); !errors.Is(err, ErrInvalidJSON) {
+		t.Error("Expected: ErrInvalidJSON, but found:", err)
+	}
+
+	if _, err := NewDataObjectFromNestedJSON(tooDeep); !errors.Is(err, ErrInvalidJSON) {
+		t.Error("Expected: ErrInvalidJSON, but found:", err)
+	}
+
+	tooLarge := `{"id":"` + strings.Repeat("x", MaxEncodedSize) + `"}`
+	if _, err := NewDataObjectFromJSON(tooLarge); !errors.Is(err, ErrTooLarge) {
+		t.Error("Expected: ErrTooLarge, but found:", err)
+	}
+}
+
+func FuzzNewDataObjectFromJSON(f *testing.F) {
+	for _, seed := range []string{
+		`{"id":"1","name":"Jon"}`,
+		`{"n":1.5,"b":true,"z":null,"a":[1,{"x":"y"}]}`,
+		"{\"nul\":\"a\x00b\",\"\x00\":\"\"}",
+		"{\"bad\":\"\xff\xfe\"}",
+		`[]`,
+		``,
+	} {
+		f.Add(seed)
+	}
+
+	f.Fuzz(func(t *testing.T, jsonString string) {
+		do, err := NewDataObjectFromJSON(jsonString)
+		if err != nil {
+			if !errors.Is(err, ErrInvalidJSON) && !errors.Is(err, ErrTooLarge) {
+				t.Fatal("Expected: ErrInvalidJSON or ErrTooLarge, but found:", err)
+			}
+			return
+		}
+
+		encoded, err := do.ToJSON()
+		if err != nil {
+			t.Fatal("Error must be nil, but found:", err.Error())
+		}
+
+		decoded, err := NewDataObjectFromJSON(encoded)
+		if err != nil {
+			t.Fatal("Error must be nil, but found:", err.Error())
+		}
+
+		if !maps.Equal(do.Data(), decoded.Data()) {
+			t.Error("Expected:", do.Data(), "but found:", decoded.Data())
+		}
+	})
+}
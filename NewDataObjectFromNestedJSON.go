@@ -0,0 +1,53 @@
+package dataobject
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// NewDataObjectFromNestedJSON creates a new data object from a JSON
+// object string with nested objects and arrays, flattening them
+// into dot and bracket keys (see Flatten)
+//
+// Numbers are kept as they appear in the JSON. The same size and nesting
+// limits as NewDataObjectFromJSON apply
+func NewDataObjectFromNestedJSON(jsonString string) (*DataObject, error) {
+	if err := checkEncodedSize(len(jsonString)); err != nil {
+		return nil, err
+	}
+
+	decoder := json.NewDecoder(strings.NewReader(jsonString))
+	decoder.UseNumber()
+
+	var object map[string]any
+	if err := decoder.Decode(&object); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidJSON, err)
+	}
+
+	if object == nil {
+		return nil, fmt.Errorf("%w: not an object", ErrInvalidJSON)
+	}
+
+	if err := checkNestingDepth(object); err != nil {
+		return nil, err
+	}
+
+	return NewDataObjectFromData(Flatten(object))
+}
+
+// ToNestedJSON converts the DataObject to a JSON string,
+// unflattening the dot and bracket keys (see Unflatten)
+func (do *DataObject) ToNestedJSON() (string, error) {
+	nested, err := Unflatten(do.Data())
+	if err != nil {
+		return "", err
+	}
+
+	jsonValue, err := json.Marshal(nested)
+	if err != nil {
+		return "", err
+	}
+
+	return string(jsonValue), nil
+}
@@ -0,0 +1,133 @@
+package dataobject
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"mime"
+	"net/http"
+)
+
+// NewDataObjectFromRequest creates a new data object with a generated ID
+// and sets the values submitted with the request (see SetDataFromRequest)
+func NewDataObjectFromRequest(r *http.Request, allowedKeys ...string) (*DataObject, error) {
+	do := NewDataObject()
+
+	if err := do.SetDataFromRequest(r, allowedKeys...); err != nil {
+		return nil, err
+	}
+
+	return do, nil
+}
+
+// SetDataFromRequest sets the values submitted with the request,
+// marking them as dirty
+//
+// The values are read from the query parameters and, depending on the
+// content type, from the JSON, URL encoded or multipart form body.
+// Body values take precedence over query parameters
+//
+// Only the allowed keys are set, to prevent mass assignment (i.e. of
+// the ID or a role). Returns ErrNoAllowedKeys if none are passed. The
+// body is limited to MaxEncodedSize, returns an error wrapping
+// ErrTooLarge if it is larger
+func (do *DataObject) SetDataFromRequest(r *http.Request, allowedKeys ...string) error {
+	if len(allowedKeys) < 1 {
+		return ErrNoAllowedKeys
+	}
+
+	values, err := requestValues(r)
+	if err != nil {
+		return err
+	}
+
+	for _, key := range allowedKeys {
+		if value, exists := values[key]; exists {
+			do.Set(key, value)
+		}
+	}
+
+	return nil
+}
+
+// requestValues returns the values submitted with the request, the
+// query parameters and the body values (see requestBodyValues), the
+// latter taking precedence
+func requestValues(r *http.Request) (map[string]string, error) {
+	values, err := requestBodyValues(r)
+	if err != nil {
+		return nil, err
+	}
+
+	for key := range r.URL.Query() {
+		if _, exists := values[key]; !exists {
+			values[key] = r.URL.Query().Get(key)
+		}
+	}
+
+	return values, nil
+}
+
+// requestBodyValues returns the values submitted in the request body,
+// reading up to MaxEncodedSize bytes of it
+func requestBodyValues(r *http.Request) (map[string]string, error) {
+	values := map[string]string{}
+
+	if r.Body != nil {
+		r.Body = http.MaxBytesReader(nil, r.Body, MaxEncodedSize)
+	}
+
+	if err := readRequestBodyValues(r, values); err != nil {
+		maxBytesError := &http.MaxBytesError{}
+		if errors.As(err, &maxBytesError) {
+			return nil, fmt.Errorf("%w: request body exceeds %d bytes", ErrTooLarge, maxBytesError.Limit)
+		}
+		return nil, err
+	}
+
+	return values, nil
+}
+
+// readRequestBodyValues adds the values submitted in the request body to the values
+func readRequestBodyValues(r *http.Request, values map[string]string) error {
+	mediaType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type"))
+
+	switch mediaType {
+	case "application/json":
+		if r.Body == nil {
+			return nil
+		}
+
+		decoder := json.NewDecoder(r.Body)
+		decoder.UseNumber() // keep numbers as submitted
+
+		var body map[string]any
+		if err := decoder.Decode(&body); err != nil {
+			return err
+		}
+
+		for key, value := range mapStringAnyToMapStringString(body) {
+			values[key] = value
+		}
+
+	case "multipart/form-data":
+		if err := r.ParseMultipartForm(32 << 20); err != nil {
+			return err
+		}
+
+		for key := range r.PostForm {
+			values[key] = r.PostForm.Get(key)
+		}
+
+	default:
+		if err := r.ParseForm(); err != nil {
+			return err
+		}
+
+		for key := range r.PostForm {
+			values[key] = r.PostForm.Get(key)
+		}
+	}
+
+	return nil
+}
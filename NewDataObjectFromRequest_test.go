@@ -0,0 +1,97 @@
+package dataobject
+
+import (
+	"errors"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+)
+
+func TestNewDataObjectFromRequestForm(t *testing.T) {
+	form := url.Values{}
+	form.Set("first_name", "Jon")
+	form.Set("role", "admin")
+
+	r := httptest.NewRequest("POST", "/users?last_name=Doe", strings.NewReader(form.Encode()))
+	r.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	user, err := NewDataObjectFromRequest(r, "first_name", "last_name")
+
+	if err != nil {
+		t.Fatal("Error must be nil, but found:", err.Error())
+	}
+
+	if user.Get("first_name") != "Jon" {
+		t.Error("Expected: Jon, but found:", user.Get("first_name"))
+	}
+
+	if user.Get("last_name") != "Doe" {
+		t.Error("Expected: Doe, but found:", user.Get("last_name"))
+	}
+
+	if _, exists := user.Data()["role"]; exists {
+		t.Error("Expected role NOT to be set, but found:", user.Get("role"))
+	}
+}
+
+func TestSetDataFromRequestJSON(t *testing.T) {
+	r := httptest.NewRequest("PUT", "/users/1", strings.NewReader(`{"first_name":"Jane","age":30,"id":"2"}`))
+	r.Header.Set("Content-Type", "application/json; charset=utf-8")
+
+	user := NewDataObjectFromExistingData(map[string]string{"id": "1", "first_name": "Jon"})
+
+	err := user.SetDataFromRequest(r, "first_name", "age")
+
+	if err != nil {
+		t.Fatal("Error must be nil, but found:", err.Error())
+	}
+
+	if user.Get("first_name") != "Jane" {
+		t.Error("Expected: Jane, but found:", user.Get("first_name"))
+	}
+
+	if user.Get("age") != "30" {
+		t.Error("Expected: 30, but found:", user.Get("age"))
+	}
+
+	if user.ID() != "1" {
+		t.Error("Expected: 1, but found:", user.ID())
+	}
+}
+
+func TestSetDataFromRequestInvalidJSON(t *testing.T) {
+	r := httptest.NewRequest("POST", "/users", strings.NewReader(`{invalid`))
+	r.Header.Set("Content-Type", "application/json")
+
+	_, err := NewDataObjectFromRequest(r, "name")
+
+	if err == nil {
+		t.Error("Error must NOT be nil, but found:", err)
+	}
+}
+
+func TestSetDataFromRequestWithoutAllowedKeys(t *testing.T) {
+	r := httptest.NewRequest("POST", "/users", strings.NewReader(`{"id":"2","role":"admin"}`))
+	r.Header.Set("Content-Type", "application/json")
+
+	user := NewDataObjectFromExistingData(map[string]string{"id": "1"})
+
+	if err := user.SetDataFromRequest(r); !errors.Is(err, ErrNoAllowedKeys) {
+		t.Error("Expected: ErrNoAllowedKeys, but found:", err)
+	}
+
+	if user.ID() != "1" || user.IsDirty() {
+		t.Error("Expected: the object to be unchanged, but found:", user.Data())
+	}
+}
+
+func TestSetDataFromRequestTooLarge(t *testing.T) {
+	body := `{"name":"` + strings.Repeat("x", MaxEncodedSize) + `"}`
+	r := httptest.NewRequest("POST", "/users", strings.NewReader(body))
+	r.Header.Set("Content-Type", "application/json")
+
+	if _, err := NewDataObjectFromRequest(r, "name"); !errors.Is(err, ErrTooLarge) {
+		t.Error("Expected: ErrTooLarge, but found:", err)
+	}
+}
@@ -0,0 +1,72 @@
+package dataobject
+
+import (
+	"database/sql"
+	"time"
+)
+
+// NewDataObjectsFromSQLRows creates a new data object per row of the
+// result, with the column names as keys, so ad-hoc queries can produce
+// data objects without scan boilerplate. The rows are closed
+//
+// Values are converted as by the typed setters: numbers as by toString,
+// times in DateTimeFormat (UTC) and booleans as "true" or "false".
+// NULL columns are omitted, so they can be told apart from empty strings
+//
+// Example:
+//
+//	rows, err := db.QueryContext(ctx, "SELECT id, email FROM users WHERE status = ?", "active")
+//	if err != nil {
+//		return err
+//	}
+//	users, err := NewDataObjectsFromSQLRows(rows)
+func NewDataObjectsFromSQLRows(rows *sql.Rows) ([]*DataObject, error) {
+	defer rows.Close()
+
+	columns, err := rows.Columns()
+	if err != nil {
+		return nil, err
+	}
+
+	values := make([]any, len(columns))
+	pointers := make([]any, len(columns))
+	for i := range values {
+		pointers[i] = &values[i]
+	}
+
+	objects := []*DataObject{}
+
+	for rows.Next() {
+		if err := rows.Scan(pointers...); err != nil {
+			return nil, err
+		}
+
+		data := make(map[string]string, len(columns))
+		for i, column := range columns {
+			if values[i] == nil {
+				continue
+			}
+			data[column] = sqlValueToString(values[i])
+		}
+
+		objects = append(objects, NewDataObjectFromExistingData(data))
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return objects, nil
+}
+
+// sqlValueToString converts a value scanned from a database to string
+func sqlValueToString(value any) string {
+	switch v := value.(type) {
+	case time.Time:
+		return v.UTC().Format(DateTimeFormat)
+	case []byte:
+		return string(v)
+	default:
+		return toString(v)
+	}
+}
@@ -0,0 +1,103 @@
+package dataobject
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"io"
+	"testing"
+	"time"
+)
+
+func TestNewDataObjectsFromSQLRows(t *testing.T) {
+	db, err := sql.Open("dataobject_test", "")
+
+	if err != nil {
+		t.Fatal("Error must be nil, but found:", err.Error())
+	}
+
+	defer db.Close()
+
+	rows, err := db.Query("SELECT")
+
+	if err != nil {
+		t.Fatal("Error must be nil, but found:", err.Error())
+	}
+
+	objects, err := NewDataObjectsFromSQLRows(rows)
+
+	if err != nil {
+		t.Fatal("Error must be nil, but found:", err.Error())
+	}
+
+	if len(objects) != 2 {
+		t.Fatal("Expected: 2, but found:", len(objects))
+	}
+
+	expected := map[string]string{
+		"id":         "1",
+		"name":       "Jon",
+		"score":      "9.5000",
+		"active":     "true",
+		"created_at": "2024-01-02 03:04:05",
+	}
+
+	for key, value := range expected {
+		if objects[0].Get(key) != value {
+			t.Error("Expected:", value, "but found:", objects[0].Get(key), "for", key)
+		}
+	}
+
+	if _, exists := objects[1].Data()["name"]; exists {
+		t.Error("Expected the NULL name to be omitted, but found:", objects[1].Data())
+	}
+
+	if objects[0].IsDirty() {
+		t.Error("Expected: false, but found:", objects[0].IsDirty())
+	}
+}
+
+func init() {
+	sql.Register("dataobject_test", testSQLDriver{})
+}
+
+// testSQLDriver is a driver returning fixed rows for any query
+type testSQLDriver struct{}
+
+func (testSQLDriver) Open(name string) (driver.Conn, error) { return testSQLConn{}, nil }
+
+type testSQLConn struct{}
+
+func (testSQLConn) Prepare(query string) (driver.Stmt, error) { return testSQLStmt{}, nil }
+func (testSQLConn) Close() error                              { return nil }
+func (testSQLConn) Begin() (driver.Tx, error)                 { return nil, driver.ErrSkip }
+
+type testSQLStmt struct{}
+
+func (testSQLStmt) Close() error                                    { return nil }
+func (testSQLStmt) NumInput() int                                   { return -1 }
+func (testSQLStmt) Exec(args []driver.Value) (driver.Result, error) { return nil, driver.ErrSkip }
+func (testSQLStmt) Query(args []driver.Value) (driver.Rows, error) {
+	return &testSQLRows{rows: [][]driver.Value{
+		{int64(1), []byte("Jon"), 9.5, true, time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)},
+		{int64(2), nil, 1.0, false, nil},
+	}}, nil
+}
+
+type testSQLRows struct {
+	rows [][]driver.Value
+}
+
+func (r *testSQLRows) Columns() []string {
+	return []string{"id", "name", "score", "active", "created_at"}
+}
+
+func (r *testSQLRows) Close() error { return nil }
+
+func (r *testSQLRows) Next(dest []driver.Value) error {
+	if len(r.rows) < 1 {
+		return io.EOF
+	}
+	copy(dest, r.rows[0])
+	r.rows = r.rows[1:]
+	return nil
+}
@@ -0,0 +1,372 @@
+package dataobject
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"time"
+)
+
+// Outbox actions of the change events written by OutboxRepository
+const (
+	OutboxActionCreate  = "create"
+	OutboxActionUpdate  = "update"
+	OutboxActionDelete  = "delete"
+	OutboxActionRestore = "restore"
+)
+
+// Transactor runs functions in a transaction, which spans the writes
+// of the repositories participating in it through the context
+type Transactor interface {
+	// InTransaction runs the function in a transaction, which is
+	// committed if it returns nil, and rolled back otherwise
+	InTransaction(ctx context.Context, fn func(ctx context.Context) error) error
+}
+
+var _ Transactor = (*SQLTransactor)(nil) // verify it runs transactions
+
+// SQLTransactor runs the functions in transactions of the database.
+// The transaction is passed in the context, so repositories writing to
+// the database must use it when there is one (see SQLConnFromContext)
+//
+// The package has no SQL repository: the repositories of the
+// application take part in the transactions by running their
+// statements on the connection of the context. A repository writing
+// directly to the database is not rolled back with the transaction
+type SQLTransactor struct {
+	db   *sql.DB
+	opts *sql.TxOptions
+}
+
+// NewSQLTransactor creates a new transactor of the database
+func NewSQLTransactor(db *sql.DB) *SQLTransactor {
+	return &SQLTransactor{db: db}
+}
+
+// SetTxOptions sets the options of the transactions, i.e. the isolation level
+func (t *SQLTransactor) SetTxOptions(opts *sql.TxOptions) *SQLTransactor {
+	t.opts = opts
+	return t
+}
+
+// sqlTxKey is the context key of the transaction of SQLTransactor
+type sqlTxKey struct{}
+
+// SQLTxFromContext returns the transaction of the context,
+// begun by SQLTransactor, if there is one
+func SQLTxFromContext(ctx context.Context) (*sql.Tx, bool) {
+	tx, ok := ctx.Value(sqlTxKey{}).(*sql.Tx)
+	return tx, ok
+}
+
+// SQLConn runs statements, i.e. *sql.DB and *sql.Tx
+type SQLConn interface {
+	ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error)
+	QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error)
+	QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row
+}
+
+// SQLConnFromContext returns the transaction of the context, begun by
+// SQLTransactor, or else the database, so repositories run their
+// statements in the transaction when there is one
+//
+// Example:
+//
+//	func (repo *UserRepository) Delete(ctx context.Context, id string) error {
+//		conn := dataobject.SQLConnFromContext(ctx, repo.db)
+//		_, err := conn.ExecContext(ctx, "DELETE FROM users WHERE id = $1", id)
+//		return err
+//	}
+func SQLConnFromContext(ctx context.Context, db *sql.DB) SQLConn {
+	if tx, inTransaction := SQLTxFromContext(ctx); inTransaction {
+		return tx
+	}
+	return db
+}
+
+// InTransaction runs the function in a new transaction, or in
+// the transaction of the context, if it already has one
+func (t *SQLTransactor) InTransaction(ctx context.Context, fn func(ctx context.Context) error) (err error) {
+	if _, inTransaction := SQLTxFromContext(ctx); inTransaction {
+		return fn(ctx)
+	}
+
+	tx, err := t.db.BeginTx(ctx, t.opts)
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback()
+		}
+	}()
+
+	if err = fn(context.WithValue(ctx, sqlTxKey{}, tx)); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+var _ DataObjectRepositoryInterface = (*OutboxRepository)(nil) // verify it extends the repository interface
+var _ ConditionalUpdater = (*OutboxRepository)(nil)            // verify it writes events of conditional updates
+var _ Querier = (*OutboxRepository)(nil)                       // verify it forwards queries
+
+// OutboxRepository decorates a repository writing a change event for
+// every Create, Update and Delete (and UpdateIf, ForceDelete and
+// Restore) into the outbox repository, in the
+// same transaction as the data write (see Transactor), so the events
+// are published by OutboxRelay if, and only if, the writes are committed
+//
+// Both repositories must participate in the transactions of the
+// transactor, i.e. tables of the same database with SQLTransactor, whose
+// repositories run their statements on SQLConnFromContext. Ensuring that
+// is up to the caller, as the decorator cannot check it. Without a
+// transactor the writes are made one after the other, which is only
+// atomic if the repositories are (i.e. for tests)
+//
+// Example:
+//
+//	transactor := NewSQLTransactor(db)
+//	repo := NewOutboxRepository(usersRepo, outboxRepo, transactor)
+//	relay := NewOutboxRelay(outboxRepo, NewWebhookEmitter(secret, endpoint), time.Second)
+//	go relay.Start(ctx)
+type OutboxRepository struct {
+	DataObjectRepositoryInterface
+	outbox     DataObjectRepositoryInterface
+	transactor Transactor
+}
+
+// NewOutboxRepository creates a new outbox decorator of the repository,
+// writing the change events into the outbox repository
+func NewOutboxRepository(inner DataObjectRepositoryInterface, outbox DataObjectRepositoryInterface, transactor Transactor) *OutboxRepository {
+	return &OutboxRepository{DataObjectRepositoryInterface: inner, outbox: outbox, transactor: transactor}
+}
+
+// Create creates the object, and the change event with all its data
+func (repo *OutboxRepository) Create(ctx context.Context, do DataObjectInterface) error {
+	event := ChangeEvent{
+		ObjectID:    do.ID(),
+		ChangedKeys: []string{},
+		Values:      copyData(do.Data()),
+		Action:      OutboxActionCreate,
+	}
+
+	for key := range event.Values {
+		event.ChangedKeys = append(event.ChangedKeys, key)
+	}
+	sort.Strings(event.ChangedKeys)
+
+	return repo.inTransaction(ctx, event, func(ctx context.Context) error {
+		return repo.DataObjectRepositoryInterface.Create(ctx, do)
+	})
+}
+
+// Update updates the object, and creates the change event with its
+// changed and removed keys (see NewChangeEvent)
+func (repo *OutboxRepository) Update(ctx context.Context, do DataObjectInterface) error {
+	event := NewChangeEvent(do)
+	event.Action = OutboxActionUpdate
+
+	return repo.inTransaction(ctx, event, func(ctx context.Context) error {
+		return repo.DataObjectRepositoryInterface.Update(ctx, do)
+	})
+}
+
+// Delete deletes the object with the ID, and creates the change event
+func (repo *OutboxRepository) Delete(ctx context.Context, id string) error {
+	event := ChangeEvent{
+		ObjectID:    id,
+		ChangedKeys: []string{},
+		Values:      map[string]string{},
+		Action:      OutboxActionDelete,
+	}
+
+	return repo.inTransaction(ctx, event, func(ctx context.Context) error {
+		return repo.DataObjectRepositoryInterface.Delete(ctx, id)
+	})
+}
+
+// UpdateIf updates the object, if it matches the entity tag, and
+// creates the change event with its changed and removed keys
+func (repo *OutboxRepository) UpdateIf(ctx context.Context, do DataObjectInterface, expectedETag string) error {
+	event := NewChangeEvent(do)
+	event.Action = OutboxActionUpdate
+
+	return repo.inTransaction(ctx, event, func(ctx context.Context) error {
+		return forwardUpdateIf(ctx, repo.DataObjectRepositoryInterface, do, expectedETag)
+	})
+}
+
+// ForceDelete deletes the object with the ID permanently,
+// and creates the change event of the delete
+func (repo *OutboxRepository) ForceDelete(ctx context.Context, id string) error {
+	event := ChangeEvent{
+		ObjectID:    id,
+		ChangedKeys: []string{},
+		Values:      map[string]string{},
+		Action:      OutboxActionDelete,
+	}
+
+	return repo.inTransaction(ctx, event, func(ctx context.Context) error {
+		return forwardForceDelete(ctx, repo.DataObjectRepositoryInterface, id)
+	})
+}
+
+// Restore restores the soft deleted object with the ID,
+// and creates the change event with the "restore" action
+func (repo *OutboxRepository) Restore(ctx context.Context, id string) error {
+	event := ChangeEvent{
+		ObjectID:    id,
+		ChangedKeys: []string{SoftDeletedAtKey},
+		Values:      map[string]string{SoftDeletedAtKey: ""},
+		Action:      OutboxActionRestore,
+	}
+
+	return repo.inTransaction(ctx, event, func(ctx context.Context) error {
+		return forwardRestore(ctx, repo.DataObjectRepositoryInterface, id)
+	})
+}
+
+// Query returns the objects matching the query
+func (repo *OutboxRepository) Query(ctx context.Context, query *Query) ([]DataObjectInterface, error) {
+	return forwardQuery(ctx, repo.DataObjectRepositoryInterface, query)
+}
+
+// inTransaction runs the write, and writes the event into the outbox,
+// in a transaction
+func (repo *OutboxRepository) inTransaction(ctx context.Context, event ChangeEvent, write func(ctx context.Context) error) error {
+	entry, err := newOutboxEntry(event)
+	if err != nil {
+		return err
+	}
+
+	writeWithEvent := func(ctx context.Context) error {
+		if err := write(ctx); err != nil {
+			return err
+		}
+		return repo.outbox.Create(ctx, entry)
+	}
+
+	if repo.transactor == nil {
+		return writeWithEvent(ctx)
+	}
+
+	return repo.transactor.InTransaction(ctx, writeWithEvent)
+}
+
+// newOutboxEntry creates the outbox object of the event, with a time
+// ordered ID, so the events are relayed in the order of the writes
+func newOutboxEntry(event ChangeEvent) (*DataObject, error) {
+	event.EventID = HumanUID()
+
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return nil, err
+	}
+
+	entry := NewDataObjectFromExistingData(map[string]string{
+		"id":         event.EventID,
+		"object_id":  event.ObjectID,
+		"action":     event.Action,
+		"event":      string(payload),
+		"created_at": time.Now().UTC().Format(time.RFC3339Nano),
+	})
+
+	return entry, nil
+}
+
+// OutboxRelay publishes the change events written into an outbox
+// by OutboxRepository with the emitter, in the order of the writes
+//
+// An event is deleted from the outbox after it is published, so it is
+// published at least once: if the deletion fails, or the process stops
+// in between, it is published again. Receivers should deduplicate
+// the events by their ID (see ChangeEvent.EventID)
+type OutboxRelay struct {
+	outbox    DataObjectRepositoryInterface
+	emitter   EventEmitter
+	interval  time.Duration
+	batchSize int
+}
+
+// OutboxRelayDefaultInterval is the interval of an OutboxRelay
+// created with an interval, which is not positive
+const OutboxRelayDefaultInterval = time.Second
+
+// NewOutboxRelay creates a new relay of the outbox, publishing on the
+// interval once started (OutboxRelayDefaultInterval if not positive),
+// 100 events at a time
+func NewOutboxRelay(outbox DataObjectRepositoryInterface, emitter EventEmitter, interval time.Duration) *OutboxRelay {
+	if interval <= 0 {
+		interval = OutboxRelayDefaultInterval
+	}
+
+	return &OutboxRelay{
+		outbox:    outbox,
+		emitter:   emitter,
+		interval:  interval,
+		batchSize: 100,
+	}
+}
+
+// SetBatchSize sets the maximum number of events published by Relay (0 for no limit)
+func (r *OutboxRelay) SetBatchSize(batchSize int) *OutboxRelay {
+	r.batchSize = batchSize
+	return r
+}
+
+// Start publishes the events on the interval until the context
+// is done. It blocks, so run it in a goroutine
+func (r *OutboxRelay) Start(ctx context.Context) error {
+	ticker := time.NewTicker(r.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			for {
+				published, err := r.Relay(ctx)
+				if err != nil || published == 0 || r.batchSize < 1 || published < r.batchSize {
+					break
+				}
+			}
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+// Relay publishes up to the batch size of the oldest events, deleting
+// them from the outbox, and returns the number of published events.
+// It stops at the first event, which fails to publish, so the order
+// is kept, and returns the error
+func (r *OutboxRelay) Relay(ctx context.Context) (int, error) {
+	entries, err := r.outbox.List(ctx, 0, r.batchSize)
+	if err != nil {
+		return 0, err
+	}
+
+	published := 0
+	for _, entry := range entries {
+		event := ChangeEvent{}
+		if err := json.Unmarshal([]byte(entry.Data()["event"]), &event); err != nil {
+			return published, fmt.Errorf("%w: outbox event %s: %s", ErrCorrupted, entry.ID(), err.Error())
+		}
+
+		if err := r.emitter.Emit(ctx, event); err != nil {
+			return published, err
+		}
+
+		if err := r.outbox.Delete(ctx, entry.ID()); err != nil && !IsNotFound(err) {
+			return published, err
+		}
+
+		published++
+	}
+
+	return published, nil
+}
@@ -0,0 +1,176 @@
+package dataobject
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+)
+
+type testTransactor struct {
+	transactions int
+	rolledBack   int
+}
+
+func (tr *testTransactor) InTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	tr.transactions++
+	err := fn(ctx)
+	if err != nil {
+		tr.rolledBack++
+	}
+	return err
+}
+
+type unavailableOutbox struct {
+	*MemoryRepository
+}
+
+func (repo *unavailableOutbox) Create(ctx context.Context, do DataObjectInterface) error {
+	return ErrUnavailable
+}
+
+type failingOnceEmitter struct {
+	testEmitter
+	failed bool
+}
+
+func (e *failingOnceEmitter) Emit(ctx context.Context, event ChangeEvent) error {
+	if !e.failed {
+		e.failed = true
+		return ErrUnavailable
+	}
+	return e.testEmitter.Emit(ctx, event)
+}
+
+func TestOutboxRepository(t *testing.T) {
+	ctx := context.Background()
+	outbox := NewMemoryRepository()
+	transactor := &testTransactor{}
+	repo := NewOutboxRepository(NewMemoryRepository(), outbox, transactor)
+
+	user := NewDataObjectFromExistingData(map[string]string{"id": "1", "name": "Jon"})
+	if err := repo.Create(ctx, user); err != nil {
+		t.Fatal("Error must be nil, but found:", err.Error())
+	}
+
+	user.Set("name", "Tim")
+	if err := repo.Update(ctx, user); err != nil {
+		t.Fatal("Error must be nil, but found:", err.Error())
+	}
+
+	if err := repo.Delete(ctx, "1"); err != nil {
+		t.Fatal("Error must be nil, but found:", err.Error())
+	}
+
+	if transactor.transactions != 3 {
+		t.Error("Expected: 3 transactions, but found:", transactor.transactions)
+	}
+
+	if count, _ := outbox.Count(ctx); count != 3 {
+		t.Fatal("Expected: 3 outbox events, but found:", count)
+	}
+
+	emitter := &testEmitter{}
+	published, err := NewOutboxRelay(outbox, emitter, 0).Relay(ctx)
+	if err != nil {
+		t.Fatal("Error must be nil, but found:", err.Error())
+	}
+
+	if published != 3 || len(emitter.events) != 3 {
+		t.Fatal("Expected: 3 published events, but found:", published, emitter.events)
+	}
+
+	actions := []string{OutboxActionCreate, OutboxActionUpdate, OutboxActionDelete}
+	for i, event := range emitter.events {
+		if event.Action != actions[i] || event.ObjectID != "1" || event.EventID == "" {
+			t.Error("Expected:", actions[i], "event of 1 with an ID, but found:", event)
+		}
+	}
+
+	if emitter.events[1].Values["name"] != "Tim" {
+		t.Error("Expected: Tim, but found:", emitter.events[1].Values)
+	}
+
+	if count, _ := outbox.Count(ctx); count != 0 {
+		t.Error("Expected: the published events to be deleted, but found:", count)
+	}
+}
+
+func TestOutboxRepositoryFailedWrites(t *testing.T) {
+	ctx := context.Background()
+	outbox := NewMemoryRepository()
+	transactor := &testTransactor{}
+	repo := NewOutboxRepository(NewMemoryRepository(), outbox, transactor)
+
+	if err := repo.Delete(ctx, "missing"); !IsNotFound(err) {
+		t.Error("Expected: ErrNotFound, but found:", err)
+	}
+
+	if count, _ := outbox.Count(ctx); count != 0 {
+		t.Error("Expected: no event for a failed write, but found:", count)
+	}
+
+	repo = NewOutboxRepository(NewMemoryRepository(), &unavailableOutbox{MemoryRepository: NewMemoryRepository()}, transactor)
+
+	if err := repo.Create(ctx, NewDataObjectFromExistingData(map[string]string{"id": "1"})); !errors.Is(err, ErrUnavailable) {
+		t.Error("Expected: the error of the outbox write, but found:", err)
+	}
+
+	if transactor.rolledBack != 2 {
+		t.Error("Expected: 2 rolled back transactions, but found:", transactor.rolledBack)
+	}
+}
+
+func TestOutboxRelayKeepsOrderOnFailure(t *testing.T) {
+	ctx := context.Background()
+	outbox := NewMemoryRepository()
+	repo := NewOutboxRepository(NewMemoryRepository(), outbox, nil)
+
+	_ = repo.Create(ctx, NewDataObjectFromExistingData(map[string]string{"id": "1"}))
+	_ = repo.Create(ctx, NewDataObjectFromExistingData(map[string]string{"id": "2"}))
+
+	emitter := &failingOnceEmitter{}
+	relay := NewOutboxRelay(outbox, emitter, 0)
+
+	if published, err := relay.Relay(ctx); !errors.Is(err, ErrUnavailable) || published != 0 {
+		t.Error("Expected: ErrUnavailable with nothing published, but found:", published, err)
+	}
+
+	if count, _ := outbox.Count(ctx); count != 2 {
+		t.Error("Expected: the events to be kept, but found:", count)
+	}
+
+	if published, err := relay.Relay(ctx); err != nil || published != 2 {
+		t.Fatal("Expected: 2 published events, but found:", published, err)
+	}
+
+	if emitter.events[0].ObjectID != "1" || emitter.events[1].ObjectID != "2" {
+		t.Error("Expected: the events in the order of the writes, but found:", emitter.events)
+	}
+}
+
+func TestOutboxRelayStartWithoutInterval(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	relay := NewOutboxRelay(NewMemoryRepository(), &testEmitter{}, 0)
+
+	cancel()
+
+	if err := relay.Start(ctx); !errors.Is(err, context.Canceled) {
+		t.Error("Expected: context.Canceled, but found:", err)
+	}
+}
+
+func TestSQLConnFromContext(t *testing.T) {
+	db, _ := sql.Open("dataobject_test", "")
+	defer db.Close()
+
+	ctx := context.Background()
+	if conn := SQLConnFromContext(ctx, db); conn != db {
+		t.Error("Expected: the database, but found:", conn)
+	}
+
+	tx := &sql.Tx{}
+	if conn := SQLConnFromContext(context.WithValue(ctx, sqlTxKey{}, tx), db); conn != tx {
+		t.Error("Expected: the transaction, but found:", conn)
+	}
+}
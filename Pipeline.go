@@ -0,0 +1,166 @@
+package dataobject
+
+// PipelineStage is a single step of a pipeline. It returns the
+// (possibly transformed) object, and false if the object must be dropped
+type PipelineStage func(do DataObjectInterface) (DataObjectInterface, bool)
+
+// Pipeline composes stages, which are applied lazily one object
+// at a time to collections or streams of data objects
+//
+// The key based stages (Transform, Rename, Drop) modify copies of the
+// objects, so the source objects are left as they are. The copies keep
+// the changed and removed keys of the objects, and track the changes
+// of the stages, so writing them back updates the modified keys
+//
+// Example:
+//
+//	pipeline := NewPipeline().
+//		Filter(func(do DataObjectInterface) bool { return do.Data()["status"] == "active" }).
+//		Rename("first_name", "given_name").
+//		Transform("email", strings.ToLower).
+//		Drop("password")
+//
+//	for do := range pipeline.Stream(source) {
+//		target.Save(do)
+//	}
+type Pipeline struct {
+	stages []PipelineStage
+}
+
+// NewPipeline creates a new empty pipeline
+func NewPipeline() *Pipeline {
+	return &Pipeline{}
+}
+
+// Stage appends a custom stage to the pipeline
+func (p *Pipeline) Stage(stage PipelineStage) *Pipeline {
+	p.stages = append(p.stages, stage)
+	return p
+}
+
+// Map appends a stage replacing each object with the result of fn
+func (p *Pipeline) Map(fn func(do DataObjectInterface) DataObjectInterface) *Pipeline {
+	return p.Stage(func(do DataObjectInterface) (DataObjectInterface, bool) {
+		return fn(do), true
+	})
+}
+
+// Filter appends a stage keeping only the objects for which fn returns true
+func (p *Pipeline) Filter(fn func(do DataObjectInterface) bool) *Pipeline {
+	return p.Stage(func(do DataObjectInterface) (DataObjectInterface, bool) {
+		return do, fn(do)
+	})
+}
+
+// Transform appends a stage replacing the value of the key
+// with the result of fn. Objects without the key, or with the key
+// marked as raw (see DataObject.WithRawKeys), are left as they are
+func (p *Pipeline) Transform(key string, fn func(value string) string) *Pipeline {
+	return p.Stage(func(do DataObjectInterface) (DataObjectInterface, bool) {
+		value, exists := do.Data()[key]
+		if !exists || isRawKey(do, key) {
+			return do, true
+		}
+
+		transformed := pipelineCopy(do)
+		transformed.Set(key, fn(value))
+
+		return transformed, true
+	})
+}
+
+// Rename appends a stage moving the value of the old key to the new key
+func (p *Pipeline) Rename(oldKey string, newKey string) *Pipeline {
+	return p.Stage(func(do DataObjectInterface) (DataObjectInterface, bool) {
+		value, exists := do.Data()[oldKey]
+		if !exists {
+			return do, true
+		}
+
+		renamed := pipelineCopy(do)
+		renamed.Remove(oldKey)
+		renamed.Set(newKey, value)
+
+		return renamed, true
+	})
+}
+
+// Drop appends a stage removing the keys from the objects
+func (p *Pipeline) Drop(keys ...string) *Pipeline {
+	return p.Stage(func(do DataObjectInterface) (DataObjectInterface, bool) {
+		dropped := pipelineCopy(do)
+
+		for _, key := range keys {
+			dropped.Remove(key)
+		}
+
+		return dropped, true
+	})
+}
+
+// pipelineCopy returns a copy of the object for a stage to modify, with
+// the changed, removed and raw keys of the object
+func pipelineCopy(do DataObjectInterface) *DataObject {
+	copied := NewDataObjectFromExistingData(copyData(do.Data()))
+
+	for key := range do.DataChanged() {
+		copied.MarkFieldDirty(key)
+	}
+
+	if removable, hasRemoved := do.(interface{ DataRemoved() []string }); hasRemoved {
+		for _, key := range removable.DataRemoved() {
+			copied.dataRemoved[key] = struct{}{}
+		}
+	}
+
+	if raw, hasRawKeys := do.(interface{ RawKeys() []string }); hasRawKeys {
+		copied.WithRawKeys(raw.RawKeys()...)
+	}
+
+	return copied
+}
+
+// Apply runs all the stages on a single object. It returns false
+// if the object has been dropped by any of the stages
+func (p *Pipeline) Apply(do DataObjectInterface) (DataObjectInterface, bool) {
+	for _, stage := range p.stages {
+		var keep bool
+		do, keep = stage(do)
+		if !keep {
+			return nil, false
+		}
+	}
+
+	return do, true
+}
+
+// Run applies the pipeline to a collection of objects
+func (p *Pipeline) Run(objects []DataObjectInterface) []DataObjectInterface {
+	result := []DataObjectInterface{}
+
+	for _, do := range objects {
+		if do, keep := p.Apply(do); keep {
+			result = append(result, do)
+		}
+	}
+
+	return result
+}
+
+// Stream applies the pipeline to a stream of objects. The returned
+// channel is closed once the input channel is closed and drained
+func (p *Pipeline) Stream(in <-chan DataObjectInterface) <-chan DataObjectInterface {
+	out := make(chan DataObjectInterface)
+
+	go func() {
+		defer close(out)
+
+		for do := range in {
+			if do, keep := p.Apply(do); keep {
+				out <- do
+			}
+		}
+	}()
+
+	return out
+}
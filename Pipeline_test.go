@@ -0,0 +1,106 @@
+package dataobject
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestPipelineRun(t *testing.T) {
+	user1 := NewDataObject()
+	user1.Set("status", "active")
+	user1.Set("first_name", "Jon")
+	user1.Set("email", "JON@TEST.COM")
+	user1.Set("password", "secret")
+
+	user2 := NewDataObject()
+	user2.Set("status", "inactive")
+
+	pipeline := NewPipeline().
+		Filter(func(do DataObjectInterface) bool { return do.Data()["status"] == "active" }).
+		Rename("first_name", "given_name").
+		Transform("email", strings.ToLower).
+		Drop("password")
+
+	result := pipeline.Run([]DataObjectInterface{user1, user2})
+
+	if len(result) != 1 {
+		t.Fatal("Expected: 1, but found:", len(result))
+	}
+
+	data := result[0].Data()
+
+	if data["given_name"] != "Jon" {
+		t.Error("Expected: Jon, but found:", data["given_name"])
+	}
+
+	if _, exists := data["first_name"]; exists {
+		t.Error("Expected first_name to be renamed, but found:", data["first_name"])
+	}
+
+	if data["email"] != "jon@test.com" {
+		t.Error("Expected: jon@test.com, but found:", data["email"])
+	}
+
+	if _, exists := data["password"]; exists {
+		t.Error("Expected password to be dropped, but found:", data["password"])
+	}
+}
+
+func TestPipelineStream(t *testing.T) {
+	in := make(chan DataObjectInterface)
+
+	go func() {
+		defer close(in)
+		for i := 0; i < 3; i++ {
+			in <- NewDataObject()
+		}
+	}()
+
+	pipeline := NewPipeline().Map(func(do DataObjectInterface) DataObjectInterface {
+		data := do.Data()
+		data["processed"] = "yes"
+		do.Hydrate(data)
+		return do
+	})
+
+	count := 0
+	for do := range pipeline.Stream(in) {
+		if do.Data()["processed"] != "yes" {
+			t.Error("Expected: yes, but found:", do.Data()["processed"])
+		}
+		count++
+	}
+
+	if count != 3 {
+		t.Error("Expected: 3, but found:", count)
+	}
+}
+
+func TestPipelineCopiesObjects(t *testing.T) {
+	user := NewDataObjectFromExistingData(map[string]string{"id": "1", "email": "JON@TEST.COM", "first_name": "Jon", "password": "secret"})
+	user.Freeze()
+
+	result, keep := NewPipeline().
+		Transform("email", strings.ToLower).
+		Rename("first_name", "given_name").
+		Drop("password").
+		Apply(user)
+
+	if !keep {
+		t.Fatal("Expected: the object to be kept")
+	}
+
+	if user.Get("email") != "JON@TEST.COM" || user.Get("password") != "secret" || user.IsDirty() {
+		t.Error("Expected: the source object to be left as it is, but found:", user.Data())
+	}
+
+	changed := result.DataChanged()
+	if changed["email"] != "jon@test.com" || changed["given_name"] != "Jon" || len(changed) != 2 {
+		t.Error("Expected: the email and given_name to be changed, but found:", changed)
+	}
+
+	removed := result.(*DataObject).DataRemoved()
+	if len(removed) != 2 {
+		t.Error("Expected: first_name and password to be removed, but found:", removed)
+	}
+}
@@ -0,0 +1,290 @@
+package dataobject
+
+import (
+	"context"
+	"fmt"
+)
+
+// AccessPolicy authorizes the access to single objects
+// (row-level authorization), see PolicyRepository
+type AccessPolicy interface {
+	// CanRead returns if the object can be read
+	CanRead(ctx context.Context, do DataObjectInterface) bool
+
+	// CanWrite returns if the object can be written with the changed
+	// values. On create the object is the new object with all its
+	// values as changed. On update it is the stored object, with the
+	// values differing from it as changed (removed keys as empty
+	// values). On delete it is the stored object, with nil changes
+	CanWrite(ctx context.Context, do DataObjectInterface, changed map[string]string) bool
+}
+
+var _ AccessPolicy = AccessPolicyFuncs{} // verify it is an access policy
+
+// AccessPolicyFuncs is an access policy from functions.
+// A nil function allows the access
+type AccessPolicyFuncs struct {
+	Read  func(ctx context.Context, do DataObjectInterface) bool
+	Write func(ctx context.Context, do DataObjectInterface, changed map[string]string) bool
+}
+
+// CanRead calls the Read function
+func (p AccessPolicyFuncs) CanRead(ctx context.Context, do DataObjectInterface) bool {
+	return p.Read == nil || p.Read(ctx, do)
+}
+
+// CanWrite calls the Write function
+func (p AccessPolicyFuncs) CanWrite(ctx context.Context, do DataObjectInterface, changed map[string]string) bool {
+	return p.Write == nil || p.Write(ctx, do, changed)
+}
+
+// OwnerPolicy returns an access policy allowing everyone to read, and
+// only the owner to write, i.e. owner-only edits. The owner is the value
+// of the owner key, and the current subject (i.e. the signed in user ID)
+// is returned by the function. The owner key cannot be changed
+//
+// Example:
+//
+//	policy := OwnerPolicy("user_id", func(ctx context.Context) string {
+//		return auth.UserID(ctx)
+//	})
+//	repo := NewPolicyRepository(postsRepo, policy)
+func OwnerPolicy(ownerKey string, subject func(ctx context.Context) string) AccessPolicy {
+	return AccessPolicyFuncs{
+		Write: func(ctx context.Context, do DataObjectInterface, changed map[string]string) bool {
+			current := subject(ctx)
+			if current == "" || do.Data()[ownerKey] != current {
+				return false
+			}
+			newOwner, changesOwner := changed[ownerKey]
+			return !changesOwner || newOwner == current
+		},
+	}
+}
+
+var _ DataObjectRepositoryInterface = (*PolicyRepository)(nil) // verify it extends the repository interface
+var _ ConditionalUpdater = (*PolicyRepository)(nil)            // verify it forwards conditional updates
+var _ Querier = (*PolicyRepository)(nil)                       // verify it forwards queries
+
+// PolicyRepository decorates a repository enforcing the access policy
+// on every object, so authorization does not have to be checked in
+// every handler
+//
+// Objects, which cannot be read, are hidden: Find, Update and Delete
+// return ErrNotFound, and List and Count skip them. Denied writes
+// return an error wrapping ErrForbidden. Updates and deletes are
+// authorized against the stored object, so the submitted data cannot
+// forge the ownership
+//
+// List and Count read all the objects of the decorated repository
+// to filter them, and Query all the objects matching the query. The
+// force deletes and restores of soft deleted objects are authorized
+// as deletes
+type PolicyRepository struct {
+	DataObjectRepositoryInterface
+	policy AccessPolicy
+}
+
+// NewPolicyRepository creates a new decorator of the repository
+// enforcing the access policy
+func NewPolicyRepository(inner DataObjectRepositoryInterface, policy AccessPolicy) *PolicyRepository {
+	return &PolicyRepository{DataObjectRepositoryInterface: inner, policy: policy}
+}
+
+// Create creates the object, if the policy allows writing it
+func (repo *PolicyRepository) Create(ctx context.Context, do DataObjectInterface) error {
+	if !repo.policy.CanWrite(ctx, do, do.Data()) {
+		return forbidden(do.ID())
+	}
+	return repo.DataObjectRepositoryInterface.Create(ctx, do)
+}
+
+// Find returns the object, if the policy allows reading it
+func (repo *PolicyRepository) Find(ctx context.Context, id string) (DataObjectInterface, error) {
+	do, err := repo.DataObjectRepositoryInterface.Find(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if !repo.policy.CanRead(ctx, do) {
+		return nil, notFound(id)
+	}
+
+	return do, nil
+}
+
+// Update updates the object, if the policy allows writing
+// the changed values to the stored object
+func (repo *PolicyRepository) Update(ctx context.Context, do DataObjectInterface) error {
+	stored, err := repo.Find(ctx, do.ID())
+	if err != nil {
+		return err
+	}
+
+	if !repo.policy.CanWrite(ctx, stored, policyChanges(stored, do)) {
+		return forbidden(do.ID())
+	}
+
+	return repo.DataObjectRepositoryInterface.Update(ctx, do)
+}
+
+// Delete deletes the object, if the policy allows writing the stored object
+func (repo *PolicyRepository) Delete(ctx context.Context, id string) error {
+	stored, err := repo.Find(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	if !repo.policy.CanWrite(ctx, stored, nil) {
+		return forbidden(id)
+	}
+
+	return repo.DataObjectRepositoryInterface.Delete(ctx, id)
+}
+
+// UpdateIf updates the object, if it matches the entity tag and
+// the policy allows writing the changed values to the stored object
+func (repo *PolicyRepository) UpdateIf(ctx context.Context, do DataObjectInterface, expectedETag string) error {
+	stored, err := repo.Find(ctx, do.ID())
+	if err != nil {
+		return err
+	}
+
+	if !repo.policy.CanWrite(ctx, stored, policyChanges(stored, do)) {
+		return forbidden(do.ID())
+	}
+
+	return forwardUpdateIf(ctx, repo.DataObjectRepositoryInterface, do, expectedETag)
+}
+
+// ForceDelete deletes the object permanently, even if it is
+// soft deleted, if the policy allows writing the stored object
+func (repo *PolicyRepository) ForceDelete(ctx context.Context, id string) error {
+	if err := repo.authorizeWithDeleted(ctx, id); err != nil {
+		return err
+	}
+
+	return forwardForceDelete(ctx, repo.DataObjectRepositoryInterface, id)
+}
+
+// Restore restores the soft deleted object,
+// if the policy allows writing the stored object
+func (repo *PolicyRepository) Restore(ctx context.Context, id string) error {
+	if err := repo.authorizeWithDeleted(ctx, id); err != nil {
+		return err
+	}
+
+	return forwardRestore(ctx, repo.DataObjectRepositoryInterface, id)
+}
+
+// authorizeWithDeleted returns an error, unless the policy allows
+// reading and writing the stored object, which may be soft deleted
+func (repo *PolicyRepository) authorizeWithDeleted(ctx context.Context, id string) error {
+	stored, err := findWithDeleted(ctx, repo.DataObjectRepositoryInterface, id)
+	if err != nil {
+		return err
+	}
+
+	if !repo.policy.CanRead(ctx, stored) {
+		return notFound(id)
+	}
+
+	if !repo.policy.CanWrite(ctx, stored, nil) {
+		return forbidden(id)
+	}
+
+	return nil
+}
+
+// Query returns the readable objects matching the query, limited
+// and offset as set in the query after hiding the unreadable ones
+func (repo *PolicyRepository) Query(ctx context.Context, query *Query) ([]DataObjectInterface, error) {
+	unpaged := *query
+	unpaged.limit, unpaged.offset = 0, 0
+
+	objects, err := forwardQuery(ctx, repo.DataObjectRepositoryInterface, &unpaged)
+	if err != nil {
+		return nil, err
+	}
+
+	readable := make([]DataObjectInterface, 0, len(objects))
+	for _, do := range objects {
+		if repo.policy.CanRead(ctx, do) {
+			readable = append(readable, do)
+		}
+	}
+
+	offset := min(query.offset, len(readable))
+	end := len(readable)
+	if query.limit > 0 {
+		end = min(offset+query.limit, len(readable))
+	}
+
+	return readable[offset:end], nil
+}
+
+// List returns up to limit readable objects (all if limit is 0)
+// sorted by ID, skipping the first offset readable objects
+func (repo *PolicyRepository) List(ctx context.Context, offset int, limit int) ([]DataObjectInterface, error) {
+	readable, err := repo.readable(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	offset = min(max(offset, 0), len(readable))
+	end := len(readable)
+	if limit > 0 {
+		end = min(offset+limit, len(readable))
+	}
+
+	return readable[offset:end], nil
+}
+
+// Count returns the number of readable objects
+func (repo *PolicyRepository) Count(ctx context.Context) (int, error) {
+	readable, err := repo.readable(ctx)
+	return len(readable), err
+}
+
+// readable returns all the objects, which the policy allows reading
+func (repo *PolicyRepository) readable(ctx context.Context) ([]DataObjectInterface, error) {
+	objects, err := repo.DataObjectRepositoryInterface.List(ctx, 0, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	readable := make([]DataObjectInterface, 0, len(objects))
+	for _, do := range objects {
+		if repo.policy.CanRead(ctx, do) {
+			readable = append(readable, do)
+		}
+	}
+
+	return readable, nil
+}
+
+// policyChanges returns the changed values of the object, and the values
+// differing from the stored object, with the missing keys as empty
+// values, as repositories may store the whole data of the object
+func policyChanges(stored DataObjectInterface, do DataObjectInterface) map[string]string {
+	changed := copyData(do.DataChanged())
+
+	data := do.Data()
+	for key, value := range data {
+		if storedValue, exists := stored.Data()[key]; !exists || storedValue != value {
+			changed[key] = value
+		}
+	}
+	for key := range stored.Data() {
+		if _, exists := data[key]; !exists {
+			changed[key] = ""
+		}
+	}
+
+	return changed
+}
+
+// forbidden returns an error wrapping ErrForbidden for the ID
+func forbidden(id string) error {
+	return fmt.Errorf("%w: %s", ErrForbidden, id)
+}
@@ -0,0 +1,117 @@
+package dataobject
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+type policySubjectKey struct{}
+
+func policyTestContext(subject string) context.Context {
+	return context.WithValue(context.Background(), policySubjectKey{}, subject)
+}
+
+func policyTestSubject(ctx context.Context) string {
+	subject, _ := ctx.Value(policySubjectKey{}).(string)
+	return subject
+}
+
+func newPolicyTestRepository() *PolicyRepository {
+	inner := NewMemoryRepository()
+	_ = inner.Create(context.Background(), NewDataObjectFromExistingData(map[string]string{"id": "1", "user_id": "jon", "title": "Jon's post"}))
+	_ = inner.Create(context.Background(), NewDataObjectFromExistingData(map[string]string{"id": "2", "user_id": "jane", "title": "Jane's post"}))
+	_ = inner.Create(context.Background(), NewDataObjectFromExistingData(map[string]string{"id": "3", "user_id": "jane", "title": "Draft", "draft": "yes"}))
+
+	owner := OwnerPolicy("user_id", policyTestSubject)
+	policy := AccessPolicyFuncs{
+		// drafts are only visible to their owners
+		Read: func(ctx context.Context, do DataObjectInterface) bool {
+			return do.Data()["draft"] != "yes" || do.Data()["user_id"] == policyTestSubject(ctx)
+		},
+		Write: owner.CanWrite,
+	}
+
+	return NewPolicyRepository(inner, policy)
+}
+
+func TestPolicyRepositoryOwnerOnlyEdits(t *testing.T) {
+	repo := newPolicyTestRepository()
+	ctx := policyTestContext("jon")
+
+	found, err := repo.Find(ctx, "2")
+	if err != nil {
+		t.Fatal("Error must be nil, but found:", err.Error())
+	}
+
+	post := NewDataObjectFromExistingData(copyData(found.Data()))
+	post.Set("title", "Hacked")
+	if err := repo.Update(ctx, post); !errors.Is(err, ErrForbidden) {
+		t.Error("Expected: ErrForbidden, but found:", err)
+	}
+
+	// the ownership cannot be forged with unmarked values
+	forged := NewDataObjectFromExistingData(map[string]string{"id": "2", "user_id": "jon", "title": "Hacked"})
+	if err := repo.Update(ctx, forged); !errors.Is(err, ErrForbidden) {
+		t.Error("Expected: ErrForbidden, but found:", err)
+	}
+
+	if err := repo.Delete(ctx, "2"); !errors.Is(err, ErrForbidden) {
+		t.Error("Expected: ErrForbidden, but found:", err)
+	}
+
+	own, _ := repo.Find(ctx, "1")
+	post = NewDataObjectFromExistingData(copyData(own.Data()))
+	post.Set("title", "Edited")
+	if err := repo.Update(ctx, post); err != nil {
+		t.Error("Error must be nil, but found:", err.Error())
+	}
+
+	post.Set("user_id", "jane")
+	if err := repo.Update(ctx, post); !errors.Is(err, ErrForbidden) {
+		t.Error("Expected: ErrForbidden when giving away the post, but found:", err)
+	}
+
+	if err := repo.Create(ctx, NewDataObjectFromExistingData(map[string]string{"id": "4", "user_id": "jane"})); !errors.Is(err, ErrForbidden) {
+		t.Error("Expected: ErrForbidden when creating for another user, but found:", err)
+	}
+
+	if err := repo.Create(ctx, NewDataObjectFromExistingData(map[string]string{"id": "4", "user_id": "jon"})); err != nil {
+		t.Error("Error must be nil, but found:", err.Error())
+	}
+}
+
+func TestPolicyRepositoryHidesUnreadable(t *testing.T) {
+	repo := newPolicyTestRepository()
+
+	if _, err := repo.Find(policyTestContext("jon"), "3"); !IsNotFound(err) {
+		t.Error("Expected: ErrNotFound for the draft of another user, but found:", err)
+	}
+
+	if err := repo.Delete(policyTestContext("jon"), "3"); !IsNotFound(err) {
+		t.Error("Expected: ErrNotFound, but found:", err)
+	}
+
+	if count, _ := repo.Count(policyTestContext("jon")); count != 2 {
+		t.Error("Expected: 2, but found:", count)
+	}
+
+	objects, _ := repo.List(policyTestContext("jane"), 1, 5)
+	if len(objects) != 2 || objects[0].ID() != "2" || objects[1].ID() != "3" {
+		t.Error("Expected: [2 3], but found:", objects)
+	}
+}
+
+func TestPolicyRepositoryCRUDHandler(t *testing.T) {
+	handler := NewCRUDHandler(newPolicyTestRepository(), nil, "title")
+
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, httptest.NewRequest("PUT", "/2", strings.NewReader(`{"title":"Hacked"}`)))
+
+	if w.Code != http.StatusForbidden {
+		t.Error("Expected:", http.StatusForbidden, "but found:", w.Code, w.Body.String())
+	}
+}
@@ -0,0 +1,539 @@
+package dataobject
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// QueryOperator is the comparison operator of a query condition
+type QueryOperator string
+
+const (
+	QueryEq       QueryOperator = "="
+	QueryNe       QueryOperator = "<>"
+	QueryGt       QueryOperator = ">"
+	QueryGte      QueryOperator = ">="
+	QueryLt       QueryOperator = "<"
+	QueryLte      QueryOperator = "<="
+	QueryIn       QueryOperator = "IN"
+	QueryContains QueryOperator = "LIKE"
+)
+
+// QueryCondition is a single condition of a query
+type QueryCondition struct {
+	Key      string
+	Operator QueryOperator
+	Values   []string
+}
+
+// QueryScope selects the soft deleted objects matched by a query
+type QueryScope string
+
+const (
+	// QueryScopeDefault leaves the soft deleted objects to the backend,
+	// which excludes them if it supports soft delete
+	QueryScopeDefault QueryScope = ""
+
+	// QueryScopeWithoutDeleted excludes the soft deleted objects
+	QueryScopeWithoutDeleted QueryScope = "without_deleted"
+
+	// QueryScopeWithDeleted includes the soft deleted objects
+	QueryScopeWithDeleted QueryScope = "with_deleted"
+
+	// QueryScopeOnlyDeleted matches only the soft deleted objects
+	QueryScopeOnlyDeleted QueryScope = "only_deleted"
+)
+
+// QueryOrder is a single sort order of a query
+type QueryOrder struct {
+	Key        string
+	Descending bool
+}
+
+// Query is a composable, backend independent filter of data objects,
+// which can be evaluated in memory (see Matches and Apply),
+// translated to SQL (see ToSQL), or passed to a Querier repository
+//
+// All the conditions must match. The comparisons are the same in memory
+// and in SQL, so a query returns the same objects from any backend:
+//   - Eq, Ne and In compare the values as strings
+//   - Gt, Gte, Lt and Lte compare as numbers if the operand is a decimal
+//     number (i.e. "18" or "-1.5"), matching only the decimal values,
+//     otherwise as strings (date times in DateTimeFormat sort as strings)
+//   - the sort orders put the decimal values first, sorted as numbers,
+//     then the other values, sorted as strings
+//
+// Note that the databases compare strings by the collation of the
+// column, which matches the byte order only for binary collations
+//
+// Example:
+//
+//	query := Where("status").Eq("active").And("age").Gt(18).OrderBy("created_at").Limit(50)
+//	users, err := repo.Query(ctx, query)
+type Query struct {
+	conditions []QueryCondition
+	orders     []QueryOrder
+	limit      int
+	offset     int
+	scope      QueryScope
+}
+
+// QueryConditionBuilder adds a condition on a key to a query
+type QueryConditionBuilder struct {
+	query *Query
+	key   string
+}
+
+// Querier is implemented by repositories, which can filter objects by a query
+type Querier interface {
+	// Query returns the objects matching the query, in the query order
+	// (by ID if no order is set), limited and offset as set in the query
+	Query(ctx context.Context, query *Query) ([]DataObjectInterface, error)
+}
+
+// NewQuery creates a new query matching all objects
+func NewQuery() *Query {
+	return &Query{}
+}
+
+// Where creates a new query with a condition on the key
+func Where(key string) *QueryConditionBuilder {
+	return NewQuery().Where(key)
+}
+
+// Where adds a condition on the key to the query
+func (q *Query) Where(key string) *QueryConditionBuilder {
+	return &QueryConditionBuilder{query: q, key: key}
+}
+
+// And adds a condition on the key to the query, the same as Where
+func (q *Query) And(key string) *QueryConditionBuilder {
+	return q.Where(key)
+}
+
+// OrderBy adds an ascending sort order on the key
+func (q *Query) OrderBy(key string) *Query {
+	q.orders = append(q.orders, QueryOrder{Key: key})
+	return q
+}
+
+// OrderByDesc adds a descending sort order on the key
+func (q *Query) OrderByDesc(key string) *Query {
+	q.orders = append(q.orders, QueryOrder{Key: key, Descending: true})
+	return q
+}
+
+// Limit limits the number of returned objects, 0 for no limit
+func (q *Query) Limit(limit int) *Query {
+	q.limit = max(limit, 0)
+	return q
+}
+
+// Offset skips the first offset matching objects
+func (q *Query) Offset(offset int) *Query {
+	q.offset = max(offset, 0)
+	return q
+}
+
+// WithoutDeleted excludes the soft deleted objects (see SoftDeletedAtKey)
+func (q *Query) WithoutDeleted() *Query {
+	q.scope = QueryScopeWithoutDeleted
+	return q
+}
+
+// WithDeleted includes the soft deleted objects, even
+// if the backend excludes them by default
+func (q *Query) WithDeleted() *Query {
+	q.scope = QueryScopeWithDeleted
+	return q
+}
+
+// OnlyDeleted matches only the soft deleted objects
+func (q *Query) OnlyDeleted() *Query {
+	q.scope = QueryScopeOnlyDeleted
+	return q
+}
+
+// Scope returns the soft delete scope of the query
+func (q *Query) Scope() QueryScope {
+	return q.scope
+}
+
+// withDefaultScope returns the query with the scope set,
+// if no scope has been set explicitly
+func (q *Query) withDefaultScope(scope QueryScope) *Query {
+	if q.scope != QueryScopeDefault {
+		return q
+	}
+	scoped := *q
+	scoped.scope = scope
+	return &scoped
+}
+
+// Conditions returns the conditions of the query
+func (q *Query) Conditions() []QueryCondition {
+	return slices.Clone(q.conditions)
+}
+
+// Orders returns the sort orders of the query
+func (q *Query) Orders() []QueryOrder {
+	return slices.Clone(q.orders)
+}
+
+// LimitValue returns the limit of the query, 0 for no limit
+func (q *Query) LimitValue() int {
+	return q.limit
+}
+
+// OffsetValue returns the offset of the query
+func (q *Query) OffsetValue() int {
+	return q.offset
+}
+
+// Eq matches the objects, which have the value for the key
+func (b *QueryConditionBuilder) Eq(value any) *Query {
+	return b.add(QueryEq, value)
+}
+
+// Ne matches the objects, which do not have the value for the key
+func (b *QueryConditionBuilder) Ne(value any) *Query {
+	return b.add(QueryNe, value)
+}
+
+// Gt matches the objects with a value greater than the value
+func (b *QueryConditionBuilder) Gt(value any) *Query {
+	return b.add(QueryGt, value)
+}
+
+// Gte matches the objects with a value greater than or equal to the value
+func (b *QueryConditionBuilder) Gte(value any) *Query {
+	return b.add(QueryGte, value)
+}
+
+// Lt matches the objects with a value less than the value
+func (b *QueryConditionBuilder) Lt(value any) *Query {
+	return b.add(QueryLt, value)
+}
+
+// Lte matches the objects with a value less than or equal to the value
+func (b *QueryConditionBuilder) Lte(value any) *Query {
+	return b.add(QueryLte, value)
+}
+
+// In matches the objects, which have any of the values for the key
+func (b *QueryConditionBuilder) In(values ...any) *Query {
+	return b.add(QueryIn, values...)
+}
+
+// Contains matches the objects with a value containing the substring
+func (b *QueryConditionBuilder) Contains(substring string) *Query {
+	return b.add(QueryContains, substring)
+}
+
+// add adds the condition to the query
+func (b *QueryConditionBuilder) add(operator QueryOperator, values ...any) *Query {
+	condition := QueryCondition{Key: b.key, Operator: operator}
+	for _, value := range values {
+		condition.Values = append(condition.Values, queryOperand(value))
+	}
+	b.query.conditions = append(b.query.conditions, condition)
+	return b.query
+}
+
+// Matches returns if the object matches all the conditions and the
+// scope of the query. Missing keys are matched as empty values
+func (q *Query) Matches(do DataObjectInterface) bool {
+	data := do.Data()
+
+	switch q.scope {
+	case QueryScopeWithoutDeleted:
+		if isSoftDeletedValue(data[SoftDeletedAtKey], time.Now()) {
+			return false
+		}
+	case QueryScopeOnlyDeleted:
+		if !isSoftDeletedValue(data[SoftDeletedAtKey], time.Now()) {
+			return false
+		}
+	}
+
+	for _, condition := range q.conditions {
+		if !condition.matches(data[condition.Key]) {
+			return false
+		}
+	}
+	return true
+}
+
+// Apply returns the objects matching the query, sorted,
+// limited and offset as set in the query
+func (q *Query) Apply(objects []DataObjectInterface) []DataObjectInterface {
+	result := []DataObjectInterface{}
+	for _, do := range objects {
+		if q.Matches(do) {
+			result = append(result, do)
+		}
+	}
+
+	if len(q.orders) > 0 {
+		slices.SortStableFunc(result, func(x DataObjectInterface, y DataObjectInterface) int {
+			for _, order := range q.orders {
+				result := compareQueryValues(x.Data()[order.Key], y.Data()[order.Key])
+				if order.Descending {
+					result = -result
+				}
+				if result != 0 {
+					return result
+				}
+			}
+			return 0
+		})
+	}
+
+	offset := min(q.offset, len(result))
+	end := len(result)
+	if q.limit > 0 {
+		end = min(offset+q.limit, len(result))
+	}
+
+	return result[offset:end]
+}
+
+// ToSQL returns a parameterized SELECT statement of the query and its
+// arguments, comparing and sorting the text columns the same as in memory
+// (see Query), which the indexes of the columns do not support for the
+// numeric comparisons and the sort orders
+//
+// Missing keys are NULL columns, which are compared and sorted as empty
+// values, the same as in memory. The conditions matching empty values
+// compare the columns with COALESCE, so they cannot use the indexes
+//
+// The soft delete scopes compare the soft_deleted_at column with the current time
+// in DateTimeFormat, the default scope does not filter the soft deleted rows
+func (q *Query) ToSQL(table string, dialect Dialect) (string, []any) {
+	query := "SELECT * FROM " + dialect.quote(table)
+	args := []any{}
+
+	clauses := make([]string, 0, len(q.conditions)+1)
+	for _, condition := range q.conditions {
+		clauses = append(clauses, condition.toSQL(dialect, &args))
+	}
+
+	if q.scope == QueryScopeWithoutDeleted || q.scope == QueryScopeOnlyDeleted {
+		column := dialect.quote(SoftDeletedAtKey)
+		args = append(args, time.Now().UTC().Format(DateTimeFormat))
+		now := dialect.placeholder(len(args))
+
+		if q.scope == QueryScopeWithoutDeleted {
+			clauses = append(clauses, "("+column+" IS NULL OR "+column+" = '' OR "+column+" > "+now+")")
+		} else {
+			clauses = append(clauses, column+" <> '' AND "+column+" <= "+now)
+		}
+	}
+
+	if len(clauses) > 0 {
+		query += " WHERE " + strings.Join(clauses, " AND ")
+	}
+
+	if len(q.orders) > 0 {
+		orders := make([]string, 0, len(q.orders))
+		for _, order := range q.orders {
+			direction := " ASC"
+			if order.Descending {
+				direction = " DESC"
+			}
+			column := dialect.quote(order.Key)
+			orders = append(orders,
+				"CASE WHEN "+dialect.isDecimal(column)+" THEN 0 ELSE 1 END"+direction,
+				dialect.toDecimal(column)+direction,
+				"COALESCE("+column+", '')"+direction)
+		}
+		query += " ORDER BY " + strings.Join(orders, ", ")
+	}
+
+	if q.limit > 0 {
+		query += " LIMIT " + strconv.Itoa(q.limit)
+	}
+
+	if q.offset > 0 {
+		if q.limit < 1 && dialect != DialectPostgres {
+			// MySQL and SQLite do not support OFFSET without LIMIT
+			query += " LIMIT " + strconv.FormatInt(1<<62, 10)
+		}
+		query += " OFFSET " + strconv.Itoa(q.offset)
+	}
+
+	return query, args
+}
+
+// matches returns if the value matches the condition
+func (c QueryCondition) matches(value string) bool {
+	first := ""
+	if len(c.Values) > 0 {
+		first = c.Values[0]
+	}
+
+	switch c.Operator {
+	case QueryEq:
+		return value == first
+	case QueryNe:
+		return value != first
+	case QueryGt:
+		return compareQueryOperand(value, first, func(result int) bool { return result > 0 })
+	case QueryGte:
+		return compareQueryOperand(value, first, func(result int) bool { return result >= 0 })
+	case QueryLt:
+		return compareQueryOperand(value, first, func(result int) bool { return result < 0 })
+	case QueryLte:
+		return compareQueryOperand(value, first, func(result int) bool { return result <= 0 })
+	case QueryIn:
+		return slices.Contains(c.Values, value)
+	case QueryContains:
+		return strings.Contains(value, first)
+	}
+
+	return false
+}
+
+// toSQL returns the SQL clause of the condition, appending its arguments.
+// NULL columns do not match a comparison, so those of the conditions
+// matching empty values are compared as empty values
+func (c QueryCondition) toSQL(dialect Dialect, args *[]any) string {
+	column := dialect.quote(c.Key)
+	if c.matches("") {
+		column = "COALESCE(" + column + ", '')"
+	}
+
+	switch c.Operator {
+	case QueryIn:
+		if len(c.Values) < 1 {
+			return "1 = 0"
+		}
+		placeholders := make([]string, 0, len(c.Values))
+		for _, value := range c.Values {
+			*args = append(*args, value)
+			placeholders = append(placeholders, dialect.placeholder(len(*args)))
+		}
+		return column + " IN (" + strings.Join(placeholders, ", ") + ")"
+	case QueryContains:
+		// ! is the escape character, as a backslash is
+		// interpreted differently by the databases
+		replacer := strings.NewReplacer("!", "!!", "%", "!%", "_", "!_")
+		*args = append(*args, "%"+replacer.Replace(c.Values[0])+"%")
+		return column + " LIKE " + dialect.placeholder(len(*args)) + " ESCAPE '!'"
+	case QueryGt, QueryGte, QueryLt, QueryLte:
+		*args = append(*args, c.Values[0])
+		placeholder := dialect.placeholder(len(*args))
+		if isQueryDecimal(c.Values[0]) {
+			return dialect.toDecimal(column) + " " + string(c.Operator) + " " + dialect.castDecimal(placeholder)
+		}
+		return column + " " + string(c.Operator) + " " + placeholder
+	default:
+		*args = append(*args, c.Values[0])
+		return column + " " + string(c.Operator) + " " + dialect.placeholder(len(*args))
+	}
+}
+
+// queryDecimalPattern matches the decimal numbers compared as numbers
+// by the queries, the same pattern as used by the SQL of the queries
+const queryDecimalPattern = `^[-+]?[0-9]+([.][0-9]+)?$`
+
+var queryDecimalRegexp = regexp.MustCompile(queryDecimalPattern)
+
+// isQueryDecimal returns if the value is compared as a number by the queries
+func isQueryDecimal(value string) bool {
+	return queryDecimalRegexp.MatchString(value)
+}
+
+// compareQueryOperand compares the value with the operand as numbers if
+// the operand is a decimal number, in which case values, which are not
+// decimal numbers, do not match, or else as strings
+func compareQueryOperand(value string, operand string, matches func(result int) bool) bool {
+	if !isQueryDecimal(operand) {
+		return matches(strings.Compare(value, operand))
+	}
+
+	if !isQueryDecimal(value) {
+		return false
+	}
+
+	return matches(CompareValues(value, operand, FieldTypeFloat))
+}
+
+// compareQueryValues compares the values in the sort order of the queries,
+// the decimal numbers first as numbers, then the other values as strings
+func compareQueryValues(a string, b string) int {
+	decimalA := isQueryDecimal(a)
+	decimalB := isQueryDecimal(b)
+
+	switch {
+	case decimalA && decimalB:
+		return CompareValues(a, b, FieldTypeFloat)
+	case decimalA:
+		return -1
+	case decimalB:
+		return 1
+	default:
+		return strings.Compare(a, b)
+	}
+}
+
+// queryOperand returns the operand as compared with the stored values,
+// i.e. 1.5 as "1.5" and times in DateTimeFormat as stored by SetTime
+func queryOperand(value any) string {
+	switch v := value.(type) {
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	case float32:
+		return strconv.FormatFloat(float64(v), 'f', -1, 32)
+	case time.Time:
+		return v.UTC().Format(DateTimeFormat)
+	case string:
+		return v
+	case nil:
+		return ""
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
+// isDecimal returns the SQL condition, which is true
+// if the text expression is a decimal number (see isQueryDecimal)
+func (d Dialect) isDecimal(expression string) string {
+	switch d {
+	case DialectPostgres:
+		return expression + " ~ '" + queryDecimalPattern + "'"
+	case DialectMySQL:
+		return expression + " REGEXP '" + queryDecimalPattern + "'"
+	default:
+		// SQLite has no regular expressions by default
+		unsigned := "(CASE WHEN substr(" + expression + ", 1, 1) IN ('+', '-') THEN substr(" + expression + ", 2) ELSE " + expression + " END)"
+		return "(" + unsigned + " <> '' AND " +
+			unsigned + " NOT GLOB '*[^0-9.]*' AND " +
+			unsigned + " NOT GLOB '*.*.*' AND " +
+			unsigned + " NOT GLOB '.*' AND " +
+			unsigned + " NOT GLOB '*.')"
+	}
+}
+
+// toDecimal returns the SQL expression of the text expression
+// as a number, or NULL if it is not a decimal number
+func (d Dialect) toDecimal(expression string) string {
+	return "CASE WHEN " + d.isDecimal(expression) + " THEN " + d.castDecimal(expression) + " END"
+}
+
+// castDecimal returns the SQL expression casting the expression to a number
+func (d Dialect) castDecimal(expression string) string {
+	switch d {
+	case DialectPostgres:
+		return "CAST(" + expression + " AS NUMERIC)"
+	case DialectMySQL:
+		return "CAST(" + expression + " AS DECIMAL(65, 30))"
+	default:
+		return "CAST(" + expression + " AS REAL)"
+	}
+}
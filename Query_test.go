@@ -0,0 +1,150 @@
+package dataobject
+
+import (
+	"context"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestQueryApply(t *testing.T) {
+	objects := []DataObjectInterface{
+		NewDataObjectFromExistingData(map[string]string{"id": "1", "status": "active", "age": "9", "name": "Jon"}),
+		NewDataObjectFromExistingData(map[string]string{"id": "2", "status": "active", "age": "30", "name": "Jane"}),
+		NewDataObjectFromExistingData(map[string]string{"id": "3", "status": "inactive", "age": "40", "name": "Joe"}),
+		NewDataObjectFromExistingData(map[string]string{"id": "4", "status": "active", "age": "19", "name": "Ann"}),
+	}
+
+	result := Where("status").Eq("active").And("age").Gt(18).OrderByDesc("age").Apply(objects)
+
+	if len(result) != 2 || result[0].ID() != "2" || result[1].ID() != "4" {
+		t.Error("Expected: 2 4, but found:", result)
+	}
+
+	result = Where("name").Contains("J").OrderBy("age").Offset(1).Limit(1).Apply(objects)
+
+	if len(result) != 1 || result[0].ID() != "2" {
+		t.Error("Expected: 2, but found:", result)
+	}
+
+	result = Where("id").In("1", 3).Apply(objects)
+
+	if len(result) != 2 {
+		t.Error("Expected: 2, but found:", len(result))
+	}
+}
+
+func TestQueryToSQL(t *testing.T) {
+	query, args := Where("status").Eq("active").And("age").Gt(18).And("name").Contains("50%").
+		OrderBy("created_at").Limit(50).ToSQL("users", DialectPostgres)
+
+	decimal := `'^[-+]?[0-9]+([.][0-9]+)?$'`
+	expected := `SELECT * FROM "users" WHERE "status" = $1 AND CASE WHEN "age" ~ ` + decimal + ` THEN CAST("age" AS NUMERIC) END > CAST($2 AS NUMERIC)` +
+		` AND "name" LIKE $3 ESCAPE '!' ORDER BY CASE WHEN "created_at" ~ ` + decimal + ` THEN 0 ELSE 1 END ASC,` +
+		` CASE WHEN "created_at" ~ ` + decimal + ` THEN CAST("created_at" AS NUMERIC) END ASC, COALESCE("created_at", '') ASC LIMIT 50`
+
+	if query != expected {
+		t.Error("Expected:", expected, "but found:", query)
+	}
+
+	if !reflect.DeepEqual(args, []any{"active", "18", "%50!%%"}) {
+		t.Error("Expected: [active 18 %50!%%], but found:", args)
+	}
+
+	query, args = Where("id").In(1, 2).ToSQL("users", DialectMySQL)
+
+	if query != "SELECT * FROM `users` WHERE `id` IN (?, ?)" || len(args) != 2 {
+		t.Error("Expected mysql IN, but found:", query, args)
+	}
+}
+
+func TestQueryToSQLMissingKeys(t *testing.T) {
+	missing := NewDataObjectFromExistingData(map[string]string{"id": "1"})
+
+	tests := []struct {
+		query    *Query
+		expected string
+	}{
+		{Where("status").Eq(""), `SELECT * FROM "users" WHERE COALESCE("status", '') = ?`},
+		{Where("status").Ne("active"), `SELECT * FROM "users" WHERE COALESCE("status", '') <> ?`},
+		{Where("status").In("", "new"), `SELECT * FROM "users" WHERE COALESCE("status", '') IN (?, ?)`},
+		{Where("name").Lt("b"), `SELECT * FROM "users" WHERE COALESCE("name", '') < ?`},
+		{Where("status").Eq("active"), `SELECT * FROM "users" WHERE "status" = ?`},
+		{Where("name").Gt("b"), `SELECT * FROM "users" WHERE "name" > ?`},
+	}
+
+	for _, test := range tests {
+		query, _ := test.query.ToSQL("users", DialectSQLite)
+
+		if query != test.expected {
+			t.Error("Expected:", test.expected, "but found:", query)
+		}
+
+		// COALESCE is used if, and only if, missing keys match in memory
+		if coalesced := strings.Contains(query, "COALESCE"); coalesced != test.query.Matches(missing) {
+			t.Error("Expected: the same match as in memory, but found:", query, test.query.Matches(missing))
+		}
+	}
+}
+
+func TestQueryComparisons(t *testing.T) {
+	objects := []DataObjectInterface{
+		NewDataObjectFromExistingData(map[string]string{"id": "1", "price": "10"}),
+		NewDataObjectFromExistingData(map[string]string{"id": "2", "price": "9"}),
+		NewDataObjectFromExistingData(map[string]string{"id": "3", "price": "abc"}),
+		NewDataObjectFromExistingData(map[string]string{"id": "4", "price": "-20"}),
+		NewDataObjectFromExistingData(map[string]string{"id": "5", "price": "1.5"}),
+		NewDataObjectFromExistingData(map[string]string{"id": "6", "price": "1e3"}),
+	}
+
+	ids := func(objects []DataObjectInterface) string {
+		result := []string{}
+		for _, do := range objects {
+			result = append(result, do.ID())
+		}
+		return strings.Join(result, " ")
+	}
+
+	// the numeric operand matches only the decimal values, as in SQL
+	if result := ids(Where("price").Gt("9").OrderBy("price").Apply(objects)); result != "1" {
+		t.Error("Expected: 1, but found:", result)
+	}
+
+	if result := ids(Where("price").Eq(1.5).Apply(objects)); result != "5" {
+		t.Error("Expected: 5, but found:", result)
+	}
+
+	if result := ids(Where("price").Gte("a").Apply(objects)); result != "3" {
+		t.Error("Expected: 3, but found:", result)
+	}
+
+	// the decimal values first as numbers, then the others as strings
+	if result := ids(NewQuery().OrderBy("price").Apply(objects)); result != "4 5 2 1 6 3" {
+		t.Error("Expected: 4 5 2 1 6 3, but found:", result)
+	}
+
+	query, args := Where("created_at").Lt(time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)).ToSQL("users", DialectSQLite)
+
+	if query != `SELECT * FROM "users" WHERE COALESCE("created_at", '') < ?` || args[0] != "2024-01-02 03:04:05" {
+		t.Error("Expected: a string comparison with the time in DateTimeFormat, but found:", query, args)
+	}
+}
+
+func TestMemoryRepositoryQuery(t *testing.T) {
+	ctx := context.Background()
+	repo := NewMemoryRepository()
+
+	_ = repo.Create(ctx, NewDataObjectFromExistingData(map[string]string{"id": "1", "status": "active"}))
+	_ = repo.Create(ctx, NewDataObjectFromExistingData(map[string]string{"id": "2", "status": "inactive"}))
+
+	result, err := repo.Query(ctx, Where("status").Eq("active"))
+
+	if err != nil {
+		t.Fatal("Error must be nil, but found:", err.Error())
+	}
+
+	if len(result) != 1 || result[0].ID() != "1" {
+		t.Error("Expected: 1, but found:", result)
+	}
+}
@@ -0,0 +1,129 @@
+package dataobject
+
+import (
+	"context"
+	"fmt"
+)
+
+// RelationKind is the kind of a relation between objects
+type RelationKind string
+
+const (
+	// RelationBelongsTo relates the object to the object,
+	// whose ID is the value of the key of the object
+	RelationBelongsTo RelationKind = "belongs_to"
+
+	// RelationHasMany relates the object to the objects,
+	// which have the ID of the object as the value of the key
+	RelationHasMany RelationKind = "has_many"
+)
+
+// relationMetaPrefix is the prefix of the metadata keys of the loaded relations
+const relationMetaPrefix = "relation:"
+
+// Relation declares a relation to the objects of a repository
+//
+// Example:
+//
+//	author := Relation{Name: "author", Kind: RelationBelongsTo, Key: "author_id", Repository: users}
+//	comments := Relation{Name: "comments", Kind: RelationHasMany, Key: "post_id", Repository: commentsRepo}
+//
+//	post, err := FindWithRelations(ctx, posts, id, author, comments)
+//	post.Related("comments")
+type Relation struct {
+	// Name is the name of the relation, used with Related
+	Name string
+
+	// Kind is the kind of the relation
+	Kind RelationKind
+
+	// Key is the key holding the ID of the related object (RelationBelongsTo),
+	// or the key of the related objects holding the ID of the object (RelationHasMany)
+	Key string
+
+	// Repository is the repository of the related objects. For RelationHasMany
+	// it must be a Querier (i.e. MemoryRepository)
+	Repository DataObjectRepositoryInterface
+}
+
+// SetRef sets the key to the ID of the other object (a reference)
+func (do *DataObject) SetRef(key string, otherID string) {
+	do.Set(key, otherID)
+}
+
+// GetRef returns the object referenced by the key from the repository.
+// Returns an error wrapping ErrNotFound if the key is empty
+// or the referenced object does not exist
+func (do *DataObject) GetRef(ctx context.Context, key string, repo DataObjectRepositoryInterface) (DataObjectInterface, error) {
+	id := do.Get(key)
+	if id == "" {
+		return nil, fmt.Errorf("%w: reference %s is not set", ErrNotFound, key)
+	}
+
+	return repo.Find(ctx, id)
+}
+
+// LoadRelations loads the objects of the relations, to be returned by
+// Related. Belongs-to references, which are not set, load no objects
+func (do *DataObject) LoadRelations(ctx context.Context, relations ...Relation) error {
+	for _, relation := range relations {
+		related, err := relation.load(ctx, do)
+		if err != nil {
+			return fmt.Errorf("dataobject: relation %s: %w", relation.Name, err)
+		}
+		do.SetMeta(relationMetaPrefix+relation.Name, related)
+	}
+
+	return nil
+}
+
+// Related returns the objects of the relation loaded by LoadRelations
+// (or FindWithRelations), or nil if the relation has not been loaded
+func (do *DataObject) Related(name string) []DataObjectInterface {
+	related, _ := do.Meta(relationMetaPrefix + name).([]DataObjectInterface)
+	return related
+}
+
+// FindWithRelations finds the object with the ID in the repository
+// and eager loads the objects of the relations (see Related)
+func FindWithRelations(ctx context.Context, repo DataObjectRepositoryInterface, id string, relations ...Relation) (*DataObject, error) {
+	found, err := repo.Find(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	do, isDataObject := found.(*DataObject)
+	if !isDataObject {
+		do = NewDataObjectFromExistingData(copyData(found.Data()))
+	}
+
+	if err := do.LoadRelations(ctx, relations...); err != nil {
+		return nil, err
+	}
+
+	return do, nil
+}
+
+// load returns the related objects of the object
+func (relation Relation) load(ctx context.Context, do DataObjectInterface) ([]DataObjectInterface, error) {
+	switch relation.Kind {
+	case RelationBelongsTo:
+		id := do.Data()[relation.Key]
+		if id == "" {
+			return []DataObjectInterface{}, nil
+		}
+		related, err := relation.Repository.Find(ctx, id)
+		if IsNotFound(err) {
+			return []DataObjectInterface{}, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		return []DataObjectInterface{related}, nil
+
+	case RelationHasMany:
+		return forwardQuery(ctx, relation.Repository, Where(relation.Key).Eq(do.ID()))
+	}
+
+	return nil, fmt.Errorf("unknown relation kind: %s", relation.Kind)
+}
@@ -0,0 +1,65 @@
+package dataobject
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+func TestRefs(t *testing.T) {
+	ctx := context.Background()
+	users := NewMemoryRepository()
+	_ = users.Create(ctx, NewDataObjectFromExistingData(map[string]string{"id": "u1", "name": "Jon"}))
+
+	post := NewDataObjectFromExistingData(map[string]string{"id": "p1"})
+
+	if _, err := post.GetRef(ctx, "author_id", users); !errors.Is(err, ErrNotFound) {
+		t.Error("Expected: ErrNotFound, but found:", err)
+	}
+
+	post.SetRef("author_id", "u1")
+
+	author, err := post.GetRef(ctx, "author_id", users)
+
+	if err != nil {
+		t.Fatal("Error must be nil, but found:", err.Error())
+	}
+
+	if author.Data()["name"] != "Jon" {
+		t.Error("Expected: Jon, but found:", author.Data()["name"])
+	}
+}
+
+func TestFindWithRelations(t *testing.T) {
+	ctx := context.Background()
+	users := NewMemoryRepository()
+	posts := NewMemoryRepository()
+	comments := NewMemoryRepository()
+
+	_ = users.Create(ctx, NewDataObjectFromExistingData(map[string]string{"id": "u1"}))
+	_ = posts.Create(ctx, NewDataObjectFromExistingData(map[string]string{"id": "p1", "author_id": "u1"}))
+	_ = comments.Create(ctx, NewDataObjectFromExistingData(map[string]string{"id": "c1", "post_id": "p1"}))
+	_ = comments.Create(ctx, NewDataObjectFromExistingData(map[string]string{"id": "c2", "post_id": "p1"}))
+	_ = comments.Create(ctx, NewDataObjectFromExistingData(map[string]string{"id": "c3", "post_id": "p2"}))
+
+	post, err := FindWithRelations(ctx, posts, "p1",
+		Relation{Name: "author", Kind: RelationBelongsTo, Key: "author_id", Repository: users},
+		Relation{Name: "comments", Kind: RelationHasMany, Key: "post_id", Repository: comments},
+	)
+
+	if err != nil {
+		t.Fatal("Error must be nil, but found:", err.Error())
+	}
+
+	if len(post.Related("author")) != 1 || post.Related("author")[0].ID() != "u1" {
+		t.Error("Expected: u1, but found:", post.Related("author"))
+	}
+
+	if len(post.Related("comments")) != 2 {
+		t.Error("Expected: 2, but found:", len(post.Related("comments")))
+	}
+
+	if post.Related("missing") != nil {
+		t.Error("Expected: nil, but found:", post.Related("missing"))
+	}
+}
@@ -0,0 +1,61 @@
+package dataobject
+
+import (
+	"strconv"
+	"strings"
+	"text/template"
+)
+
+// Render renders the text template with the data of the object as
+// the template context, so the keys can be used as {{.first_name}}.
+// Missing keys render as empty strings
+//
+// Besides the built-in template functions the following are available:
+//
+//	formatDate value layout  formats a date time value with the Go layout
+//	formatNumber value decimals  formats a number with the decimals
+//	upper value, lower value  change the case of the value
+//	default fallback value  returns the fallback if the value is empty
+//
+// Example:
+//
+//	text, err := order.Render(`Dear {{.first_name}}, your order of
+//	{{formatNumber .total 2}} placed on {{formatDate .created_at "2 Jan 2006"}} is on its way`)
+func (do *DataObject) Render(tmpl string) (string, error) {
+	t, err := template.New("dataobject").Option("missingkey=zero").Funcs(renderFuncs).Parse(tmpl)
+	if err != nil {
+		return "", err
+	}
+
+	var b strings.Builder
+	if err := t.Execute(&b, do.Data()); err != nil {
+		return "", err
+	}
+
+	return b.String(), nil
+}
+
+var renderFuncs = template.FuncMap{
+	"formatDate": func(value string, layout string) string {
+		t, err := parseDateTime(value)
+		if err != nil {
+			return value
+		}
+		return t.Format(layout)
+	},
+	"formatNumber": func(value string, decimals int) string {
+		number, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return value
+		}
+		return strconv.FormatFloat(number, 'f', decimals, 64)
+	},
+	"upper": strings.ToUpper,
+	"lower": strings.ToLower,
+	"default": func(fallback string, value string) string {
+		if value == "" {
+			return fallback
+		}
+		return value
+	},
+}
@@ -0,0 +1,30 @@
+package dataobject
+
+import (
+	"html"
+	"strings"
+)
+
+// RenderTable renders the objects as an HTML table with the columns.
+// The values are HTML escaped after formatting
+func RenderTable(objects []DataObjectInterface, columns []Column) string {
+	var b strings.Builder
+
+	b.WriteString(`<table class="table"><thead><tr>`)
+	for _, column := range columns {
+		b.WriteString(`<th>` + html.EscapeString(column.header()) + `</th>`)
+	}
+	b.WriteString(`</tr></thead><tbody>`)
+
+	for _, do := range objects {
+		b.WriteString(`<tr>`)
+		for _, column := range columns {
+			b.WriteString(`<td>` + html.EscapeString(column.value(do)) + `</td>`)
+		}
+		b.WriteString(`</tr>`)
+	}
+
+	b.WriteString(`</tbody></table>`)
+
+	return b.String()
+}
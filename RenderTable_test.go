@@ -0,0 +1,69 @@
+package dataobject
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+var testTableColumns = []Column{
+	{Key: "first_name", Label: "First Name"},
+	{Key: "status", Format: strings.ToUpper},
+}
+
+var testTableObjects = []DataObjectInterface{
+	NewDataObjectFromExistingData(map[string]string{"id": "1", "first_name": "<Jon>", "status": "active"}),
+	NewDataObjectFromExistingData(map[string]string{"id": "2", "first_name": "Jane, Jr", "status": "inactive"}),
+}
+
+func TestRenderTable(t *testing.T) {
+	table := RenderTable(testTableObjects, testTableColumns)
+
+	expected := `<table class="table"><thead><tr><th>First Name</th><th>status</th></tr></thead><tbody>` +
+		`<tr><td>&lt;Jon&gt;</td><td>ACTIVE</td></tr>` +
+		`<tr><td>Jane, Jr</td><td>INACTIVE</td></tr>` +
+		`</tbody></table>`
+
+	if table != expected {
+		t.Error("Expected:", expected, "but found:", table)
+	}
+}
+
+func TestWriteCSV(t *testing.T) {
+	var b bytes.Buffer
+
+	err := WriteCSV(&b, testTableObjects, testTableColumns)
+
+	if err != nil {
+		t.Fatal("Error must be nil, but found:", err.Error())
+	}
+
+	expected := "First Name,status\n<Jon>,ACTIVE\n\"Jane, Jr\",INACTIVE\n"
+
+	if b.String() != expected {
+		t.Error("Expected:", expected, "but found:", b.String())
+	}
+}
+
+func TestWriteCSVNeutralizesFormulas(t *testing.T) {
+	var b bytes.Buffer
+
+	objects := []DataObjectInterface{
+		NewDataObjectFromExistingData(map[string]string{"value": "=HYPERLINK(\"http://test.com\")"}),
+		NewDataObjectFromExistingData(map[string]string{"value": "+1+1"}),
+		NewDataObjectFromExistingData(map[string]string{"value": "@SUM(A1)"}),
+		NewDataObjectFromExistingData(map[string]string{"value": "-5.5"}),
+	}
+
+	err := WriteCSV(&b, objects, []Column{{Key: "value", Label: "-value"}})
+
+	if err != nil {
+		t.Fatal("Error must be nil, but found:", err.Error())
+	}
+
+	expected := "'-value\n\"'=HYPERLINK(\"\"http://test.com\"\")\"\n'+1+1\n'@SUM(A1)\n-5.5\n"
+
+	if b.String() != expected {
+		t.Error("Expected:", expected, "but found:", b.String())
+	}
+}
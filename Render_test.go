@@ -0,0 +1,30 @@
+package dataobject
+
+import (
+	"testing"
+)
+
+func TestDataObjectRender(t *testing.T) {
+	order := NewDataObjectFromExistingData(map[string]string{
+		"id":         "1",
+		"first_name": "Jon",
+		"total":      "12.5000",
+		"created_at": "2024-03-04 05:06:07",
+	})
+
+	text, err := order.Render(`Dear {{upper .first_name}}, {{formatNumber .total 2}} on {{formatDate .created_at "2 Jan 2006"}} via {{default "post" .carrier}}`)
+
+	if err != nil {
+		t.Fatal("Error must be nil, but found:", err.Error())
+	}
+
+	expected := "Dear JON, 12.50 on 4 Mar 2024 via post"
+
+	if text != expected {
+		t.Error("Expected:", expected, "but found:", text)
+	}
+
+	if _, err := order.Render(`{{.first_name`); err == nil {
+		t.Error("Error must NOT be nil, but found:", err)
+	}
+}
@@ -0,0 +1,137 @@
+package dataobject
+
+import (
+	"context"
+)
+
+// RewriteOptions configures Rewrite
+type RewriteOptions struct {
+	// BatchSize is the number of objects read from the repository
+	// at a time, defaults to VerifyDefaultBatchSize
+	BatchSize int
+
+	// Offset is the offset to resume from, as reported by OnProgress
+	Offset int
+
+	// OnProgress, if set, is called after each batch
+	OnProgress func(progress RewriteProgress)
+
+	// Transformers are applied to each object after the pipeline, i.e. to
+	// re-encrypt values after a key rotation (see ReencryptValues). An
+	// error of a transformer stops the rewrite
+	Transformers []RewriteTransformer
+}
+
+// RewriteTransformer modifies the object being rewritten with its setters,
+// so only the objects it changes are written back
+type RewriteTransformer func(do *DataObject) error
+
+// RewriteProgress reports the progress of Rewrite
+type RewriteProgress struct {
+	// Offset is the offset of the next batch, pass it
+	// as RewriteOptions.Offset to resume after a failure
+	Offset int `json:"offset"`
+
+	// Processed is the number of objects processed
+	Processed int `json:"processed"`
+
+	// Updated is the number of objects, which have been changed and written back
+	Updated int `json:"updated"`
+}
+
+// Rewrite reads all the objects of the repository in batches, applies
+// the pipeline (if any) and the transformers to each, and writes back
+// only the objects whose data has changed, i.e. to re-encrypt values
+// after a key rotation, or to re-apply transformations after a policy
+// change. The objects are written back as copies, with the modified
+// keys marked as changed and removed
+//
+// Objects dropped by the pipeline are left as they are. On error the
+// returned progress holds the offset of the failed batch to resume from
+//
+// Example:
+//
+//	keys.Rotate("2024-07", newKey)
+//	progress, err := Rewrite(ctx, repo, nil, RewriteOptions{
+//		Transformers: []RewriteTransformer{ReencryptValues(keys, "ssn")},
+//		OnProgress:   func(p RewriteProgress) { log.Println("processed", p.Processed) },
+//	})
+func Rewrite(ctx context.Context, repo DataObjectRepositoryInterface, pipeline *Pipeline, opts RewriteOptions) (RewriteProgress, error) {
+	progress := RewriteProgress{Offset: max(opts.Offset, 0)}
+
+	batchSize := opts.BatchSize
+	if batchSize < 1 {
+		batchSize = VerifyDefaultBatchSize
+	}
+
+	for {
+		if err := ctx.Err(); err != nil {
+			return progress, err
+		}
+
+		objects, err := repo.List(ctx, progress.Offset, batchSize)
+		if err != nil {
+			return progress, err
+		}
+
+		for _, do := range objects {
+			rewritten, keep, err := rewriteObject(do, pipeline, opts.Transformers)
+			if err != nil {
+				return progress, err
+			}
+
+			if keep && rewritten.IsDirty() {
+				if err := repo.Update(ctx, rewritten); err != nil {
+					return progress, err
+				}
+				progress.Updated++
+			}
+
+			progress.Processed++
+		}
+
+		progress.Offset += len(objects)
+
+		if opts.OnProgress != nil {
+			opts.OnProgress(progress)
+		}
+
+		if len(objects) < batchSize {
+			return progress, nil
+		}
+	}
+}
+
+// rewriteObject returns a copy of the object with the changes of the
+// pipeline and the transformers, and false if the pipeline dropped it
+func rewriteObject(do DataObjectInterface, pipeline *Pipeline, transformers []RewriteTransformer) (*DataObject, bool, error) {
+	before := copyData(do.Data())
+	rewritten := NewDataObjectFromExistingData(copyData(before))
+
+	if pipeline != nil {
+		transformed, keep := pipeline.Apply(NewDataObjectFromExistingData(copyData(before)))
+		if !keep {
+			return nil, false, nil
+		}
+
+		data := transformed.Data()
+		for key, value := range data {
+			if previous, exists := before[key]; !exists || previous != value {
+				rewritten.Set(key, value)
+			}
+		}
+		for key := range before {
+			if _, exists := data[key]; !exists {
+				rewritten.Remove(key)
+			}
+		}
+	}
+
+	for _, transformer := range transformers {
+		if err := transformer(rewritten); err != nil {
+			return nil, false, err
+		}
+	}
+
+	return rewritten, true, nil
+}
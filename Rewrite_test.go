@@ -0,0 +1,143 @@
+package dataobject
+
+import (
+	"context"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestRewrite(t *testing.T) {
+	ctx := context.Background()
+	repo := NewMemoryRepository()
+
+	for i := 0; i < 5; i++ {
+		email := "user" + strconv.Itoa(i) + "@test.com"
+		if i%2 == 0 {
+			email = strings.ToUpper(email)
+		}
+		_ = repo.Create(ctx, NewDataObjectFromExistingData(map[string]string{"id": strconv.Itoa(i), "email": email}))
+	}
+
+	calls := 0
+	progress, err := Rewrite(ctx, repo, NewPipeline().Transform("email", strings.ToLower), RewriteOptions{
+		BatchSize:  2,
+		OnProgress: func(p RewriteProgress) { calls++ },
+	})
+
+	if err != nil {
+		t.Fatal("Error must be nil, but found:", err.Error())
+	}
+
+	if progress.Processed != 5 || progress.Updated != 3 || progress.Offset != 5 {
+		t.Error("Expected: 5 processed, 3 updated, but found:", progress)
+	}
+
+	if calls != 3 {
+		t.Error("Expected: 3, but found:", calls)
+	}
+
+	found, _ := repo.Find(ctx, "0")
+
+	if found.Data()["email"] != "user0@test.com" {
+		t.Error("Expected: user0@test.com, but found:", found.Data()["email"])
+	}
+
+	progress, _ = Rewrite(ctx, repo, NewPipeline().Transform("email", strings.ToLower), RewriteOptions{Offset: 3})
+
+	if progress.Processed != 2 || progress.Updated != 0 {
+		t.Error("Expected: 2 processed, 0 updated, but found:", progress)
+	}
+}
+
+func TestRewriteComparesAllKeys(t *testing.T) {
+	ctx := context.Background()
+	repo := NewMemoryRepository()
+	_ = repo.Create(ctx, NewDataObjectFromExistingData(map[string]string{"id": "1", "hash": "ABC"}))
+
+	progress, err := Rewrite(ctx, repo, NewPipeline().Transform("hash", strings.ToLower), RewriteOptions{})
+	if err != nil {
+		t.Fatal("Error must be nil, but found:", err.Error())
+	}
+
+	if progress.Updated != 1 {
+		t.Error("Expected: 1 updated, but found:", progress)
+	}
+
+	if found, _ := repo.Find(ctx, "1"); found.Data()["hash"] != "abc" {
+		t.Error("Expected: abc, but found:", found.Data()["hash"])
+	}
+}
+
+func TestRewriteWritesBackChangedKeys(t *testing.T) {
+	ctx := context.Background()
+	inner := NewMemoryRepository()
+	_ = inner.Create(ctx, NewDataObjectFromExistingData(map[string]string{"id": "1", "email": "JON@TEST.COM"}))
+	_ = inner.Create(ctx, NewDataObjectFromExistingData(map[string]string{"id": "2", "email": "jane@test.com"}))
+
+	repo := NewWriteBehindRepository(inner, time.Hour)
+
+	progress, err := Rewrite(ctx, repo, NewPipeline().Transform("email", strings.ToLower), RewriteOptions{})
+	if err != nil {
+		t.Fatal("Error must be nil, but found:", err.Error())
+	}
+
+	if progress.Updated != 1 {
+		t.Error("Expected: 1 updated, but found:", progress)
+	}
+
+	if err := repo.Flush(ctx); err != nil {
+		t.Fatal("Error must be nil, but found:", err.Error())
+	}
+
+	if found, _ := inner.Find(ctx, "1"); found.Data()["email"] != "jon@test.com" {
+		t.Error("Expected: jon@test.com, but found:", found.Data()["email"])
+	}
+
+	// a stage returning the objects as they are changes nothing
+	progress, _ = Rewrite(ctx, repo, NewPipeline().Map(func(do DataObjectInterface) DataObjectInterface { return do }), RewriteOptions{})
+
+	if progress.Updated != 0 {
+		t.Error("Expected: 0 updated, but found:", progress)
+	}
+}
+
+func TestRewriteReencryptValues(t *testing.T) {
+	ctx := context.Background()
+	repo := NewMemoryRepository()
+	keys := NewKeyRing("v1", testOldKey)
+
+	encrypted, _ := EncryptValue(keys, "123-45-6789")
+	_ = repo.Create(ctx, NewDataObjectFromExistingData(map[string]string{"id": "1", "ssn": encrypted}))
+	_ = repo.Create(ctx, NewDataObjectFromExistingData(map[string]string{"id": "2", "ssn": "987-65-4321"}))
+
+	keys.Rotate("v2", testSealKey)
+
+	progress, err := Rewrite(ctx, repo, nil, RewriteOptions{Transformers: []RewriteTransformer{ReencryptValues(keys, "ssn")}})
+	if err != nil {
+		t.Fatal("Error must be nil, but found:", err.Error())
+	}
+
+	if progress.Updated != 2 {
+		t.Error("Expected: 2 updated, but found:", progress)
+	}
+
+	for id, ssn := range map[string]string{"1": "123-45-6789", "2": "987-65-4321"} {
+		found, _ := repo.Find(ctx, id)
+		if keyID, _ := encryptedValueKeyID(found.Data()["ssn"]); keyID != "v2" {
+			t.Error("Expected: encrypted with v2, but found:", keyID)
+		}
+
+		// only the current key is needed from now on
+		if value, err := DecryptValue(NewKeyRing("v2", testSealKey), found.Data()["ssn"]); value != ssn {
+			t.Error("Expected:", ssn, "but found:", value, err)
+		}
+	}
+
+	progress, _ = Rewrite(ctx, repo, nil, RewriteOptions{Transformers: []RewriteTransformer{ReencryptValues(keys, "ssn")}})
+
+	if progress.Updated != 0 {
+		t.Error("Expected: 0 updated, but found:", progress)
+	}
+}
@@ -0,0 +1,145 @@
+package dataobject
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Dialect is the SQL dialect of the generated statements
+type Dialect string
+
+const (
+	DialectMySQL    Dialect = "mysql"
+	DialectPostgres Dialect = "postgres"
+	DialectSQLite   Dialect = "sqlite"
+)
+
+// quote quotes the identifier (table or column name)
+func (d Dialect) quote(identifier string) string {
+	if d == DialectMySQL {
+		return "`" + strings.ReplaceAll(identifier, "`", "``") + "`"
+	}
+	return `"` + strings.ReplaceAll(identifier, `"`, `""`) + `"`
+}
+
+// placeholder returns the placeholder of the n-th (from 1) argument
+func (d Dialect) placeholder(n int) string {
+	if d == DialectPostgres {
+		return "$" + strconv.Itoa(n)
+	}
+	return "?"
+}
+
+// ToSQLInsert returns a parameterized INSERT statement
+// of the data (columns sorted) and its arguments
+//
+// Example:
+//
+//	query, args := user.ToSQLInsert("users", DialectPostgres)
+//	_, err := db.ExecContext(ctx, query, args...)
+func (do *DataObject) ToSQLInsert(table string, dialect Dialect) (string, []any) {
+	return SQLInsert(table, []DataObjectInterface{do}, dialect)
+}
+
+// ToSQLUpsert returns a parameterized INSERT statement, which updates
+// the existing row with the same ID instead, and its arguments
+func (do *DataObject) ToSQLUpsert(table string, dialect Dialect) (string, []any) {
+	return SQLUpsert(table, []DataObjectInterface{do}, dialect)
+}
+
+// SQLInsert returns a parameterized multi-row INSERT statement
+// of the objects and its arguments. The columns are the sorted
+// keys of all the objects, missing keys are inserted as NULL.
+// Returns an empty statement if there are no objects
+func SQLInsert(table string, objects []DataObjectInterface, dialect Dialect) (string, []any) {
+	if len(objects) < 1 {
+		return "", nil
+	}
+
+	columns := sqlColumns(objects)
+
+	quoted := make([]string, len(columns))
+	for i, column := range columns {
+		quoted[i] = dialect.quote(column)
+	}
+
+	args := make([]any, 0, len(columns)*len(objects))
+	rows := make([]string, 0, len(objects))
+
+	for _, do := range objects {
+		data := do.Data()
+		placeholders := make([]string, len(columns))
+		for i, column := range columns {
+			if value, exists := data[column]; exists {
+				args = append(args, value)
+			} else {
+				args = append(args, nil)
+			}
+			placeholders[i] = dialect.placeholder(len(args))
+		}
+		rows = append(rows, "("+strings.Join(placeholders, ", ")+")")
+	}
+
+	query := "INSERT INTO " + dialect.quote(table) +
+		" (" + strings.Join(quoted, ", ") + ") VALUES " + strings.Join(rows, ", ")
+
+	return query, args
+}
+
+// SQLUpsert returns a parameterized multi-row INSERT statement of the
+// objects, which updates the existing rows with the same ID instead
+// (see SQLInsert). The id column must be the primary or a unique key
+func SQLUpsert(table string, objects []DataObjectInterface, dialect Dialect) (string, []any) {
+	query, args := SQLInsert(table, objects, dialect)
+	if query == "" {
+		return "", nil
+	}
+
+	updates := []string{}
+	for _, column := range sqlColumns(objects) {
+		if column == "id" {
+			continue
+		}
+
+		quoted := dialect.quote(column)
+
+		if dialect == DialectMySQL {
+			updates = append(updates, quoted+" = VALUES("+quoted+")")
+		} else {
+			updates = append(updates, quoted+" = excluded."+quoted)
+		}
+	}
+
+	if dialect == DialectMySQL {
+		if len(updates) < 1 {
+			return strings.Replace(query, "INSERT", "INSERT IGNORE", 1), args
+		}
+		return query + " ON DUPLICATE KEY UPDATE " + strings.Join(updates, ", "), args
+	}
+
+	if len(updates) < 1 {
+		return query + " ON CONFLICT (" + dialect.quote("id") + ") DO NOTHING", args
+	}
+
+	return query + " ON CONFLICT (" + dialect.quote("id") + ") DO UPDATE SET " + strings.Join(updates, ", "), args
+}
+
+// sqlColumns returns the sorted keys of all the objects
+func sqlColumns(objects []DataObjectInterface) []string {
+	seen := map[string]struct{}{}
+	columns := []string{}
+
+	for _, do := range objects {
+		for key := range do.Data() {
+			if _, exists := seen[key]; !exists {
+				seen[key] = struct{}{}
+				columns = append(columns, key)
+			}
+		}
+	}
+
+	sort.Strings(columns)
+
+	return columns
+}
@@ -0,0 +1,53 @@
+package dataobject
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestToSQLInsert(t *testing.T) {
+	do := NewDataObjectFromExistingData(map[string]string{"id": "1", "name": "Jon"})
+
+	query, args := do.ToSQLInsert("users", DialectPostgres)
+
+	if query != `INSERT INTO "users" ("id", "name") VALUES ($1, $2)` {
+		t.Error("Expected postgres insert, but found:", query)
+	}
+
+	if !reflect.DeepEqual(args, []any{"1", "Jon"}) {
+		t.Error("Expected: [1 Jon], but found:", args)
+	}
+
+	query, _ = do.ToSQLUpsert("users", DialectMySQL)
+
+	if query != "INSERT INTO `users` (`id`, `name`) VALUES (?, ?) ON DUPLICATE KEY UPDATE `name` = VALUES(`name`)" {
+		t.Error("Expected mysql upsert, but found:", query)
+	}
+
+	query, _ = do.ToSQLUpsert("users", DialectSQLite)
+
+	if query != `INSERT INTO "users" ("id", "name") VALUES (?, ?) ON CONFLICT ("id") DO UPDATE SET "name" = excluded."name"` {
+		t.Error("Expected sqlite upsert, but found:", query)
+	}
+}
+
+func TestSQLInsertMany(t *testing.T) {
+	objects := []DataObjectInterface{
+		NewDataObjectFromExistingData(map[string]string{"id": "1", "name": "Jon"}),
+		NewDataObjectFromExistingData(map[string]string{"id": "2", "email": "jane@test.com"}),
+	}
+
+	query, args := SQLInsert("users", objects, DialectPostgres)
+
+	if query != `INSERT INTO "users" ("email", "id", "name") VALUES ($1, $2, $3), ($4, $5, $6)` {
+		t.Error("Expected multi-row insert, but found:", query)
+	}
+
+	if !reflect.DeepEqual(args, []any{nil, "1", "Jon", "jane@test.com", "2", nil}) {
+		t.Error("Expected NULL for missing keys, but found:", args)
+	}
+
+	if query, _ := SQLInsert("users", nil, DialectPostgres); query != "" {
+		t.Error("Expected empty statement, but found:", query)
+	}
+}
@@ -0,0 +1,257 @@
+package dataobject
+
+import (
+	"context"
+	"crypto/rand"
+	"database/sql"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// SQLLockerDefaultTTL is the default time after which a lock,
+// which has not been released (i.e. a crashed worker), expires
+const SQLLockerDefaultTTL = time.Minute
+
+// SQLLockerPollInterval is the interval of retrying to acquire a held lock
+const SQLLockerPollInterval = 50 * time.Millisecond
+
+var _ Locker = (*SQLLocker)(nil) // verify it extends the locker interface
+
+// SQLLocker is a locker for workers sharing a SQL database. A lock is
+// a row in the locks table (see CreateTable) with the ID as primary key
+//
+// Example:
+//
+//	locker := NewSQLLocker(db, "locks", DialectPostgres)
+//	if err := locker.CreateTable(ctx); err != nil {
+//		return err
+//	}
+type SQLLocker struct {
+	db      *sql.DB
+	table   string
+	dialect Dialect
+	ttl     time.Duration
+}
+
+// NewSQLLocker creates a new locker storing the locks in the table
+func NewSQLLocker(db *sql.DB, table string, dialect Dialect) *SQLLocker {
+	return &SQLLocker{db: db, table: table, dialect: dialect, ttl: SQLLockerDefaultTTL}
+}
+
+// SetTTL sets the time after which a lock, which has not been released, expires
+func (l *SQLLocker) SetTTL(ttl time.Duration) *SQLLocker {
+	l.ttl = ttl
+	return l
+}
+
+// CreateTable creates the locks table, if it does not exist
+func (l *SQLLocker) CreateTable(ctx context.Context) error {
+	_, err := l.db.ExecContext(ctx, "CREATE TABLE IF NOT EXISTS "+l.dialect.quote(l.table)+" ("+
+		l.dialect.quote("id")+" VARCHAR(191) NOT NULL PRIMARY KEY, "+
+		l.dialect.quote("owner")+" VARCHAR(64) NOT NULL, "+
+		l.dialect.quote("expires_at")+" VARCHAR(19) NOT NULL)")
+	return err
+}
+
+// Lock blocks until the lock on the ID is acquired or the context is done.
+// The lock expires after the TTL, use Acquire to refresh it
+func (l *SQLLocker) Lock(ctx context.Context, id string) (func() error, error) {
+	lock, err := l.Acquire(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return lock.Unlock, nil
+}
+
+// Acquire blocks until the lock on the ID is acquired or the context is
+// done. The lock expires after the TTL (see ExpiresAt), unless refreshed
+// before (see Refresh), so a holder working longer than the TTL must
+// refresh it, or another worker may acquire it
+func (l *SQLLocker) Acquire(ctx context.Context, id string) (*SQLLock, error) {
+	owner, err := newLockOwner()
+	if err != nil {
+		return nil, err
+	}
+
+	for {
+		expiresAt, acquired, err := l.tryLock(ctx, id, owner)
+		if err != nil {
+			return nil, err
+		}
+
+		if acquired {
+			return &SQLLock{locker: l, id: id, owner: owner, expiresAt: expiresAt}, nil
+		}
+
+		select {
+		case <-time.After(SQLLockerPollInterval):
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+}
+
+// tryLock deletes the expired lock on the ID and tries to insert a new
+// one. Returns false without an error if the lock is held by another owner
+func (l *SQLLocker) tryLock(ctx context.Context, id string, owner string) (time.Time, bool, error) {
+	now := time.Now().UTC()
+	expiresAt := now.Add(l.ttl).Truncate(time.Second)
+
+	_, err := l.db.ExecContext(ctx, "DELETE FROM "+l.dialect.quote(l.table)+
+		" WHERE "+l.dialect.quote("id")+" = "+l.dialect.placeholder(1)+
+		" AND "+l.dialect.quote("expires_at")+" < "+l.dialect.placeholder(2), id, now.Format(DateTimeFormat))
+	if err != nil {
+		return time.Time{}, false, err
+	}
+
+	err = l.insert(ctx, id, owner, expiresAt)
+	if err == nil {
+		return expiresAt, true, nil
+	}
+
+	// the insert fails on the primary key while the lock is held. As the
+	// errors of the constraint violations are specific to the drivers,
+	// other failures (i.e. a missing table or a broken connection) are
+	// told apart by the lock not being held
+	held, heldErr := l.isHeld(ctx, id, "")
+	if heldErr != nil {
+		return time.Time{}, false, errors.Join(err, heldErr)
+	}
+
+	if !held {
+		// released since, or the insert fails otherwise
+		return l.retryInsert(ctx, id, owner, expiresAt)
+	}
+
+	return time.Time{}, false, nil
+}
+
+// retryInsert inserts the lock once more after a failed insert, returning
+// its error if it fails again while the lock is not held
+func (l *SQLLocker) retryInsert(ctx context.Context, id string, owner string, expiresAt time.Time) (time.Time, bool, error) {
+	err := l.insert(ctx, id, owner, expiresAt)
+	if err == nil {
+		return expiresAt, true, nil
+	}
+
+	if held, heldErr := l.isHeld(ctx, id, ""); heldErr == nil && held {
+		return time.Time{}, false, nil
+	}
+
+	return time.Time{}, false, err
+}
+
+// insert inserts the lock on the ID
+func (l *SQLLocker) insert(ctx context.Context, id string, owner string, expiresAt time.Time) error {
+	_, err := l.db.ExecContext(ctx, "INSERT INTO "+l.dialect.quote(l.table)+" ("+
+		l.dialect.quote("id")+", "+l.dialect.quote("owner")+", "+l.dialect.quote("expires_at")+") VALUES ("+
+		l.dialect.placeholder(1)+", "+l.dialect.placeholder(2)+", "+l.dialect.placeholder(3)+")",
+		id, owner, expiresAt.Format(DateTimeFormat))
+	return err
+}
+
+// isHeld returns if the lock on the ID exists, held by the owner if not empty
+func (l *SQLLocker) isHeld(ctx context.Context, id string, owner string) (bool, error) {
+	query := "SELECT COUNT(*) FROM " + l.dialect.quote(l.table) +
+		" WHERE " + l.dialect.quote("id") + " = " + l.dialect.placeholder(1)
+	args := []any{id}
+
+	if owner != "" {
+		query += " AND " + l.dialect.quote("owner") + " = " + l.dialect.placeholder(2)
+		args = append(args, owner)
+	}
+
+	count := 0
+	if err := l.db.QueryRowContext(ctx, query, args...).Scan(&count); err != nil {
+		return false, err
+	}
+
+	return count > 0, nil
+}
+
+// SQLLock is a lock acquired with SQLLocker.Acquire
+type SQLLock struct {
+	locker *SQLLocker
+	id     string
+	owner  string
+
+	mu        sync.Mutex
+	expiresAt time.Time
+}
+
+// ID returns the locked ID
+func (lock *SQLLock) ID() string {
+	return lock.id
+}
+
+// Owner returns the random token identifying the holder of the lock
+func (lock *SQLLock) Owner() string {
+	return lock.owner
+}
+
+// ExpiresAt returns the time after which the lock
+// may be acquired by another worker, unless refreshed
+func (lock *SQLLock) ExpiresAt() time.Time {
+	lock.mu.Lock()
+	defer lock.mu.Unlock()
+
+	return lock.expiresAt
+}
+
+// Refresh extends the lock by the TTL of the locker from now. Returns an
+// error wrapping ErrLockLost if the lock is no longer held (i.e. it has
+// expired and has been acquired by another worker)
+func (lock *SQLLock) Refresh(ctx context.Context) error {
+	l := lock.locker
+	expiresAt := time.Now().UTC().Add(l.ttl).Truncate(time.Second)
+
+	result, err := l.db.ExecContext(ctx, "UPDATE "+l.dialect.quote(l.table)+
+		" SET "+l.dialect.quote("expires_at")+" = "+l.dialect.placeholder(1)+
+		" WHERE "+l.dialect.quote("id")+" = "+l.dialect.placeholder(2)+
+		" AND "+l.dialect.quote("owner")+" = "+l.dialect.placeholder(3),
+		expiresAt.Format(DateTimeFormat), lock.id, lock.owner)
+	if err != nil {
+		return err
+	}
+
+	if affected, err := result.RowsAffected(); err != nil || affected < 1 {
+		// some databases do not count the rows updated to the same value
+		held, err := l.isHeld(ctx, lock.id, lock.owner)
+		if err != nil {
+			return err
+		}
+		if !held {
+			return fmt.Errorf("%w: %s", ErrLockLost, lock.id)
+		}
+	}
+
+	lock.mu.Lock()
+	defer lock.mu.Unlock()
+
+	lock.expiresAt = expiresAt
+
+	return nil
+}
+
+// Unlock releases the lock, if still held
+func (lock *SQLLock) Unlock() error {
+	l := lock.locker
+
+	// the context of the lock may be done already
+	_, err := l.db.ExecContext(context.Background(), "DELETE FROM "+l.dialect.quote(l.table)+
+		" WHERE "+l.dialect.quote("id")+" = "+l.dialect.placeholder(1)+
+		" AND "+l.dialect.quote("owner")+" = "+l.dialect.placeholder(2), lock.id, lock.owner)
+	return err
+}
+
+// newLockOwner returns a random token identifying the holder of a lock
+func newLockOwner() (string, error) {
+	token := make([]byte, 16)
+	if _, err := rand.Read(token); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(token), nil
+}
@@ -0,0 +1,187 @@
+package dataobject
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"io"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestSQLLocker(t *testing.T) {
+	db, err := sql.Open("dataobject_locks_test", "")
+
+	if err != nil {
+		t.Fatal("Error must be nil, but found:", err.Error())
+	}
+
+	defer db.Close()
+
+	locker := NewSQLLocker(db, "locks", DialectSQLite)
+
+	if err := locker.CreateTable(context.Background()); err != nil {
+		t.Fatal("Error must be nil, but found:", err.Error())
+	}
+
+	unlock, err := locker.Lock(context.Background(), "1")
+
+	if err != nil {
+		t.Fatal("Error must be nil, but found:", err.Error())
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 120*time.Millisecond)
+	defer cancel()
+
+	if _, err := locker.Lock(ctx, "1"); !errors.Is(err, context.DeadlineExceeded) {
+		t.Error("Expected: context.DeadlineExceeded, but found:", err)
+	}
+
+	if err := unlock(); err != nil {
+		t.Fatal("Error must be nil, but found:", err.Error())
+	}
+
+	if _, err := locker.Lock(context.Background(), "1"); err != nil {
+		t.Error("Error must be nil, but found:", err.Error())
+	}
+}
+
+func TestSQLLockerRefresh(t *testing.T) {
+	db, _ := sql.Open("dataobject_locks_test", "")
+	defer db.Close()
+
+	locker := NewSQLLocker(db, "locks", DialectSQLite).SetTTL(time.Hour)
+
+	lock, err := locker.Acquire(context.Background(), "2")
+	if err != nil {
+		t.Fatal("Error must be nil, but found:", err.Error())
+	}
+
+	if lock.ExpiresAt().Before(time.Now().Add(59 * time.Minute)) {
+		t.Error("Expected: to expire in an hour, but found:", lock.ExpiresAt())
+	}
+
+	if err := lock.Refresh(context.Background()); err != nil {
+		t.Error("Error must be nil, but found:", err.Error())
+	}
+
+	_ = lock.Unlock()
+
+	if err := lock.Refresh(context.Background()); !errors.Is(err, ErrLockLost) {
+		t.Error("Expected: ErrLockLost, but found:", err)
+	}
+}
+
+func TestSQLLockerReturnsInsertErrors(t *testing.T) {
+	db, _ := sql.Open("dataobject_locks_test", "readonly")
+	defer db.Close()
+
+	locker := NewSQLLocker(db, "locks", DialectSQLite)
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	if _, err := locker.Lock(ctx, "3"); err == nil || errors.Is(err, context.DeadlineExceeded) {
+		t.Error("Expected: the error of the insert, but found:", err)
+	}
+}
+
+func init() {
+	sql.Register("dataobject_locks_test", &testLocksDriver{locks: map[string]string{}})
+}
+
+// testLocksDriver emulates the locks table of the SQLLocker statements
+type testLocksDriver struct {
+	mu    sync.Mutex
+	locks map[string]string
+}
+
+func (d *testLocksDriver) Open(name string) (driver.Conn, error) {
+	return testLocksConn{driver: d, readonly: name == "readonly"}, nil
+}
+
+// testLocksConn fails the inserts, if readonly
+type testLocksConn struct {
+	driver   *testLocksDriver
+	readonly bool
+}
+
+func (c testLocksConn) Prepare(query string) (driver.Stmt, error) {
+	if c.readonly && strings.HasPrefix(query, "INSERT") {
+		return nil, errors.New("attempt to write a readonly database")
+	}
+	return testLocksStmt{driver: c.driver, query: query}, nil
+}
+func (c testLocksConn) Close() error              { return nil }
+func (c testLocksConn) Begin() (driver.Tx, error) { return nil, driver.ErrSkip }
+
+type testLocksStmt struct {
+	driver *testLocksDriver
+	query  string
+}
+
+func (s testLocksStmt) Close() error  { return nil }
+func (s testLocksStmt) NumInput() int { return -1 }
+
+func (s testLocksStmt) Query(args []driver.Value) (driver.Rows, error) {
+	s.driver.mu.Lock()
+	defer s.driver.mu.Unlock()
+
+	owner, exists := s.driver.locks[args[0].(string)]
+	if exists && len(args) > 1 && owner != args[1].(string) {
+		exists = false
+	}
+
+	count := int64(0)
+	if exists {
+		count = 1
+	}
+
+	return &testLocksRows{count: count}, nil
+}
+
+func (s testLocksStmt) Exec(args []driver.Value) (driver.Result, error) {
+	s.driver.mu.Lock()
+	defer s.driver.mu.Unlock()
+
+	switch {
+	case strings.HasPrefix(s.query, "INSERT"):
+		id := args[0].(string)
+		if _, exists := s.driver.locks[id]; exists {
+			return nil, errors.New("UNIQUE constraint failed")
+		}
+		s.driver.locks[id] = args[1].(string)
+	case strings.HasPrefix(s.query, "UPDATE"):
+		if s.driver.locks[args[1].(string)] != args[2].(string) {
+			return driver.RowsAffected(0), nil
+		}
+	case strings.HasPrefix(s.query, "DELETE") && strings.Contains(s.query, `"owner"`):
+		id := args[0].(string)
+		if s.driver.locks[id] == args[1].(string) {
+			delete(s.driver.locks, id)
+		}
+	}
+
+	return driver.RowsAffected(1), nil
+}
+
+// testLocksRows is the single row of a count
+type testLocksRows struct {
+	count int64
+	read  bool
+}
+
+func (r *testLocksRows) Columns() []string { return []string{"count"} }
+func (r *testLocksRows) Close() error      { return nil }
+
+func (r *testLocksRows) Next(dest []driver.Value) error {
+	if r.read {
+		return io.EOF
+	}
+	r.read = true
+	dest[0] = r.count
+	return nil
+}
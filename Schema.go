@@ -0,0 +1,132 @@
+package dataobject
+
+import (
+	"slices"
+	"strconv"
+	"time"
+)
+
+// FieldType is the type of the values of a schema field
+type FieldType string
+
+const (
+	FieldTypeString   FieldType = "string"
+	FieldTypeInt      FieldType = "int"
+	FieldTypeFloat    FieldType = "float"
+	FieldTypeBool     FieldType = "bool"
+	FieldTypeDateTime FieldType = "datetime"
+)
+
+// DateTimeFormat is the format of date time values ("2006-01-02 15:04:05").
+// Values in RFC 3339 format are accepted as well
+const DateTimeFormat = time.DateTime
+
+// SchemaField describes a single key of a data object
+type SchemaField struct {
+	// Key is the key of the field in the data
+	Key string
+
+	// Label is the human readable name of the field
+	Label string
+
+	// Type is the type of the values, defaults to string
+	Type FieldType
+
+	// Required marks the field as not allowing empty values
+	Required bool
+
+	// Options lists the allowed values, if not empty
+	Options []string
+}
+
+// Schema describes the fields of a data object
+type Schema struct {
+	fields []SchemaField
+}
+
+// NewSchema creates a new schema with the fields
+func NewSchema(fields ...SchemaField) *Schema {
+	return &Schema{fields: fields}
+}
+
+// Fields returns the fields of the schema in the declared order
+func (s *Schema) Fields() []SchemaField {
+	return append([]SchemaField{}, s.fields...)
+}
+
+// Keys returns the keys of the fields in the declared order
+func (s *Schema) Keys() []string {
+	keys := make([]string, 0, len(s.fields))
+	for _, field := range s.fields {
+		keys = append(keys, field.Key)
+	}
+	return keys
+}
+
+// Field returns the field for the key, and false if there is none
+func (s *Schema) Field(key string) (SchemaField, bool) {
+	for _, field := range s.fields {
+		if field.Key == key {
+			return field, true
+		}
+	}
+	return SchemaField{}, false
+}
+
+// Validate validates the data against the schema,
+// returns the errors per field, or nil if the data is valid
+func (s *Schema) Validate(data map[string]string) []FieldError {
+	var errs []FieldError
+
+	for _, field := range s.fields {
+		if message := field.validate(data[field.Key]); message != "" {
+			errs = append(errs, FieldError{Key: field.Key, Message: message})
+		}
+	}
+
+	return errs
+}
+
+// validate returns the validation error message for the value, if any
+func (field SchemaField) validate(value string) string {
+	if value == "" {
+		if field.Required {
+			return "is required"
+		}
+		return ""
+	}
+
+	if len(field.Options) > 0 && !slices.Contains(field.Options, value) {
+		return "is not an allowed value"
+	}
+
+	switch field.Type {
+	case FieldTypeInt:
+		if _, err := strconv.ParseInt(value, 10, 64); err != nil {
+			return "must be an integer"
+		}
+	case FieldTypeFloat:
+		if _, err := strconv.ParseFloat(value, 64); err != nil {
+			return "must be a number"
+		}
+	case FieldTypeBool:
+		if _, err := strconv.ParseBool(value); err != nil {
+			return "must be a boolean"
+		}
+	case FieldTypeDateTime:
+		if _, err := parseDateTime(value); err != nil {
+			return "must be a date time"
+		}
+	}
+
+	return ""
+}
+
+// parseDateTime parses a date time value in DateTimeFormat or RFC 3339
+func parseDateTime(value string) (time.Time, error) {
+	t, err := time.Parse(DateTimeFormat, value)
+	if err == nil {
+		return t, nil
+	}
+	return time.Parse(time.RFC3339Nano, value)
+}
@@ -0,0 +1,39 @@
+package dataobject
+
+import (
+	"testing"
+)
+
+func TestSchemaValidate(t *testing.T) {
+	schema := NewSchema(
+		SchemaField{Key: "email", Required: true},
+		SchemaField{Key: "age", Type: FieldTypeInt},
+		SchemaField{Key: "status", Options: []string{"active", "inactive"}},
+		SchemaField{Key: "created_at", Type: FieldTypeDateTime},
+	)
+
+	errs := schema.Validate(map[string]string{
+		"email":      "jon@test.com",
+		"age":        "30",
+		"status":     "active",
+		"created_at": "2024-01-02 03:04:05",
+	})
+
+	if len(errs) != 0 {
+		t.Error("Expected: no errors, but found:", errs)
+	}
+
+	errs = schema.Validate(map[string]string{
+		"age":        "thirty",
+		"status":     "banned",
+		"created_at": "yesterday",
+	})
+
+	if len(errs) != 4 {
+		t.Fatal("Expected: 4 errors, but found:", errs)
+	}
+
+	if errs[0].Error() != "email is required" {
+		t.Error("Expected: email is required, but found:", errs[0].Error())
+	}
+}
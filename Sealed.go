@@ -0,0 +1,174 @@
+package dataobject
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+)
+
+// MaxCookieSize is the maximum size of a cookie accepted by browsers
+const MaxCookieSize = 4096
+
+// SessionStoreInterface is a store holding the raw session data
+// of the current request (i.e. a session or key-value store)
+type SessionStoreInterface interface {
+	Get() ([]byte, error)
+	Set(value []byte) error
+}
+
+// ToSealed encrypts and signs the data of the object with AES-GCM
+// using the key (16, 24 or 32 bytes), returns it base64 URL encoded.
+// The data can only be restored with NewDataObjectFromSealed
+func (do *DataObject) ToSealed(key []byte) (string, error) {
+	sealed, err := do.seal(key, nil)
+	if err != nil {
+		return "", err
+	}
+
+	return base64.RawURLEncoding.EncodeToString(sealed), nil
+}
+
+// NewDataObjectFromSealed restores a data object from the data produced
+// by ToSealed, returns an error if the data has been tampered with
+func NewDataObjectFromSealed(sealed string, key []byte) (*DataObject, error) {
+	ciphertext, err := base64.RawURLEncoding.DecodeString(sealed)
+	if err != nil {
+		return nil, err
+	}
+
+	return newDataObjectFromSealed(key, ciphertext, nil)
+}
+
+// sealedPurpose binds the data sealed with a key provider to its use
+const sealedPurpose = "sealed"
+
+// ToSealedWithKeys encrypts and signs the data of the object with AES-GCM
+// using the current key of the provider, returns it base64 URL encoded.
+// The data is tagged with the key ID, so it can still be restored with
+// NewDataObjectFromSealedWithKeys after a key rotation
+func (do *DataObject) ToSealedWithKeys(keys KeyProvider) (string, error) {
+	jsonValue, err := json.Marshal(do.Data())
+	if err != nil {
+		return "", err
+	}
+
+	sealed, err := encryptBlob(keys, jsonValue, sealedPurpose)
+	if err != nil {
+		return "", err
+	}
+
+	return base64.RawURLEncoding.EncodeToString(sealed), nil
+}
+
+// NewDataObjectFromSealedWithKeys restores a data object from the data
+// produced by ToSealedWithKeys, returns an error if the data has been
+// tampered with, or its key is not provided
+func NewDataObjectFromSealedWithKeys(sealed string, keys KeyProvider) (*DataObject, error) {
+	blob, err := base64.RawURLEncoding.DecodeString(sealed)
+	if err != nil {
+		return nil, err
+	}
+
+	plaintext, err := decryptBlob(keys, blob, sealedPurpose)
+	if err != nil {
+		return nil, err
+	}
+
+	data := map[string]string{}
+	if err := json.Unmarshal(plaintext, &data); err != nil {
+		return nil, err
+	}
+
+	return NewDataObjectFromExistingData(data), nil
+}
+
+// WriteCookie stores the sealed data of the object in the cookie.
+// The cookie value is bound to the cookie name, so it cannot be
+// swapped with another cookie sealed with the same key
+//
+// Example:
+//
+//	err := cart.WriteCookie(w, http.Cookie{Name: "cart", Path: "/", HttpOnly: true, Secure: true}, key)
+func (do *DataObject) WriteCookie(w http.ResponseWriter, cookie http.Cookie, key []byte) error {
+	sealed, err := do.seal(key, []byte(cookie.Name))
+	if err != nil {
+		return err
+	}
+
+	cookie.Value = base64.RawURLEncoding.EncodeToString(sealed)
+
+	if len(cookie.String()) > MaxCookieSize {
+		return ErrCookieTooLarge
+	}
+
+	http.SetCookie(w, &cookie)
+
+	return nil
+}
+
+// NewDataObjectFromCookie restores a data object from the cookie with
+// the name written by WriteCookie, returns http.ErrNoCookie if missing
+func NewDataObjectFromCookie(r *http.Request, name string, key []byte) (*DataObject, error) {
+	cookie, err := r.Cookie(name)
+	if err != nil {
+		return nil, err
+	}
+
+	ciphertext, err := base64.RawURLEncoding.DecodeString(cookie.Value)
+	if err != nil {
+		return nil, err
+	}
+
+	return newDataObjectFromSealed(key, ciphertext, []byte(name))
+}
+
+// SaveToSession stores the sealed data of the object in the session store.
+// As session stores commonly keep the data in a cookie, returns
+// ErrCookieTooLarge if the data base64 encoded exceeds MaxCookieSize
+func (do *DataObject) SaveToSession(store SessionStoreInterface, key []byte) error {
+	sealed, err := do.seal(key, nil)
+	if err != nil {
+		return err
+	}
+
+	if base64.RawURLEncoding.EncodedLen(len(sealed)) > MaxCookieSize {
+		return ErrCookieTooLarge
+	}
+
+	return store.Set(sealed)
+}
+
+// NewDataObjectFromSession restores a data object from the session store
+func NewDataObjectFromSession(store SessionStoreInterface, key []byte) (*DataObject, error) {
+	ciphertext, err := store.Get()
+	if err != nil {
+		return nil, err
+	}
+
+	return newDataObjectFromSealed(key, ciphertext, nil)
+}
+
+// seal encrypts the JSON data of the object
+func (do *DataObject) seal(key []byte, additionalData []byte) ([]byte, error) {
+	jsonValue, err := json.Marshal(do.Data())
+	if err != nil {
+		return nil, err
+	}
+
+	return seal(key, jsonValue, additionalData)
+}
+
+// newDataObjectFromSealed decrypts the JSON data and hydrates a new object
+func newDataObjectFromSealed(key []byte, ciphertext []byte, additionalData []byte) (*DataObject, error) {
+	plaintext, err := open(key, ciphertext, additionalData)
+	if err != nil {
+		return nil, err
+	}
+
+	data := map[string]string{}
+	if err := json.Unmarshal(plaintext, &data); err != nil {
+		return nil, err
+	}
+
+	return NewDataObjectFromExistingData(data), nil
+}
@@ -0,0 +1,141 @@
+package dataobject
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+var testSealKey = []byte("0123456789abcdef0123456789abcdef")
+
+func TestDataObjectToSealed(t *testing.T) {
+	cart := NewDataObject()
+	cart.Set("items", "3")
+
+	sealed, err := cart.ToSealed(testSealKey)
+
+	if err != nil {
+		t.Fatal("Error must be nil, but found:", err.Error())
+	}
+
+	if strings.Contains(sealed, "items") {
+		t.Error("Expected data to be encrypted, but found:", sealed)
+	}
+
+	restored, err := NewDataObjectFromSealed(sealed, testSealKey)
+
+	if err != nil {
+		t.Fatal("Error must be nil, but found:", err.Error())
+	}
+
+	if restored.Get("items") != "3" {
+		t.Error("Expected: 3, but found:", restored.Get("items"))
+	}
+
+	if _, err := NewDataObjectFromSealed(sealed, []byte("fedcba9876543210fedcba9876543210")); err == nil {
+		t.Error("Error must NOT be nil, but found:", err)
+	}
+}
+
+func TestDataObjectWriteCookie(t *testing.T) {
+	cart := NewDataObject()
+	cart.Set("items", "3")
+
+	w := httptest.NewRecorder()
+
+	err := cart.WriteCookie(w, http.Cookie{Name: "cart", Path: "/", HttpOnly: true}, testSealKey)
+
+	if err != nil {
+		t.Fatal("Error must be nil, but found:", err.Error())
+	}
+
+	r := httptest.NewRequest("GET", "/", nil)
+	for _, cookie := range w.Result().Cookies() {
+		r.AddCookie(cookie)
+	}
+
+	restored, err := NewDataObjectFromCookie(r, "cart", testSealKey)
+
+	if err != nil {
+		t.Fatal("Error must be nil, but found:", err.Error())
+	}
+
+	if restored.ID() != cart.ID() {
+		t.Error("Expected:", cart.ID(), "but found:", restored.ID())
+	}
+
+	// too large
+	cart.Set("notes", strings.Repeat("x", MaxCookieSize))
+
+	if err := cart.WriteCookie(httptest.NewRecorder(), http.Cookie{Name: "cart"}, testSealKey); err == nil {
+		t.Error("Error must NOT be nil, but found:", err)
+	}
+}
+
+type testSessionStore struct {
+	value []byte
+}
+
+func (s *testSessionStore) Get() ([]byte, error) { return s.value, nil }
+
+func (s *testSessionStore) Set(value []byte) error {
+	s.value = value
+	return nil
+}
+
+func TestDataObjectSaveToSession(t *testing.T) {
+	store := &testSessionStore{}
+
+	preferences := NewDataObject()
+	preferences.Set("theme", "dark")
+
+	if err := preferences.SaveToSession(store, testSealKey); err != nil {
+		t.Fatal("Error must be nil, but found:", err.Error())
+	}
+
+	restored, err := NewDataObjectFromSession(store, testSealKey)
+
+	if err != nil {
+		t.Fatal("Error must be nil, but found:", err.Error())
+	}
+
+	if restored.Get("theme") != "dark" {
+		t.Error("Expected: dark, but found:", restored.Get("theme"))
+	}
+
+	// too large
+	preferences.Set("notes", strings.Repeat("x", MaxCookieSize))
+
+	if err := preferences.SaveToSession(store, testSealKey); !errors.Is(err, ErrCookieTooLarge) {
+		t.Error("Expected: ErrCookieTooLarge, but found:", err)
+	}
+}
+
+func TestDataObjectToSealedWithKeys(t *testing.T) {
+	keys := NewKeyRing("v1", testSealKey)
+
+	cart := NewDataObject()
+	cart.Set("items", "3")
+
+	sealed, err := cart.ToSealedWithKeys(keys)
+	if err != nil {
+		t.Fatal("Error must be nil, but found:", err.Error())
+	}
+
+	keys.Rotate("v2", []byte("fedcba9876543210fedcba9876543210"))
+
+	restored, err := NewDataObjectFromSealedWithKeys(sealed, keys)
+	if err != nil {
+		t.Fatal("Error must be nil, but found:", err.Error())
+	}
+
+	if restored.Get("items") != "3" {
+		t.Error("Expected: 3, but found:", restored.Get("items"))
+	}
+
+	if _, err := NewDataObjectFromSealedWithKeys(sealed[:len(sealed)-2]+"AA", keys); err == nil {
+		t.Error("Error must NOT be nil for tampered data")
+	}
+}
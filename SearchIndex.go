@@ -0,0 +1,153 @@
+package dataobject
+
+import (
+	"math"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// SearchIndex is a lightweight in-memory full-text index
+// over selected keys of data objects
+//
+// Values are tokenized into lowercase words, and searches
+// return the IDs of the matching objects ranked by relevance
+//
+// The index is safe for concurrent use
+type SearchIndex struct {
+	mu sync.RWMutex
+
+	// keys holds the keys which are indexed
+	keys []string
+
+	// postings maps token -> object ID -> token frequency
+	postings map[string]map[string]int
+
+	// tokens maps object ID -> indexed tokens
+	tokens map[string][]string
+}
+
+// NewSearchIndex creates a new search index over the passed keys
+func NewSearchIndex(keys ...string) *SearchIndex {
+	return &SearchIndex{
+		keys:     keys,
+		postings: map[string]map[string]int{},
+		tokens:   map[string][]string{},
+	}
+}
+
+// Add indexes the object. If the object is already indexed, it is re-indexed
+func (s *SearchIndex) Add(do DataObjectInterface) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	id := do.ID()
+	s.remove(id)
+
+	data := do.Data()
+	frequencies := map[string]int{}
+	for _, key := range s.keys {
+		for _, token := range tokenize(data[key]) {
+			frequencies[token]++
+		}
+	}
+
+	tokens := make([]string, 0, len(frequencies))
+	for token, frequency := range frequencies {
+		if s.postings[token] == nil {
+			s.postings[token] = map[string]int{}
+		}
+		s.postings[token][id] = frequency
+		tokens = append(tokens, token)
+	}
+
+	s.tokens[id] = tokens
+}
+
+// Remove removes the object with the specified ID from the index
+func (s *SearchIndex) Remove(id string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.remove(id)
+}
+
+// Search returns the IDs of the objects matching all the words
+// of the query, ordered by relevance (most relevant first)
+//
+// Each query word matches indexed words starting with it,
+// with exact matches ranked higher than prefix matches
+func (s *SearchIndex) Search(query string) []string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	queryTokens := tokenize(query)
+	if len(queryTokens) < 1 {
+		return []string{}
+	}
+
+	documentCount := float64(len(s.tokens))
+	scores := map[string]float64{}
+
+	for i, queryToken := range queryTokens {
+		tokenScores := map[string]float64{}
+
+		for token, postings := range s.postings {
+			if !strings.HasPrefix(token, queryToken) {
+				continue
+			}
+
+			weight := 1.0
+			if token != queryToken {
+				weight = 0.5
+			}
+
+			idf := math.Log(1 + documentCount/float64(len(postings)))
+
+			for id, frequency := range postings {
+				tokenScores[id] += weight * float64(frequency) * idf
+			}
+		}
+
+		// all the query words must match
+		for id, score := range tokenScores {
+			if i == 0 {
+				scores[id] = score
+			} else if _, exists := scores[id]; exists {
+				scores[id] += score
+			}
+		}
+
+		for id := range scores {
+			if _, exists := tokenScores[id]; !exists {
+				delete(scores, id)
+			}
+		}
+	}
+
+	ids := make([]string, 0, len(scores))
+	for id := range scores {
+		ids = append(ids, id)
+	}
+
+	sort.Slice(ids, func(i, j int) bool {
+		if scores[ids[i]] != scores[ids[j]] {
+			return scores[ids[i]] > scores[ids[j]]
+		}
+		return ids[i] < ids[j]
+	})
+
+	return ids
+}
+
+// remove removes the object ID from the postings
+func (s *SearchIndex) remove(id string) {
+	for _, token := range s.tokens[id] {
+		delete(s.postings[token], id)
+		if len(s.postings[token]) < 1 {
+			delete(s.postings, token)
+		}
+	}
+
+	delete(s.tokens, id)
+}
@@ -0,0 +1,70 @@
+package dataobject
+
+import (
+	"testing"
+)
+
+func TestSearchIndexSearch(t *testing.T) {
+	index := NewSearchIndex("first_name", "last_name", "bio")
+
+	user1 := NewDataObject()
+	user1.Set("first_name", "Jon")
+	user1.Set("last_name", "Doe")
+	user1.Set("bio", "Go developer")
+	index.Add(user1)
+
+	user2 := NewDataObject()
+	user2.Set("first_name", "Jane")
+	user2.Set("last_name", "Doe")
+	user2.Set("bio", "Go, Go, Go! Loves Go and Gophers")
+	index.Add(user2)
+
+	user3 := NewDataObject()
+	user3.Set("first_name", "Jim")
+	user3.Set("last_name", "Smith")
+	index.Add(user3)
+
+	results := index.Search("doe")
+
+	if len(results) != 2 {
+		t.Fatal("Expected: 2, but found:", len(results))
+	}
+
+	results = index.Search("go doe")
+
+	if len(results) != 2 {
+		t.Fatal("Expected: 2, but found:", len(results))
+	}
+
+	if results[0] != user2.ID() {
+		t.Error("Expected:", user2.ID(), "but found:", results[0])
+	}
+
+	// prefix match
+	results = index.Search("smi")
+
+	if len(results) != 1 || results[0] != user3.ID() {
+		t.Error("Expected:", user3.ID(), "but found:", results)
+	}
+
+	// all words must match
+	results = index.Search("jon smith")
+
+	if len(results) != 0 {
+		t.Error("Expected: 0, but found:", len(results))
+	}
+}
+
+func TestSearchIndexRemove(t *testing.T) {
+	index := NewSearchIndex("first_name")
+
+	user := NewDataObject()
+	user.Set("first_name", "Jon")
+	index.Add(user)
+
+	index.Remove(user.ID())
+
+	if len(index.Search("jon")) != 0 {
+		t.Error("Expected: 0, but found:", len(index.Search("jon")))
+	}
+}
@@ -0,0 +1,70 @@
+package dataobject
+
+// Unique returns the objects with distinct values for the key,
+// keeping the first object for each value and preserving the order
+func Unique(objects []DataObjectInterface, byKey string) []DataObjectInterface {
+	seen := map[string]struct{}{}
+	result := []DataObjectInterface{}
+
+	for _, do := range objects {
+		value := do.Data()[byKey]
+
+		if _, exists := seen[value]; exists {
+			continue
+		}
+
+		seen[value] = struct{}{}
+		result = append(result, do)
+	}
+
+	return result
+}
+
+// Union returns the objects of both collections, distinct by ID.
+// The objects of the first collection take precedence
+func Union(a []DataObjectInterface, b []DataObjectInterface) []DataObjectInterface {
+	all := make([]DataObjectInterface, 0, len(a)+len(b))
+	all = append(all, a...)
+	all = append(all, b...)
+
+	return Unique(all, "id")
+}
+
+// Intersect returns the objects of the first collection,
+// which have an object with the same ID in the second collection
+func Intersect(a []DataObjectInterface, b []DataObjectInterface) []DataObjectInterface {
+	ids := idSet(b)
+	result := []DataObjectInterface{}
+
+	for _, do := range a {
+		if _, exists := ids[do.ID()]; exists {
+			result = append(result, do)
+		}
+	}
+
+	return result
+}
+
+// DiffByID returns the objects of the first collection,
+// which do not have an object with the same ID in the second collection
+func DiffByID(a []DataObjectInterface, b []DataObjectInterface) []DataObjectInterface {
+	ids := idSet(b)
+	result := []DataObjectInterface{}
+
+	for _, do := range a {
+		if _, exists := ids[do.ID()]; !exists {
+			result = append(result, do)
+		}
+	}
+
+	return result
+}
+
+// idSet returns the set of IDs of the objects
+func idSet(objects []DataObjectInterface) map[string]struct{} {
+	ids := make(map[string]struct{}, len(objects))
+	for _, do := range objects {
+		ids[do.ID()] = struct{}{}
+	}
+	return ids
+}
@@ -0,0 +1,45 @@
+package dataobject
+
+import (
+	"testing"
+)
+
+func TestUnique(t *testing.T) {
+	user1 := NewDataObjectFromExistingData(map[string]string{"id": "1", "email": "jon@test.com"})
+	user2 := NewDataObjectFromExistingData(map[string]string{"id": "2", "email": "jane@test.com"})
+	user3 := NewDataObjectFromExistingData(map[string]string{"id": "3", "email": "jon@test.com"})
+
+	result := Unique([]DataObjectInterface{user1, user2, user3}, "email")
+
+	if len(result) != 2 {
+		t.Fatal("Expected: 2, but found:", len(result))
+	}
+
+	if result[0].ID() != "1" || result[1].ID() != "2" {
+		t.Error("Expected: 1, 2 but found:", result[0].ID(), result[1].ID())
+	}
+}
+
+func TestUnionIntersectDiffByID(t *testing.T) {
+	user1 := NewDataObjectFromExistingData(map[string]string{"id": "1"})
+	user2 := NewDataObjectFromExistingData(map[string]string{"id": "2"})
+	user3 := NewDataObjectFromExistingData(map[string]string{"id": "3"})
+
+	a := []DataObjectInterface{user1, user2}
+	b := []DataObjectInterface{user2, user3}
+
+	union := Union(a, b)
+	if len(union) != 3 {
+		t.Error("Expected: 3, but found:", len(union))
+	}
+
+	intersect := Intersect(a, b)
+	if len(intersect) != 1 || intersect[0].ID() != "2" {
+		t.Error("Expected: [2], but found:", intersect)
+	}
+
+	diff := DiffByID(a, b)
+	if len(diff) != 1 || diff[0].ID() != "1" {
+		t.Error("Expected: [1], but found:", diff)
+	}
+}
@@ -0,0 +1,38 @@
+package dataobject
+
+import "time"
+
+// SoftDeletedAtKey is the key holding the time an object has been
+// soft deleted at. Empty values and times in the future (i.e. the
+// "9999-12-31 23:59:59" sentinel) mean the object is not deleted
+const SoftDeletedAtKey = "soft_deleted_at"
+
+// IsSoftDeleted returns if the object has been soft deleted
+func IsSoftDeleted(do DataObjectInterface) bool {
+	return isSoftDeletedValue(do.Data()[SoftDeletedAtKey], time.Now())
+}
+
+// SoftDelete marks the object as soft deleted now
+func (do *DataObject) SoftDelete() {
+	do.SetTime(SoftDeletedAtKey, time.Now())
+}
+
+// Undelete marks the object as not soft deleted
+func (do *DataObject) Undelete() {
+	do.Set(SoftDeletedAtKey, "")
+}
+
+// isSoftDeletedValue returns if the soft deleted at value is
+// a time, which is not after now
+func isSoftDeletedValue(value string, now time.Time) bool {
+	if value == "" {
+		return false
+	}
+
+	deletedAt, err := parseDateTime(value)
+	if err != nil {
+		return false
+	}
+
+	return !deletedAt.After(now)
+}
@@ -0,0 +1,127 @@
+package dataobject
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestIsSoftDeleted(t *testing.T) {
+	do := NewDataObject()
+
+	if IsSoftDeleted(do) {
+		t.Error("Expected: false, but found:", IsSoftDeleted(do))
+	}
+
+	do.Set(SoftDeletedAtKey, "9999-12-31 23:59:59")
+
+	if IsSoftDeleted(do) {
+		t.Error("Expected: false, but found:", IsSoftDeleted(do))
+	}
+
+	do.SoftDelete()
+
+	if !IsSoftDeleted(do) {
+		t.Error("Expected: true, but found:", IsSoftDeleted(do))
+	}
+
+	do.Undelete()
+
+	if IsSoftDeleted(do) {
+		t.Error("Expected: false, but found:", IsSoftDeleted(do))
+	}
+}
+
+func TestMemoryRepositorySoftDelete(t *testing.T) {
+	ctx := context.Background()
+	repo := NewMemoryRepository("status").WithSoftDelete()
+
+	_ = repo.Create(ctx, NewDataObjectFromExistingData(map[string]string{"id": "1", "status": "active"}))
+	_ = repo.Create(ctx, NewDataObjectFromExistingData(map[string]string{"id": "2", "status": "active"}))
+
+	if err := repo.Delete(ctx, "1"); err != nil {
+		t.Fatal("Error must be nil, but found:", err.Error())
+	}
+
+	if _, err := repo.Find(ctx, "1"); !errors.Is(err, ErrNotFound) {
+		t.Error("Expected: ErrNotFound, but found:", err)
+	}
+
+	if count, _ := repo.Count(ctx); count != 1 {
+		t.Error("Expected: 1, but found:", count)
+	}
+
+	if active, _ := repo.FindBy(ctx, "status", "active"); len(active) != 1 {
+		t.Error("Expected: 1, but found:", len(active))
+	}
+
+	tests := map[*Query]int{
+		Where("status").Eq("active"):                    1,
+		Where("status").Eq("active").WithDeleted():      2,
+		Where("status").Eq("active").OnlyDeleted():      1,
+		NewQuery().WithoutDeleted().Where("id").Eq("2"): 1,
+	}
+
+	for query, expected := range tests {
+		if result, _ := repo.Query(ctx, query); len(result) != expected {
+			t.Error("Expected:", expected, "but found:", len(result), "for", query.Scope())
+		}
+	}
+
+	if err := repo.Delete(ctx, "1"); !errors.Is(err, ErrNotFound) {
+		t.Error("Expected: ErrNotFound, but found:", err)
+	}
+
+	trashed := NewDataObjectFromExistingData(map[string]string{"id": "1", "status": "inactive"})
+
+	if err := repo.Update(ctx, trashed); !errors.Is(err, ErrNotFound) {
+		t.Error("Expected: ErrNotFound for Update, but found:", err)
+	}
+
+	if err := repo.UpdateIf(ctx, trashed, "*"); !errors.Is(err, ErrNotFound) {
+		t.Error("Expected: ErrNotFound for UpdateIf, but found:", err)
+	}
+
+	if err := repo.Create(ctx, trashed); !errors.Is(err, ErrAlreadyExists) {
+		t.Error("Expected: ErrAlreadyExists for Create, but found:", err)
+	}
+
+	if err := repo.Restore(ctx, "2"); !errors.Is(err, ErrNotFound) {
+		t.Error("Expected: ErrNotFound for an object, which is not deleted, but found:", err)
+	}
+
+	if err := repo.Restore(ctx, "1"); err != nil {
+		t.Fatal("Error must be nil, but found:", err.Error())
+	}
+
+	if found, err := repo.Find(ctx, "1"); err != nil || found.Data()["status"] != "active" {
+		t.Error("Expected: the restored object, but found:", found, err)
+	}
+
+	_ = repo.Delete(ctx, "1")
+
+	if err := repo.ForceDelete(ctx, "1"); err != nil {
+		t.Error("Error must be nil, but found:", err.Error())
+	}
+}
+
+func TestQueryToSQLScopes(t *testing.T) {
+	query, args := NewQuery().OnlyDeleted().ToSQL("users", DialectSQLite)
+
+	if !strings.HasSuffix(query, `WHERE "soft_deleted_at" <> '' AND "soft_deleted_at" <= ?`) || len(args) != 1 {
+		t.Error("Expected only deleted clause, but found:", query, args)
+	}
+
+	query, _ = Where("id").Eq("1").WithoutDeleted().ToSQL("users", DialectSQLite)
+
+	if !strings.Contains(query, `"id" = ? AND ("soft_deleted_at" IS NULL`) {
+		t.Error("Expected without deleted clause, but found:", query)
+	}
+
+	query, _ = NewQuery().ToSQL("users", DialectSQLite)
+
+	if query != `SELECT * FROM "users"` {
+		t.Error(`Expected: SELECT * FROM "users", but found:`, query)
+	}
+}
@@ -0,0 +1,143 @@
+package dataobject
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// Trash actions of the emitted change events
+const (
+	TrashActionTrash   = "trash"
+	TrashActionRestore = "restore"
+	TrashActionDelete  = "delete"
+)
+
+// forceDeleter is implemented by repositories with soft delete,
+// which can delete objects permanently (see MemoryRepository)
+type forceDeleter interface {
+	ForceDelete(ctx context.Context, id string) error
+}
+
+// restorer is implemented by repositories with soft delete, which
+// can restore soft deleted objects (see MemoryRepository)
+type restorer interface {
+	Restore(ctx context.Context, id string) error
+}
+
+// Trash moves the object with the ID to the trash by marking it as soft
+// deleted (see SoftDeletedAtKey), and emits a change event with the
+// "trash" action to the emitters
+func Trash(ctx context.Context, repo DataObjectRepositoryInterface, id string, emitters ...EventEmitter) error {
+	found, err := repo.Find(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	if IsSoftDeleted(found) {
+		return notFound(id)
+	}
+
+	do := NewDataObjectFromExistingData(copyData(found.Data()))
+	do.SoftDelete()
+
+	if err := repo.Update(ctx, do); err != nil {
+		return err
+	}
+
+	return emitTrashEvent(ctx, do, TrashActionTrash, emitters)
+}
+
+// RestoreFromTrash restores the object with the ID from the trash,
+// and emits a change event with the "restore" action to the emitters.
+// Returns an error wrapping ErrNotFound if the object is not in the trash
+func RestoreFromTrash(ctx context.Context, repo DataObjectRepositoryInterface, id string, emitters ...EventEmitter) error {
+	trashed, err := findTrashed(ctx, repo, Where("id").Eq(id))
+	if err != nil {
+		return err
+	}
+
+	if len(trashed) < 1 {
+		return notFound(id)
+	}
+
+	do := NewDataObjectFromExistingData(copyData(trashed[0].Data()))
+	do.Undelete()
+
+	err = forwardRestore(ctx, repo, id)
+	if errors.Is(err, ErrNotSupported) {
+		err = repo.Update(ctx, do)
+	}
+
+	if err != nil {
+		return err
+	}
+
+	return emitTrashEvent(ctx, do, TrashActionRestore, emitters)
+}
+
+// EmptyTrash permanently deletes the objects, which have been in the
+// trash for longer than olderThan, and emits a change event with the
+// "delete" action for each to the emitters. Returns the number of
+// deleted objects
+func EmptyTrash(ctx context.Context, repo DataObjectRepositoryInterface, olderThan time.Duration, emitters ...EventEmitter) (int, error) {
+	trashed, err := findTrashed(ctx, repo, NewQuery())
+	if err != nil {
+		return 0, err
+	}
+
+	cutoff := time.Now().Add(-olderThan)
+	deleted := 0
+
+	for _, do := range trashed {
+		if !isSoftDeletedValue(do.Data()[SoftDeletedAtKey], cutoff) {
+			continue
+		}
+
+		if err := forceDelete(ctx, repo, do.ID()); err != nil {
+			return deleted, err
+		}
+
+		deleted++
+
+		if err := emitTrashEvent(ctx, do, TrashActionDelete, emitters); err != nil {
+			return deleted, err
+		}
+	}
+
+	return deleted, nil
+}
+
+// findTrashed returns the soft deleted objects matching the query,
+// using the repository queries if supported, or else listing all the objects
+func findTrashed(ctx context.Context, repo DataObjectRepositoryInterface, query *Query) ([]DataObjectInterface, error) {
+	return queryRepository(ctx, repo, query.OnlyDeleted())
+}
+
+// findWithDeleted returns the object with the ID, even if it is soft
+// deleted, or an error wrapping ErrNotFound if there is none
+func findWithDeleted(ctx context.Context, repo DataObjectRepositoryInterface, id string) (DataObjectInterface, error) {
+	found, err := queryRepository(ctx, repo, Where("id").Eq(id).WithDeleted())
+	if err != nil {
+		return nil, err
+	}
+
+	if len(found) < 1 {
+		return nil, notFound(id)
+	}
+
+	return found[0], nil
+}
+
+// emitTrashEvent emits the change event of the object with the action to all the emitters
+func emitTrashEvent(ctx context.Context, do DataObjectInterface, action string, emitters []EventEmitter) error {
+	event := NewChangeEvent(do)
+	event.Action = action
+
+	errs := []error{}
+	for _, emitter := range emitters {
+		errs = append(errs, emitter.Emit(ctx, event))
+	}
+
+	return errors.Join(errs...)
+}
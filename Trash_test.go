@@ -0,0 +1,164 @@
+package dataobject
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+type testEmitter struct {
+	events []ChangeEvent
+}
+
+func (e *testEmitter) Emit(ctx context.Context, event ChangeEvent) error {
+	e.events = append(e.events, event)
+	return nil
+}
+
+func TestTrash(t *testing.T) {
+	ctx := context.Background()
+	emitter := &testEmitter{}
+
+	for _, repo := range []*MemoryRepository{NewMemoryRepository(), NewMemoryRepository().WithSoftDelete()} {
+		emitter.events = nil
+
+		_ = repo.Create(ctx, NewDataObjectFromExistingData(map[string]string{"id": "1"}))
+		_ = repo.Create(ctx, NewDataObjectFromExistingData(map[string]string{"id": "2"}))
+
+		if err := Trash(ctx, repo, "1", emitter); err != nil {
+			t.Fatal("Error must be nil, but found:", err.Error())
+		}
+
+		if err := Trash(ctx, repo, "1", emitter); !errors.Is(err, ErrNotFound) {
+			t.Error("Expected: ErrNotFound, but found:", err)
+		}
+
+		if err := RestoreFromTrash(ctx, repo, "1", emitter); err != nil {
+			t.Fatal("Error must be nil, but found:", err.Error())
+		}
+
+		if err := RestoreFromTrash(ctx, repo, "1", emitter); !errors.Is(err, ErrNotFound) {
+			t.Error("Expected: ErrNotFound, but found:", err)
+		}
+
+		_ = Trash(ctx, repo, "2", emitter)
+
+		deleted, err := EmptyTrash(ctx, repo, time.Hour, emitter)
+
+		if err != nil || deleted != 0 {
+			t.Error("Expected: 0, but found:", deleted, err)
+		}
+
+		deleted, err = EmptyTrash(ctx, repo, 0, emitter)
+
+		if err != nil || deleted != 1 {
+			t.Error("Expected: 1, but found:", deleted, err)
+		}
+
+		if count, _ := repo.Count(ctx); count != 1 {
+			t.Error("Expected: 1, but found:", count)
+		}
+
+		actions := []string{}
+		for _, event := range emitter.events {
+			actions = append(actions, event.Action)
+		}
+
+		if len(actions) != 4 || actions[0] != TrashActionTrash || actions[1] != TrashActionRestore || actions[3] != TrashActionDelete {
+			t.Error("Expected: [trash restore trash delete], but found:", actions)
+		}
+	}
+}
+
+func TestTrashThroughDecorators(t *testing.T) {
+	ctx := context.Background()
+	journal, err := OpenJournal(filepath.Join(t.TempDir(), "trash.journal"))
+	if err != nil {
+		t.Fatal("Error must be nil, but found:", err)
+	}
+	defer journal.Close()
+
+	decorators := map[string]func(inner DataObjectRepositoryInterface) DataObjectRepositoryInterface{
+		"policy": func(inner DataObjectRepositoryInterface) DataObjectRepositoryInterface {
+			return NewPolicyRepository(inner, AccessPolicyFuncs{})
+		},
+		"idempotent": func(inner DataObjectRepositoryInterface) DataObjectRepositoryInterface {
+			return NewIdempotentRepository(inner, time.Minute)
+		},
+		"metered": func(inner DataObjectRepositoryInterface) DataObjectRepositoryInterface {
+			return NewMeteredRepository(inner)
+		},
+		"failover": func(inner DataObjectRepositoryInterface) DataObjectRepositoryInterface {
+			return NewFailoverRepository(inner, NewMemoryRepository())
+		},
+		"write-behind": func(inner DataObjectRepositoryInterface) DataObjectRepositoryInterface {
+			return NewWriteBehindRepository(inner, time.Minute)
+		},
+		"journaled": func(inner DataObjectRepositoryInterface) DataObjectRepositoryInterface {
+			return NewJournaledRepository(inner, journal)
+		},
+		"outbox": func(inner DataObjectRepositoryInterface) DataObjectRepositoryInterface {
+			return NewOutboxRepository(inner, NewMemoryRepository(), nil)
+		},
+		"cached": func(inner DataObjectRepositoryInterface) DataObjectRepositoryInterface {
+			return NewCachedRepository(inner, NewMemoryCache())
+		},
+	}
+
+	for name, decorate := range decorators {
+		inner := NewMemoryRepository().WithSoftDelete()
+		repo := decorate(inner)
+
+		_ = repo.Create(ctx, NewDataObjectFromExistingData(map[string]string{"id": "1"}))
+		_ = repo.Create(ctx, NewDataObjectFromExistingData(map[string]string{"id": "2"}))
+
+		if err := Trash(ctx, repo, "1"); err != nil {
+			t.Fatal(name, "Error must be nil, but found:", err)
+		}
+
+		if err := RestoreFromTrash(ctx, repo, "1"); err != nil {
+			t.Fatal(name, "Error must be nil, but found:", err)
+		}
+
+		_ = Trash(ctx, repo, "2")
+
+		deleted, err := EmptyTrash(ctx, repo, 0)
+		if err != nil || deleted != 1 {
+			t.Error(name, "Expected: 1, but found:", deleted, err)
+		}
+
+		if all, _ := inner.Query(ctx, NewQuery().WithDeleted()); len(all) != 1 || all[0].ID() != "1" {
+			t.Error(name, "Expected: only 1 to be left, but found:", all)
+		}
+
+		updater, isConditional := repo.(ConditionalUpdater)
+		if !isConditional {
+			t.Fatal(name, "Expected: a ConditionalUpdater, but found:", repo)
+		}
+
+		err = updater.UpdateIf(ctx, NewDataObjectFromExistingData(map[string]string{"id": "1", "name": "Jon"}), `"stale"`)
+		if !errors.Is(err, ErrVersionConflict) {
+			t.Error(name, "Expected: ErrVersionConflict, but found:", err)
+		}
+	}
+}
+
+func TestTrashNotSupported(t *testing.T) {
+	ctx := context.Background()
+	repo := NewMeteredRepository(struct{ DataObjectRepositoryInterface }{NewMemoryRepository()})
+
+	if _, err := repo.Query(ctx, NewQuery()); !errors.Is(err, ErrNotSupported) {
+		t.Error("Expected: ErrNotSupported, but found:", err)
+	}
+
+	if err := repo.ForceDelete(ctx, "1"); !errors.Is(err, ErrNotSupported) {
+		t.Error("Expected: ErrNotSupported, but found:", err)
+	}
+
+	deleted, err := EmptyTrash(ctx, repo, 0)
+	if err != nil || deleted != 0 {
+		t.Error("Expected: 0, but found:", deleted, err)
+	}
+}
@@ -0,0 +1,100 @@
+package dataobject
+
+import (
+	"sort"
+	"sync"
+)
+
+// ObjectTypeKey is the key holding the type name of polymorphic objects
+const ObjectTypeKey = "object_type"
+
+// TypeFactory wraps a data object into the concrete type (i.e. *UserObject)
+type TypeFactory func(do *DataObject) DataObjectInterface
+
+// TypeRegistry maps the type names (see ObjectTypeKey) to the factories
+// of their concrete types, so objects from heterogeneous storage are
+// returned as the correct wrappers
+//
+// Example:
+//
+//	RegisterType("user", func(do *DataObject) DataObjectInterface { return &UserObject{DataObject: do} })
+//
+//	object, err := NewDataObjectFromJSONTyped(`{"id":"1","object_type":"user"}`)
+//	user := object.(*UserObject)
+type TypeRegistry struct {
+	mu        sync.RWMutex
+	factories map[string]TypeFactory
+}
+
+// NewTypeRegistry creates a new empty type registry
+func NewTypeRegistry() *TypeRegistry {
+	return &TypeRegistry{factories: map[string]TypeFactory{}}
+}
+
+// DefaultTypeRegistry is the registry used by RegisterType and NewDataObjectFromJSONTyped
+var DefaultTypeRegistry = NewTypeRegistry()
+
+// Register registers the factory of the type name,
+// replacing the previously registered factory
+func (r *TypeRegistry) Register(typeName string, factory TypeFactory) *TypeRegistry {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.factories[typeName] = factory
+	return r
+}
+
+// Types returns the registered type names, sorted
+func (r *TypeRegistry) Types() []string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	types := make([]string, 0, len(r.factories))
+	for typeName := range r.factories {
+		types = append(types, typeName)
+	}
+	sort.Strings(types)
+	return types
+}
+
+// Wrap wraps the object into the concrete type of its type name.
+// Objects of types, which are not registered, are returned as they are
+func (r *TypeRegistry) Wrap(do *DataObject) DataObjectInterface {
+	r.mu.RLock()
+	factory, registered := r.factories[do.Get(ObjectTypeKey)]
+	r.mu.RUnlock()
+
+	if !registered {
+		return do
+	}
+
+	return factory(do)
+}
+
+// NewDataObjectFromExistingData creates a new object of the concrete
+// type of the data (see Wrap), hydrated with the data
+func (r *TypeRegistry) NewDataObjectFromExistingData(data map[string]string) DataObjectInterface {
+	return r.Wrap(NewDataObjectFromExistingData(data))
+}
+
+// NewDataObjectFromJSON creates a new object of the concrete
+// type of the JSON object string (see Wrap)
+func (r *TypeRegistry) NewDataObjectFromJSON(jsonString string) (DataObjectInterface, error) {
+	do, err := NewDataObjectFromJSON(jsonString)
+	if err != nil {
+		return nil, err
+	}
+
+	return r.Wrap(do), nil
+}
+
+// RegisterType registers the factory of the type name in the default registry
+func RegisterType(typeName string, factory TypeFactory) {
+	DefaultTypeRegistry.Register(typeName, factory)
+}
+
+// NewDataObjectFromJSONTyped creates a new object of the concrete type
+// of the JSON object string, as registered in the default registry
+func NewDataObjectFromJSONTyped(jsonString string) (DataObjectInterface, error) {
+	return DefaultTypeRegistry.NewDataObjectFromJSON(jsonString)
+}
@@ -0,0 +1,64 @@
+package dataobject
+
+import (
+	"reflect"
+	"testing"
+)
+
+type testUserObject struct {
+	*DataObject
+}
+
+type testOrderObject struct {
+	*DataObject
+}
+
+func TestTypeRegistry(t *testing.T) {
+	registry := NewTypeRegistry().
+		Register("user", func(do *DataObject) DataObjectInterface { return &testUserObject{DataObject: do} }).
+		Register("order", func(do *DataObject) DataObjectInterface { return &testOrderObject{DataObject: do} })
+
+	if types := registry.Types(); !reflect.DeepEqual(types, []string{"order", "user"}) {
+		t.Error("Expected: [order user], but found:", types)
+	}
+
+	user, err := registry.NewDataObjectFromJSON(`{"id":"1","object_type":"user"}`)
+
+	if err != nil {
+		t.Fatal("Error must be nil, but found:", err.Error())
+	}
+
+	if _, isUser := user.(*testUserObject); !isUser {
+		t.Errorf("Expected: *testUserObject, but found: %T", user)
+	}
+
+	order := registry.NewDataObjectFromExistingData(map[string]string{"id": "2", ObjectTypeKey: "order"})
+
+	if _, isOrder := order.(*testOrderObject); !isOrder {
+		t.Errorf("Expected: *testOrderObject, but found: %T", order)
+	}
+
+	unknown, _ := registry.NewDataObjectFromJSON(`{"id":"3","object_type":"invoice"}`)
+
+	if _, isDataObject := unknown.(*DataObject); !isDataObject {
+		t.Errorf("Expected: *DataObject, but found: %T", unknown)
+	}
+
+	if _, err := registry.NewDataObjectFromJSON(`[]`); err == nil {
+		t.Error("Error must NOT be nil, but found:", err)
+	}
+}
+
+func TestNewDataObjectFromJSONTyped(t *testing.T) {
+	RegisterType("test_user", func(do *DataObject) DataObjectInterface { return &testUserObject{DataObject: do} })
+
+	user, err := NewDataObjectFromJSONTyped(`{"id":"1","object_type":"test_user"}`)
+
+	if err != nil {
+		t.Fatal("Error must be nil, but found:", err.Error())
+	}
+
+	if _, isUser := user.(*testUserObject); !isUser {
+		t.Errorf("Expected: *testUserObject, but found: %T", user)
+	}
+}
@@ -0,0 +1,87 @@
+package dataobject
+
+import "context"
+
+// VerifyDefaultBatchSize is the default number of objects read per batch
+const VerifyDefaultBatchSize = 100
+
+// VerifyOptions configures Verify
+type VerifyOptions struct {
+	// BatchSize is the number of objects read from the repository
+	// at a time, defaults to VerifyDefaultBatchSize
+	BatchSize int
+
+	// MaxViolations stops the verification once reached, 0 for no limit
+	MaxViolations int
+
+	// OnViolation, if set, is called for each violation as it is found
+	OnViolation func(violation Violation)
+}
+
+// Violation lists the invalid fields of a stored object
+type Violation struct {
+	ID     string       `json:"id"`
+	Fields []FieldError `json:"fields"`
+}
+
+// VerifyReport is the result of Verify
+type VerifyReport struct {
+	// Checked is the number of objects checked
+	Checked int `json:"checked"`
+
+	// Violations lists the objects, which do not match the schema
+	Violations []Violation `json:"violations"`
+}
+
+// Verify reads all the objects of the repository in batches, validates
+// them against the schema, and reports the violations (missing required
+// keys, values not allowed, unparsable values), i.e. as a data quality job
+//
+// Example:
+//
+//	report, err := Verify(ctx, repo, schema, VerifyOptions{
+//		OnViolation: func(v Violation) { log.Println(v.ID, v.Fields) },
+//	})
+func Verify(ctx context.Context, repo DataObjectRepositoryInterface, schema *Schema, opts VerifyOptions) (VerifyReport, error) {
+	report := VerifyReport{Violations: []Violation{}}
+
+	batchSize := opts.BatchSize
+	if batchSize < 1 {
+		batchSize = VerifyDefaultBatchSize
+	}
+
+	for offset := 0; ; offset += batchSize {
+		if err := ctx.Err(); err != nil {
+			return report, err
+		}
+
+		objects, err := repo.List(ctx, offset, batchSize)
+		if err != nil {
+			return report, err
+		}
+
+		for _, do := range objects {
+			report.Checked++
+
+			fields := schema.Validate(do.Data())
+			if len(fields) < 1 {
+				continue
+			}
+
+			violation := Violation{ID: do.ID(), Fields: fields}
+			report.Violations = append(report.Violations, violation)
+
+			if opts.OnViolation != nil {
+				opts.OnViolation(violation)
+			}
+
+			if opts.MaxViolations > 0 && len(report.Violations) >= opts.MaxViolations {
+				return report, nil
+			}
+		}
+
+		if len(objects) < batchSize {
+			return report, nil
+		}
+	}
+}
@@ -0,0 +1,56 @@
+package dataobject
+
+import (
+	"context"
+	"strconv"
+	"testing"
+)
+
+func TestVerify(t *testing.T) {
+	ctx := context.Background()
+	repo := NewMemoryRepository()
+	schema := NewSchema(
+		SchemaField{Key: "email", Required: true},
+		SchemaField{Key: "status", Options: []string{"active", "inactive"}},
+		SchemaField{Key: "created_at", Type: FieldTypeDateTime},
+	)
+
+	for i := 0; i < 5; i++ {
+		_ = repo.Create(ctx, NewDataObjectFromExistingData(map[string]string{
+			"id":     strconv.Itoa(i),
+			"email":  "user" + strconv.Itoa(i) + "@test.com",
+			"status": "active",
+		}))
+	}
+
+	_ = repo.Create(ctx, NewDataObjectFromExistingData(map[string]string{"id": "6", "status": "deleted"}))
+	_ = repo.Create(ctx, NewDataObjectFromExistingData(map[string]string{"id": "7", "email": "x@test.com", "created_at": "yesterday"}))
+
+	found := []string{}
+	report, err := Verify(ctx, repo, schema, VerifyOptions{
+		BatchSize:   2,
+		OnViolation: func(v Violation) { found = append(found, v.ID) },
+	})
+
+	if err != nil {
+		t.Fatal("Error must be nil, but found:", err.Error())
+	}
+
+	if report.Checked != 7 {
+		t.Error("Expected: 7, but found:", report.Checked)
+	}
+
+	if len(report.Violations) != 2 || len(found) != 2 {
+		t.Fatal("Expected: 2, but found:", report.Violations)
+	}
+
+	if report.Violations[0].ID != "6" || len(report.Violations[0].Fields) != 2 {
+		t.Error("Expected: 6 with 2 fields, but found:", report.Violations[0])
+	}
+
+	report, _ = Verify(ctx, repo, schema, VerifyOptions{MaxViolations: 1})
+
+	if len(report.Violations) != 1 {
+		t.Error("Expected: 1, but found:", len(report.Violations))
+	}
+}
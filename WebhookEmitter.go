@@ -0,0 +1,196 @@
+package dataobject
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"time"
+)
+
+var _ EventEmitter = (*WebhookEmitter)(nil) // verify it emits change events
+
+// WebhookSignatureHeader is the header holding the HMAC-SHA256
+// signature of the payload in the format "sha256=<hex>"
+const WebhookSignatureHeader = "X-Signature-256"
+
+// WebhookKeyIDHeader is the header holding the ID of the key signing the
+// payload, when signing with a key provider (see WithKeyProvider)
+const WebhookKeyIDHeader = "X-Signature-Key-Id"
+
+// WebhookEmitter POSTs change events as signed JSON payloads
+// to the configured endpoints, retrying failed deliveries
+//
+// Example:
+//
+//	emitter := NewWebhookEmitter([]byte("secret"), "https://example.com/hooks")
+//	err := emitter.Emit(ctx, NewChangeEvent(user))
+type WebhookEmitter struct {
+	secret     []byte
+	keys       KeyProvider
+	endpoints  []string
+	client     *http.Client
+	maxRetries int
+	retryDelay time.Duration
+}
+
+// NewWebhookEmitter creates a new webhook emitter signing the payloads
+// with the secret, with 3 retries and a 1 second initial retry delay
+func NewWebhookEmitter(secret []byte, endpoints ...string) *WebhookEmitter {
+	return &WebhookEmitter{
+		secret:     secret,
+		endpoints:  endpoints,
+		client:     &http.Client{Timeout: 10 * time.Second},
+		maxRetries: 3,
+		retryDelay: time.Second,
+	}
+}
+
+// WithKeyProvider signs the payloads with the current key of the
+// provider instead of the secret, sending its ID in the
+// WebhookKeyIDHeader header, so the receivers can rotate keys
+//
+// The payloads are signed with a 32 byte subkey of the key, derived
+// with HKDF-SHA256 without a salt and WebhookKeyLabel as the info,
+// which receivers verify with VerifyWebhookSignatureWithKeys
+func (e *WebhookEmitter) WithKeyProvider(keys KeyProvider) *WebhookEmitter {
+	e.keys = keys
+	return e
+}
+
+// SetHTTPClient sets the HTTP client used for the deliveries
+func (e *WebhookEmitter) SetHTTPClient(client *http.Client) *WebhookEmitter {
+	e.client = client
+	return e
+}
+
+// SetMaxRetries sets the number of retries of a failed delivery
+func (e *WebhookEmitter) SetMaxRetries(maxRetries int) *WebhookEmitter {
+	e.maxRetries = maxRetries
+	return e
+}
+
+// SetRetryDelay sets the delay before the first retry,
+// which is doubled on every following retry
+func (e *WebhookEmitter) SetRetryDelay(retryDelay time.Duration) *WebhookEmitter {
+	e.retryDelay = retryDelay
+	return e
+}
+
+// Emit delivers the event to all the endpoints. An error is returned
+// for each endpoint, which failed after all the retries
+func (e *WebhookEmitter) Emit(ctx context.Context, event ChangeEvent) error {
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+
+	secret, keyID := e.secret, ""
+	if e.keys != nil {
+		if keyID, secret, err = e.keys.CurrentKey(); err != nil {
+			return err
+		}
+		secret = webhookKey(secret)
+	}
+
+	signature := webhookSignature{value: "sha256=" + SignWebhookPayload(secret, payload), keyID: keyID}
+
+	errs := []error{}
+	for _, endpoint := range e.endpoints {
+		if err := e.deliver(ctx, endpoint, payload, signature); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// deliver POSTs the payload to the endpoint, retrying on network
+// errors, 5xx and 429 responses
+func (e *WebhookEmitter) deliver(ctx context.Context, endpoint string, payload []byte, signature webhookSignature) error {
+	delay := e.retryDelay
+
+	for attempt := 0; ; attempt++ {
+		retry, err := e.post(ctx, endpoint, payload, signature)
+		if err == nil || !retry || attempt >= e.maxRetries {
+			return err
+		}
+
+		select {
+		case <-ctx.Done():
+			return &WebhookError{Endpoint: endpoint, Err: ctx.Err()}
+		case <-time.After(delay):
+		}
+
+		delay *= 2
+	}
+}
+
+// post makes a single delivery attempt, returns if it can be retried
+func (e *WebhookEmitter) post(ctx context.Context, endpoint string, payload []byte, signature webhookSignature) (bool, error) {
+	request, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, bytes.NewReader(payload))
+	if err != nil {
+		return false, &WebhookError{Endpoint: endpoint, Err: err}
+	}
+
+	request.Header.Set("Content-Type", "application/json")
+	request.Header.Set(WebhookSignatureHeader, signature.value)
+	if signature.keyID != "" {
+		request.Header.Set(WebhookKeyIDHeader, signature.keyID)
+	}
+
+	response, err := e.client.Do(request)
+	if err != nil {
+		return ctx.Err() == nil, &WebhookError{Endpoint: endpoint, Err: err}
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode >= 200 && response.StatusCode < 300 {
+		return false, nil
+	}
+
+	retry := response.StatusCode >= 500 || response.StatusCode == http.StatusTooManyRequests
+
+	return retry, &WebhookError{Endpoint: endpoint, StatusCode: response.StatusCode}
+}
+
+// webhookSignature is the signature of a payload, with the ID
+// of the signing key if signed with a key provider
+type webhookSignature struct {
+	value string
+	keyID string
+}
+
+// SignWebhookPayload returns the hex encoded HMAC-SHA256 of the payload
+func SignWebhookPayload(secret []byte, payload []byte) string {
+	mac := hmac.New(sha256.New, secret)
+	mac.Write(payload)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// VerifyWebhookSignature verifies the signature header value
+// (in the format "sha256=<hex>") of the payload, for receivers
+func VerifyWebhookSignature(secret []byte, payload []byte, signature string) bool {
+	expected := "sha256=" + SignWebhookPayload(secret, payload)
+	return hmac.Equal([]byte(expected), []byte(signature))
+}
+
+// VerifyWebhookSignatureWithKeys verifies the signature header value of
+// the payload signed with the key of the ID (the WebhookKeyIDHeader
+// header value) from the provider, for receivers
+func VerifyWebhookSignatureWithKeys(keys KeyProvider, payload []byte, signature string, keyID string) bool {
+	secret, err := keys.Key(keyID)
+	if err != nil {
+		return false
+	}
+	return VerifyWebhookSignature(webhookKey(secret), payload, signature)
+}
+
+// webhookKey derives the signing subkey of the key of a provider
+func webhookKey(key []byte) []byte {
+	return deriveKey(key, WebhookKeyLabel, 32)
+}
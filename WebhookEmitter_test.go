@@ -0,0 +1,96 @@
+package dataobject
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestWebhookEmitterEmit(t *testing.T) {
+	secret := []byte("secret")
+	attempts := 0
+	var received ChangeEvent
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+
+		if attempts == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+
+		payload, _ := io.ReadAll(r.Body)
+
+		if !VerifyWebhookSignature(secret, payload, r.Header.Get(WebhookSignatureHeader)) {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+
+		_ = json.Unmarshal(payload, &received)
+	}))
+	defer server.Close()
+
+	user := NewDataObjectFromExistingData(map[string]string{"id": "1", "first_name": "Jon"})
+	user.Set("first_name", "Jane")
+
+	emitter := NewWebhookEmitter(secret, server.URL).SetRetryDelay(time.Millisecond)
+
+	err := emitter.Emit(context.Background(), NewChangeEvent(user))
+
+	if err != nil {
+		t.Fatal("Error must be nil, but found:", err.Error())
+	}
+
+	if attempts != 2 {
+		t.Error("Expected: 2, but found:", attempts)
+	}
+
+	if received.ObjectID != "1" {
+		t.Error("Expected: 1, but found:", received.ObjectID)
+	}
+
+	if received.Values["first_name"] != "Jane" {
+		t.Error("Expected: Jane, but found:", received.Values["first_name"])
+	}
+}
+
+func TestWebhookEmitterEmitFails(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+	}))
+	defer server.Close()
+
+	emitter := NewWebhookEmitter([]byte("secret"), server.URL).SetRetryDelay(time.Millisecond)
+
+	err := emitter.Emit(context.Background(), NewChangeEvent(NewDataObject()))
+
+	if err == nil {
+		t.Error("Error must NOT be nil, but found:", err)
+	}
+}
+
+func TestWebhookEmitterWithKeyProvider(t *testing.T) {
+	keys := NewKeyRing("v1", []byte("old secret")).Rotate("v2", []byte("new secret"))
+	verified := false
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		payload, _ := io.ReadAll(r.Body)
+		keyID := r.Header.Get(WebhookKeyIDHeader)
+		verified = keyID == "v2" && VerifyWebhookSignatureWithKeys(keys, payload, r.Header.Get(WebhookSignatureHeader), keyID)
+	}))
+	defer server.Close()
+
+	emitter := NewWebhookEmitter(nil, server.URL).WithKeyProvider(keys)
+
+	if err := emitter.Emit(context.Background(), NewChangeEvent(NewDataObject())); err != nil {
+		t.Fatal("Error must be nil, but found:", err.Error())
+	}
+
+	if !verified {
+		t.Error("Expected: the payload signed with the key v2")
+	}
+}
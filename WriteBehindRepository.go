@@ -0,0 +1,344 @@
+package dataobject
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+)
+
+var _ DataObjectRepositoryInterface = (*WriteBehindRepository)(nil) // verify it extends the repository interface
+var _ ConditionalUpdater = (*WriteBehindRepository)(nil)            // verify it forwards conditional updates
+var _ Querier = (*WriteBehindRepository)(nil)                       // verify it forwards queries
+
+// WriteBehindRepository decorates a repository buffering the updates,
+// which are flushed in batches on an interval (see Start and Flush)
+//
+// Multiple updates of the same object are coalesced into a single write
+// of the changed keys (see DataObject.DataChanged), which are applied on
+// top of the stored object, greatly reducing the writes of hot objects
+// like counters and presence data. Find and List return the objects
+// with the buffered changes applied. Query flushes the buffered changes
+// first, so the query matches the current objects, and UpdateIf the
+// changes of the object, so its entity tag is checked against them
+//
+// Example:
+//
+//	repo := NewWriteBehindRepository(sqlRepo, time.Second)
+//	go repo.Start(ctx) // flushes every second, and once more when ctx is done
+type WriteBehindRepository struct {
+	inner    DataObjectRepositoryInterface
+	interval time.Duration
+
+	mu         sync.Mutex
+	pending    map[string]*writeBehindEntry
+	maxPending int
+}
+
+// writeBehindEntry holds the buffered changes of an object
+type writeBehindEntry struct {
+	changed map[string]string
+	removed map[string]struct{}
+}
+
+// WriteBehindDefaultInterval is the interval of a WriteBehindRepository
+// created with an interval, which is not positive
+const WriteBehindDefaultInterval = time.Second
+
+// NewWriteBehindRepository creates a new write-behind decorator of the
+// repository, flushing on the interval once started
+// (WriteBehindDefaultInterval if not positive)
+func NewWriteBehindRepository(inner DataObjectRepositoryInterface, interval time.Duration) *WriteBehindRepository {
+	if interval <= 0 {
+		interval = WriteBehindDefaultInterval
+	}
+
+	return &WriteBehindRepository{
+		inner:    inner,
+		interval: interval,
+		pending:  map[string]*writeBehindEntry{},
+	}
+}
+
+// SetMaxPending sets the maximum number of objects with buffered
+// changes, after which an update flushes immediately (0 for no limit)
+func (repo *WriteBehindRepository) SetMaxPending(maxPending int) *WriteBehindRepository {
+	repo.maxPending = maxPending
+	return repo
+}
+
+// Start flushes the buffered changes on the interval until the context
+// is done, then flushes once more. It blocks, so run it in a goroutine
+func (repo *WriteBehindRepository) Start(ctx context.Context) error {
+	ticker := time.NewTicker(repo.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			_ = repo.Flush(ctx)
+		case <-ctx.Done():
+			return repo.Flush(context.WithoutCancel(ctx))
+		}
+	}
+}
+
+// Pending returns the number of objects with buffered changes
+func (repo *WriteBehindRepository) Pending() int {
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+
+	return len(repo.pending)
+}
+
+// Flush writes the buffered changes to the repository. The changes,
+// which fail to be written, are buffered again under any newer changes
+// to be retried, unless the failure is permanent (i.e. the object does
+// not exist or is not valid). Those are dropped and their errors returned
+func (repo *WriteBehindRepository) Flush(ctx context.Context) error {
+	repo.mu.Lock()
+	pending := repo.pending
+	repo.pending = map[string]*writeBehindEntry{}
+	repo.mu.Unlock()
+
+	ids := make([]string, 0, len(pending))
+	for id := range pending {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	errs := []error{}
+
+	for _, id := range ids {
+		err := repo.write(ctx, id, pending[id])
+		if err == nil {
+			continue
+		}
+
+		if isPermanentWriteError(err) {
+			errs = append(errs, fmt.Errorf("dataobject: dropped the buffered changes of %s: %w", id, err))
+			continue
+		}
+
+		errs = append(errs, err)
+		repo.requeue(id, pending[id])
+	}
+
+	return errors.Join(errs...)
+}
+
+// Create stores a new object directly
+func (repo *WriteBehindRepository) Create(ctx context.Context, do DataObjectInterface) error {
+	return repo.inner.Create(ctx, do)
+}
+
+// Find returns the object with the buffered changes applied
+func (repo *WriteBehindRepository) Find(ctx context.Context, id string) (DataObjectInterface, error) {
+	found, err := repo.inner.Find(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	return repo.overlay(found), nil
+}
+
+// Update buffers the changed and removed keys of the object,
+// coalescing them with the already buffered changes of the object.
+// Objects without changes are ignored
+func (repo *WriteBehindRepository) Update(ctx context.Context, do DataObjectInterface) error {
+	if do.ID() == "" {
+		return ErrMissingID
+	}
+
+	entry := &writeBehindEntry{changed: copyData(do.DataChanged()), removed: map[string]struct{}{}}
+	if removable, ok := do.(interface{ DataRemoved() []string }); ok {
+		for _, key := range removable.DataRemoved() {
+			entry.removed[key] = struct{}{}
+		}
+	}
+
+	if len(entry.changed) < 1 && len(entry.removed) < 1 {
+		return nil
+	}
+
+	pending := repo.enqueue(do.ID(), entry)
+
+	if repo.maxPending > 0 && pending >= repo.maxPending {
+		return repo.Flush(ctx)
+	}
+
+	return nil
+}
+
+// Delete discards the buffered changes and deletes the object directly
+func (repo *WriteBehindRepository) Delete(ctx context.Context, id string) error {
+	repo.mu.Lock()
+	delete(repo.pending, id)
+	repo.mu.Unlock()
+
+	return repo.inner.Delete(ctx, id)
+}
+
+// UpdateIf flushes the buffered changes of the object, and
+// updates it directly, if it matches the entity tag
+func (repo *WriteBehindRepository) UpdateIf(ctx context.Context, do DataObjectInterface, expectedETag string) error {
+	if err := repo.flushObject(ctx, do.ID()); err != nil {
+		return err
+	}
+
+	return forwardUpdateIf(ctx, repo.inner, do, expectedETag)
+}
+
+// ForceDelete discards the buffered changes
+// and deletes the object permanently
+func (repo *WriteBehindRepository) ForceDelete(ctx context.Context, id string) error {
+	repo.mu.Lock()
+	delete(repo.pending, id)
+	repo.mu.Unlock()
+
+	return forwardForceDelete(ctx, repo.inner, id)
+}
+
+// Restore restores the soft deleted object directly
+func (repo *WriteBehindRepository) Restore(ctx context.Context, id string) error {
+	return forwardRestore(ctx, repo.inner, id)
+}
+
+// Query flushes the buffered changes, and returns the objects
+// matching the query
+func (repo *WriteBehindRepository) Query(ctx context.Context, query *Query) ([]DataObjectInterface, error) {
+	if err := repo.Flush(ctx); err != nil {
+		return nil, err
+	}
+
+	return forwardQuery(ctx, repo.inner, query)
+}
+
+// flushObject writes the buffered changes of the object, if any,
+// buffering them again if they fail to be written for a transient reason
+func (repo *WriteBehindRepository) flushObject(ctx context.Context, id string) error {
+	repo.mu.Lock()
+	entry, exists := repo.pending[id]
+	delete(repo.pending, id)
+	repo.mu.Unlock()
+
+	if !exists {
+		return nil
+	}
+
+	err := repo.write(ctx, id, entry)
+	if err != nil && !isPermanentWriteError(err) {
+		repo.requeue(id, entry)
+	}
+
+	return err
+}
+
+// List returns the objects with the buffered changes applied
+func (repo *WriteBehindRepository) List(ctx context.Context, offset int, limit int) ([]DataObjectInterface, error) {
+	objects, err := repo.inner.List(ctx, offset, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]DataObjectInterface, 0, len(objects))
+	for _, do := range objects {
+		result = append(result, repo.overlay(do))
+	}
+
+	return result, nil
+}
+
+// Count returns the number of stored objects
+func (repo *WriteBehindRepository) Count(ctx context.Context) (int, error) {
+	return repo.inner.Count(ctx)
+}
+
+// enqueue merges the entry over the already buffered changes of the
+// object, as it is newer. Returns the number of objects with buffered changes
+func (repo *WriteBehindRepository) enqueue(id string, entry *writeBehindEntry) int {
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+
+	if existing, exists := repo.pending[id]; exists {
+		existing.apply(entry)
+		return len(repo.pending)
+	}
+
+	repo.pending[id] = entry
+	return len(repo.pending)
+}
+
+// requeue merges the failed entry under the changes of the object buffered
+// since it was taken for flushing, so the newer changes take precedence
+func (repo *WriteBehindRepository) requeue(id string, failed *writeBehindEntry) {
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+
+	if newer, exists := repo.pending[id]; exists {
+		failed.apply(newer)
+	}
+
+	repo.pending[id] = failed
+}
+
+// apply applies the newer changes over the changes of the entry
+func (entry *writeBehindEntry) apply(newer *writeBehindEntry) {
+	for key, value := range newer.changed {
+		entry.changed[key] = value
+		delete(entry.removed, key)
+	}
+
+	for key := range newer.removed {
+		entry.removed[key] = struct{}{}
+		delete(entry.changed, key)
+	}
+}
+
+// isPermanentWriteError returns if retrying the write cannot succeed
+func isPermanentWriteError(err error) bool {
+	return errors.Is(err, ErrNotFound) ||
+		errors.Is(err, ErrValidation) ||
+		errors.Is(err, ErrMissingID) ||
+		errors.Is(err, ErrInvalidID) ||
+		errors.Is(err, ErrForbidden)
+}
+
+// write applies the buffered changes on top of the stored object
+func (repo *WriteBehindRepository) write(ctx context.Context, id string, entry *writeBehindEntry) error {
+	found, err := repo.inner.Find(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	do := NewDataObjectFromExistingData(copyData(found.Data()))
+	do.SetData(entry.changed)
+	for key := range entry.removed {
+		do.Remove(key)
+	}
+
+	return repo.inner.Update(ctx, do)
+}
+
+// overlay returns a copy of the object with the buffered changes applied
+func (repo *WriteBehindRepository) overlay(do DataObjectInterface) DataObjectInterface {
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+
+	entry, exists := repo.pending[do.ID()]
+	if !exists {
+		return do
+	}
+
+	data := copyData(do.Data())
+	for key, value := range entry.changed {
+		data[key] = value
+	}
+	for key := range entry.removed {
+		delete(data, key)
+	}
+
+	return NewDataObjectFromExistingData(data)
+}
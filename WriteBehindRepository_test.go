@@ -0,0 +1,170 @@
+package dataobject
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"testing"
+	"time"
+)
+
+// countingRepository counts the updates of the decorated repository
+type countingRepository struct {
+	*MemoryRepository
+	updates int
+}
+
+func (repo *countingRepository) Update(ctx context.Context, do DataObjectInterface) error {
+	repo.updates++
+	return repo.MemoryRepository.Update(ctx, do)
+}
+
+func TestWriteBehindRepository(t *testing.T) {
+	ctx := context.Background()
+	inner := &countingRepository{MemoryRepository: NewMemoryRepository()}
+	repo := NewWriteBehindRepository(inner, time.Hour)
+
+	_ = repo.Create(ctx, NewDataObjectFromExistingData(map[string]string{"id": "1", "views": "0", "status": "online", "tmp": "x"}))
+
+	for i := 1; i <= 5; i++ {
+		do := NewDataObjectFromExistingData(map[string]string{"id": "1"})
+		do.Set("views", strconv.Itoa(i))
+		_ = repo.Update(ctx, do)
+	}
+
+	removal := NewDataObjectFromExistingData(map[string]string{"id": "1", "tmp": "x"})
+	removal.Remove("tmp")
+	_ = repo.Update(ctx, removal)
+
+	if inner.updates != 0 || repo.Pending() != 1 {
+		t.Fatal("Expected: 0 updates, 1 pending, but found:", inner.updates, repo.Pending())
+	}
+
+	found, _ := repo.Find(ctx, "1")
+
+	if found.Data()["views"] != "5" {
+		t.Error("Expected: 5, but found:", found.Data()["views"])
+	}
+
+	if err := repo.Flush(ctx); err != nil {
+		t.Fatal("Error must be nil, but found:", err.Error())
+	}
+
+	if inner.updates != 1 || repo.Pending() != 0 {
+		t.Error("Expected: 1 update, 0 pending, but found:", inner.updates, repo.Pending())
+	}
+
+	stored, _ := inner.Find(ctx, "1")
+
+	if stored.Data()["views"] != "5" || stored.Data()["status"] != "online" {
+		t.Error("Expected: views 5, status online, but found:", stored.Data())
+	}
+
+	if _, exists := stored.Data()["tmp"]; exists {
+		t.Error("Expected tmp to be removed, but found:", stored.Data())
+	}
+}
+
+// unavailableRepository fails the updates of the decorated repository,
+// calling the hook first
+type unavailableRepository struct {
+	*MemoryRepository
+	onUpdate func()
+}
+
+func (repo *unavailableRepository) Update(ctx context.Context, do DataObjectInterface) error {
+	if repo.onUpdate != nil {
+		repo.onUpdate()
+	}
+	return errors.New("connection refused")
+}
+
+func TestWriteBehindRepositoryRequeuesFailures(t *testing.T) {
+	ctx := context.Background()
+	inner := &unavailableRepository{MemoryRepository: NewMemoryRepository()}
+	repo := NewWriteBehindRepository(inner, time.Hour)
+
+	_ = repo.Create(ctx, NewDataObjectFromExistingData(map[string]string{"id": "1"}))
+
+	do := NewDataObjectFromExistingData(map[string]string{"id": "1"})
+	do.Set("n", "1")
+	do.Set("status", "old")
+	_ = repo.Update(ctx, do)
+
+	// updated again while the first update is being flushed
+	inner.onUpdate = func() {
+		inner.onUpdate = nil
+		newer := NewDataObjectFromExistingData(map[string]string{"id": "1"})
+		newer.Set("n", "2")
+		_ = repo.Update(ctx, newer)
+	}
+
+	if err := repo.Flush(ctx); err == nil {
+		t.Error("Error must NOT be nil, but found:", err)
+	}
+
+	if repo.Pending() != 1 {
+		t.Error("Expected: 1, but found:", repo.Pending())
+	}
+
+	found, _ := repo.Find(ctx, "1")
+	if found.Data()["n"] != "2" || found.Data()["status"] != "old" {
+		t.Error("Expected: the newer change to win over the failed one, but found:", found.Data())
+	}
+}
+
+func TestWriteBehindRepositoryDropsPermanentFailures(t *testing.T) {
+	ctx := context.Background()
+	repo := NewWriteBehindRepository(NewMemoryRepository(), time.Hour)
+
+	do := NewDataObjectFromExistingData(map[string]string{"id": "missing"})
+	do.Set("views", "1")
+	_ = repo.Update(ctx, do)
+
+	if err := repo.Flush(ctx); !errors.Is(err, ErrNotFound) {
+		t.Error("Expected: ErrNotFound, but found:", err)
+	}
+
+	if repo.Pending() != 0 {
+		t.Error("Expected: 0, but found:", repo.Pending())
+	}
+
+	if err := repo.Flush(ctx); err != nil {
+		t.Error("Error must be nil after dropping the changes, but found:", err)
+	}
+}
+
+func TestWriteBehindRepositoryStart(t *testing.T) {
+	inner := NewMemoryRepository()
+	repo := NewWriteBehindRepository(inner, time.Millisecond)
+	ctx, cancel := context.WithCancel(context.Background())
+
+	_ = repo.Create(ctx, NewDataObjectFromExistingData(map[string]string{"id": "1"}))
+
+	do := NewDataObjectFromExistingData(map[string]string{"id": "1"})
+	do.Set("views", "1")
+	_ = repo.Update(ctx, do)
+
+	done := make(chan error)
+	go func() { done <- repo.Start(ctx) }()
+
+	cancel()
+	<-done
+
+	stored, _ := inner.Find(context.Background(), "1")
+
+	if stored.Data()["views"] != "1" {
+		t.Error("Expected: 1, but found:", stored.Data()["views"])
+	}
+}
+
+func TestWriteBehindRepositoryStartWithoutInterval(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	repo := NewWriteBehindRepository(NewMemoryRepository(), 0)
+
+	cancel()
+
+	if err := repo.Start(ctx); err != nil {
+		t.Error("Error must be nil, but found:", err)
+	}
+}
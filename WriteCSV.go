@@ -0,0 +1,50 @@
+package dataobject
+
+import (
+	"encoding/csv"
+	"io"
+	"strings"
+)
+
+// WriteCSV writes the objects as CSV with the columns,
+// with a header row holding the column labels
+//
+// Cells starting with =, +, -, @, a tab or a carriage return, which
+// spreadsheets would evaluate as formulas, are prefixed with a single
+// quote (CSV injection). Decimal numbers, i.e. "-5", are written as is
+func WriteCSV(w io.Writer, objects []DataObjectInterface, columns []Column) error {
+	writer := csv.NewWriter(w)
+
+	row := make([]string, len(columns))
+
+	for i, column := range columns {
+		row[i] = csvCell(column.header())
+	}
+
+	if err := writer.Write(row); err != nil {
+		return err
+	}
+
+	for _, do := range objects {
+		for i, column := range columns {
+			row[i] = csvCell(column.value(do))
+		}
+
+		if err := writer.Write(row); err != nil {
+			return err
+		}
+	}
+
+	writer.Flush()
+
+	return writer.Error()
+}
+
+// csvCell returns the value neutralized, if
+// a spreadsheet would evaluate it as a formula
+func csvCell(value string) string {
+	if value == "" || !strings.ContainsRune("=+-@\t\r", rune(value[0])) || isQueryDecimal(value) {
+		return value
+	}
+	return "'" + value
+}
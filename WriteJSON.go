@@ -0,0 +1,107 @@
+package dataobject
+
+import (
+	"encoding/json"
+	"net/http"
+)
+
+// WriteJSONOption configures the JSON written by WriteJSON and WriteListJSON
+type WriteJSONOption func(options *writeJSONOptions)
+
+type writeJSONOptions struct {
+	include []string
+	exclude []string
+	pretty  bool
+	status  int
+}
+
+// JSONInclude writes only the specified keys
+func JSONInclude(keys ...string) WriteJSONOption {
+	return func(options *writeJSONOptions) {
+		options.include = append(options.include, keys...)
+	}
+}
+
+// JSONExclude leaves out the specified keys (i.e. password hashes)
+func JSONExclude(keys ...string) WriteJSONOption {
+	return func(options *writeJSONOptions) {
+		options.exclude = append(options.exclude, keys...)
+	}
+}
+
+// JSONPretty indents the written JSON
+func JSONPretty() WriteJSONOption {
+	return func(options *writeJSONOptions) {
+		options.pretty = true
+	}
+}
+
+// JSONStatus sets the HTTP status code, defaults to 200 OK
+func JSONStatus(status int) WriteJSONOption {
+	return func(options *writeJSONOptions) {
+		options.status = status
+	}
+}
+
+// WriteJSON writes the data of the object as a JSON response
+//
+// Example:
+//
+//	func (c *UserController) Show(w http.ResponseWriter, r *http.Request) {
+//		user.WriteJSON(w, JSONExclude("password"))
+//	}
+func (do *DataObject) WriteJSON(w http.ResponseWriter, opts ...WriteJSONOption) error {
+	options := newWriteJSONOptions(opts)
+	return writeJSON(w, options, options.filter(do.Data()))
+}
+
+// WriteListJSON writes the data of the objects as a JSON array response
+func WriteListJSON(w http.ResponseWriter, objects []DataObjectInterface, opts ...WriteJSONOption) error {
+	options := newWriteJSONOptions(opts)
+
+	list := make([]map[string]string, 0, len(objects))
+	for _, do := range objects {
+		list = append(list, options.filter(do.Data()))
+	}
+
+	return writeJSON(w, options, list)
+}
+
+// newWriteJSONOptions applies the options to the defaults
+func newWriteJSONOptions(opts []WriteJSONOption) writeJSONOptions {
+	options := writeJSONOptions{status: http.StatusOK}
+	for _, opt := range opts {
+		opt(&options)
+	}
+	return options
+}
+
+// filter returns a copy of the data with the include and exclude lists applied
+func (options writeJSONOptions) filter(data map[string]string) map[string]string {
+	if len(options.include) > 0 {
+		data = onlyKeys(data, options.include)
+	}
+	return exceptKeys(data, options.exclude)
+}
+
+// writeJSON writes the value as JSON with the content type and status
+func writeJSON(w http.ResponseWriter, options writeJSONOptions, value any) error {
+	var jsonValue []byte
+	var err error
+
+	if options.pretty {
+		jsonValue, err = json.MarshalIndent(value, "", "  ")
+	} else {
+		jsonValue, err = json.Marshal(value)
+	}
+
+	if err != nil {
+		return err
+	}
+
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(options.status)
+	_, err = w.Write(jsonValue)
+
+	return err
+}
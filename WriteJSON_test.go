@@ -0,0 +1,51 @@
+package dataobject
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestDataObjectWriteJSON(t *testing.T) {
+	user := NewDataObjectFromExistingData(map[string]string{"id": "1", "first_name": "Jon", "password": "secret"})
+
+	w := httptest.NewRecorder()
+
+	err := user.WriteJSON(w, JSONExclude("password"), JSONStatus(http.StatusCreated))
+
+	if err != nil {
+		t.Fatal("Error must be nil, but found:", err.Error())
+	}
+
+	if w.Code != http.StatusCreated {
+		t.Error("Expected:", http.StatusCreated, "but found:", w.Code)
+	}
+
+	if !strings.HasPrefix(w.Header().Get("Content-Type"), "application/json") {
+		t.Error("Expected: application/json, but found:", w.Header().Get("Content-Type"))
+	}
+
+	if w.Body.String() != `{"first_name":"Jon","id":"1"}` {
+		t.Error(`Expected: {"first_name":"Jon","id":"1"}, but found:`, w.Body.String())
+	}
+}
+
+func TestWriteListJSON(t *testing.T) {
+	user1 := NewDataObjectFromExistingData(map[string]string{"id": "1", "first_name": "Jon"})
+	user2 := NewDataObjectFromExistingData(map[string]string{"id": "2", "first_name": "Jane"})
+
+	w := httptest.NewRecorder()
+
+	err := WriteListJSON(w, []DataObjectInterface{user1, user2}, JSONInclude("id"), JSONPretty())
+
+	if err != nil {
+		t.Fatal("Error must be nil, but found:", err.Error())
+	}
+
+	expected := "[\n  {\n    \"id\": \"1\"\n  },\n  {\n    \"id\": \"2\"\n  }\n]"
+
+	if w.Body.String() != expected {
+		t.Error("Expected:", expected, "but found:", w.Body.String())
+	}
+}
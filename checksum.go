@@ -0,0 +1,32 @@
+package dataobject
+
+import (
+	"encoding/binary"
+	"fmt"
+	"hash/crc32"
+)
+
+// checksumSize is the size of the CRC-32 footer appended by appendChecksum
+const checksumSize = 4
+
+// appendChecksum appends the CRC-32 (IEEE) of the payload as a big endian footer
+func appendChecksum(payload []byte) []byte {
+	return binary.BigEndian.AppendUint32(payload, crc32.ChecksumIEEE(payload))
+}
+
+// verifyChecksum returns the payload without the footer, or an error
+// wrapping ErrCorrupted if it is truncated or does not match the checksum
+func verifyChecksum(encoded []byte) ([]byte, error) {
+	if len(encoded) < checksumSize {
+		return nil, fmt.Errorf("%w: truncated", ErrCorrupted)
+	}
+
+	payload := encoded[:len(encoded)-checksumSize]
+	expected := binary.BigEndian.Uint32(encoded[len(encoded)-checksumSize:])
+
+	if crc32.ChecksumIEEE(payload) != expected {
+		return nil, fmt.Errorf("%w: checksum mismatch", ErrCorrupted)
+	}
+
+	return payload, nil
+}
@@ -0,0 +1,51 @@
+package dataobject
+
+import (
+	"bytes"
+	"encoding/gob"
+	"errors"
+	"testing"
+)
+
+func TestGobCorruptionDetection(t *testing.T) {
+	do := NewDataObjectFromExistingData(map[string]string{"id": "1", "name": "Jon"})
+
+	buffer := bytes.Buffer{}
+	if err := gob.NewEncoder(&buffer).Encode(do); err != nil {
+		t.Fatal("Error must be nil, but found:", err.Error())
+	}
+	encoded := buffer.Bytes()
+
+	if _, err := FromGob(encoded, wrapTestUser); err != nil {
+		t.Fatal("Error must be nil, but found:", err.Error())
+	}
+
+	truncated := encoded[:len(encoded)-3]
+	if _, err := FromGob(truncated, wrapTestUser); !errors.Is(err, ErrCorrupted) {
+		t.Error("Expected: ErrCorrupted for truncated data, but found:", err)
+	}
+
+	for i := range encoded {
+		flipped := bytes.Clone(encoded)
+		flipped[i] ^= 0x10
+
+		if decoded, err := FromGob(flipped, wrapTestUser); err == nil && decoded.Get("name") != "Jon" {
+			t.Error("Expected: ErrCorrupted for a flipped bit at", i, "but found:", decoded.Data())
+		} else if err != nil && !errors.Is(err, ErrCorrupted) {
+			t.Error("Expected: ErrCorrupted for a flipped bit at", i, "but found:", err)
+		}
+	}
+}
+
+func TestGobDecodeChecksum(t *testing.T) {
+	encoded, _ := NewDataObjectFromExistingData(map[string]string{"id": "1"}).GobEncode()
+	encoded[0] ^= 0xff
+
+	if err := (&DataObject{}).GobDecode(encoded); !errors.Is(err, ErrCorrupted) {
+		t.Error("Expected: ErrCorrupted, but found:", err)
+	}
+
+	if err := (&DataObject{}).GobDecode([]byte{1, 2}); !errors.Is(err, ErrCorrupted) {
+		t.Error("Expected: ErrCorrupted, but found:", err)
+	}
+}
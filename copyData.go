@@ -0,0 +1,10 @@
+package dataobject
+
+// copyData returns a shallow copy of the data map
+func copyData(data map[string]string) map[string]string {
+	result := make(map[string]string, len(data))
+	for k, v := range data {
+		result[k] = v
+	}
+	return result
+}
@@ -0,0 +1,179 @@
+package dataobject
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+var (
+	// ErrNotFound is returned when no object matches the lookup
+	ErrNotFound = errors.New("dataobject: not found")
+
+	// ErrAlreadyExists is returned when creating an object with an ID, which is taken
+	ErrAlreadyExists = errors.New("dataobject: already exists")
+
+	// ErrMissingID is returned when an object without an ID is stored
+	ErrMissingID = errors.New("dataobject: missing id")
+
+	// ErrInvalidJSON is returned when the JSON is not a valid data object
+	ErrInvalidJSON = errors.New("dataobject: invalid json")
+
+	// ErrVersionConflict is returned when an object has been modified concurrently
+	ErrVersionConflict = errors.New("dataobject: version conflict")
+
+	// ErrInvalidSchemaVersion is returned when the schema version is not a number
+	ErrInvalidSchemaVersion = errors.New("dataobject: invalid schema version")
+
+	// ErrInvalidSealedData is returned when sealed data cannot be decrypted,
+	// because it is malformed, has been tampered with, or the key is wrong
+	ErrInvalidSealedData = errors.New("dataobject: invalid sealed data")
+
+	// ErrCookieTooLarge is returned when the cookie exceeds MaxCookieSize
+	ErrCookieTooLarge = errors.New("dataobject: cookie too large")
+
+	// ErrFrozen is the panic value when modifying a frozen object
+	ErrFrozen = errors.New("dataobject: object is frozen")
+
+	// ErrValidation is matched by all ValidationError values
+	ErrValidation = errors.New("dataobject: validation failed")
+
+	// ErrNoAllowedKeys is returned when binding the values of a request
+	// without allowed keys, which would allow mass assignment
+	ErrNoAllowedKeys = errors.New("dataobject: no allowed keys")
+
+	// ErrInvalidPath is returned when a query path cannot be parsed
+	ErrInvalidPath = errors.New("dataobject: invalid path")
+
+	// ErrDeleteRestricted is returned when deleting an object,
+	// which still has related objects (see CascadeRestrict)
+	ErrDeleteRestricted = errors.New("dataobject: delete restricted")
+
+	// ErrLockLost is returned when refreshing a lock,
+	// which is no longer held (see SQLLock.Refresh)
+	ErrLockLost = errors.New("dataobject: lock lost")
+
+	// ErrDuplicateRequest is returned when creating an object with an
+	// idempotency key, which has already been used (see IdempotentRepository)
+	ErrDuplicateRequest = errors.New("dataobject: duplicate request")
+
+	// ErrInvalidID is returned when decoding an object,
+	// which ID is rejected by the ID validator (see SetIDValidator)
+	ErrInvalidID = errors.New("dataobject: invalid id")
+
+	// ErrTooLarge is returned when the input exceeds the size limit
+	ErrTooLarge = errors.New("dataobject: too large")
+
+	// ErrCorrupted is returned when encoded data is truncated
+	// or does not match its checksum
+	ErrCorrupted = errors.New("dataobject: corrupted data")
+
+	// ErrUnavailable is returned when the repository is down,
+	// and the write cannot be queued (see FailoverRepository)
+	ErrUnavailable = errors.New("dataobject: unavailable")
+
+	// ErrUnknownKey is returned when decrypting data
+	// encrypted with a key, which is not provided (see KeyProvider)
+	ErrUnknownKey = errors.New("dataobject: unknown key")
+
+	// ErrKeyUnavailable is returned when a key cannot be fetched,
+	// i.e. the key management service is down (see CallbackKeyProvider)
+	ErrKeyUnavailable = errors.New("dataobject: key unavailable")
+
+	// ErrNotEncrypted is returned when loading data, which is not
+	// encrypted, while encryption is configured (see Journal.WithEncryption)
+	ErrNotEncrypted = errors.New("dataobject: not encrypted")
+
+	// ErrForbidden is returned when the access policy
+	// denies a write (see PolicyRepository)
+	ErrForbidden = errors.New("dataobject: forbidden")
+
+	// ErrNotSupported is returned by a decorator, when the decorated
+	// repository does not support the operation (i.e. queries)
+	ErrNotSupported = errors.New("dataobject: not supported")
+
+	// ErrSaltTooShort is returned when the salt
+	// is too short (see HashWithSalt)
+	ErrSaltTooShort = errors.New("dataobject: salt too short")
+
+	// ErrInvalidName is returned when a type or field name
+	// is not a valid GraphQL name (see GraphQLSDL)
+	ErrInvalidName = errors.New("dataobject: invalid name")
+)
+
+// IsNotFound returns if the error is or wraps ErrNotFound
+func IsNotFound(err error) bool {
+	return errors.Is(err, ErrNotFound)
+}
+
+// notFound returns an error wrapping ErrNotFound for the ID
+func notFound(id string) error {
+	return fmt.Errorf("%w: %s", ErrNotFound, id)
+}
+
+// ValidationError is returned when an object does not match its schema
+type ValidationError struct {
+	Fields []FieldError
+}
+
+// Error returns the message listing all the invalid fields
+func (e *ValidationError) Error() string {
+	messages := make([]string, 0, len(e.Fields))
+	for _, field := range e.Fields {
+		messages = append(messages, field.Error())
+	}
+	return ErrValidation.Error() + ": " + strings.Join(messages, ", ")
+}
+
+// Is makes errors.Is(err, ErrValidation) match validation errors
+func (e *ValidationError) Is(target error) bool {
+	return target == ErrValidation
+}
+
+// MigrationError is returned when a migration fails
+type MigrationError struct {
+	// Version is the version the data was being migrated to
+	Version int
+
+	// ID is the ID of the migrated data
+	ID string
+
+	// Err is the error returned by the migration
+	Err error
+}
+
+// Error returns the message with the version and the migration error
+func (e *MigrationError) Error() string {
+	return "dataobject: migration to schema version " + strconv.Itoa(e.Version) + " failed: " + e.Err.Error()
+}
+
+// Unwrap returns the error returned by the migration
+func (e *MigrationError) Unwrap() error {
+	return e.Err
+}
+
+// WebhookError is returned when a webhook delivery fails
+type WebhookError struct {
+	// Endpoint is the URL of the failed endpoint
+	Endpoint string
+
+	// StatusCode is the last response status code, 0 if there was no response
+	StatusCode int
+
+	// Err is the underlying error, if any
+	Err error
+}
+
+// Error returns the message with the endpoint and the failure
+func (e *WebhookError) Error() string {
+	if e.Err != nil {
+		return "dataobject: webhook " + e.Endpoint + " failed: " + e.Err.Error()
+	}
+	return "dataobject: webhook " + e.Endpoint + " failed: unexpected status code " + strconv.Itoa(e.StatusCode)
+}
+
+// Unwrap returns the underlying error
+func (e *WebhookError) Unwrap() error {
+	return e.Err
+}
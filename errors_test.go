@@ -0,0 +1,77 @@
+package dataobject
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+func TestTypedErrors(t *testing.T) {
+	repo := NewMemoryRepository()
+
+	err := repo.Create(context.Background(), NewDataObjectFromExistingData(map[string]string{}))
+
+	if !errors.Is(err, ErrMissingID) {
+		t.Error("Expected: ErrMissingID, but found:", err)
+	}
+
+	user := NewDataObject()
+	_ = repo.Create(context.Background(), user)
+	err = repo.Create(context.Background(), user)
+
+	if !errors.Is(err, ErrAlreadyExists) {
+		t.Error("Expected: ErrAlreadyExists, but found:", err)
+	}
+
+	migrationErr := errors.New("boom")
+	migrator := NewMigrator().Register(1, func(data map[string]string) (map[string]string, error) {
+		return nil, migrationErr
+	})
+
+	_, err = migrator.Migrate(map[string]string{"id": "1"})
+
+	var target *MigrationError
+	if !errors.As(err, &target) || target.Version != 1 || !errors.Is(err, migrationErr) {
+		t.Error("Expected: MigrationError for version 1, but found:", err)
+	}
+
+	_, err = migrator.Migrate(map[string]string{"id": "1", SchemaVersionKey: "abc"})
+
+	if !errors.Is(err, ErrInvalidSchemaVersion) {
+		t.Error("Expected: ErrInvalidSchemaVersion, but found:", err)
+	}
+
+	_, err = NewDataObjectFromSealed("bm90IHNlYWxlZA", testSealKey)
+
+	if !errors.Is(err, ErrInvalidSealedData) {
+		t.Error("Expected: ErrInvalidSealedData, but found:", err)
+	}
+
+	err = error(&ValidationError{Fields: []FieldError{{Key: "email", Message: "is required"}}})
+
+	if !errors.Is(err, ErrValidation) {
+		t.Error("Expected: ErrValidation, but found:", err)
+	}
+
+	if err.Error() != "dataobject: validation failed: email is required" {
+		t.Error("Expected: dataobject: validation failed: email is required, but found:", err.Error())
+	}
+}
+
+func TestIsNotFound(t *testing.T) {
+	repo := NewMemoryRepository()
+
+	_, err := repo.Find(context.Background(), "missing")
+
+	if !IsNotFound(err) {
+		t.Error("Expected: ErrNotFound, but found:", err)
+	}
+
+	if err.Error() != "dataobject: not found: missing" {
+		t.Error("Expected: dataobject: not found: missing, but found:", err.Error())
+	}
+
+	if IsNotFound(errors.New("other")) {
+		t.Error("Expected other errors NOT to be not found")
+	}
+}
@@ -0,0 +1,21 @@
+package dataobject
+
+// onlyKeys returns a copy of the data with only the specified keys
+func onlyKeys(data map[string]string, keys []string) map[string]string {
+	result := map[string]string{}
+	for _, key := range keys {
+		if value, exists := data[key]; exists {
+			result[key] = value
+		}
+	}
+	return result
+}
+
+// exceptKeys returns a copy of the data without the specified keys
+func exceptKeys(data map[string]string, keys []string) map[string]string {
+	result := copyData(data)
+	for _, key := range keys {
+		delete(result, key)
+	}
+	return result
+}
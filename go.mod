@@ -3,3 +3,5 @@ module github.com/gouniverse/dataobject
 go 1.22
 
 require github.com/gouniverse/uid v1.4.0
+
+require golang.org/x/text v0.14.0
@@ -0,0 +1,200 @@
+// Package grpcrepository serves a dataobject repository over gRPC, and
+// provides the repository consuming it, so stores can be shared across
+// service boundaries. The service is defined in repository.proto
+//
+// It is a separate module, so only its users depend on gRPC. The service
+// uses only well-known protobuf types, so it needs no generated code
+//
+// Example:
+//
+//	server := grpc.NewServer()
+//	grpcrepository.Register(server, dataobject.NewMemoryRepository())
+//
+//	conn, err := grpc.NewClient(address, grpc.WithTransportCredentials(creds))
+//	repo := grpcrepository.NewRepository(conn)
+package grpcrepository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/gouniverse/dataobject"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/protobuf/types/known/structpb"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+// ServiceName is the full name of the service in repository.proto
+const ServiceName = "dataobject.v1.Repository"
+
+// RepositoryServer is the server API of the service
+type RepositoryServer interface {
+	Create(ctx context.Context, object *structpb.Struct) (*emptypb.Empty, error)
+	Find(ctx context.Context, id *wrapperspb.StringValue) (*structpb.Struct, error)
+	Update(ctx context.Context, object *structpb.Struct) (*emptypb.Empty, error)
+	Delete(ctx context.Context, id *wrapperspb.StringValue) (*emptypb.Empty, error)
+	List(ctx context.Context, page *structpb.Struct) (*structpb.ListValue, error)
+	Count(ctx context.Context, empty *emptypb.Empty) (*wrapperspb.Int64Value, error)
+}
+
+// ServiceDesc describes the service for grpc.ServiceRegistrar
+var ServiceDesc = grpc.ServiceDesc{
+	ServiceName: ServiceName,
+	HandlerType: (*RepositoryServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{MethodName: "Create", Handler: unaryHandler("Create", RepositoryServer.Create)},
+		{MethodName: "Find", Handler: unaryHandler("Find", RepositoryServer.Find)},
+		{MethodName: "Update", Handler: unaryHandler("Update", RepositoryServer.Update)},
+		{MethodName: "Delete", Handler: unaryHandler("Delete", RepositoryServer.Delete)},
+		{MethodName: "List", Handler: unaryHandler("List", RepositoryServer.List)},
+		{MethodName: "Count", Handler: unaryHandler("Count", RepositoryServer.Count)},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "repository.proto",
+}
+
+// methodHandler is the handler of a method in grpc.MethodDesc
+type methodHandler = func(srv any, ctx context.Context, dec func(any) error, interceptor grpc.UnaryServerInterceptor) (any, error)
+
+// unaryHandler returns the handler of the method, decoding the request
+// and passing it through the interceptor of the server, if any
+func unaryHandler[Req any, Res any](method string, call func(RepositoryServer, context.Context, *Req) (*Res, error)) methodHandler {
+	fullMethod := "/" + ServiceName + "/" + method
+
+	return func(srv any, ctx context.Context, dec func(any) error, interceptor grpc.UnaryServerInterceptor) (any, error) {
+		in := new(Req)
+		if err := dec(in); err != nil {
+			return nil, err
+		}
+
+		if interceptor == nil {
+			return call(srv.(RepositoryServer), ctx, in)
+		}
+
+		info := &grpc.UnaryServerInfo{Server: srv, FullMethod: fullMethod}
+		handler := func(ctx context.Context, req any) (any, error) {
+			return call(srv.(RepositoryServer), ctx, req.(*Req))
+		}
+		return interceptor(ctx, in, info, handler)
+	}
+}
+
+// errorCodes maps the errors of the repositories to the status codes.
+// The first error of a code is the one returned for it, if the
+// status message does not identify another one
+var errorCodes = []struct {
+	err  error
+	code codes.Code
+}{
+	{dataobject.ErrNotFound, codes.NotFound},
+	{dataobject.ErrAlreadyExists, codes.AlreadyExists},
+	{dataobject.ErrDuplicateRequest, codes.AlreadyExists},
+	{dataobject.ErrValidation, codes.InvalidArgument},
+	{dataobject.ErrMissingID, codes.InvalidArgument},
+	{dataobject.ErrInvalidID, codes.InvalidArgument},
+	{dataobject.ErrVersionConflict, codes.Aborted},
+	{dataobject.ErrDeleteRestricted, codes.FailedPrecondition},
+	{dataobject.ErrForbidden, codes.PermissionDenied},
+	{dataobject.ErrUnavailable, codes.Unavailable},
+}
+
+// toStatus converts the error of a repository to a status error
+func toStatus(err error) error {
+	if err == nil {
+		return nil
+	}
+
+	if _, isStatus := status.FromError(err); isStatus {
+		return err
+	}
+
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return status.FromContextError(err).Err()
+	}
+
+	for _, mapping := range errorCodes {
+		if errors.Is(err, mapping.err) {
+			return status.Error(mapping.code, err.Error())
+		}
+	}
+
+	return status.Error(codes.Internal, err.Error())
+}
+
+// fromStatus converts a status error to an error wrapping the error
+// of the repository it was converted from (see toStatus)
+func fromStatus(ctx context.Context, err error) error {
+	if err == nil {
+		return nil
+	}
+
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+
+	s, isStatus := status.FromError(err)
+	if !isStatus {
+		return err
+	}
+
+	var fallback error
+	for _, mapping := range errorCodes {
+		if mapping.code != s.Code() {
+			continue
+		}
+		if strings.Contains(s.Message(), mapping.err.Error()) {
+			return &remoteError{err: mapping.err, status: err}
+		}
+		if fallback == nil {
+			fallback = mapping.err
+		}
+	}
+
+	if fallback != nil {
+		return &remoteError{err: fallback, status: err}
+	}
+
+	return err
+}
+
+// remoteError is an error returned by the server, which wraps
+// the error of the repository, and the status error
+type remoteError struct {
+	err    error
+	status error
+}
+
+func (e *remoteError) Error() string {
+	return status.Convert(e.status).Message()
+}
+
+func (e *remoteError) Unwrap() []error {
+	return []error{e.err, e.status}
+}
+
+// toStruct converts the data of an object to a struct of string values
+func toStruct(data map[string]string) *structpb.Struct {
+	fields := make(map[string]*structpb.Value, len(data))
+	for key, value := range data {
+		fields[key] = structpb.NewStringValue(value)
+	}
+	return &structpb.Struct{Fields: fields}
+}
+
+// fromStruct converts a struct of string values to the data of an object
+func fromStruct(object *structpb.Struct) (map[string]string, error) {
+	data := make(map[string]string, len(object.GetFields()))
+	for key, value := range object.GetFields() {
+		stringValue, isString := value.GetKind().(*structpb.Value_StringValue)
+		if !isString {
+			return nil, fmt.Errorf("%w: the value of %q is not a string", dataobject.ErrValidation, key)
+		}
+		data[key] = stringValue.StringValue
+	}
+	return data, nil
+}
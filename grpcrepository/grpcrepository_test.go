@@ -0,0 +1,81 @@
+package grpcrepository
+
+import (
+	"context"
+	"errors"
+	"net"
+	"testing"
+
+	"github.com/gouniverse/dataobject"
+	"github.com/gouniverse/dataobject/repositorytest"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/test/bufconn"
+	"google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+// serve serves the repository over an in-memory connection,
+// returns the connection and the server
+func serve(t *testing.T, repo dataobject.DataObjectRepositoryInterface) (*grpc.ClientConn, *grpc.Server) {
+	t.Helper()
+
+	listener := bufconn.Listen(1024 * 1024)
+	server := grpc.NewServer()
+	Register(server, repo)
+	go func() { _ = server.Serve(listener) }()
+	t.Cleanup(server.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufconn",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return listener.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		t.Fatal("Error must be nil, but found:", err.Error())
+	}
+	t.Cleanup(func() { _ = conn.Close() })
+
+	return conn, server
+}
+
+func TestRepository(t *testing.T) {
+	repositorytest.Run(t, func() dataobject.DataObjectRepositoryInterface {
+		conn, _ := serve(t, dataobject.NewMemoryRepository())
+		return NewRepository(conn)
+	})
+}
+
+func TestRepositoryErrors(t *testing.T) {
+	ctx := context.Background()
+	orders := dataobject.NewMemoryRepository()
+	_ = orders.Create(ctx, dataobject.NewDataObjectFromExistingData(map[string]string{"id": "1", "user_id": "1"}))
+
+	conn, server := serve(t, dataobject.NewCascadeRepository(dataobject.NewMemoryRepository(),
+		dataobject.CascadeRule{Repository: orders, Key: "user_id", Action: dataobject.CascadeRestrict},
+	))
+	repo := NewRepository(conn)
+
+	_ = repo.Create(ctx, dataobject.NewDataObjectFromExistingData(map[string]string{"id": "1"}))
+
+	if err := repo.Delete(ctx, "1"); !errors.Is(err, dataobject.ErrDeleteRestricted) {
+		t.Error("Expected: ErrDeleteRestricted, but found:", err)
+	}
+
+	invalid := &structpb.Struct{Fields: map[string]*structpb.Value{"id": structpb.NewNumberValue(3)}}
+	if err := repo.invoke(ctx, "Create", invalid, &emptypb.Empty{}); !errors.Is(err, dataobject.ErrValidation) {
+		t.Error("Expected: ErrValidation for a value, which is not a string, but found:", err)
+	}
+
+	canceled, cancel := context.WithCancel(ctx)
+	cancel()
+	if _, err := repo.Find(canceled, "1"); !errors.Is(err, context.Canceled) {
+		t.Error("Expected: context.Canceled, but found:", err)
+	}
+
+	server.Stop()
+
+	if _, err := repo.Find(ctx, "1"); !dataobject.IsUnavailableError(err) {
+		t.Error("Expected: an unavailable error, but found:", err)
+	}
+}
@@ -0,0 +1,98 @@
+package grpcrepository
+
+import (
+	"context"
+
+	"github.com/gouniverse/dataobject"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/protobuf/types/known/structpb"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+var _ dataobject.DataObjectRepositoryInterface = (*Repository)(nil) // verify it implements the repository interface
+
+// Repository is a repository consuming the service over a connection.
+// The errors returned by the server wrap the errors of its repository
+// (i.e. dataobject.ErrNotFound), transport failures wrap
+// dataobject.ErrUnavailable (see dataobject.IsUnavailableError)
+type Repository struct {
+	conn grpc.ClientConnInterface
+	opts []grpc.CallOption
+}
+
+// NewRepository creates a new repository using the connection,
+// i.e. a *grpc.ClientConn, with the options for every call
+func NewRepository(conn grpc.ClientConnInterface, opts ...grpc.CallOption) *Repository {
+	return &Repository{conn: conn, opts: opts}
+}
+
+// invoke calls the method of the service
+func (repo *Repository) invoke(ctx context.Context, method string, in any, out any) error {
+	err := repo.conn.Invoke(ctx, "/"+ServiceName+"/"+method, in, out, repo.opts...)
+	return fromStatus(ctx, err)
+}
+
+// Create creates the object
+func (repo *Repository) Create(ctx context.Context, do dataobject.DataObjectInterface) error {
+	return repo.invoke(ctx, "Create", toStruct(do.Data()), &emptypb.Empty{})
+}
+
+// Find returns the object with the ID
+func (repo *Repository) Find(ctx context.Context, id string) (dataobject.DataObjectInterface, error) {
+	object := &structpb.Struct{}
+	if err := repo.invoke(ctx, "Find", wrapperspb.String(id), object); err != nil {
+		return nil, err
+	}
+
+	data, err := fromStruct(object)
+	if err != nil {
+		return nil, err
+	}
+
+	return dataobject.NewDataObjectFromExistingData(data), nil
+}
+
+// Update updates the object
+func (repo *Repository) Update(ctx context.Context, do dataobject.DataObjectInterface) error {
+	return repo.invoke(ctx, "Update", toStruct(do.Data()), &emptypb.Empty{})
+}
+
+// Delete deletes the object with the ID
+func (repo *Repository) Delete(ctx context.Context, id string) error {
+	return repo.invoke(ctx, "Delete", wrapperspb.String(id), &emptypb.Empty{})
+}
+
+// List returns up to limit objects, skipping the first offset objects
+func (repo *Repository) List(ctx context.Context, offset int, limit int) ([]dataobject.DataObjectInterface, error) {
+	page := &structpb.Struct{Fields: map[string]*structpb.Value{
+		"offset": structpb.NewNumberValue(float64(offset)),
+		"limit":  structpb.NewNumberValue(float64(limit)),
+	}}
+
+	list := &structpb.ListValue{}
+	if err := repo.invoke(ctx, "List", page, list); err != nil {
+		return nil, err
+	}
+
+	objects := make([]dataobject.DataObjectInterface, 0, len(list.GetValues()))
+	for _, value := range list.GetValues() {
+		data, err := fromStruct(value.GetStructValue())
+		if err != nil {
+			return nil, err
+		}
+		objects = append(objects, dataobject.NewDataObjectFromExistingData(data))
+	}
+
+	return objects, nil
+}
+
+// Count returns the number of objects
+func (repo *Repository) Count(ctx context.Context) (int, error) {
+	count := &wrapperspb.Int64Value{}
+	if err := repo.invoke(ctx, "Count", &emptypb.Empty{}, count); err != nil {
+		return 0, err
+	}
+
+	return int(count.GetValue()), nil
+}
@@ -0,0 +1,129 @@
+package grpcrepository
+
+import (
+	"context"
+	"fmt"
+	"math"
+
+	"github.com/gouniverse/dataobject"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/protobuf/types/known/structpb"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+var _ RepositoryServer = (*Server)(nil) // verify it implements the service
+
+// Server serves a repository as the service
+type Server struct {
+	repo dataobject.DataObjectRepositoryInterface
+}
+
+// NewServer creates a new server of the repository
+func NewServer(repo dataobject.DataObjectRepositoryInterface) *Server {
+	return &Server{repo: repo}
+}
+
+// Register registers a new server of the repository with the registrar,
+// i.e. a *grpc.Server
+func Register(registrar grpc.ServiceRegistrar, repo dataobject.DataObjectRepositoryInterface) {
+	registrar.RegisterService(&ServiceDesc, NewServer(repo))
+}
+
+// Create creates the object
+func (s *Server) Create(ctx context.Context, object *structpb.Struct) (*emptypb.Empty, error) {
+	data, err := fromStruct(object)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	if err := s.repo.Create(ctx, dataobject.NewDataObjectFromExistingData(data)); err != nil {
+		return nil, toStatus(err)
+	}
+
+	return &emptypb.Empty{}, nil
+}
+
+// Find returns the object with the ID
+func (s *Server) Find(ctx context.Context, id *wrapperspb.StringValue) (*structpb.Struct, error) {
+	do, err := s.repo.Find(ctx, id.GetValue())
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	return toStruct(do.Data()), nil
+}
+
+// Update updates the object
+func (s *Server) Update(ctx context.Context, object *structpb.Struct) (*emptypb.Empty, error) {
+	data, err := fromStruct(object)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	if err := s.repo.Update(ctx, dataobject.NewDataObjectFromExistingData(data)); err != nil {
+		return nil, toStatus(err)
+	}
+
+	return &emptypb.Empty{}, nil
+}
+
+// Delete deletes the object with the ID
+func (s *Server) Delete(ctx context.Context, id *wrapperspb.StringValue) (*emptypb.Empty, error) {
+	if err := s.repo.Delete(ctx, id.GetValue()); err != nil {
+		return nil, toStatus(err)
+	}
+
+	return &emptypb.Empty{}, nil
+}
+
+// List returns up to "limit" objects, skipping the first "offset" objects
+func (s *Server) List(ctx context.Context, page *structpb.Struct) (*structpb.ListValue, error) {
+	offset, err := pageNumber(page, "offset")
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	limit, err := pageNumber(page, "limit")
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	objects, err := s.repo.List(ctx, offset, limit)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	values := make([]*structpb.Value, 0, len(objects))
+	for _, do := range objects {
+		values = append(values, structpb.NewStructValue(toStruct(do.Data())))
+	}
+
+	return &structpb.ListValue{Values: values}, nil
+}
+
+// Count returns the number of objects
+func (s *Server) Count(ctx context.Context, _ *emptypb.Empty) (*wrapperspb.Int64Value, error) {
+	count, err := s.repo.Count(ctx)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	return wrapperspb.Int64(int64(count)), nil
+}
+
+// pageNumber returns the number of the page request under the key,
+// 0 if there is none
+func pageNumber(page *structpb.Struct, key string) (int, error) {
+	value, exists := page.GetFields()[key]
+	if !exists {
+		return 0, nil
+	}
+
+	number, isNumber := value.GetKind().(*structpb.Value_NumberValue)
+	if !isNumber || number.NumberValue < 0 || number.NumberValue > math.MaxInt32 || number.NumberValue != math.Trunc(number.NumberValue) {
+		return 0, fmt.Errorf("%w: %s must be a non-negative integer", dataobject.ErrValidation, key)
+	}
+
+	return int(number.NumberValue), nil
+}
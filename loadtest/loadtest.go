@@ -0,0 +1,255 @@
+// Package loadtest drives a dataobject repository with a configurable
+// read/write mix and concurrency, reporting the throughput and the
+// latency percentiles, so backends can be compared on real hardware
+//
+// Example:
+//
+//	report, err := loadtest.Run(ctx, repo, loadtest.Options{
+//		Concurrency: 16,
+//		Duration:    30 * time.Second,
+//		ReadRatio:   0.9,
+//	})
+//	fmt.Println(report)
+package loadtest
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gouniverse/dataobject"
+)
+
+// DefaultConcurrency is the default number of concurrent workers
+const DefaultConcurrency = 8
+
+// DefaultObjects is the default number of objects seeded before the run
+const DefaultObjects = 1000
+
+// DefaultDuration is the default duration of the run,
+// if no number of operations is set
+const DefaultDuration = 10 * time.Second
+
+// Options configures a load test run
+type Options struct {
+	// Concurrency is the number of concurrent workers (DefaultConcurrency if 0)
+	Concurrency int
+
+	// Duration is how long the run lasts (DefaultDuration if 0 and
+	// Operations is 0). The run also ends when the context is done
+	Duration time.Duration
+
+	// Operations ends the run after this many operations, if not 0
+	Operations int
+
+	// ReadRatio is the ratio of reads (Find) among the operations, from
+	// 0 (writes only) to 1 (reads only). Writes are Updates of a random object
+	ReadRatio float64
+
+	// Objects is the number of objects created before the run (DefaultObjects if 0)
+	Objects int
+
+	// Schema is the schema of the generated objects (see
+	// dataobject.GenerateFake), a name and an email if nil
+	Schema *dataobject.Schema
+
+	// Seed seeds the generated objects and the operation mix
+	Seed int64
+}
+
+// Latencies are the latency percentiles of an operation
+type Latencies struct {
+	P50 time.Duration `json:"p50"`
+	P90 time.Duration `json:"p90"`
+	P99 time.Duration `json:"p99"`
+	Max time.Duration `json:"max"`
+}
+
+// Report is the result of a load test run
+type Report struct {
+	// Duration is the actual duration of the run
+	Duration time.Duration `json:"duration"`
+
+	// Reads and Writes are the numbers of completed operations
+	Reads  int `json:"reads"`
+	Writes int `json:"writes"`
+
+	// Errors is the number of failed operations
+	Errors int `json:"errors"`
+
+	// Throughput is the number of operations per second
+	Throughput float64 `json:"throughput"`
+
+	// ReadLatencies and WriteLatencies are the latency percentiles
+	ReadLatencies  Latencies `json:"read_latencies"`
+	WriteLatencies Latencies `json:"write_latencies"`
+}
+
+// String returns the report as human readable text
+func (r Report) String() string {
+	b := strings.Builder{}
+	fmt.Fprintf(&b, "duration:   %s\n", r.Duration.Round(time.Millisecond))
+	fmt.Fprintf(&b, "operations: %d reads, %d writes, %d errors\n", r.Reads, r.Writes, r.Errors)
+	fmt.Fprintf(&b, "throughput: %.0f ops/s\n", r.Throughput)
+	fmt.Fprintf(&b, "reads:      p50 %s, p90 %s, p99 %s, max %s\n", r.ReadLatencies.P50, r.ReadLatencies.P90, r.ReadLatencies.P99, r.ReadLatencies.Max)
+	fmt.Fprintf(&b, "writes:     p50 %s, p90 %s, p99 %s, max %s\n", r.WriteLatencies.P50, r.WriteLatencies.P90, r.WriteLatencies.P99, r.WriteLatencies.Max)
+	return b.String()
+}
+
+// Run seeds the repository with the objects, then drives it with the
+// operation mix until the duration elapses, the number of operations
+// is reached, or the context is done
+//
+// The seeded objects are not deleted afterwards, so use a dedicated
+// repository (i.e. an empty table)
+func Run(ctx context.Context, repo dataobject.DataObjectRepositoryInterface, opts Options) (Report, error) {
+	opts = withDefaults(opts)
+
+	objects := dataobject.GenerateFakeMany(opts.Schema, opts.Objects, opts.Seed)
+	ids := make([]string, 0, len(objects))
+	for _, do := range objects {
+		if err := repo.Create(ctx, do); err != nil {
+			return Report{}, fmt.Errorf("loadtest: seeding: %w", err)
+		}
+		ids = append(ids, do.ID())
+	}
+
+	if opts.Duration > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, opts.Duration)
+		defer cancel()
+	}
+
+	results := make([]workerResult, opts.Concurrency)
+	remaining := make(chan struct{}, max(opts.Operations, 0))
+	for i := 0; i < opts.Operations; i++ {
+		remaining <- struct{}{}
+	}
+
+	start := time.Now()
+
+	wg := sync.WaitGroup{}
+	for i := range results {
+		wg.Add(1)
+		go func(result *workerResult, random *rand.Rand) {
+			defer wg.Done()
+			for ctx.Err() == nil {
+				if opts.Operations > 0 {
+					select {
+					case <-remaining:
+					default:
+						return
+					}
+				}
+				result.run(ctx, repo, ids, opts.ReadRatio, random)
+			}
+		}(&results[i], rand.New(rand.NewSource(opts.Seed+int64(i)+1)))
+	}
+	wg.Wait()
+
+	return newReport(results, time.Since(start)), nil
+}
+
+// withDefaults returns the options with the defaults applied
+func withDefaults(opts Options) Options {
+	if opts.Concurrency <= 0 {
+		opts.Concurrency = DefaultConcurrency
+	}
+	if opts.Objects <= 0 {
+		opts.Objects = DefaultObjects
+	}
+	if opts.Duration <= 0 && opts.Operations <= 0 {
+		opts.Duration = DefaultDuration
+	}
+	if opts.Schema == nil {
+		opts.Schema = dataobject.NewSchema(
+			dataobject.SchemaField{Key: "name"},
+			dataobject.SchemaField{Key: "email"},
+		)
+	}
+	opts.ReadRatio = min(max(opts.ReadRatio, 0), 1)
+	return opts
+}
+
+// workerResult collects the latencies of a worker
+type workerResult struct {
+	reads  []time.Duration
+	writes []time.Duration
+	errors int
+}
+
+// run runs a single random operation
+func (r *workerResult) run(ctx context.Context, repo dataobject.DataObjectRepositoryInterface, ids []string, readRatio float64, random *rand.Rand) {
+	id := ids[random.Intn(len(ids))]
+
+	if random.Float64() < readRatio {
+		start := time.Now()
+		_, err := repo.Find(ctx, id)
+		r.record(ctx, &r.reads, start, err)
+		return
+	}
+
+	do := dataobject.NewDataObjectFromExistingID(id)
+	do.Set("loadtest", fmt.Sprint(random.Int63()))
+
+	start := time.Now()
+	err := repo.Update(ctx, do)
+	r.record(ctx, &r.writes, start, err)
+}
+
+// record records the latency of a successful operation, or the error.
+// Operations interrupted by the end of the run are not counted
+func (r *workerResult) record(ctx context.Context, latencies *[]time.Duration, start time.Time, err error) {
+	elapsed := time.Since(start)
+
+	switch {
+	case err == nil:
+		*latencies = append(*latencies, elapsed)
+	case ctx.Err() == nil:
+		r.errors++
+	}
+}
+
+// newReport merges the worker results
+func newReport(results []workerResult, duration time.Duration) Report {
+	reads := []time.Duration{}
+	writes := []time.Duration{}
+	report := Report{Duration: duration}
+
+	for _, result := range results {
+		reads = append(reads, result.reads...)
+		writes = append(writes, result.writes...)
+		report.Errors += result.errors
+	}
+
+	report.Reads = len(reads)
+	report.Writes = len(writes)
+	report.ReadLatencies = percentiles(reads)
+	report.WriteLatencies = percentiles(writes)
+
+	if duration > 0 {
+		report.Throughput = float64(report.Reads+report.Writes) / duration.Seconds()
+	}
+
+	return report
+}
+
+// percentiles returns the latency percentiles, using the nearest rank
+func percentiles(latencies []time.Duration) Latencies {
+	if len(latencies) < 1 {
+		return Latencies{}
+	}
+
+	slices.Sort(latencies)
+
+	rank := func(p float64) time.Duration {
+		index := int(p*float64(len(latencies))+0.5) - 1
+		return latencies[min(max(index, 0), len(latencies)-1)]
+	}
+
+	return Latencies{P50: rank(0.50), P90: rank(0.90), P99: rank(0.99), Max: latencies[len(latencies)-1]}
+}
@@ -0,0 +1,73 @@
+package loadtest
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gouniverse/dataobject"
+)
+
+func TestRunOperations(t *testing.T) {
+	repo := dataobject.NewMemoryRepository()
+
+	report, err := Run(context.Background(), repo, Options{
+		Concurrency: 4,
+		Operations:  1000,
+		ReadRatio:   0.8,
+		Objects:     50,
+		Seed:        1,
+	})
+
+	if err != nil {
+		t.Fatal("Error must be nil, but found:", err.Error())
+	}
+
+	if report.Reads+report.Writes != 1000 || report.Errors != 0 {
+		t.Error("Expected: 1000 operations without errors, but found:", report)
+	}
+
+	if report.Reads < 700 || report.Reads > 900 {
+		t.Error("Expected: about 800 reads, but found:", report.Reads)
+	}
+
+	if report.ReadLatencies.P50 > report.ReadLatencies.P99 || report.ReadLatencies.P99 > report.ReadLatencies.Max {
+		t.Error("Expected: ordered percentiles, but found:", report.ReadLatencies)
+	}
+
+	if count, _ := repo.Count(context.Background()); count != 50 {
+		t.Error("Expected: 50, but found:", count)
+	}
+
+	if !strings.Contains(report.String(), "throughput") {
+		t.Error("Expected: the throughput in the report, but found:", report.String())
+	}
+}
+
+func TestRunDuration(t *testing.T) {
+	report, err := Run(context.Background(), dataobject.NewMemoryRepository(), Options{
+		Duration: 50 * time.Millisecond,
+		Objects:  10,
+	})
+
+	if err != nil {
+		t.Fatal("Error must be nil, but found:", err.Error())
+	}
+
+	if report.Writes == 0 || report.Reads != 0 || report.Duration < 50*time.Millisecond {
+		t.Error("Expected: writes only for the duration, but found:", report)
+	}
+}
+
+func TestPercentiles(t *testing.T) {
+	latencies := []time.Duration{}
+	for i := 100; i >= 1; i-- {
+		latencies = append(latencies, time.Duration(i))
+	}
+
+	found := percentiles(latencies)
+	if found.P50 != 50 || found.P90 != 90 || found.P99 != 99 || found.Max != 100 {
+		t.Error("Expected: 50 90 99 100, but found:", found)
+	}
+}
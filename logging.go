@@ -0,0 +1,32 @@
+package dataobject
+
+import "log/slog"
+
+// LogRedactedKeys are the key fragments, whose values are redacted in
+// the logged payloads and in the anonymized exports (i.e. "password"
+// redacts "password_hash"), see Anonymization
+var LogRedactedKeys = []string{"password", "secret", "token", "api_key", "hash", "salt"}
+
+// logPayload returns the data as a log attribute with the values
+// of the sensitive keys (see LogRedactedKeys) redacted
+func logPayload(data map[string]string) slog.Attr {
+	attrs := make([]any, 0, len(data))
+
+	for key, value := range data {
+		if isSensitiveKey(key) {
+			value = RedactedValue
+		}
+
+		attrs = append(attrs, slog.String(key, value))
+	}
+
+	return slog.Group("data", attrs...)
+}
+
+// logDebug logs the message at debug level if the logger is set
+func logDebug(logger *slog.Logger, msg string, args ...any) {
+	if logger == nil {
+		return
+	}
+	logger.Debug(msg, args...)
+}
@@ -0,0 +1,36 @@
+package dataobject
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"strings"
+	"testing"
+)
+
+func TestMemoryRepositoryWithLogger(t *testing.T) {
+	var b bytes.Buffer
+	logger := slog.New(slog.NewTextHandler(&b, &slog.HandlerOptions{Level: slog.LevelDebug}))
+
+	repo := NewMemoryRepository().WithLogger(logger)
+
+	user := NewDataObject()
+	user.Set("email", "jon@test.com")
+	user.Set("password_hash", "s3cr3t")
+
+	_ = repo.Create(context.Background(), user)
+
+	logged := b.String()
+
+	if !strings.Contains(logged, "dataobject: create") {
+		t.Error("Expected to contain: dataobject: create, but found:", logged)
+	}
+
+	if !strings.Contains(logged, "data.email=jon@test.com") {
+		t.Error("Expected to contain: data.email=jon@test.com, but found:", logged)
+	}
+
+	if strings.Contains(logged, "s3cr3t") {
+		t.Error("Expected password to be redacted, but found:", logged)
+	}
+}
@@ -0,0 +1,164 @@
+// Package prommetrics exports the measurements of the dataobject
+// package (see dataobject.Metrics) as Prometheus collectors. It is a
+// separate module, so only its users depend on the Prometheus client
+//
+// Example:
+//
+//	if _, err := prommetrics.WithMetrics(prometheus.DefaultRegisterer); err != nil {
+//		return err
+//	}
+//	repo := dataobject.NewMeteredRepository(sqlRepo)
+package prommetrics
+
+import (
+	"sync/atomic"
+	"time"
+
+	"github.com/gouniverse/dataobject"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// Namespace is the namespace of the metric names
+const Namespace = "dataobject"
+
+var _ dataobject.Metrics = (*Collectors)(nil) // verify it receives the measurements
+
+// Collectors holds the Prometheus collectors of the measurements
+type Collectors struct {
+	// ObjectsCreated counts the objects created in metered repositories
+	ObjectsCreated prometheus.Counter
+
+	// SerializationDuration observes the serialization time per format
+	SerializationDuration *prometheus.HistogramVec
+
+	// RepositoryOperationDuration observes the latency of the repository
+	// operations per operation and result ("ok", "not_found" or "error")
+	RepositoryOperationDuration *prometheus.HistogramVec
+
+	// CacheLookups counts the cache lookups per result ("hit" or "miss")
+	CacheLookups *prometheus.CounterVec
+
+	// CacheHitRatio is the ratio of the cache lookups, which were hits
+	CacheHitRatio prometheus.GaugeFunc
+
+	cacheHits   atomic.Uint64
+	cacheMisses atomic.Uint64
+}
+
+// New creates the collectors, without registering them
+func New() *Collectors {
+	c := &Collectors{
+		ObjectsCreated: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: Namespace,
+			Name:      "objects_created_total",
+			Help:      "Number of objects created in metered repositories.",
+		}),
+		SerializationDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: Namespace,
+			Name:      "serialization_duration_seconds",
+			Help:      "Time spent serializing objects.",
+			Buckets:   []float64{.000001, .000005, .00001, .00005, .0001, .0005, .001, .005, .01},
+		}, []string{"format"}),
+		RepositoryOperationDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: Namespace,
+			Name:      "repository_operation_duration_seconds",
+			Help:      "Latency of the repository operations.",
+			Buckets:   prometheus.DefBuckets,
+		}, []string{"operation", "result"}),
+		CacheLookups: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: Namespace,
+			Name:      "cache_lookups_total",
+			Help:      "Number of cache lookups of the cached finders.",
+		}, []string{"result"}),
+	}
+
+	c.CacheHitRatio = prometheus.NewGaugeFunc(prometheus.GaugeOpts{
+		Namespace: Namespace,
+		Name:      "cache_hit_ratio",
+		Help:      "Ratio of the cache lookups, which were hits.",
+	}, c.cacheHitRatio)
+
+	return c
+}
+
+// WithMetrics creates the collectors, registers them with the
+// registerer, and makes the dataobject package report to them
+// (see dataobject.SetMetrics)
+func WithMetrics(reg prometheus.Registerer) (*Collectors, error) {
+	c := New()
+	if err := c.Register(reg); err != nil {
+		return nil, err
+	}
+
+	dataobject.SetMetrics(c)
+
+	return c, nil
+}
+
+// Register registers the collectors with the registerer
+func (c *Collectors) Register(reg prometheus.Registerer) error {
+	collectors := []prometheus.Collector{
+		c.ObjectsCreated,
+		c.SerializationDuration,
+		c.RepositoryOperationDuration,
+		c.CacheLookups,
+		c.CacheHitRatio,
+	}
+
+	for _, collector := range collectors {
+		if err := reg.Register(collector); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// ObjectCreated counts the created object
+func (c *Collectors) ObjectCreated() {
+	c.ObjectsCreated.Inc()
+}
+
+// ObserveSerialization observes the serialization time
+func (c *Collectors) ObserveSerialization(format string, duration time.Duration) {
+	c.SerializationDuration.WithLabelValues(format).Observe(duration.Seconds())
+}
+
+// ObserveRepositoryOperation observes the latency of the operation
+func (c *Collectors) ObserveRepositoryOperation(operation string, duration time.Duration, err error) {
+	c.RepositoryOperationDuration.WithLabelValues(operation, result(err)).Observe(duration.Seconds())
+}
+
+// ObserveCacheLookup counts the cache lookup
+func (c *Collectors) ObserveCacheLookup(hit bool) {
+	if hit {
+		c.cacheHits.Add(1)
+		c.CacheLookups.WithLabelValues("hit").Inc()
+		return
+	}
+
+	c.cacheMisses.Add(1)
+	c.CacheLookups.WithLabelValues("miss").Inc()
+}
+
+// cacheHitRatio returns the ratio of the cache lookups, which were hits
+func (c *Collectors) cacheHitRatio() float64 {
+	hits := c.cacheHits.Load()
+	total := hits + c.cacheMisses.Load()
+	if total == 0 {
+		return 0
+	}
+	return float64(hits) / float64(total)
+}
+
+// result returns the result label of the operation error
+func result(err error) string {
+	switch {
+	case err == nil:
+		return "ok"
+	case dataobject.IsNotFound(err):
+		return "not_found"
+	default:
+		return "error"
+	}
+}
@@ -0,0 +1,75 @@
+package prommetrics
+
+import (
+	"context"
+	"testing"
+
+	"github.com/gouniverse/dataobject"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+func TestWithMetrics(t *testing.T) {
+	reg := prometheus.NewRegistry()
+
+	c, err := WithMetrics(reg)
+	if err != nil {
+		t.Fatal("Error must be nil, but found:", err.Error())
+	}
+	defer dataobject.SetMetrics(nil)
+
+	ctx := context.Background()
+	repo := dataobject.NewMeteredRepository(dataobject.NewMemoryRepository())
+
+	do := dataobject.NewDataObject()
+	if err := repo.Create(ctx, do); err != nil {
+		t.Fatal("Error must be nil, but found:", err.Error())
+	}
+	_, _ = repo.Find(ctx, do.ID())
+	_, _ = repo.Find(ctx, "missing")
+	_, _ = do.ToJSON()
+
+	cache := dataobject.NewMemoryCache()
+	finder := dataobject.NewCachedFinder(repo, cache)
+	_, _ = finder.Find(ctx, do.ID())
+	_, _ = finder.Find(ctx, do.ID())
+
+	if found := testutil.ToFloat64(c.ObjectsCreated); found != 1 {
+		t.Error("Expected: 1, but found:", found)
+	}
+
+	if found := testutil.ToFloat64(c.CacheHitRatio); found != 0.5 {
+		t.Error("Expected: 0.5, but found:", found)
+	}
+
+	if found := testutil.CollectAndCount(c.RepositoryOperationDuration); found != 3 {
+		t.Error("Expected: 3 series (create ok, find ok, find not_found), but found:", found)
+	}
+
+	if found := testutil.CollectAndCount(c.SerializationDuration); found < 1 {
+		t.Error("Expected: the json serialization, but found:", found)
+	}
+
+	if found := testutil.ToFloat64(c.CacheLookups.WithLabelValues("miss")); found != 1 {
+		t.Error("Expected: 1, but found:", found)
+	}
+
+	names := []string{"objects_created_total", "serialization_duration_seconds", "repository_operation_duration_seconds", "cache_lookups_total", "cache_hit_ratio"}
+	for _, name := range names {
+		if found, err := testutil.GatherAndCount(reg, Namespace+"_"+name); err != nil || found < 1 {
+			t.Error("Expected: the registered metric", name, "but found:", found, err)
+		}
+	}
+}
+
+func TestRegisterTwice(t *testing.T) {
+	reg := prometheus.NewRegistry()
+
+	if err := New().Register(reg); err != nil {
+		t.Fatal("Error must be nil, but found:", err.Error())
+	}
+
+	if err := New().Register(reg); err == nil {
+		t.Error("Error must NOT be nil when registering twice")
+	}
+}
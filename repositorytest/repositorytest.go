@@ -0,0 +1,387 @@
+// Package repositorytest provides a conformance suite for implementations
+// of dataobject.DataObjectRepositoryInterface, so third-party backends can
+// verify they honor the interface contract
+//
+// Example:
+//
+//	func TestMyRepository(t *testing.T) {
+//		repositorytest.Run(t, func() dataobject.DataObjectRepositoryInterface {
+//			return NewMyRepository(newTestDatabase(t))
+//		})
+//	}
+package repositorytest
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/gouniverse/dataobject"
+)
+
+// ConcurrentWriters is the number of goroutines used by the concurrency tests
+const ConcurrentWriters = 20
+
+// Run runs the conformance suite as subtests. Every subtest gets an
+// empty repository from newRepo
+//
+// Covered are CRUD, not found semantics, pagination and counting,
+// concurrent writes, and, if the repository implements them, queries
+// (dataobject.Querier) and conditional updates (dataobject.ConditionalUpdater).
+// Soft deleting repositories pass as long as deleted objects are hidden
+func Run(t *testing.T, newRepo func() dataobject.DataObjectRepositoryInterface) {
+	t.Helper()
+
+	tests := []struct {
+		name string
+		test func(t *testing.T, repo dataobject.DataObjectRepositoryInterface)
+	}{
+		{"CreateAndFind", testCreateAndFind},
+		{"CreateDuplicate", testCreateDuplicate},
+		{"CreateMissingID", testCreateMissingID},
+		{"Update", testUpdate},
+		{"UpdateNotFound", testUpdateNotFound},
+		{"Delete", testDelete},
+		{"DeleteNotFound", testDeleteNotFound},
+		{"FindNotFound", testFindNotFound},
+		{"ReturnsCopies", testReturnsCopies},
+		{"ListPagination", testListPagination},
+		{"Count", testCount},
+		{"ConcurrentCreates", testConcurrentCreates},
+		{"ConcurrentUpdates", testConcurrentUpdates},
+		{"Query", testQuery},
+		{"UpdateIf", testUpdateIf},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			test.test(t, newRepo())
+		})
+	}
+}
+
+// newObject returns a new object with the ID and the key value pairs
+func newObject(id string, keyValues ...string) *dataobject.DataObject {
+	do := dataobject.NewDataObjectFromExistingID(id)
+	for i := 0; i+1 < len(keyValues); i += 2 {
+		do.Set(keyValues[i], keyValues[i+1])
+	}
+	return do
+}
+
+// mustCreate creates the objects, failing the test on error
+func mustCreate(t *testing.T, repo dataobject.DataObjectRepositoryInterface, objects ...*dataobject.DataObject) {
+	t.Helper()
+	for _, do := range objects {
+		if err := repo.Create(context.Background(), do); err != nil {
+			t.Fatal("Error must be nil, but found:", err.Error())
+		}
+	}
+}
+
+func testCreateAndFind(t *testing.T, repo dataobject.DataObjectRepositoryInterface) {
+	mustCreate(t, repo, newObject("1", "name", "Jon", "email", "jon@example.com"))
+
+	found, err := repo.Find(context.Background(), "1")
+	if err != nil {
+		t.Fatal("Error must be nil, but found:", err.Error())
+	}
+
+	if found.ID() != "1" || found.Data()["name"] != "Jon" || found.Data()["email"] != "jon@example.com" {
+		t.Error("Expected: the created object, but found:", found.Data())
+	}
+}
+
+func testCreateDuplicate(t *testing.T, repo dataobject.DataObjectRepositoryInterface) {
+	mustCreate(t, repo, newObject("1"))
+
+	if err := repo.Create(context.Background(), newObject("1")); !errors.Is(err, dataobject.ErrAlreadyExists) {
+		t.Error("Expected: ErrAlreadyExists, but found:", err)
+	}
+}
+
+func testCreateMissingID(t *testing.T, repo dataobject.DataObjectRepositoryInterface) {
+	do := dataobject.NewDataObjectFromExistingData(map[string]string{"name": "Jon"})
+
+	if err := repo.Create(context.Background(), do); !errors.Is(err, dataobject.ErrMissingID) {
+		t.Error("Expected: ErrMissingID, but found:", err)
+	}
+}
+
+func testUpdate(t *testing.T, repo dataobject.DataObjectRepositoryInterface) {
+	ctx := context.Background()
+	mustCreate(t, repo, newObject("1", "name", "Jon"))
+
+	found, err := repo.Find(ctx, "1")
+	if err != nil {
+		t.Fatal("Error must be nil, but found:", err.Error())
+	}
+
+	updated := dataobject.NewDataObjectFromExistingData(found.Data())
+	updated.Set("name", "Tom")
+
+	if err := repo.Update(ctx, updated); err != nil {
+		t.Fatal("Error must be nil, but found:", err.Error())
+	}
+
+	found, err = repo.Find(ctx, "1")
+	if err != nil {
+		t.Fatal("Error must be nil, but found:", err.Error())
+	}
+
+	if found.Data()["name"] != "Tom" {
+		t.Error("Expected: Tom, but found:", found.Data()["name"])
+	}
+}
+
+func testUpdateNotFound(t *testing.T, repo dataobject.DataObjectRepositoryInterface) {
+	if err := repo.Update(context.Background(), newObject("missing")); !dataobject.IsNotFound(err) {
+		t.Error("Expected: ErrNotFound, but found:", err)
+	}
+}
+
+func testDelete(t *testing.T, repo dataobject.DataObjectRepositoryInterface) {
+	ctx := context.Background()
+	mustCreate(t, repo, newObject("1"), newObject("2"))
+
+	if err := repo.Delete(ctx, "1"); err != nil {
+		t.Fatal("Error must be nil, but found:", err.Error())
+	}
+
+	if _, err := repo.Find(ctx, "1"); !dataobject.IsNotFound(err) {
+		t.Error("Expected: ErrNotFound after delete, but found:", err)
+	}
+
+	if err := repo.Delete(ctx, "1"); !dataobject.IsNotFound(err) {
+		t.Error("Expected: ErrNotFound deleting twice, but found:", err)
+	}
+
+	objects, err := repo.List(ctx, 0, 0)
+	if err != nil {
+		t.Fatal("Error must be nil, but found:", err.Error())
+	}
+
+	if len(objects) != 1 || objects[0].ID() != "2" {
+		t.Error("Expected: only 2 to be listed, but found:", ids(objects))
+	}
+
+	if count, _ := repo.Count(ctx); count != 1 {
+		t.Error("Expected: 1, but found:", count)
+	}
+}
+
+func testDeleteNotFound(t *testing.T, repo dataobject.DataObjectRepositoryInterface) {
+	if err := repo.Delete(context.Background(), "missing"); !dataobject.IsNotFound(err) {
+		t.Error("Expected: ErrNotFound, but found:", err)
+	}
+}
+
+func testFindNotFound(t *testing.T, repo dataobject.DataObjectRepositoryInterface) {
+	found, err := repo.Find(context.Background(), "missing")
+
+	if !dataobject.IsNotFound(err) {
+		t.Error("Expected: ErrNotFound, but found:", err)
+	}
+
+	if found != nil {
+		t.Error("Expected: nil, but found:", found.Data())
+	}
+}
+
+func testReturnsCopies(t *testing.T, repo dataobject.DataObjectRepositoryInterface) {
+	ctx := context.Background()
+	created := newObject("1", "name", "Jon")
+	mustCreate(t, repo, created)
+
+	created.Set("name", "changed after create")
+
+	found, err := repo.Find(ctx, "1")
+	if err != nil {
+		t.Fatal("Error must be nil, but found:", err.Error())
+	}
+
+	found.Data()["name"] = "changed after find"
+
+	found, _ = repo.Find(ctx, "1")
+	if found.Data()["name"] != "Jon" {
+		t.Error("Expected: the stored object not to change without Update, but found:", found.Data()["name"])
+	}
+}
+
+func testListPagination(t *testing.T, repo dataobject.DataObjectRepositoryInterface) {
+	ctx := context.Background()
+	mustCreate(t, repo, newObject("3"), newObject("1"), newObject("5"), newObject("2"), newObject("4"))
+
+	tests := []struct {
+		offset   int
+		limit    int
+		expected string
+	}{
+		{0, 0, "[1 2 3 4 5]"},
+		{0, 2, "[1 2]"},
+		{2, 2, "[3 4]"},
+		{4, 2, "[5]"},
+		{5, 2, "[]"},
+		{10, 0, "[]"},
+	}
+
+	for _, test := range tests {
+		objects, err := repo.List(ctx, test.offset, test.limit)
+		if err != nil {
+			t.Fatal("Error must be nil, but found:", err.Error())
+		}
+
+		if found := fmt.Sprint(ids(objects)); found != test.expected {
+			t.Error("Expected:", test.expected, "for offset", test.offset, "and limit", test.limit, "but found:", found)
+		}
+	}
+}
+
+func testCount(t *testing.T, repo dataobject.DataObjectRepositoryInterface) {
+	ctx := context.Background()
+
+	if count, err := repo.Count(ctx); err != nil || count != 0 {
+		t.Error("Expected: 0, but found:", count, err)
+	}
+
+	mustCreate(t, repo, newObject("1"), newObject("2"), newObject("3"))
+
+	if count, err := repo.Count(ctx); err != nil || count != 3 {
+		t.Error("Expected: 3, but found:", count, err)
+	}
+}
+
+func testConcurrentCreates(t *testing.T, repo dataobject.DataObjectRepositoryInterface) {
+	ctx := context.Background()
+
+	errs := make(chan error, ConcurrentWriters*2)
+	wg := sync.WaitGroup{}
+
+	for i := 0; i < ConcurrentWriters; i++ {
+		wg.Add(2)
+
+		// distinct IDs must all be created
+		go func(i int) {
+			defer wg.Done()
+			errs <- repo.Create(ctx, newObject(fmt.Sprintf("%03d", i)))
+		}(i)
+
+		// the same ID must be created exactly once
+		go func() {
+			defer wg.Done()
+			if err := repo.Create(ctx, newObject("shared")); err != nil && !errors.Is(err, dataobject.ErrAlreadyExists) {
+				errs <- err
+			}
+		}()
+	}
+
+	wg.Wait()
+	close(errs)
+
+	for err := range errs {
+		if err != nil {
+			t.Error("Error must be nil, but found:", err.Error())
+		}
+	}
+
+	if count, _ := repo.Count(ctx); count != ConcurrentWriters+1 {
+		t.Error("Expected:", ConcurrentWriters+1, "but found:", count)
+	}
+}
+
+func testConcurrentUpdates(t *testing.T, repo dataobject.DataObjectRepositoryInterface) {
+	ctx := context.Background()
+	mustCreate(t, repo, newObject("1", "writer", ""))
+
+	wg := sync.WaitGroup{}
+	for i := 0; i < ConcurrentWriters; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			if err := repo.Update(ctx, newObject("1", "writer", fmt.Sprint(i))); err != nil {
+				t.Error("Error must be nil, but found:", err.Error())
+			}
+			if _, err := repo.Find(ctx, "1"); err != nil {
+				t.Error("Error must be nil, but found:", err.Error())
+			}
+		}(i)
+	}
+	wg.Wait()
+
+	found, err := repo.Find(ctx, "1")
+	if err != nil {
+		t.Fatal("Error must be nil, but found:", err.Error())
+	}
+
+	if found.Data()["writer"] == "" {
+		t.Error("Expected: the value of one of the writers, but found:", found.Data())
+	}
+}
+
+func testQuery(t *testing.T, repo dataobject.DataObjectRepositoryInterface) {
+	querier, isQuerier := repo.(dataobject.Querier)
+	if !isQuerier {
+		t.Skip("the repository does not implement dataobject.Querier")
+	}
+
+	mustCreate(t, repo,
+		newObject("1", "role", "admin", "age", "30"),
+		newObject("2", "role", "user", "age", "20"),
+		newObject("3", "role", "admin", "age", "40"),
+	)
+
+	query := dataobject.NewQuery().Where("role").Eq("admin").OrderByDesc("age")
+
+	objects, err := querier.Query(context.Background(), query)
+	if err != nil {
+		t.Fatal("Error must be nil, but found:", err.Error())
+	}
+
+	if found := fmt.Sprint(ids(objects)); found != "[3 1]" {
+		t.Error("Expected: [3 1], but found:", found)
+	}
+
+	objects, err = querier.Query(context.Background(), dataobject.NewQuery().Where("role").Eq("none"))
+	if err != nil || len(objects) != 0 {
+		t.Error("Expected: no objects and no error, but found:", ids(objects), err)
+	}
+}
+
+func testUpdateIf(t *testing.T, repo dataobject.DataObjectRepositoryInterface) {
+	updater, isConditional := repo.(dataobject.ConditionalUpdater)
+	if !isConditional {
+		t.Skip("the repository does not implement dataobject.ConditionalUpdater")
+	}
+
+	ctx := context.Background()
+	mustCreate(t, repo, newObject("1", "name", "Jon"))
+
+	found, err := repo.Find(ctx, "1")
+	if err != nil {
+		t.Fatal("Error must be nil, but found:", err.Error())
+	}
+	etag := dataobject.ETagOf(found.Data())
+
+	if err := updater.UpdateIf(ctx, newObject("1", "name", "Tom"), etag); err != nil {
+		t.Fatal("Error must be nil, but found:", err.Error())
+	}
+
+	if err := updater.UpdateIf(ctx, newObject("1", "name", "Tim"), etag); !errors.Is(err, dataobject.ErrVersionConflict) {
+		t.Error("Expected: ErrVersionConflict, but found:", err)
+	}
+
+	if err := updater.UpdateIf(ctx, newObject("missing"), "*"); !dataobject.IsNotFound(err) {
+		t.Error("Expected: ErrNotFound, but found:", err)
+	}
+}
+
+// ids returns the IDs of the objects
+func ids(objects []dataobject.DataObjectInterface) []string {
+	result := make([]string, 0, len(objects))
+	for _, do := range objects {
+		result = append(result, do.ID())
+	}
+	return result
+}
@@ -0,0 +1,38 @@
+package repositorytest
+
+import (
+	"testing"
+	"time"
+
+	"github.com/gouniverse/dataobject"
+)
+
+func TestMemoryRepository(t *testing.T) {
+	Run(t, func() dataobject.DataObjectRepositoryInterface {
+		return dataobject.NewMemoryRepository()
+	})
+}
+
+func TestMemoryRepositoryWithSoftDelete(t *testing.T) {
+	Run(t, func() dataobject.DataObjectRepositoryInterface {
+		return dataobject.NewMemoryRepository().WithSoftDelete()
+	})
+}
+
+func TestDecoratedRepositories(t *testing.T) {
+	Run(t, func() dataobject.DataObjectRepositoryInterface {
+		return dataobject.NewCascadeRepository(dataobject.NewMemoryRepository())
+	})
+
+	Run(t, func() dataobject.DataObjectRepositoryInterface {
+		return dataobject.NewIdempotentRepository(dataobject.NewMemoryRepository(), time.Minute)
+	})
+
+	Run(t, func() dataobject.DataObjectRepositoryInterface {
+		return dataobject.NewFailoverRepository(dataobject.NewMemoryRepository(), dataobject.NewMemoryRepository())
+	})
+
+	Run(t, func() dataobject.DataObjectRepositoryInterface {
+		return dataobject.NewPolicyRepository(dataobject.NewMemoryRepository(), dataobject.AccessPolicyFuncs{})
+	})
+}
@@ -0,0 +1,13 @@
+package dataobject
+
+import (
+	"strings"
+	"unicode"
+)
+
+// tokenize splits a text into lowercase words
+func tokenize(text string) []string {
+	return strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsNumber(r)
+	})
+}
@@ -0,0 +1,29 @@
+package dataobject
+
+import "strconv"
+
+// typedValue converts the string value to the Go value of the field type
+// (int64, float64 or bool). Strings, date times and values, which cannot
+// be converted, are returned as they are. Empty values are returned as nil
+func typedValue(fieldType FieldType, value string) any {
+	if value == "" {
+		return nil
+	}
+
+	switch fieldType {
+	case FieldTypeInt:
+		if number, err := strconv.ParseInt(value, 10, 64); err == nil {
+			return number
+		}
+	case FieldTypeFloat:
+		if number, err := strconv.ParseFloat(value, 64); err == nil {
+			return number
+		}
+	case FieldTypeBool:
+		if boolean, err := strconv.ParseBool(value); err == nil {
+			return boolean
+		}
+	}
+
+	return value
+}